@@ -33,6 +33,7 @@ const (
 	DefaultNodeName               = "StatusIM"
 	DefaultLogFile                = "geth.log"
 	DefaultAPILogFile             = "api.log"
+	DefaultTracesFile             = "traces.jsonl"
 
 	DefaultLogLevel                   = "ERROR"
 	DefaultMaxPeers                   = 20
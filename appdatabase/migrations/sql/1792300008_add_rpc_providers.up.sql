@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS rpc_providers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    url VARCHAR NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    priority INTEGER NOT NULL DEFAULT 0,
+    auth_type VARCHAR NOT NULL DEFAULT 'no-auth',
+    auth_login VARCHAR NOT NULL DEFAULT '',
+    auth_password VARCHAR NOT NULL DEFAULT '',
+    auth_token VARCHAR NOT NULL DEFAULT '',
+    max_requests_per_second INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_rpc_providers_chain_id ON rpc_providers (chain_id);
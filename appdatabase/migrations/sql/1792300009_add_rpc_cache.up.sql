@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS rpc_cache (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    cache_key VARCHAR NOT NULL,
+    value BLOB NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rpc_cache_chain_id_cache_key ON rpc_cache (chain_id, cache_key);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS circuit_breaker_states (
+    name VARCHAR PRIMARY KEY NOT NULL,
+    open_until INTEGER NOT NULL,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR NOT NULL DEFAULT ''
+) WITHOUT ROWID;
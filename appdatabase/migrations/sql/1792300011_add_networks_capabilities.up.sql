@@ -0,0 +1,2 @@
+-- JSON encoded params.NetworkCapabilities, NULL until the network is probed
+ALTER TABLE networks ADD COLUMN capabilities VARCHAR;
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/logutils/tracing"
 )
 
 type FallbackFunc func() ([]any, error)
@@ -85,11 +86,13 @@ type Config struct {
 type CircuitBreaker struct {
 	config             Config
 	circuitNameHandler func(string) string
+	monitor            *Monitor
 }
 
 func NewCircuitBreaker(config Config) *CircuitBreaker {
 	return &CircuitBreaker{
-		config: config,
+		config:  config,
+		monitor: DefaultMonitor(),
 	}
 }
 
@@ -128,6 +131,8 @@ func (cb *CircuitBreaker) Execute(cmd *Command) CommandResult {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, span := tracing.Start(ctx, "circuitbreaker.Execute", tracing.Int64("functors", int64(len(cmd.functors))))
+	defer func() { span.End(result.err) }()
 
 	for i, f := range cmd.functors {
 		if cmd.cancel {
@@ -141,15 +146,20 @@ func (cb *CircuitBreaker) Execute(cmd *Command) CommandResult {
 			circuitName = cb.circuitNameHandler(circuitName)
 		}
 
-		// if last command, execute without circuit
-		if i == len(cmd.functors)-1 {
+		functorCtx, functorSpan := tracing.Start(ctx, "circuitbreaker.Functor", tracing.String("circuit", circuitName))
+		forcedState := cb.monitor.forcedState(circuitName)
+		if forcedState == CircuitOpen || (forcedState == CircuitAuto && i < len(cmd.functors)-1 && cb.monitor.restoredOpen(circuitName)) {
+			// Forced open, or restored open and not the last command
+			err = hystrix.ErrCircuitOpen
+		} else if i == len(cmd.functors)-1 || forcedState == CircuitClosed {
+			// if last command or forced closed, execute without circuit
 			res, execErr := f.exec()
 			err = execErr
 			if err == nil {
 				result.res = res
 				result.err = nil
 			}
-			result.addCallStatus(circuitName, err)
+			cb.addCallStatus(&result, circuitName, err)
 		} else {
 			if hystrix.GetCircuitSettings()[circuitName] == nil {
 				hystrix.ConfigureCommand(circuitName, hystrix.CommandConfig{
@@ -161,14 +171,14 @@ func (cb *CircuitBreaker) Execute(cmd *Command) CommandResult {
 				})
 			}
 
-			err = hystrix.DoC(ctx, circuitName, func(ctx context.Context) error {
+			err = hystrix.DoC(functorCtx, circuitName, func(ctx context.Context) error {
 				res, err := f.exec()
 				// Write to result only if success
 				if err == nil {
 					result.res = res
 					result.err = nil
 				}
-				result.addCallStatus(circuitName, err)
+				cb.addCallStatus(&result, circuitName, err)
 
 				// If the command has been cancelled, we don't count
 				// the error towars breaking the circuit, and then we break
@@ -182,7 +192,12 @@ func (cb *CircuitBreaker) Execute(cmd *Command) CommandResult {
 				}
 				return err
 			}, nil)
+
+			// The circuit exists once the command ran in it
+			circuit, _, _ := hystrix.GetCircuit(circuitName)
+			cb.monitor.updateState(circuitName, circuit.IsOpen(), cb.sleepWindow())
 		}
+		functorSpan.End(err)
 		if err == nil {
 			break
 		}
@@ -195,6 +210,23 @@ func (cb *CircuitBreaker) Execute(cmd *Command) CommandResult {
 	return result
 }
 
+func (cb *CircuitBreaker) addCallStatus(result *CommandResult, circuitName string, err error) {
+	result.addCallStatus(circuitName, err)
+	cb.monitor.recordCall(circuitName, result.functorCallStatuses[len(result.functorCallStatuses)-1])
+}
+
+func (cb *CircuitBreaker) sleepWindow() time.Duration {
+	if cb.config.SleepWindow == 0 {
+		return time.Duration(hystrix.DefaultSleepWindow) * time.Millisecond
+	}
+	return time.Duration(cb.config.SleepWindow) * time.Millisecond
+}
+
+// SetMonitor sets the monitor of the circuits, DefaultMonitor is used otherwise
+func (cb *CircuitBreaker) SetMonitor(monitor *Monitor) {
+	cb.monitor = monitor
+}
+
 func (c *CircuitBreaker) SetOverrideCircuitNameHandler(f func(string) string) {
 	c.circuitNameHandler = f
 }
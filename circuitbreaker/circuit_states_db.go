@@ -0,0 +1,61 @@
+package circuitbreaker
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CircuitStateData is the persisted state of an open circuit
+type CircuitStateData struct {
+	Name                string
+	OpenUntil           time.Time
+	ConsecutiveFailures int
+	LastError           string
+}
+
+type CircuitStatesDB struct {
+	db *sql.DB
+}
+
+func NewCircuitStatesDB(db *sql.DB) *CircuitStatesDB {
+	return &CircuitStatesDB{
+		db: db,
+	}
+}
+
+func (c *CircuitStatesDB) Save(state CircuitStateData) error {
+	query := `INSERT OR REPLACE INTO circuit_breaker_states (name, open_until, consecutive_failures, last_error) VALUES (?, ?, ?, ?)`
+	_, err := c.db.Exec(query, state.Name, state.OpenUntil.Unix(), state.ConsecutiveFailures, state.LastError)
+	return err
+}
+
+func (c *CircuitStatesDB) GetAll() ([]CircuitStateData, error) {
+	rows, err := c.db.Query(`SELECT name, open_until, consecutive_failures, last_error FROM circuit_breaker_states`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []CircuitStateData
+	for rows.Next() {
+		var state CircuitStateData
+		openUntilSecs := int64(0)
+		if err := rows.Scan(&state.Name, &openUntilSecs, &state.ConsecutiveFailures, &state.LastError); err != nil {
+			return nil, err
+		}
+		state.OpenUntil = time.Unix(openUntilSecs, 0)
+		result = append(result, state)
+	}
+	return result, rows.Err()
+}
+
+func (c *CircuitStatesDB) Delete(name string) error {
+	_, err := c.db.Exec(`DELETE FROM circuit_breaker_states WHERE name = ?`, name)
+	return err
+}
+
+// DeleteExpired deletes the circuits whose sleep window ended
+func (c *CircuitStatesDB) DeleteExpired(now time.Time) error {
+	_, err := c.db.Exec(`DELETE FROM circuit_breaker_states WHERE open_until <= ?`, now.Unix())
+	return err
+}
@@ -0,0 +1,280 @@
+package circuitbreaker
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/status-im/status-go/logutils"
+)
+
+// CircuitState is the state of a circuit, the functors of an open circuit are not executed
+type CircuitState string
+
+const (
+	CircuitClosed CircuitState = "closed"
+	CircuitOpen   CircuitState = "open"
+	// CircuitAuto releases a forced state
+	CircuitAuto CircuitState = ""
+)
+
+// maxCallsHistory is the number of recent calls kept per circuit
+const maxCallsHistory = 20
+
+type CallStatus struct {
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// CircuitStatus is the state and the recent calls of a circuit since the start, the state of the circuits open
+// before a restart is restored
+type CircuitStatus struct {
+	Name   string       `json:"name"`
+	State  CircuitState `json:"state"`
+	Forced bool         `json:"forced"`
+	// OpenUntil is the end of the sleep window of a circuit restored open
+	OpenUntil           time.Time    `json:"openUntil"`
+	Successes           int          `json:"successes"`
+	Failures            int          `json:"failures"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	LastSuccessAt       time.Time    `json:"lastSuccessAt"`
+	LastFailureAt       time.Time    `json:"lastFailureAt"`
+	LastError           string       `json:"lastError,omitempty"`
+	History             []CallStatus `json:"history"`
+}
+
+type circuitRecord struct {
+	status CircuitStatus
+	forced CircuitState
+}
+
+// Monitor keeps the states of the circuits of all the circuit breakers, as the circuits of hystrix are global.
+// The circuits opening are persisted, so that their functors are not executed again right after a restart.
+type Monitor struct {
+	mu             sync.Mutex
+	circuits       map[string]*circuitRecord
+	db             *CircuitStatesDB
+	onStateChanged func(CircuitStatus)
+	now            func() time.Time
+}
+
+func NewMonitor() *Monitor {
+	return &Monitor{
+		circuits: make(map[string]*circuitRecord),
+		now:      time.Now,
+	}
+}
+
+var defaultMonitor = NewMonitor()
+
+// DefaultMonitor returns the monitor used by the circuit breakers
+func DefaultMonitor() *Monitor {
+	return defaultMonitor
+}
+
+// SetDB persists the circuits opening in the db and restores the circuits still in their sleep window
+func (m *Monitor) SetDB(db *sql.DB) error {
+	statesDB := NewCircuitStatesDB(db)
+	now := m.now()
+	if err := statesDB.DeleteExpired(now); err != nil {
+		return err
+	}
+	states, err := statesDB.GetAll()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.db = statesDB
+	for _, state := range states {
+		record := m.circuit(state.Name)
+		record.status.State = CircuitOpen
+		record.status.OpenUntil = state.OpenUntil
+		record.status.ConsecutiveFailures = state.ConsecutiveFailures
+		record.status.LastError = state.LastError
+	}
+	return nil
+}
+
+// SetStateChangedHandler sets the function called when a circuit opens or closes
+func (m *Monitor) SetStateChangedHandler(f func(CircuitStatus)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.onStateChanged = f
+}
+
+// Statuses returns the statuses of the circuits sorted by name
+func (m *Monitor) Statuses() []CircuitStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]CircuitStatus, 0, len(m.circuits))
+	for _, record := range m.circuits {
+		result = append(result, record.copyStatus())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Status returns the status of the circuit, false when no call was made in it
+func (m *Monitor) Status(name string) (CircuitStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.circuits[name]
+	if !ok {
+		return CircuitStatus{}, false
+	}
+	return record.copyStatus(), true
+}
+
+// ForceState forces the circuit open or closed until CircuitAuto is set, it is meant for debugging and is not
+// persisted
+func (m *Monitor) ForceState(name string, state CircuitState) error {
+	if state != CircuitOpen && state != CircuitClosed && state != CircuitAuto {
+		return fmt.Errorf("invalid circuit state: %s", state)
+	}
+
+	m.mu.Lock()
+	record := m.circuit(name)
+	record.forced = state
+	record.status.Forced = state != CircuitAuto
+	notify := m.setState(record, state)
+	m.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+func (m *Monitor) circuit(name string) *circuitRecord {
+	record, ok := m.circuits[name]
+	if !ok {
+		record = &circuitRecord{status: CircuitStatus{Name: name, State: CircuitClosed}}
+		m.circuits[name] = record
+	}
+	return record
+}
+
+func (r *circuitRecord) copyStatus() CircuitStatus {
+	status := r.status
+	status.History = append([]CallStatus(nil), r.status.History...)
+	return status
+}
+
+// forcedState returns the state forced for the circuit, CircuitAuto when none
+func (m *Monitor) forcedState(name string) CircuitState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.circuits[name]; ok {
+		return record.forced
+	}
+	return CircuitAuto
+}
+
+// restoredOpen returns true while the circuit restored open is in its sleep window
+func (m *Monitor) restoredOpen(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.circuits[name]
+	return ok && m.now().Before(record.status.OpenUntil)
+}
+
+// recordCall adds the call to the history of the circuit
+func (m *Monitor) recordCall(name string, status FunctorCallStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record := m.circuit(name)
+	call := CallStatus{Timestamp: status.Timestamp}
+	if status.Err != nil {
+		call.Error = status.Err.Error()
+		record.status.Failures++
+		record.status.ConsecutiveFailures++
+		record.status.LastFailureAt = status.Timestamp
+		record.status.LastError = call.Error
+	} else {
+		record.status.Successes++
+		record.status.ConsecutiveFailures = 0
+		record.status.LastSuccessAt = status.Timestamp
+	}
+
+	record.status.History = append(record.status.History, call)
+	if len(record.status.History) > maxCallsHistory {
+		record.status.History = record.status.History[len(record.status.History)-maxCallsHistory:]
+	}
+}
+
+// updateState sets the state of the circuit read from hystrix, the open circuits are persisted until the end of
+// their sleep window
+func (m *Monitor) updateState(name string, open bool, sleepWindow time.Duration) {
+	m.mu.Lock()
+	record := m.circuit(name)
+	var notify func()
+	switch {
+	case record.forced != CircuitAuto:
+		notify = func() {}
+	case open:
+		if record.status.State != CircuitOpen {
+			record.status.OpenUntil = m.now().Add(sleepWindow)
+		}
+		notify = m.setState(record, CircuitOpen)
+	case m.now().Before(record.status.OpenUntil):
+		// Restored open, hystrix doesn't know about it
+		notify = func() {}
+	default:
+		record.status.OpenUntil = time.Time{}
+		notify = m.setState(record, CircuitClosed)
+	}
+	m.mu.Unlock()
+
+	notify()
+}
+
+// setState changes the state of the circuit and persists it, it returns the function notifying the change to be
+// called without the lock
+func (m *Monitor) setState(record *circuitRecord, state CircuitState) func() {
+	if state == CircuitAuto {
+		// The actual state is known with the next call
+		return func() {}
+	}
+	if record.status.State == state {
+		return func() {}
+	}
+	record.status.State = state
+
+	if m.db != nil && !record.status.Forced {
+		var err error
+		if state == CircuitOpen {
+			err = m.db.Save(CircuitStateData{
+				Name:                record.status.Name,
+				OpenUntil:           record.status.OpenUntil,
+				ConsecutiveFailures: record.status.ConsecutiveFailures,
+				LastError:           record.status.LastError,
+			})
+		} else {
+			err = m.db.Delete(record.status.Name)
+		}
+		if err != nil {
+			logutils.ZapLogger().Warn("failed to persist circuit state", zap.String("circuit", record.status.Name), zap.Error(err))
+		}
+	}
+
+	status := record.copyStatus()
+	onStateChanged := m.onStateChanged
+	return func() {
+		if onStateChanged != nil {
+			onStateChanged(status)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/afex/hystrix-go/hystrix"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/appdatabase"
+	"github.com/status-im/status-go/t/helpers"
+)
+
+func setupMonitorTest(t *testing.T) (*CircuitBreaker, *Monitor, string) {
+	cb := NewCircuitBreaker(Config{
+		Timeout:                1000,
+		MaxConcurrentRequests:  100,
+		RequestVolumeThreshold: 10,
+		SleepWindow:            60000,
+		ErrorPercentThreshold:  10,
+	})
+	monitor := NewMonitor()
+	cb.SetMonitor(monitor)
+	// unique name to avoid conflicts with go tests `-count` option
+	return cb, monitor, fmt.Sprintf("Monitor_%d", time.Now().UnixNano())
+}
+
+func newTestCommand(calls map[string]int, names ...string) *Command {
+	cmd := NewCommand(context.TODO(), nil)
+	for _, name := range names {
+		name := name
+		cmd.Add(NewFunctor(func() ([]any, error) {
+			calls[name]++
+			return []any{name}, nil
+		}, name))
+	}
+	return cmd
+}
+
+func TestMonitor_Statuses(t *testing.T) {
+	cb, monitor, name := setupMonitorTest(t)
+	errFailed := errors.New("provider failed")
+
+	cmd := NewCommand(context.TODO(), []*Functor{
+		NewFunctor(func() ([]any, error) { return nil, errFailed }, name+"1"),
+		NewFunctor(func() ([]any, error) { return []any{success}, nil }, name+"2"),
+	})
+	result := cb.Execute(cmd)
+	require.NoError(t, result.Error())
+
+	statuses := monitor.Statuses()
+	require.Len(t, statuses, 2)
+	require.Equal(t, name+"1", statuses[0].Name)
+	require.Equal(t, CircuitClosed, statuses[0].State)
+	require.Equal(t, 1, statuses[0].Failures)
+	require.Equal(t, 1, statuses[0].ConsecutiveFailures)
+	require.Equal(t, errFailed.Error(), statuses[0].LastError)
+	require.Len(t, statuses[0].History, 1)
+	require.Equal(t, 1, statuses[1].Successes)
+	require.Empty(t, statuses[1].History[0].Error)
+
+	// The history is bounded
+	for i := 0; i < maxCallsHistory; i++ {
+		cb.Execute(NewCommand(context.TODO(), []*Functor{
+			NewFunctor(func() ([]any, error) { return []any{success}, nil }, name+"1"),
+		}))
+	}
+	status, ok := monitor.Status(name + "1")
+	require.True(t, ok)
+	require.Len(t, status.History, maxCallsHistory)
+	require.Equal(t, 0, status.ConsecutiveFailures)
+	require.Equal(t, maxCallsHistory, status.Successes)
+}
+
+func TestMonitor_ForceState(t *testing.T) {
+	cb, monitor, name := setupMonitorTest(t)
+	calls := make(map[string]int)
+
+	var changes []CircuitStatus
+	monitor.SetStateChangedHandler(func(status CircuitStatus) {
+		changes = append(changes, status)
+	})
+
+	require.Error(t, monitor.ForceState(name+"1", "half-open"))
+
+	// Forced open circuits are skipped, even for the last functor
+	require.NoError(t, monitor.ForceState(name+"1", CircuitOpen))
+	result := cb.Execute(newTestCommand(calls, name+"1", name+"2"))
+	require.NoError(t, result.Error())
+	require.Equal(t, name+"2", result.Result()[0])
+	require.Zero(t, calls[name+"1"])
+
+	result = cb.Execute(newTestCommand(calls, name+"1"))
+	require.ErrorIs(t, result.Error(), hystrix.ErrCircuitOpen)
+
+	require.Len(t, changes, 1)
+	require.Equal(t, CircuitOpen, changes[0].State)
+	require.True(t, changes[0].Forced)
+
+	// Released
+	require.NoError(t, monitor.ForceState(name+"1", CircuitAuto))
+	result = cb.Execute(newTestCommand(calls, name+"1", name+"2"))
+	require.NoError(t, result.Error())
+	require.Equal(t, name+"1", result.Result()[0])
+	require.Equal(t, CircuitClosed, changes[len(changes)-1].State)
+	require.False(t, changes[len(changes)-1].Forced)
+}
+
+func TestMonitor_RestoreOpenCircuits(t *testing.T) {
+	db, cleanup, err := helpers.SetupTestSQLDB(appdatabase.DbInitializer{}, "circuit-states-tests")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, cleanup()) }()
+
+	_, monitor, name := setupMonitorTest(t)
+	require.NoError(t, monitor.SetDB(db))
+
+	// Opening circuits are persisted until the end of their sleep window
+	monitor.recordCall(name+"1", FunctorCallStatus{Name: name + "1", Timestamp: time.Now(), Err: errors.New("dead")})
+	monitor.updateState(name+"1", true, time.Minute)
+	monitor.updateState(name+"expired", true, -time.Second)
+
+	// After a restart
+	cb, restored, _ := setupMonitorTest(t)
+	require.NoError(t, restored.SetDB(db))
+	statuses := restored.Statuses()
+	require.Len(t, statuses, 1)
+	require.Equal(t, name+"1", statuses[0].Name)
+	require.Equal(t, CircuitOpen, statuses[0].State)
+	require.Equal(t, "dead", statuses[0].LastError)
+	require.Equal(t, 1, statuses[0].ConsecutiveFailures)
+
+	// The circuit is not used while in its sleep window, unless it is the last one
+	calls := make(map[string]int)
+	result := cb.Execute(newTestCommand(calls, name+"1", name+"2"))
+	require.NoError(t, result.Error())
+	require.Equal(t, name+"2", result.Result()[0])
+	require.Zero(t, calls[name+"1"])
+
+	result = cb.Execute(newTestCommand(calls, name+"1"))
+	require.NoError(t, result.Error())
+	require.Equal(t, 1, calls[name+"1"])
+
+	// Closed once the sleep window ended and the circuit works
+	restored.now = func() time.Time { return time.Now().Add(time.Minute) }
+	result = cb.Execute(newTestCommand(calls, name+"1", name+"2"))
+	require.NoError(t, result.Error())
+	require.Equal(t, name+"1", result.Result()[0])
+	status, _ := restored.Status(name + "1")
+	require.Equal(t, CircuitClosed, status.State)
+
+	states, err := NewCircuitStatesDB(db).GetAll()
+	require.NoError(t, err)
+	require.Empty(t, states)
+}
@@ -0,0 +1,38 @@
+package across
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errorNotAvailableOnChainID = errors.New("not available for chainID")
+
+type Deployment struct {
+	SpokePool common.Address
+	// WrappedNative is used as input/output token when bridging the native token
+	WrappedNative common.Address
+}
+
+var deploymentByChainID = map[uint64]Deployment{
+	1: { // mainnet
+		SpokePool:     common.HexToAddress("0x5c7BCd6E7De5423a257D81B442095A1a6ced35C5"),
+		WrappedNative: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+	},
+	10: { // optimism
+		SpokePool:     common.HexToAddress("0x6f26Bf09B1C792e3228e5467807a900A503c0281"),
+		WrappedNative: common.HexToAddress("0x4200000000000000000000000000000000000006"),
+	},
+	42161: { // arbitrum
+		SpokePool:     common.HexToAddress("0xe35e9842fceaCA96570B734083f4a58e8F7C5f2A"),
+		WrappedNative: common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+	},
+}
+
+func ContractDeployment(chainID uint64) (Deployment, error) {
+	deployment, exists := deploymentByChainID[chainID]
+	if !exists {
+		return Deployment{}, errorNotAvailableOnChainID
+	}
+	return deployment, nil
+}
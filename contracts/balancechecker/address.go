@@ -6,7 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var errorNotAvailableOnChainID = errors.New("BalanceChecker not available for chainID")
+var ErrorNotAvailableOnChainID = errors.New("BalanceChecker not available for chainID")
 
 var contractDataByChainID = map[uint64]common.Address{
 	1:        common.HexToAddress("0x040EA8bFE441597849A9456182fa46D38B75BC05"), // mainnet
@@ -21,7 +21,7 @@ var contractDataByChainID = map[uint64]common.Address{
 func ContractAddress(chainID uint64) (common.Address, error) {
 	contract, exists := contractDataByChainID[chainID]
 	if !exists {
-		return *new(common.Address), errorNotAvailableOnChainID
+		return *new(common.Address), ErrorNotAvailableOnChainID
 	}
 	return contract, nil
 }
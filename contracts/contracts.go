@@ -10,10 +10,12 @@ import (
 	"github.com/status-im/status-go/contracts/directory"
 	"github.com/status-im/status-go/contracts/ethscan"
 	"github.com/status-im/status-go/contracts/ierc20"
+	"github.com/status-im/status-go/contracts/multicall3"
 	"github.com/status-im/status-go/contracts/registrar"
 	"github.com/status-im/status-go/contracts/resolver"
 	"github.com/status-im/status-go/contracts/snt"
 	"github.com/status-im/status-go/contracts/stickers"
+	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc"
 )
 
@@ -48,13 +50,41 @@ func (c *ContractMaker) NewRegistryWithAddress(chainID uint64, address common.Ad
 }
 
 func (c *ContractMaker) NewRegistry(chainID uint64) (*resolver.ENSRegistryWithFallback, error) {
-	contractAddr, err := resolver.ContractAddress(chainID)
+	contractAddr, err := c.ENSRegistryAddress(chainID)
 	if err != nil {
 		return nil, err
 	}
 	return c.NewRegistryWithAddress(chainID, contractAddr)
 }
 
+// ENSRegistryAddress returns the ENS registry of the chain, the networks added by the user have it when their probe
+// found it
+func (c *ContractMaker) ENSRegistryAddress(chainID uint64) (common.Address, error) {
+	if capabilities := c.networkCapabilities(chainID); capabilities != nil {
+		if !capabilities.ENS {
+			return common.Address{}, resolver.ErrorNotAvailableOnChainID
+		}
+		return resolver.RegistryAddress, nil
+	}
+	return resolver.ContractAddress(chainID)
+}
+
+// networkCapabilities returns the probed capabilities of the chain, nil when they are unknown
+func (c *ContractMaker) networkCapabilities(chainID uint64) *params.NetworkCapabilities {
+	if c.RPCClient == nil {
+		return nil
+	}
+	networkManager := c.RPCClient.GetNetworkManager()
+	if networkManager == nil {
+		return nil
+	}
+	network := networkManager.Find(chainID)
+	if network == nil {
+		return nil
+	}
+	return network.Capabilities
+}
+
 func (c *ContractMaker) NewPublicResolver(chainID uint64, resolverAddress *common.Address) (*resolver.PublicResolver, error) {
 	backend, err := c.RPCClient.EthClient(chainID)
 	if err != nil {
@@ -178,9 +208,14 @@ func (c *ContractMaker) NewDirectory(chainID uint64) (*directory.Directory, erro
 	)
 }
 
+// NewEthScan returns the balance scanner of the chain. The networks added by the user don't have the ethscan
+// contract, Multicall3 is used instead when their probe found it.
 func (c *ContractMaker) NewEthScan(chainID uint64) (ethscan.BalanceScannerIface, uint, error) {
 	contractAddr, err := ethscan.ContractAddress(chainID)
 	if err != nil {
+		if capabilities := c.networkCapabilities(chainID); capabilities != nil && capabilities.Multicall3 {
+			return c.newMulticall3Scanner(chainID)
+		}
 		return nil, 0, err
 	}
 
@@ -202,6 +237,17 @@ func (c *ContractMaker) NewEthScan(chainID uint64) (ethscan.BalanceScannerIface,
 	return scanner, contractCreatedAt, err
 }
 
+func (c *ContractMaker) newMulticall3Scanner(chainID uint64) (ethscan.BalanceScannerIface, uint, error) {
+	backend, err := c.RPCClient.EthClient(chainID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The block where Multicall3 was deployed is unknown, it is assumed to be available at all the blocks
+	scanner, err := multicall3.NewBalanceScanner(multicall3.ContractAddress, backend)
+	return scanner, 0, err
+}
+
 func (c *ContractMaker) NewBalanceChecker(chainID uint64) (*balancechecker.BalanceChecker, error) {
 	contractAddr, err := balancechecker.ContractAddress(chainID)
 	if err != nil {
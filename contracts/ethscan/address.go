@@ -6,7 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var errorNotAvailableOnChainID = errors.New("not available for chainID")
+var ErrorNotAvailableOnChainID = errors.New("not available for chainID")
 
 type ContractData struct {
 	Address        common.Address
@@ -26,7 +26,7 @@ var contractDataByChainID = map[uint64]ContractData{
 func ContractAddress(chainID uint64) (common.Address, error) {
 	contract, exists := contractDataByChainID[chainID]
 	if !exists {
-		return *new(common.Address), errorNotAvailableOnChainID
+		return *new(common.Address), ErrorNotAvailableOnChainID
 	}
 	return contract.Address, nil
 }
@@ -34,7 +34,7 @@ func ContractAddress(chainID uint64) (common.Address, error) {
 func ContractCreatedAt(chainID uint64) (uint, error) {
 	contract, exists := contractDataByChainID[chainID]
 	if !exists {
-		return 0, errorNotAvailableOnChainID
+		return 0, ErrorNotAvailableOnChainID
 	}
 	return contract.CreatedAtBlock, nil
 }
@@ -10,6 +10,9 @@ import (
 
 var ErrorNotAvailableOnChainID = errors.New("not available for chainID")
 
+// PredeployAddress is the address of the oracle on the OP Stack chains
+var PredeployAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+
 var contractAddressByChainID = map[uint64]common.Address{
 	wallet_common.OptimismMainnet: common.HexToAddress("0x8527c030424728cF93E72bDbf7663281A44Eeb22"),
 	wallet_common.OptimismSepolia: common.HexToAddress("0x5230210c2b4995FD5084b0F5FD0D7457aebb5010"),
@@ -0,0 +1,8 @@
+package multicall3
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractAddress is the address of Multicall3 on the chains where it is deployed, it is the same on all of them
+var ContractAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
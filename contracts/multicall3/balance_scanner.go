@@ -0,0 +1,95 @@
+package multicall3
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/contracts/ethscan"
+	"github.com/status-im/status-go/contracts/ierc20"
+)
+
+// BalanceScanner fetches the balances with Multicall3 on the chains without the ethscan contract
+type BalanceScanner struct {
+	caller   *Multicall3Caller
+	multi    abi.ABI
+	erc20ABI abi.ABI
+}
+
+func NewBalanceScanner(address common.Address, caller bind.ContractCaller) (*BalanceScanner, error) {
+	multicallCaller, err := NewMulticall3Caller(address, caller)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := Multicall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	erc20ABI, err := abi.JSON(strings.NewReader(ierc20.IERC20ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &BalanceScanner{
+		caller:   multicallCaller,
+		multi:    *multi,
+		erc20ABI: erc20ABI,
+	}, nil
+}
+
+func (s *BalanceScanner) EtherBalances(opts *bind.CallOpts, addresses []common.Address) ([]ethscan.BalanceScannerResult, error) {
+	calls := make([]Multicall3Call3, 0, len(addresses))
+	for _, address := range addresses {
+		data, err := s.multi.Pack("getEthBalance", address)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, Multicall3Call3{Target: ContractAddress, AllowFailure: true, CallData: data})
+	}
+	return s.aggregate(opts, calls)
+}
+
+func (s *BalanceScanner) TokenBalances(opts *bind.CallOpts, addresses []common.Address, tokenAddress common.Address) ([]ethscan.BalanceScannerResult, error) {
+	calls := make([]Multicall3Call3, 0, len(addresses))
+	for _, address := range addresses {
+		data, err := s.erc20ABI.Pack("balanceOf", address)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, Multicall3Call3{Target: tokenAddress, AllowFailure: true, CallData: data})
+	}
+	return s.aggregate(opts, calls)
+}
+
+func (s *BalanceScanner) TokensBalance(opts *bind.CallOpts, owner common.Address, contracts []common.Address) ([]ethscan.BalanceScannerResult, error) {
+	data, err := s.erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]Multicall3Call3, 0, len(contracts))
+	for _, contract := range contracts {
+		calls = append(calls, Multicall3Call3{Target: contract, AllowFailure: true, CallData: data})
+	}
+	return s.aggregate(opts, calls)
+}
+
+// aggregate makes the calls in a single eth_call, the calls to addresses without code succeed with no data and
+// are reported as failed like ethscan does
+func (s *BalanceScanner) aggregate(opts *bind.CallOpts, calls []Multicall3Call3) ([]ethscan.BalanceScannerResult, error) {
+	res, err := s.caller.Aggregate3(opts, calls)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ethscan.BalanceScannerResult, 0, len(res))
+	for _, r := range res {
+		results = append(results, ethscan.BalanceScannerResult{
+			Success: r.Success && len(r.ReturnData) >= common.HashLength,
+			Data:    r.ReturnData,
+		})
+	}
+	return results, nil
+}
+
+// Verify that BalanceScanner implements ethscan.BalanceScannerIface
+var _ ethscan.BalanceScannerIface = (*BalanceScanner)(nil)
@@ -0,0 +1,5 @@
+package multicall3
+
+// aggregate3 is payable in the contract, it is declared view in multicall3.abi to be called with eth_call
+
+//go:generate abigen -abi multicall3.abi -pkg multicall3 -out multicall3.go
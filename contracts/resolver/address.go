@@ -6,17 +6,20 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var errorNotAvailableOnChainID = errors.New("not available for chainID")
+var ErrorNotAvailableOnChainID = errors.New("not available for chainID")
+
+// RegistryAddress is the address of the ENS registry, it is the same on the chains where it is deployed
+var RegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
 
 var contractAddressByChainID = map[uint64]common.Address{
-	1:        common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"), // mainnet
-	11155111: common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"), // sepolia testnet
+	1:        RegistryAddress, // mainnet
+	11155111: RegistryAddress, // sepolia testnet
 }
 
 func ContractAddress(chainID uint64) (common.Address, error) {
 	addr, exists := contractAddressByChainID[chainID]
 	if !exists {
-		return *new(common.Address), errorNotAvailableOnChainID
+		return *new(common.Address), ErrorNotAvailableOnChainID
 	}
 	return addr, nil
 }
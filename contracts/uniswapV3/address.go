@@ -0,0 +1,41 @@
+package uniswapv3
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errorNotAvailableOnChainID = errors.New("not available for chainID")
+
+type Deployment struct {
+	QuoterV2      common.Address
+	SwapRouter02  common.Address
+	WrappedNative common.Address
+}
+
+var deploymentByChainID = map[uint64]Deployment{
+	1: { // mainnet
+		QuoterV2:      common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e"),
+		SwapRouter02:  common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		WrappedNative: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+	},
+	10: { // optimism
+		QuoterV2:      common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e"),
+		SwapRouter02:  common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		WrappedNative: common.HexToAddress("0x4200000000000000000000000000000000000006"),
+	},
+	42161: { // arbitrum
+		QuoterV2:      common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e"),
+		SwapRouter02:  common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"),
+		WrappedNative: common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+	},
+}
+
+func ContractDeployment(chainID uint64) (Deployment, error) {
+	deployment, exists := deploymentByChainID[chainID]
+	if !exists {
+		return Deployment{}, errorNotAvailableOnChainID
+	}
+	return deployment, nil
+}
@@ -1,3 +1,7 @@
 package uniswapv3
 
+// The QuoterV2 quote functions are not view but are meant to be called with eth_call
+
 //go:generate abigen --abi IUniswapV3Pool.abi --pkg uniswapv3 --out uniswapv3pool.go
+//go:generate abigen --abi IQuoterV2.abi --pkg uniswapv3 --type IQuoterV2 --out quoterv2.go
+//go:generate abigen --abi ISwapRouter02.abi --pkg uniswapv3 --type ISwapRouter02 --out swaprouter02.go
@@ -0,0 +1,339 @@
+package ens
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxOffchainLookups bounds the number of gateway round trips for a single call, as recommended by EIP-3668
+	maxOffchainLookups = 4
+	gatewayTimeout     = 10 * time.Second
+	// maxGatewayResponseSize protects against gateways returning huge payloads
+	maxGatewayResponseSize = 1 << 20
+
+	offchainLookupABI = `[
+	{"name":"OffchainLookup","type":"error","inputs":[{"name":"sender","type":"address"},{"name":"urls","type":"string[]"},
+		{"name":"callData","type":"bytes"},{"name":"callbackFunction","type":"bytes4"},{"name":"extraData","type":"bytes"}]},
+	{"name":"callback","type":"function","inputs":[{"name":"response","type":"bytes"},{"name":"extraData","type":"bytes"}],"outputs":[]},
+	{"name":"signedResponse","type":"function","inputs":[{"name":"result","type":"bytes"},{"name":"expires","type":"uint64"},{"name":"sig","type":"bytes"}],"outputs":[]},
+	{"name":"signers","type":"function","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"bool"}]}
+	]`
+)
+
+var (
+	ErrTooManyOffchainLookups = errors.New("too many offchain lookups")
+	ErrInvalidOffchainLookup  = errors.New("invalid offchain lookup")
+	ErrGatewayFailed          = errors.New("all gateways failed")
+	ErrGatewayResponseExpired = errors.New("gateway response expired")
+	ErrUntrustedGatewaySigner = errors.New("gateway response not signed by a signer of the resolver")
+)
+
+// resolveWithProofSelector is the callback of the ENS offchain resolvers whose gateway responses are signed
+var resolveWithProofSelector = [4]byte{0xf4, 0xd4, 0xd2, 0xf8}
+
+var ccipABI abi.ABI
+
+func init() {
+	var err error
+	ccipABI, err = abi.JSON(strings.NewReader(offchainLookupABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ContractCaller is the subset of the eth client used for resolution
+type ContractCaller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// OffchainLookup is the revert data of contracts deferring a call to a gateway (EIP-3668)
+type OffchainLookup struct {
+	Sender           common.Address
+	URLs             []string
+	CallData         []byte
+	CallbackFunction [4]byte
+	ExtraData        []byte
+}
+
+// parseOffchainLookup returns the lookup if the error is an OffchainLookup revert
+func parseOffchainLookup(err error) (*OffchainLookup, bool) {
+	var dataErr gethrpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	var data []byte
+	switch d := dataErr.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(d)
+		if err != nil {
+			return nil, false
+		}
+		data = decoded
+	case []byte:
+		data = d
+	default:
+		return nil, false
+	}
+
+	lookupError := ccipABI.Errors["OffchainLookup"]
+	if len(data) < 4 || !bytes.Equal(data[:4], lookupError.ID[:4]) {
+		return nil, false
+	}
+
+	values, err := lookupError.Inputs.Unpack(data[4:])
+	if err != nil || len(values) != 5 {
+		return nil, false
+	}
+
+	lookup := &OffchainLookup{}
+	lookup.Sender, _ = values[0].(common.Address)
+	lookup.URLs, _ = values[1].([]string)
+	lookup.CallData, _ = values[2].([]byte)
+	lookup.CallbackFunction, _ = values[3].([4]byte)
+	lookup.ExtraData, _ = values[4].([]byte)
+	return lookup, true
+}
+
+// GatewayClient queries CCIP-Read gateways
+type GatewayClient struct {
+	httpClient *http.Client
+}
+
+func NewGatewayClient() *GatewayClient {
+	return &GatewayClient{
+		httpClient: &http.Client{Timeout: gatewayTimeout},
+	}
+}
+
+type gatewayRequest struct {
+	Data   string `json:"data"`
+	Sender string `json:"sender"`
+}
+
+type gatewayResponse struct {
+	Data string `json:"data"`
+}
+
+// Fetch queries the gateways of the lookup in order until one answers, following the EIP-3668 URL template rules
+func (g *GatewayClient) Fetch(ctx context.Context, lookup *OffchainLookup) ([]byte, error) {
+	sender := strings.ToLower(lookup.Sender.Hex())
+	data := hexutil.Encode(lookup.CallData)
+
+	var errs []error
+	for _, template := range lookup.URLs {
+		url := strings.ReplaceAll(template, "{sender}", sender)
+
+		var req *http.Request
+		var err error
+		if strings.Contains(template, "{data}") {
+			url = strings.ReplaceAll(url, "{data}", data)
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		} else {
+			var body []byte
+			body, err = json.Marshal(gatewayRequest{Data: data, Sender: sender})
+			if err != nil {
+				return nil, err
+			}
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+			if req != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		res, err := g.do(req)
+		if err != nil {
+			errs = append(errs, err)
+			// 4xx responses are final, other gateways would answer the same
+			var statusErr *gatewayStatusError
+			if errors.As(err, &statusErr) && statusErr.code >= 400 && statusErr.code < 500 {
+				break
+			}
+			continue
+		}
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrGatewayFailed, errors.Join(errs...))
+}
+
+type gatewayStatusError struct {
+	code    int
+	message string
+}
+
+func (e *gatewayStatusError) Error() string {
+	return fmt.Sprintf("gateway returned %d: %s", e.code, e.message)
+}
+
+func (g *GatewayClient) do(req *http.Request) ([]byte, error) {
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGatewayResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &gatewayStatusError{code: resp.StatusCode, message: string(body)}
+	}
+
+	return decodeGatewayResponse(body)
+}
+
+func decodeGatewayResponse(body []byte) ([]byte, error) {
+	var res gatewayResponse
+	err := json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(res.Data)
+}
+
+// recoverResponseSigner checks the responses meant for resolveWithProof callbacks before calling the resolver:
+// the response must not be expired and must carry a valid signature of the request. It returns the signer,
+// which must then be one of the resolver's signers
+func recoverResponseSigner(lookup *OffchainLookup, response []byte, now time.Time) (common.Address, error) {
+	values, err := ccipABI.Methods["signedResponse"].Inputs.Unpack(response)
+	if err != nil || len(values) != 3 {
+		return common.Address{}, fmt.Errorf("%w: malformed signed response", ErrInvalidOffchainLookup)
+	}
+	result, _ := values[0].([]byte)
+	expires, _ := values[1].(uint64)
+	sig, _ := values[2].([]byte)
+
+	if expires < uint64(now.Unix()) {
+		return common.Address{}, ErrGatewayResponseExpired
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: invalid signature length", ErrInvalidOffchainLookup)
+	}
+	// the contracts expect Ethereum style signatures
+	sigCopy := make([]byte, len(sig))
+	copy(sigCopy, sig)
+	if sigCopy[crypto.RecoveryIDOffset] >= 27 {
+		sigCopy[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(signedResponseHash(lookup.Sender, expires, lookup.ExtraData, result), sigCopy)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidOffchainLookup, err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// signedResponseHash is the message signed by offchain resolver gateways
+func signedResponseHash(target common.Address, expires uint64, request []byte, result []byte) []byte {
+	expiresBytes := new(big.Int).SetUint64(expires).FillBytes(make([]byte, 8))
+	return crypto.Keccak256(
+		[]byte{0x19, 0x00},
+		target.Bytes(),
+		expiresBytes,
+		crypto.Keccak256(request),
+		crypto.Keccak256(result),
+	)
+}
+
+// CCIPCaller performs contract calls following the offchain lookups requested by the called contracts
+type CCIPCaller struct {
+	caller  ContractCaller
+	gateway *GatewayClient
+}
+
+func NewCCIPCaller(caller ContractCaller, gateway *GatewayClient) *CCIPCaller {
+	return &CCIPCaller{
+		caller:  caller,
+		gateway: gateway,
+	}
+}
+
+func (c *CCIPCaller) Call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	for i := 0; i <= maxOffchainLookups; i++ {
+		res, err := c.caller.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+		if err == nil {
+			return res, nil
+		}
+
+		lookup, ok := parseOffchainLookup(err)
+		if !ok {
+			return nil, err
+		}
+		// EIP-3668: the sender must be the called contract, otherwise the lookup comes from a nested call
+		if lookup.Sender != to {
+			return nil, fmt.Errorf("%w: sender %s doesn't match %s", ErrInvalidOffchainLookup, lookup.Sender.Hex(), to.Hex())
+		}
+		if len(lookup.URLs) == 0 {
+			return nil, fmt.Errorf("%w: no gateway", ErrInvalidOffchainLookup)
+		}
+
+		response, err := c.gateway.Fetch(ctx, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		if lookup.CallbackFunction == resolveWithProofSelector {
+			err = c.verifySignedResponse(ctx, lookup, response)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		callbackArgs, err := ccipABI.Methods["callback"].Inputs.Pack(response, lookup.ExtraData)
+		if err != nil {
+			return nil, err
+		}
+		data = append(lookup.CallbackFunction[:], callbackArgs...)
+	}
+
+	return nil, ErrTooManyOffchainLookups
+}
+
+// verifySignedResponse checks that the response is valid and signed by one of the signers of the resolver
+// which raised the lookup, as done by the resolver in the callback
+func (c *CCIPCaller) verifySignedResponse(ctx context.Context, lookup *OffchainLookup, response []byte) error {
+	signer, err := recoverResponseSigner(lookup, response, time.Now())
+	if err != nil {
+		return err
+	}
+
+	data, err := ccipABI.Pack("signers", signer)
+	if err != nil {
+		return err
+	}
+	out, err := c.caller.CallContract(ctx, ethereum.CallMsg{To: &lookup.Sender, Data: data}, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUntrustedGatewaySigner, err)
+	}
+
+	var trusted bool
+	err = ccipABI.UnpackIntoInterface(&trusted, "signers", out)
+	if err != nil || !trusted {
+		return fmt.Errorf("%w: %s", ErrUntrustedGatewaySigner, signer.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package ens
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+func offchainLookupError(t *testing.T, lookup OffchainLookup) error {
+	lookupError := ccipABI.Errors["OffchainLookup"]
+	args, err := lookupError.Inputs.Pack(lookup.Sender, lookup.URLs, lookup.CallData, lookup.CallbackFunction, lookup.ExtraData)
+	require.NoError(t, err)
+	return &revertError{data: hexutil.Encode(append(lookupError.ID[:4], args...))}
+}
+
+type callHandler func(call ethereum.CallMsg) ([]byte, error)
+
+type testCaller struct {
+	handlers map[common.Address]callHandler
+}
+
+func (c *testCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	handler, ok := c.handlers[*call.To]
+	if !ok {
+		return nil, nil
+	}
+	return handler(call)
+}
+
+func TestParseOffchainLookup(t *testing.T) {
+	lookup := OffchainLookup{
+		Sender:           common.HexToAddress("0x1"),
+		URLs:             []string{"https://gateway.example/{sender}/{data}.json"},
+		CallData:         []byte{0x01, 0x02},
+		CallbackFunction: [4]byte{0xaa, 0xbb, 0xcc, 0xdd},
+		ExtraData:        []byte{0x03},
+	}
+
+	parsed, ok := parseOffchainLookup(offchainLookupError(t, lookup))
+	require.True(t, ok)
+	require.Equal(t, lookup, *parsed)
+
+	_, ok = parseOffchainLookup(&revertError{data: "0x08c379a0"})
+	require.False(t, ok)
+
+	_, ok = parseOffchainLookup(context.DeadlineExceeded)
+	require.False(t, ok)
+}
+
+func TestGatewayClientFetch(t *testing.T) {
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000ab")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/failing":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/get/0x00000000000000000000000000000000000000ab/0x0102.json":
+			require.Equal(t, http.MethodGet, r.Method)
+			_, _ = w.Write([]byte(`{"data":"0x0a0b"}`))
+		case "/post":
+			require.Equal(t, http.MethodPost, r.Method)
+			var req gatewayRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "0x0102", req.Data)
+			require.Equal(t, "0x00000000000000000000000000000000000000ab", req.Sender)
+			_, _ = w.Write([]byte(`{"data":"0x0c"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGatewayClient()
+	lookup := &OffchainLookup{
+		Sender:   sender,
+		CallData: []byte{0x01, 0x02},
+		URLs:     []string{server.URL + "/failing", server.URL + "/get/{sender}/{data}.json"},
+	}
+
+	res, err := client.Fetch(context.Background(), lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x0a, 0x0b}, res)
+
+	lookup.URLs = []string{server.URL + "/post"}
+	res, err = client.Fetch(context.Background(), lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x0c}, res)
+
+	// client errors aren't retried on the next gateways
+	lookup.URLs = []string{server.URL + "/missing", server.URL + "/post"}
+	_, err = client.Fetch(context.Background(), lookup)
+	require.ErrorIs(t, err, ErrGatewayFailed)
+}
+
+func signedResponse(t *testing.T, key *ecdsa.PrivateKey, sender common.Address, expires uint64, request []byte, result []byte) []byte {
+	sig, err := crypto.Sign(signedResponseHash(sender, expires, request, result), key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	res, err := ccipABI.Methods["signedResponse"].Inputs.Pack(result, expires, sig)
+	require.NoError(t, err)
+	return res
+}
+
+func TestRecoverResponseSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	now := time.Now()
+	lookup := &OffchainLookup{
+		Sender:    common.HexToAddress("0x1"),
+		ExtraData: []byte{0x01},
+	}
+
+	response := signedResponse(t, key, lookup.Sender, uint64(now.Add(time.Minute).Unix()), lookup.ExtraData, []byte{0x02})
+	signer, err := recoverResponseSigner(lookup, response, now)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	response = signedResponse(t, key, lookup.Sender, uint64(now.Add(-time.Minute).Unix()), lookup.ExtraData, []byte{0x02})
+	_, err = recoverResponseSigner(lookup, response, now)
+	require.ErrorIs(t, err, ErrGatewayResponseExpired)
+
+	_, err = recoverResponseSigner(lookup, []byte{0x01}, now)
+	require.ErrorIs(t, err, ErrInvalidOffchainLookup)
+}
+
+func TestCCIPCallerVerifySignedResponse(t *testing.T) {
+	trustedKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	untrustedKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	resolver := common.HexToAddress("0x1")
+	caller := &testCaller{handlers: map[common.Address]callHandler{
+		resolver: func(call ethereum.CallMsg) ([]byte, error) {
+			args, err := ccipABI.Methods["signers"].Inputs.Unpack(call.Data[4:])
+			require.NoError(t, err)
+			return ccipABI.Methods["signers"].Outputs.Pack(args[0] == crypto.PubkeyToAddress(trustedKey.PublicKey))
+		},
+	}}
+	ccipCaller := NewCCIPCaller(caller, NewGatewayClient())
+
+	lookup := &OffchainLookup{Sender: resolver, ExtraData: []byte{0x01}}
+	expires := uint64(time.Now().Add(time.Minute).Unix())
+
+	response := signedResponse(t, trustedKey, lookup.Sender, expires, lookup.ExtraData, []byte{0x02})
+	require.NoError(t, ccipCaller.verifySignedResponse(context.Background(), lookup, response))
+
+	response = signedResponse(t, untrustedKey, lookup.Sender, expires, lookup.ExtraData, []byte{0x02})
+	require.ErrorIs(t, ccipCaller.verifySignedResponse(context.Background(), lookup, response), ErrUntrustedGatewaySigner)
+
+	// resolvers without signers can't vouch for the response
+	lookup.Sender = common.HexToAddress("0x2")
+	caller.handlers[lookup.Sender] = func(call ethereum.CallMsg) ([]byte, error) {
+		return nil, &revertError{data: "0x"}
+	}
+	response = signedResponse(t, trustedKey, lookup.Sender, expires, lookup.ExtraData, []byte{0x02})
+	require.ErrorIs(t, ccipCaller.verifySignedResponse(context.Background(), lookup, response), ErrUntrustedGatewaySigner)
+}
+
+func TestCCIPCallerCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":"0x0a"}`))
+	}))
+	defer server.Close()
+
+	target := common.HexToAddress("0x1")
+	callback := [4]byte{0x11, 0x22, 0x33, 0x44}
+	lookup := OffchainLookup{
+		Sender:           target,
+		URLs:             []string{server.URL + "/{data}"},
+		CallData:         []byte{0x01},
+		CallbackFunction: callback,
+		ExtraData:        []byte{0x02},
+	}
+
+	caller := &testCaller{handlers: map[common.Address]callHandler{
+		target: func(call ethereum.CallMsg) ([]byte, error) {
+			if [4]byte(call.Data[:4]) != callback {
+				return nil, offchainLookupError(t, lookup)
+			}
+			args, err := ccipABI.Methods["callback"].Inputs.Unpack(call.Data[4:])
+			require.NoError(t, err)
+			require.Equal(t, []byte{0x0a}, args[0])
+			require.Equal(t, []byte{0x02}, args[1])
+			return []byte{0xff}, nil
+		},
+	}}
+
+	ccipCaller := NewCCIPCaller(caller, NewGatewayClient())
+	res, err := ccipCaller.Call(context.Background(), target, []byte{0x00, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff}, res)
+
+	// lookups raised by another contract are rejected
+	_, err = ccipCaller.Call(context.Background(), common.HexToAddress("0x2"), []byte{0x00, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	caller.handlers[common.HexToAddress("0x2")] = caller.handlers[target]
+	_, err = ccipCaller.Call(context.Background(), common.HexToAddress("0x2"), []byte{0x00, 0x00, 0x00, 0x00})
+	require.ErrorIs(t, err, ErrInvalidOffchainLookup)
+
+	// lookups must end
+	lookup.CallbackFunction = [4]byte{}
+	_, err = ccipCaller.Call(context.Background(), target, []byte{0x00, 0x00, 0x00, 0x00})
+	require.ErrorIs(t, err, ErrTooManyOffchainLookups)
+}
@@ -43,7 +43,7 @@ func (m *Verifier) ReverseResolve(address common.Address, rpcEndpoint string) (s
 	return ens.ReverseResolve(ethClient, address)
 }
 
-func (m *Verifier) verifyENSName(ensInfo enstypes.ENSDetails, ethclient *ethclient.Client) enstypes.ENSResponse {
+func (m *Verifier) verifyENSName(ctx context.Context, ensInfo enstypes.ENSDetails, resolver *Resolver) enstypes.ENSResponse {
 	publicKeyStr := ensInfo.PublicKeyString
 	ensName := ensInfo.Name
 	m.logger.Info("Resolving ENS name", zap.String("name", ensName), zap.String("publicKey", publicKeyStr))
@@ -65,14 +65,8 @@ func (m *Verifier) verifyENSName(ensInfo enstypes.ENSDetails, ethclient *ethclie
 		return response
 	}
 
-	// Resolve ensName
-	resolver, err := ens.NewResolver(ethclient, ensName)
-	if err != nil {
-		m.logger.Error("error while creating ENS name resolver", zap.String("ensName", ensName), zap.Error(err))
-		response.Error = err
-		return response
-	}
-	x, y, err := resolver.PubKey()
+	// Resolve ensName, following wildcard and offchain resolvers
+	x, y, err := resolver.PubKey(ctx, ensName)
 	if err != nil {
 		m.logger.Error("error while resolving public key from ENS name", zap.String("ensName", ensName), zap.Error(err))
 		response.Error = err
@@ -100,10 +94,12 @@ func (m *Verifier) CheckBatch(ensDetails []enstypes.ENSDetails, rpcEndpoint, con
 		return nil, err
 	}
 
+	resolver := NewResolver(ethclient, common.HexToAddress(contractAddress))
+
 	for _, ensInfo := range ensDetails {
 		go func(info enstypes.ENSDetails) {
 			defer gocommon.LogOnPanic()
-			ch <- m.verifyENSName(info, ethclient)
+			ch <- m.verifyENSName(ctx, info, resolver)
 		}(ensInfo)
 	}
 
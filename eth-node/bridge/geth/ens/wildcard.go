@@ -0,0 +1,229 @@
+package ens
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	ens "github.com/wealdtech/go-ens/v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	resolutionCacheTTL      = 5 * time.Minute
+	resolutionCacheCapacity = 1000
+
+	resolverABI = `[
+	{"name":"resolver","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"supportsInterface","type":"function","stateMutability":"view","inputs":[{"name":"interfaceID","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"resolve","type":"function","stateMutability":"view","inputs":[{"name":"name","type":"bytes"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes"}]},
+	{"name":"addr","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"pubkey","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"x","type":"bytes32"},{"name":"y","type":"bytes32"}]},
+	{"name":"contenthash","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"bytes"}]}
+	]`
+)
+
+var ErrResolverNotFound = errors.New("no resolver found")
+
+// extendedResolverInterfaceID is the ENSIP-10 IExtendedResolver interface id
+var extendedResolverInterfaceID = [4]byte{0x90, 0x61, 0xb9, 0x23}
+
+var resolverContractABI abi.ABI
+
+func init() {
+	var err error
+	resolverContractABI, err = abi.JSON(strings.NewReader(resolverABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Resolver resolves ENS records following ENSIP-10 wildcard resolution and EIP-3668 offchain lookups
+type Resolver struct {
+	caller   *CCIPCaller
+	registry common.Address
+
+	cache *ttlcache.Cache[string, []byte]
+}
+
+func NewResolver(caller ContractCaller, registry common.Address) *Resolver {
+	return &Resolver{
+		caller:   NewCCIPCaller(caller, NewGatewayClient()),
+		registry: registry,
+		cache: ttlcache.New[string, []byte](
+			ttlcache.WithTTL[string, []byte](resolutionCacheTTL),
+			ttlcache.WithCapacity[string, []byte](resolutionCacheCapacity),
+			ttlcache.WithDisableTouchOnHit[string, []byte](),
+		),
+	}
+}
+
+// FindResolver returns the resolver of the name or, as defined by ENSIP-10, of its closest parent having one.
+// A resolver found on a parent is only used if it supports wildcard resolution
+func (r *Resolver) FindResolver(ctx context.Context, name string) (resolver common.Address, extended bool, err error) {
+	labels := strings.Split(name, ".")
+	for i := range labels {
+		parent := strings.Join(labels[i:], ".")
+		node, err := ens.NameHash(parent)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+
+		resolver, err = r.resolverOf(ctx, node)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		if resolver == (common.Address{}) {
+			continue
+		}
+
+		extended = r.supportsInterface(ctx, resolver, extendedResolverInterfaceID)
+		if i > 0 && !extended {
+			break
+		}
+		return resolver, extended, nil
+	}
+
+	return common.Address{}, false, ErrResolverNotFound
+}
+
+// Resolve runs the resolver call data for the name, through resolve(bytes,bytes) when the resolver supports it
+func (r *Resolver) Resolve(ctx context.Context, name string, data []byte) ([]byte, error) {
+	name, err := ens.Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := name + ":" + hex.EncodeToString(data)
+	if res, ok := r.getCached(key); ok {
+		return res, nil
+	}
+
+	resolver, extended, err := r.FindResolver(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []byte
+	if extended {
+		callData, err := resolverContractABI.Pack("resolve", ens.DNSWireFormat(name), data)
+		if err != nil {
+			return nil, err
+		}
+		out, err := r.caller.Call(ctx, resolver, callData)
+		if err != nil {
+			return nil, err
+		}
+		err = resolverContractABI.UnpackIntoInterface(&res, "resolve", out)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		res, err = r.caller.Call(ctx, resolver, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.setCached(key, res)
+	return res, nil
+}
+
+func (r *Resolver) Addr(ctx context.Context, name string) (common.Address, error) {
+	var addr common.Address
+	err := r.resolveRecord(ctx, name, "addr", &addr)
+	return addr, err
+}
+
+func (r *Resolver) PubKey(ctx context.Context, name string) (x [32]byte, y [32]byte, err error) {
+	out, err := r.resolve(ctx, name, "pubkey")
+	if err != nil {
+		return x, y, err
+	}
+
+	values, err := resolverContractABI.Unpack("pubkey", out)
+	if err != nil {
+		return x, y, err
+	}
+	x, _ = values[0].([32]byte)
+	y, _ = values[1].([32]byte)
+	return x, y, nil
+}
+
+func (r *Resolver) ContentHash(ctx context.Context, name string) ([]byte, error) {
+	var contentHash []byte
+	err := r.resolveRecord(ctx, name, "contenthash", &contentHash)
+	return contentHash, err
+}
+
+func (r *Resolver) resolveRecord(ctx context.Context, name string, method string, out interface{}) error {
+	res, err := r.resolve(ctx, name, method)
+	if err != nil {
+		return err
+	}
+	return resolverContractABI.UnpackIntoInterface(out, method, res)
+}
+
+func (r *Resolver) resolve(ctx context.Context, name string, method string) ([]byte, error) {
+	node, err := ens.NameHash(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := resolverContractABI.Pack(method, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Resolve(ctx, name, data)
+}
+
+func (r *Resolver) resolverOf(ctx context.Context, node [32]byte) (common.Address, error) {
+	data, err := resolverContractABI.Pack("resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	out, err := r.caller.Call(ctx, r.registry, data)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var resolver common.Address
+	err = resolverContractABI.UnpackIntoInterface(&resolver, "resolver", out)
+	return resolver, err
+}
+
+func (r *Resolver) supportsInterface(ctx context.Context, resolver common.Address, interfaceID [4]byte) bool {
+	data, err := resolverContractABI.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		return false
+	}
+
+	// resolvers predating EIP-165 revert or return nothing
+	out, err := r.caller.Call(ctx, resolver, data)
+	if err != nil {
+		return false
+	}
+
+	var supported bool
+	err = resolverContractABI.UnpackIntoInterface(&supported, "supportsInterface", out)
+	return err == nil && supported
+}
+
+func (r *Resolver) getCached(key string) ([]byte, bool) {
+	item := r.cache.Get(key)
+	if item == nil {
+		return nil, false
+	}
+	return item.Value(), true
+}
+
+func (r *Resolver) setCached(key string, value []byte) {
+	r.cache.Set(key, value, ttlcache.DefaultTTL)
+}
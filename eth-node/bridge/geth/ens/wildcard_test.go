@@ -0,0 +1,104 @@
+package ens
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ens "github.com/wealdtech/go-ens/v3"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func registryHandler(t *testing.T, resolvers map[string]common.Address) callHandler {
+	return func(call ethereum.CallMsg) ([]byte, error) {
+		values, err := resolverContractABI.Methods["resolver"].Inputs.Unpack(call.Data[4:])
+		require.NoError(t, err)
+		node := values[0].([32]byte)
+
+		var resolver common.Address
+		for name, addr := range resolvers {
+			nameNode, err := ens.NameHash(name)
+			require.NoError(t, err)
+			if nameNode == node {
+				resolver = addr
+			}
+		}
+		return resolverContractABI.Methods["resolver"].Outputs.Pack(resolver)
+	}
+}
+
+func resolverHandler(t *testing.T, extended bool, addr common.Address) callHandler {
+	return func(call ethereum.CallMsg) ([]byte, error) {
+		method, err := resolverContractABI.MethodById(call.Data[:4])
+		require.NoError(t, err)
+
+		switch method.Name {
+		case "supportsInterface":
+			values, err := method.Inputs.Unpack(call.Data[4:])
+			require.NoError(t, err)
+			return method.Outputs.Pack(extended && values[0].([4]byte) == extendedResolverInterfaceID)
+		case "resolve":
+			require.True(t, extended)
+			values, err := method.Inputs.Unpack(call.Data[4:])
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(ens.DNSWireFormat("sub.example.eth"), values[0].([]byte)))
+			res, err := resolverContractABI.Methods["addr"].Outputs.Pack(addr)
+			require.NoError(t, err)
+			return method.Outputs.Pack(res)
+		case "addr":
+			return method.Outputs.Pack(addr)
+		}
+		return nil, nil
+	}
+}
+
+func TestResolverWildcard(t *testing.T) {
+	registry := common.HexToAddress("0x1")
+	resolver := common.HexToAddress("0x2")
+	expected := common.HexToAddress("0x3")
+
+	caller := &testCaller{handlers: map[common.Address]callHandler{
+		registry: registryHandler(t, map[string]common.Address{"example.eth": resolver}),
+		resolver: resolverHandler(t, true, expected),
+	}}
+
+	r := NewResolver(caller, registry)
+
+	found, extended, err := r.FindResolver(context.Background(), "sub.example.eth")
+	require.NoError(t, err)
+	require.Equal(t, resolver, found)
+	require.True(t, extended)
+
+	addr, err := r.Addr(context.Background(), "sub.example.eth")
+	require.NoError(t, err)
+	require.Equal(t, expected, addr)
+
+	// results are served from the cache
+	delete(caller.handlers, resolver)
+	addr, err = r.Addr(context.Background(), "sub.example.eth")
+	require.NoError(t, err)
+	require.Equal(t, expected, addr)
+}
+
+func TestResolverParentWithoutWildcardSupport(t *testing.T) {
+	registry := common.HexToAddress("0x1")
+	resolver := common.HexToAddress("0x2")
+	expected := common.HexToAddress("0x3")
+
+	caller := &testCaller{handlers: map[common.Address]callHandler{
+		registry: registryHandler(t, map[string]common.Address{"example.eth": resolver}),
+		resolver: resolverHandler(t, false, expected),
+	}}
+
+	r := NewResolver(caller, registry)
+
+	_, err := r.Addr(context.Background(), "sub.example.eth")
+	require.ErrorIs(t, err, ErrResolverNotFound)
+
+	addr, err := r.Addr(context.Background(), "example.eth")
+	require.NoError(t, err)
+	require.Equal(t, expected, addr)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -161,6 +162,16 @@ func (d *Downloader) Get(hash string, download bool) ([]byte, error) {
 		return nil, err
 	}
 
+	return d.GetByCID(cid, download)
+}
+
+// GetByCID is like Get for content already addressed by a CID, optionally followed by a path
+// within it (i.e. "<cid>/metadata.json"), as found in ipfs:// URIs
+func (d *Downloader) GetByCID(cid string, download bool) ([]byte, error) {
+	if cid == "" || strings.Contains(cid, "..") {
+		return nil, errors.New("invalid ipfs cid")
+	}
+
 	exists, content, err := d.exists(cid)
 	if err != nil {
 		return nil, err
@@ -187,8 +198,13 @@ func (d *Downloader) Get(hash string, download bool) ([]byte, error) {
 	return done.response, done.err
 }
 
+// cachePath flattens paths within a CID so that they are cached as a single file
+func (d *Downloader) cachePath(cid string) string {
+	return filepath.Join(d.ipfsDir, strings.ReplaceAll(cid, "/", "_"))
+}
+
 func (d *Downloader) exists(cid string) (bool, []byte, error) {
-	path := filepath.Join(d.ipfsDir, cid)
+	path := d.cachePath(cid)
 	_, err := os.Stat(path)
 	if err == nil {
 		fileContent, err := os.ReadFile(path)
@@ -199,7 +215,7 @@ func (d *Downloader) exists(cid string) (bool, []byte, error) {
 }
 
 func (d *Downloader) download(cid string, download bool) ([]byte, error) {
-	path := filepath.Join(d.ipfsDir, cid)
+	path := d.cachePath(cid)
 
 	req, err := http.NewRequest(http.MethodGet, params.IpfsGatewayURL+cid, nil)
 	if err != nil {
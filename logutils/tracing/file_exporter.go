@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+)
+
+const (
+	serviceName = "status-go"
+
+	exportBatchSize     = 256
+	exportInterval      = time.Second
+	exportQueueCapacity = 4096
+
+	// OpenTelemetry span kind and status codes
+	spanKindInternal = 1
+	statusCodeOk     = 1
+	statusCodeError  = 2
+)
+
+// FileExporter writes the spans in batches to a file, one line of OpenTelemetry JSON (OTLP/JSON
+// ExportTraceServiceRequest) per batch, as the file exporter of the OpenTelemetry collector does.
+// Spans are dropped when the queue is full.
+type FileExporter struct {
+	writer io.Writer
+	spans  chan SpanData
+	quit   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+func NewFileExporter(file string) (*FileExporter, error) {
+	if len(file) == 0 {
+		return nil, errors.New("file is required")
+	}
+
+	fileOpts := logutils.FileOptions{
+		Filename:   file,
+		MaxBackups: 1,
+	}
+	return newExporter(logutils.ZapSyncerWithRotation(fileOpts)), nil
+}
+
+func newExporter(writer io.Writer) *FileExporter {
+	e := &FileExporter{
+		writer: writer,
+		spans:  make(chan SpanData, exportQueueCapacity),
+		quit:   make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e
+}
+
+func (e *FileExporter) Export(span SpanData) {
+	select {
+	case e.spans <- span:
+	default:
+	}
+}
+
+// Close writes the queued spans
+func (e *FileExporter) Close() error {
+	e.once.Do(func() {
+		close(e.quit)
+	})
+	e.wg.Wait()
+	return nil
+}
+
+func (e *FileExporter) run() {
+	defer gocommon.LogOnPanic()
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.write(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) == exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.quit:
+			for {
+				select {
+				case span := <-e.spans:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *FileExporter) write(spans []SpanData) {
+	encoded, err := json.Marshal(newTraceRequest(spans))
+	if err != nil {
+		logutils.ZapLogger().Warn("failed to encode spans", zap.Int("count", len(spans)), zap.Error(err))
+		return
+	}
+	_, _ = e.writer.Write(append(encoded, '\n'))
+}
+
+// OTLP/JSON encoding of the spans
+
+type traceRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func newTraceRequest(spans []SpanData) traceRequest {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, newOtlpSpan(span))
+	}
+	return traceRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{Attributes: []keyValue{newKeyValue(String("service.name", serviceName))}},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: serviceName},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func newOtlpSpan(span SpanData) otlpSpan {
+	result := otlpSpan{
+		TraceID:           span.TraceID.String(),
+		SpanID:            span.SpanID.String(),
+		Name:              span.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status:            status{Code: statusCodeOk},
+	}
+	if !span.ParentID.IsZero() {
+		result.ParentSpanID = span.ParentID.String()
+	}
+	for _, attribute := range span.Attributes {
+		result.Attributes = append(result.Attributes, newKeyValue(attribute))
+	}
+	if span.Err != nil {
+		result.Status = status{Code: statusCodeError, Message: span.Err.Error()}
+	}
+	return result
+}
+
+func newKeyValue(attribute Attribute) keyValue {
+	var value anyValue
+	switch v := attribute.Value.(type) {
+	case string:
+		value.StringValue = &v
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		value.IntValue = &s
+	case uint64:
+		s := strconv.FormatUint(v, 10)
+		value.IntValue = &s
+	case bool:
+		value.BoolValue = &v
+	default:
+		s := fmt.Sprint(v)
+		value.StringValue = &s
+	}
+	return keyValue{Key: attribute.Key, Value: value}
+}
@@ -0,0 +1,203 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type TraceID [16]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+type SpanID [8]byte
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+func (s SpanID) IsZero() bool {
+	return s == SpanID{}
+}
+
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Uint64(key string, value uint64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData is an ended span as given to the exporter
+type SpanData struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	ParentID   SpanID
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	Err        error
+}
+
+// Exporter receives the ended spans, it must not block
+type Exporter interface {
+	Export(span SpanData)
+	Close() error
+}
+
+type exporterHolder struct {
+	exporter Exporter
+}
+
+var currentExporter atomic.Pointer[exporterHolder]
+
+// SetExporter enables the tracing with the exporter, nil disables it. The previous exporter is closed.
+func SetExporter(exporter Exporter) error {
+	var holder *exporterHolder
+	if exporter != nil {
+		holder = &exporterHolder{exporter: exporter}
+	}
+	previous := currentExporter.Swap(holder)
+	if previous != nil {
+		return previous.exporter.Close()
+	}
+	return nil
+}
+
+// ConfigureAndEnableTracing exports the spans to the file in the OpenTelemetry JSON format
+func ConfigureAndEnableTracing(file string) error {
+	exporter, err := NewFileExporter(file)
+	if err != nil {
+		return err
+	}
+	return SetExporter(exporter)
+}
+
+func DisableTracing() error {
+	return SetExporter(nil)
+}
+
+func Enabled() bool {
+	return currentExporter.Load() != nil
+}
+
+// Span is an operation of a trace. The spans are only recorded while the tracing is enabled, otherwise Start returns
+// a nil span whose methods do nothing.
+type Span struct {
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+type spanKey struct{}
+type traceIDKey struct{}
+
+// Start starts a span, child of the span of the context
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+
+	span := &Span{
+		data: SpanData{
+			SpanID:     newSpanID(),
+			Name:       name,
+			Start:      time.Now(),
+			Attributes: attributes,
+		},
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.data.TraceID = parent.data.TraceID
+		span.data.ParentID = parent.data.SpanID
+	} else if traceID, ok := ctx.Value(traceIDKey{}).(TraceID); ok {
+		span.data.TraceID = traceID
+	} else {
+		span.data.TraceID = newTraceID()
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// WithTraceKey makes the spans started without a parent part of the trace of the key, so that the spans of the
+// calls of a same user action, e.g. the calls sharing the uuid of a route, are in one trace
+func WithTraceKey(ctx context.Context, key string) context.Context {
+	var traceID TraceID
+	hash := sha256.Sum256([]byte(key))
+	copy(traceID[:], hash[:])
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// TraceIDFromContext returns the trace of the span of the context, empty when there is none
+func TraceIDFromContext(ctx context.Context) string {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.data.TraceID.String()
+	}
+	return ""
+}
+
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Attributes = append(s.data.Attributes, attributes...)
+}
+
+// End ends the span with an error status when err is not nil, the span is exported once
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	s.data.Err = err
+	data := s.data
+	s.mu.Unlock()
+
+	if holder := currentExporter.Load(); holder != nil {
+		holder.exporter.Export(data)
+	}
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func (e *recordingExporter) Export(span SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+func (e *recordingExporter) Close() error {
+	return nil
+}
+
+func setupExporter(t *testing.T, exporter Exporter) {
+	require.NoError(t, SetExporter(exporter))
+	t.Cleanup(func() { require.NoError(t, DisableTracing()) })
+}
+
+func TestStart_Disabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "disabled")
+	require.Nil(t, span)
+	require.Empty(t, TraceIDFromContext(ctx))
+
+	// The methods of the nil span do nothing
+	span.SetAttributes(String("key", "value"))
+	span.End(errors.New("failed"))
+}
+
+func TestStart_Propagation(t *testing.T) {
+	exporter := &recordingExporter{}
+	setupExporter(t, exporter)
+
+	ctx, parent := Start(context.Background(), "parent", String("method", "eth_call"))
+	_, child := Start(ctx, "child")
+	child.SetAttributes(Uint64("chainID", 1))
+	child.End(errors.New("failed"))
+	child.End(nil)
+	parent.End(nil)
+
+	require.Len(t, exporter.spans, 2)
+	childData, parentData := exporter.spans[0], exporter.spans[1]
+	require.Equal(t, parentData.TraceID, childData.TraceID)
+	require.Equal(t, parentData.SpanID, childData.ParentID)
+	require.True(t, parentData.ParentID.IsZero())
+	require.EqualError(t, childData.Err, "failed")
+	require.Equal(t, []Attribute{Uint64("chainID", 1)}, childData.Attributes)
+	require.Equal(t, parentData.TraceID.String(), TraceIDFromContext(ctx))
+
+	// The spans of a same key share the trace
+	keyCtx := WithTraceKey(context.Background(), "route-uuid")
+	_, first := Start(keyCtx, "first")
+	_, second := Start(WithTraceKey(context.Background(), "route-uuid"), "second")
+	first.End(nil)
+	second.End(nil)
+	require.Equal(t, exporter.spans[2].TraceID, exporter.spans[3].TraceID)
+	require.NotEqual(t, parentData.TraceID, exporter.spans[2].TraceID)
+}
+
+func TestFileExporter(t *testing.T) {
+	var buffer bytes.Buffer
+	exporter := newExporter(&buffer)
+	setupExporter(t, exporter)
+
+	ctx, parent := Start(context.Background(), "parent", String("method", "eth_call"), Int64("attempt", 2), Bool("cached", false))
+	_, child := Start(ctx, "child")
+	child.End(errors.New("failed"))
+	parent.End(nil)
+	require.NoError(t, exporter.Close())
+
+	var request traceRequest
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &request))
+	require.Len(t, request.ResourceSpans, 1)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "child", spans[0].Name)
+	require.Len(t, spans[0].TraceID, 32)
+	require.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	require.Equal(t, status{Code: statusCodeError, Message: "failed"}, spans[0].Status)
+
+	require.Equal(t, "parent", spans[1].Name)
+	require.Empty(t, spans[1].ParentSpanID)
+	require.Equal(t, statusCodeOk, spans[1].Status.Code)
+	require.Len(t, spans[1].Attributes, 3)
+	require.Equal(t, "eth_call", *spans[1].Attributes[0].Value.StringValue)
+	require.Equal(t, "2", *spans[1].Attributes[1].Value.IntValue)
+	require.False(t, *spans[1].Attributes[2].Value.BoolValue)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/status-im/status-go/images"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/logutils/requestlog"
+	"github.com/status-im/status-go/logutils/tracing"
 	"github.com/status-im/status-go/mobile/callog"
 	m_requests "github.com/status-im/status-go/mobile/requests"
 	"github.com/status-im/status-go/multiaccounts"
@@ -170,6 +171,7 @@ func initializeLogging(request *requests.InitializeApplication) error {
 	logutils.ZapLogger().Info("logging initialised",
 		zap.Any("logSettings", logSettings),
 		zap.Bool("APILoggingEnabled", request.APILoggingEnabled),
+		zap.Bool("TracingEnabled", request.TracingEnabled),
 	)
 
 	if request.APILoggingEnabled {
@@ -180,6 +182,13 @@ func initializeLogging(request *requests.InitializeApplication) error {
 		}
 	}
 
+	if request.TracingEnabled {
+		err = tracing.ConfigureAndEnableTracing(path.Join(request.LogDir, api.DefaultTracesFile))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -355,6 +355,7 @@ func (n *StatusNode) setupRPCClient() (err error) {
 		DB:              n.appDB,
 		WalletFeed:      &n.walletFeed,
 		ProviderConfigs: providerConfigs,
+		Quorums:         n.config.WalletConfig.RPCQuorums,
 	}
 	n.rpcClient, err = rpc.NewClient(config)
 	n.rpcClient.Start(context.Background())
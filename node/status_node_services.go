@@ -435,7 +435,7 @@ func wakuRateLimiter(wakuCfg *params.WakuConfig, clusterCfg *params.ClusterConfi
 
 func (b *StatusNode) connectorService() *connector.Service {
 	if b.connectorSrvc == nil {
-		b.connectorSrvc = connector.NewService(b.walletDB, b.rpcClient, b.rpcClient.NetworkManager)
+		b.connectorSrvc = connector.NewService(b.walletDB, b.rpcClient, b.rpcClient.NetworkManager, b.pendingTracker)
 	}
 	return b.connectorSrvc
 }
@@ -495,6 +495,7 @@ func (b *StatusNode) ensService(timesource func() time.Time) *ens.Service {
 func (b *StatusNode) pendingTrackerService(walletFeed *event.Feed) *transactions.PendingTxTracker {
 	if b.pendingTracker == nil {
 		b.pendingTracker = transactions.NewPendingTxTracker(b.walletDB, b.rpcClient, b.rpcFiltersSrvc, walletFeed, transactions.PendingCheckInterval)
+		b.pendingTracker.SetSubscriptions(b.rpcClient.Subscriptions())
 		if b.transactor != nil {
 			b.transactor.SetPendingTracker(b.pendingTracker)
 		}
@@ -597,6 +598,7 @@ func (b *StatusNode) walletService(accountsDB *accounts.Database, appDB *sql.DB,
 			b.pendingTracker,
 			walletFeed,
 			b.httpServer,
+			b.downloader,
 			statusProxyStageName,
 		)
 	}
@@ -541,6 +541,71 @@ type Network struct {
 	ShortName              string          `json:"shortName"`
 	TokenOverrides         []TokenOverride `json:"tokenOverrides"`
 	RelatedChainID         uint64          `json:"relatedChainId"`
+	// Capabilities are probed for the networks added by the user, nil when unknown
+	Capabilities *NetworkCapabilities `json:"capabilities,omitempty"`
+}
+
+// L2FeeModel is the way a layer 2 network charges the cost of posting its data to layer 1
+type L2FeeModel string
+
+const (
+	NoL2FeeModel L2FeeModel = ""
+	// OptimismL2FeeModel exposes the fee with the GasPriceOracle predeploy
+	OptimismL2FeeModel L2FeeModel = "optimism"
+	// ArbitrumL2FeeModel includes the fee in the estimated gas, see the ArbGasInfo precompile
+	ArbitrumL2FeeModel L2FeeModel = "arbitrum"
+)
+
+// NetworkCapabilities are the features found by probing the RPC of a network. The wallet assumes that the networks
+// without capabilities support all its features.
+type NetworkCapabilities struct {
+	EIP1559    bool       `json:"eip1559"`
+	L2FeeModel L2FeeModel `json:"l2FeeModel,omitempty"`
+	Multicall3 bool       `json:"multicall3"`
+	ENS        bool       `json:"ens"`
+	// TokenLists is true when the known token lists have tokens of the network
+	TokenLists bool `json:"tokenLists"`
+	// Archive is true when the RPC serves the state of old blocks
+	Archive  bool  `json:"archive"`
+	ProbedAt int64 `json:"probedAt"`
+}
+
+// RpcProviderAuthType is the way a user defined RPC provider authenticates the requests
+type RpcProviderAuthType string
+
+const (
+	NoAuth RpcProviderAuthType = "no-auth"
+	// BasicAuth sends AuthLogin and AuthPassword in the Authorization header
+	BasicAuth RpcProviderAuthType = "basic-auth"
+	// HeaderAuth sends AuthToken in the AuthLogin header, e.g. `x-api-key`
+	HeaderAuth RpcProviderAuthType = "header-auth"
+	// URLTokenAuth puts AuthToken in place of `{token}` in the URL, or appends it to the URL path
+	URLTokenAuth RpcProviderAuthType = "url-token-auth"
+)
+
+// RpcProvider is an RPC provider added by the user to a network, it is used along the configured providers
+// of the network, ordered by priority.
+type RpcProvider struct {
+	ID       int64               `json:"id"`
+	ChainID  uint64              `json:"chainId"`
+	Name     string              `json:"name"`
+	URL      string              `json:"url"`
+	Enabled  bool                `json:"enabled"`
+	Priority int                 `json:"priority"` // Lower is used first, the configured providers have priorities from 0 to 4
+	AuthType RpcProviderAuthType `json:"authType"`
+	// AuthLogin is the user of BasicAuth or the header name of HeaderAuth
+	AuthLogin    string `json:"authLogin,omitempty"`
+	AuthPassword string `json:"authPassword,omitempty"`
+	AuthToken    string `json:"authToken,omitempty"`
+	// MaxRequestsPerSecond limits the requests sent to the provider, 0 for the default limit
+	MaxRequestsPerSecond int `json:"maxRequestsPerSecond"`
+}
+
+// RpcQuorumConfig requires the same result from Threshold of the first Providers providers of a chain
+type RpcQuorumConfig struct {
+	Providers int `json:"providers"`
+	// Threshold is the majority of the providers when not set
+	Threshold int `json:"threshold,omitempty"`
 }
 
 // WalletConfig extra configuration for wallet.Service.
@@ -560,21 +625,30 @@ type WalletConfig struct {
 	StatusProxyStageName          string            `json:"StatusProxyStageName"`
 	EnableCelerBridge             bool              `json:"EnableCelerBridge"`
 	EnableMercuryoProvider        bool              `json:"EnableMercuryoProvider"`
+	// DisableTokenURIFetching keeps the wallet from fetching collectible metadata from the third party hosts
+	// set by the token contracts, revealing the user's IP and holdings to them
+	DisableTokenURIFetching bool `json:"DisableTokenURIFetching"`
+	// RPCQuorums are the quorums required per chain and per operation, "tokenPermissions" for the balances
+	// checked for the community token permissions, "signingNonce" for the nonce read before signing and
+	// "trustedHeaders" for the headers the proof-verified client checks the responses against
+	RPCQuorums map[uint64]map[string]RpcQuorumConfig `json:"RPCQuorums"`
 }
 
 // MarshalJSON custom marshalling to avoid exposing sensitive data in log,
 // there's a function called `startNode` will log NodeConfig which include WalletConfig
 func (wc WalletConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Enabled                bool `json:"Enabled"`
-		StatusProxyEnabled     bool `json:"StatusProxyEnabled"`
-		EnableCelerBridge      bool `json:"EnableCelerBridge"`
-		EnableMercuryoProvider bool `json:"EnableMercuryoProvider"`
+		Enabled                 bool `json:"Enabled"`
+		StatusProxyEnabled      bool `json:"StatusProxyEnabled"`
+		EnableCelerBridge       bool `json:"EnableCelerBridge"`
+		EnableMercuryoProvider  bool `json:"EnableMercuryoProvider"`
+		DisableTokenURIFetching bool `json:"DisableTokenURIFetching"`
 	}{
-		Enabled:                wc.Enabled,
-		StatusProxyEnabled:     wc.StatusProxyEnabled,
-		EnableCelerBridge:      wc.EnableCelerBridge,
-		EnableMercuryoProvider: wc.EnableMercuryoProvider,
+		Enabled:                 wc.Enabled,
+		StatusProxyEnabled:      wc.StatusProxyEnabled,
+		EnableCelerBridge:       wc.EnableCelerBridge,
+		EnableMercuryoProvider:  wc.EnableMercuryoProvider,
+		DisableTokenURIFetching: wc.DisableTokenURIFetching,
 	})
 }
 
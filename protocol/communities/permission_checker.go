@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/protocol/ens"
 	"github.com/status-im/status-go/protocol/protobuf"
+	"github.com/status-im/status-go/rpc/chain"
 	walletcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
@@ -414,8 +415,10 @@ func (p *DefaultPermissionChecker) checkPermissions(permissionsParsedData *PrePa
 
 	ownedERC20TokenBalances := make(map[uint64]map[gethcommon.Address]map[gethcommon.Address]*hexutil.Big, 0)
 	if len(chainIDsForERC20) > 0 {
-		// this only returns balances for the networks we're actually interested in
-		balances, err := getBalancesByChain(context.Background(), accounts, erc20TokenAddresses, chainIDsForERC20)
+		// this only returns balances for the networks we're actually interested in, the providers must agree on them
+		// when a quorum is configured
+		ctx := chain.WithQuorumOperation(context.Background(), chain.QuorumOperationTokenPermissions)
+		balances, err := getBalancesByChain(ctx, accounts, erc20TokenAddresses, chainIDsForERC20)
 		if err != nil {
 			return nil, err
 		}
@@ -35,6 +35,7 @@ import (
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/images"
+	"github.com/status-im/status-go/logutils/tracing"
 	multiaccountscommon "github.com/status-im/status-go/multiaccounts/common"
 
 	"github.com/status-im/status-go/multiaccounts"
@@ -1957,6 +1958,14 @@ func (m *Messenger) dispatchPairInstallationMessage(ctx context.Context, spec co
 }
 
 func (m *Messenger) dispatchMessage(ctx context.Context, rawMessage common.RawMessage) (common.RawMessage, error) {
+	ctx, span := tracing.Start(ctx, "messenger.dispatchMessage", tracing.String("chatID", rawMessage.LocalChatID),
+		tracing.String("messageType", rawMessage.MessageType.String()))
+	rawMessage, err := m.dispatchMessageToChat(ctx, rawMessage)
+	span.End(err)
+	return rawMessage, err
+}
+
+func (m *Messenger) dispatchMessageToChat(ctx context.Context, rawMessage common.RawMessage) (common.RawMessage, error) {
 	var err error
 	var id []byte
 	logger := m.logger.With(zap.String("site", "dispatchMessage"), zap.String("chatID", rawMessage.LocalChatID))
@@ -2106,7 +2115,11 @@ func (m *Messenger) dispatchMessage(ctx context.Context, rawMessage common.RawMe
 }
 
 // SendChatMessage takes a minimal message and sends it based on the corresponding chat
-func (m *Messenger) SendChatMessage(ctx context.Context, message *common.Message) (*MessengerResponse, error) {
+func (m *Messenger) SendChatMessage(ctx context.Context, message *common.Message) (response *MessengerResponse, err error) {
+	ctx, span := tracing.Start(ctx, "messenger.SendChatMessage", tracing.String("chatID", message.ChatId),
+		tracing.String("contentType", message.ContentType.String()))
+	defer func() { span.End(err) }()
+
 	return m.sendChatMessage(ctx, message)
 }
 
@@ -23,6 +23,9 @@ type InitializeApplication struct {
 	LogEnabled        bool   `json:"logEnabled"`
 	LogLevel          string `json:"logLevel"`
 	APILoggingEnabled bool   `json:"apiLoggingEnabled"`
+	// TracingEnabled exports the spans of the requests to the traces file of the LogDir,
+	// in the OpenTelemetry JSON format.
+	TracingEnabled bool `json:"tracingEnabled"`
 }
 
 func (i *InitializeApplication) Validate() error {
@@ -19,12 +19,15 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/circuitbreaker"
 	"github.com/status-im/status-go/healthmanager"
 	"github.com/status-im/status-go/healthmanager/rpcstatus"
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc/chain/ethclient"
+	"github.com/status-im/status-go/rpc/chain/rpccache"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
 	"github.com/status-im/status-go/rpc/chain/tagger"
 	"github.com/status-im/status-go/services/rpcstats"
@@ -40,6 +43,7 @@ type ClientInterface interface {
 	connection.Connectable
 	GetLimiter() rpclimiter.RequestLimiter
 	SetLimiter(rpclimiter.RequestLimiter)
+	TrustedHeaders() HeaderSource
 }
 
 type HealthMonitor interface {
@@ -68,10 +72,13 @@ func ClientWithTag(chainClient ClientInterface, tag, groupTag string) ClientInte
 
 type ClientWithFallback struct {
 	ChainID                uint64
-	ethClients             []ethclient.RPSLimitedEthClientInterface
+	ethClients             *atomic.Pointer[[]ethclient.RPSLimitedEthClientInterface] // shared with the copies, so they follow the provider changes
 	commonLimiter          rpclimiter.RequestLimiter
 	circuitbreaker         *circuitbreaker.CircuitBreaker
 	providersHealthManager *healthmanager.ProvidersHealthManager
+	cache                  *rpccache.Cache // nil when the responses are not cached
+	finalized              *finalizedBlock
+	quorums                map[string]params.RpcQuorumConfig // per operation, nil when the first successful result is used
 
 	WalletNotifier func(chainId uint64, message string)
 
@@ -88,6 +95,9 @@ func (c *ClientWithFallback) Copy() interface{} {
 		ethClients:     c.ethClients,
 		commonLimiter:  c.commonLimiter,
 		circuitbreaker: c.circuitbreaker,
+		cache:          c.cache,
+		finalized:      c.finalized,
+		quorums:        c.quorums,
 		WalletNotifier: c.WalletNotifier,
 		isConnected:    c.isConnected,
 		LastCheckedAt:  c.LastCheckedAt,
@@ -127,22 +137,40 @@ func NewClient(ethClients []ethclient.RPSLimitedEthClientInterface, chainID uint
 	isConnected := &atomic.Bool{}
 	isConnected.Store(true)
 
+	sharedEthClients := &atomic.Pointer[[]ethclient.RPSLimitedEthClientInterface]{}
+	sharedEthClients.Store(&ethClients)
+
 	return &ClientWithFallback{
 		ChainID:                chainID,
-		ethClients:             ethClients,
+		ethClients:             sharedEthClients,
 		isConnected:            isConnected,
 		LastCheckedAt:          time.Now().Unix(),
 		circuitbreaker:         circuitbreaker.NewCircuitBreaker(cbConfig),
 		providersHealthManager: providersHealthManager,
+		finalized:              &finalizedBlock{},
 	}
 }
 
 func (c *ClientWithFallback) Close() {
-	for _, client := range c.ethClients {
+	for _, client := range c.getEthClients() {
 		client.Close()
 	}
 }
 
+func (c *ClientWithFallback) getEthClients() []ethclient.RPSLimitedEthClientInterface {
+	return *c.ethClients.Load()
+}
+
+// SetEthClients replaces the providers of the client and its copies, the calls in progress keep using the previous
+// providers which are returned to be closed by the caller. The health statuses of the previous providers are dropped.
+func (c *ClientWithFallback) SetEthClients(ethClients []ethclient.RPSLimitedEthClientInterface) []ethclient.RPSLimitedEthClientInterface {
+	previous := *c.ethClients.Swap(&ethClients)
+	if c.providersHealthManager != nil {
+		c.providersHealthManager.Reset()
+	}
+	return previous
+}
+
 // Not found should not be cancelling the requests, as that's returned
 // when we are hitting a non archival node for example, it should continue the
 // chain as the next provider might have archival support.
@@ -192,15 +220,48 @@ func (c *ClientWithFallback) IsConnected() bool {
 	return c.isConnected.Load()
 }
 
-func (c *ClientWithFallback) makeCall(ctx context.Context, ethClients []ethclient.RPSLimitedEthClientInterface, f func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error)) (interface{}, error) {
+// allowCall checks the limits of the tag and the group tag of the client
+func (c *ClientWithFallback) allowCall() error {
 	if c.commonLimiter != nil {
 		if allow, err := c.commonLimiter.Allow(c.tag); !allow {
-			return nil, fmt.Errorf("tag=%s, %w", c.tag, err)
+			return fmt.Errorf("tag=%s, %w", c.tag, err)
 		}
 
 		if allow, err := c.commonLimiter.Allow(c.groupTag); !allow {
-			return nil, fmt.Errorf("groupTag=%s, %w", c.groupTag, err)
+			return fmt.Errorf("groupTag=%s, %w", c.groupTag, err)
+		}
+	}
+	return nil
+}
+
+// callProvider calls f once the limiter of the provider allows it, the limit is reduced when the provider
+// rejects the call because of its own limit
+func callProvider(provider ethclient.RPSLimitedEthClientInterface, f func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error)) (interface{}, error) {
+	limiter := provider.GetLimiter()
+	if limiter != nil {
+		err := limiter.WaitForRequestsAvailability(1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := f(provider)
+	if err != nil && limiter != nil && isRPSLimitError(err) {
+		limiter.ReduceLimit()
+
+		err = limiter.WaitForRequestsAvailability(1)
+		if err != nil {
+			return nil, err
 		}
+
+		res, err = f(provider)
+	}
+	return res, err
+}
+
+func (c *ClientWithFallback) makeCall(ctx context.Context, ethClients []ethclient.RPSLimitedEthClientInterface, f func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error)) (interface{}, error) {
+	if err := c.allowCall(); err != nil {
+		return nil, err
 	}
 
 	c.LastCheckedAt = time.Now().Unix()
@@ -209,30 +270,8 @@ func (c *ClientWithFallback) makeCall(ctx context.Context, ethClients []ethclien
 	for _, provider := range ethClients {
 		provider := provider
 		cmd.Add(circuitbreaker.NewFunctor(func() ([]interface{}, error) {
-			limiter := provider.GetLimiter()
-			if limiter != nil {
-				err := provider.GetLimiter().WaitForRequestsAvailability(1)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			res, err := f(provider)
+			res, err := callProvider(provider, f)
 			if err != nil {
-				if limiter != nil && isRPSLimitError(err) {
-					provider.GetLimiter().ReduceLimit()
-
-					err = provider.GetLimiter().WaitForRequestsAvailability(1)
-					if err != nil {
-						return nil, err
-					}
-
-					res, err = f(provider)
-					if err == nil {
-						return []interface{}{res}, err
-					}
-				}
-
 				if isVMError(err) || errors.Is(err, context.Canceled) {
 					cmd.Cancel()
 				}
@@ -259,7 +298,7 @@ func (c *ClientWithFallback) BlockByHash(ctx context.Context, hash common.Hash)
 	rpcstats.CountCallWithTag("eth_BlockByHash", c.tag)
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.BlockByHash(ctx, hash)
 		},
 	)
@@ -274,9 +313,17 @@ func (c *ClientWithFallback) BlockByHash(ctx context.Context, hash common.Hash)
 }
 
 func (c *ClientWithFallback) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	cacheable := c.isFinalized(ctx, number)
+	if cacheable {
+		block := new(types.Block)
+		if c.getCached("eth_BlockByNumber", blockCacheKey(number), func(value []byte) error { return rlp.DecodeBytes(value, block) }) {
+			return block, nil
+		}
+	}
+
 	rpcstats.CountCallWithTag("eth_BlockByNumber", c.tag)
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.BlockByNumber(ctx, number)
 		},
 	)
@@ -287,14 +334,18 @@ func (c *ClientWithFallback) BlockByNumber(ctx context.Context, number *big.Int)
 		return nil, err
 	}
 
-	return res.(*types.Block), nil
+	block := res.(*types.Block)
+	if cacheable {
+		c.putCached(blockCacheKey(number), func() ([]byte, error) { return rlp.EncodeToBytes(block) })
+	}
+	return block, nil
 }
 
 func (c *ClientWithFallback) BlockNumber(ctx context.Context) (uint64, error) {
 	rpcstats.CountCallWithTag("eth_BlockNumber", c.tag)
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.BlockNumber(ctx)
 		},
 	)
@@ -309,9 +360,15 @@ func (c *ClientWithFallback) BlockNumber(ctx context.Context) (uint64, error) {
 }
 
 func (c *ClientWithFallback) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	// The hash commits to the content of the header, it can always be cached
+	header := new(types.Header)
+	if c.getCached("eth_HeaderByHash", headerByHashCacheKey(hash), func(value []byte) error { return rlp.DecodeBytes(value, header) }) {
+		return header, nil
+	}
+
 	rpcstats.CountCallWithTag("eth_HeaderByHash", c.tag)
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.HeaderByHash(ctx, hash)
 		},
 	)
@@ -322,14 +379,24 @@ func (c *ClientWithFallback) HeaderByHash(ctx context.Context, hash common.Hash)
 		return nil, err
 	}
 
-	return res.(*types.Header), nil
+	header = res.(*types.Header)
+	c.putCached(headerByHashCacheKey(hash), func() ([]byte, error) { return rlp.EncodeToBytes(header) })
+	return header, nil
 }
 
 func (c *ClientWithFallback) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	cacheable := c.isFinalized(ctx, number)
+	if cacheable {
+		header := new(types.Header)
+		if c.getCached("eth_HeaderByNumber", headerCacheKey(number), func(value []byte) error { return rlp.DecodeBytes(value, header) }) {
+			return header, nil
+		}
+	}
+
 	rpcstats.CountCallWithTag("eth_HeaderByNumber", c.tag)
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
-			return client.HeaderByNumber(ctx, number)
+	res, err := c.makeQuorumCall(
+		ctx, "eth_HeaderByNumber", number, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
+			return client.HeaderByNumber(ctx, blockNumber)
 		},
 	)
 
@@ -339,14 +406,18 @@ func (c *ClientWithFallback) HeaderByNumber(ctx context.Context, number *big.Int
 		return nil, err
 	}
 
-	return res.(*types.Header), nil
+	header := res.(*types.Header)
+	if cacheable {
+		c.putCached(headerCacheKey(number), func() ([]byte, error) { return rlp.EncodeToBytes(header) })
+	}
+	return header, nil
 }
 
 func (c *ClientWithFallback) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
 	rpcstats.CountCallWithTag("eth_TransactionByHash", c.tag)
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			tx, isPending, err := client.TransactionByHash(ctx, hash)
 			return []any{tx, isPending}, err
 		},
@@ -366,7 +437,7 @@ func (c *ClientWithFallback) TransactionSender(ctx context.Context, tx *types.Tr
 	rpcstats.CountCall("eth_TransactionSender")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.TransactionSender(ctx, tx, block, index)
 		},
 	)
@@ -377,10 +448,15 @@ func (c *ClientWithFallback) TransactionSender(ctx context.Context, tx *types.Tr
 }
 
 func (c *ClientWithFallback) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt := new(types.Receipt)
+	if c.getCached("eth_TransactionReceipt", receiptCacheKey(txHash), receipt.UnmarshalJSON) {
+		return receipt, nil
+	}
+
 	rpcstats.CountCall("eth_TransactionReceipt")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.TransactionReceipt(ctx, txHash)
 		},
 	)
@@ -391,14 +467,19 @@ func (c *ClientWithFallback) TransactionReceipt(ctx context.Context, txHash comm
 		return nil, err
 	}
 
-	return res.(*types.Receipt), nil
+	receipt = res.(*types.Receipt)
+	// The transaction can be moved to another block until its block is finalized
+	if c.isFinalized(ctx, receipt.BlockNumber) {
+		c.putCached(receiptCacheKey(txHash), receipt.MarshalJSON)
+	}
+	return receipt, nil
 }
 
 func (c *ClientWithFallback) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
 	rpcstats.CountCall("eth_SyncProgress")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.SyncProgress(ctx)
 		},
 	)
@@ -419,8 +500,8 @@ func (c *ClientWithFallback) NetworkID() uint64 {
 func (c *ClientWithFallback) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
 	rpcstats.CountCallWithTag("eth_BalanceAt", c.tag)
 
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	res, err := c.makeQuorumCall(
+		ctx, "eth_BalanceAt", blockNumber, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
 			return client.BalanceAt(ctx, account, blockNumber)
 		},
 	)
@@ -438,7 +519,7 @@ func (c *ClientWithFallback) StorageAt(ctx context.Context, account common.Addre
 	rpcstats.CountCall("eth_StorageAt")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.StorageAt(ctx, account, key, blockNumber)
 		},
 	)
@@ -456,7 +537,7 @@ func (c *ClientWithFallback) CodeAt(ctx context.Context, account common.Address,
 	rpcstats.CountCall("eth_CodeAt")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.CodeAt(ctx, account, blockNumber)
 		},
 	)
@@ -473,8 +554,8 @@ func (c *ClientWithFallback) CodeAt(ctx context.Context, account common.Address,
 func (c *ClientWithFallback) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
 	rpcstats.CountCallWithTag("eth_NonceAt", c.tag)
 
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	res, err := c.makeQuorumCall(
+		ctx, "eth_NonceAt", blockNumber, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
 			return client.NonceAt(ctx, account, blockNumber)
 		},
 	)
@@ -492,13 +573,13 @@ func (c *ClientWithFallback) FilterLogs(ctx context.Context, q ethereum.FilterQu
 	rpcstats.CountCallWithTag("eth_FilterLogs", c.tag)
 
 	// Override providers name to use a separate circuit for this command as it more often fails due to rate limiting
-	ethClients := make([]ethclient.RPSLimitedEthClientInterface, len(c.ethClients))
-	for i, client := range c.ethClients {
+	ethClients := make([]ethclient.RPSLimitedEthClientInterface, len(c.getEthClients()))
+	for i, client := range c.getEthClients() {
 		ethClients[i] = client.CopyWithName(client.GetName() + "_FilterLogs")
 	}
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.FilterLogs(ctx, q)
 		},
 	)
@@ -517,7 +598,7 @@ func (c *ClientWithFallback) SubscribeFilterLogs(ctx context.Context, q ethereum
 	rpcstats.CountCall("eth_SubscribeFilterLogs")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.SubscribeFilterLogs(ctx, q, ch)
 		},
 	)
@@ -534,8 +615,8 @@ func (c *ClientWithFallback) SubscribeFilterLogs(ctx context.Context, q ethereum
 func (c *ClientWithFallback) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
 	rpcstats.CountCall("eth_PendingBalanceAt")
 
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	res, err := c.makeQuorumCall(
+		ctx, "eth_PendingBalanceAt", pendingBlock, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
 			return client.PendingBalanceAt(ctx, account)
 		},
 	)
@@ -553,7 +634,7 @@ func (c *ClientWithFallback) PendingStorageAt(ctx context.Context, account commo
 	rpcstats.CountCall("eth_PendingStorageAt")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.PendingStorageAt(ctx, account, key)
 		},
 	)
@@ -571,7 +652,7 @@ func (c *ClientWithFallback) PendingCodeAt(ctx context.Context, account common.A
 	rpcstats.CountCall("eth_PendingCodeAt")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.PendingCodeAt(ctx, account)
 		},
 	)
@@ -588,8 +669,8 @@ func (c *ClientWithFallback) PendingCodeAt(ctx context.Context, account common.A
 func (c *ClientWithFallback) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	rpcstats.CountCall("eth_PendingNonceAt")
 
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	res, err := c.makeQuorumCall(
+		ctx, "eth_PendingNonceAt", pendingBlock, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
 			return client.PendingNonceAt(ctx, account)
 		},
 	)
@@ -607,7 +688,7 @@ func (c *ClientWithFallback) PendingTransactionCount(ctx context.Context) (uint,
 	rpcstats.CountCall("eth_PendingTransactionCount")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.PendingTransactionCount(ctx)
 		},
 	)
@@ -622,10 +703,21 @@ func (c *ClientWithFallback) PendingTransactionCount(ctx context.Context) (uint,
 }
 
 func (c *ClientWithFallback) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var cacheKey string
+	if c.isFinalized(ctx, blockNumber) {
+		cacheKey, _ = callCacheKey(msg, blockNumber)
+	}
+	if cacheKey != "" {
+		var data []byte
+		if c.getCached("eth_CallContract", cacheKey, func(value []byte) error { data = value; return nil }) {
+			return data, nil
+		}
+	}
+
 	rpcstats.CountCall("eth_CallContract_" + msg.To.String())
 
-	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	res, err := c.makeQuorumCall(
+		ctx, "eth_CallContract", blockNumber, func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
 			return client.CallContract(ctx, msg, blockNumber)
 		},
 	)
@@ -636,14 +728,18 @@ func (c *ClientWithFallback) CallContract(ctx context.Context, msg ethereum.Call
 		return nil, err
 	}
 
-	return res.([]byte), nil
+	data := res.([]byte)
+	if cacheKey != "" {
+		c.putCached(cacheKey, func() ([]byte, error) { return data, nil })
+	}
+	return data, nil
 }
 
 func (c *ClientWithFallback) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
 	rpcstats.CountCall("eth_PendingCallContract")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.PendingCallContract(ctx, msg)
 		},
 	)
@@ -661,7 +757,7 @@ func (c *ClientWithFallback) SuggestGasPrice(ctx context.Context) (*big.Int, err
 	rpcstats.CountCall("eth_SuggestGasPrice")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.SuggestGasPrice(ctx)
 		},
 	)
@@ -679,7 +775,7 @@ func (c *ClientWithFallback) SuggestGasTipCap(ctx context.Context) (*big.Int, er
 	rpcstats.CountCall("eth_SuggestGasTipCap")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.SuggestGasTipCap(ctx)
 		},
 	)
@@ -697,7 +793,7 @@ func (c *ClientWithFallback) FeeHistory(ctx context.Context, blockCount uint64,
 	rpcstats.CountCall("eth_FeeHistory")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 		},
 	)
@@ -715,7 +811,7 @@ func (c *ClientWithFallback) EstimateGas(ctx context.Context, msg ethereum.CallM
 	rpcstats.CountCall("eth_EstimateGas")
 
 	res, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return client.EstimateGas(ctx, msg)
 		},
 	)
@@ -733,7 +829,7 @@ func (c *ClientWithFallback) SendTransaction(ctx context.Context, tx *types.Tran
 	rpcstats.CountCall("eth_SendTransaction")
 
 	_, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return nil, client.SendTransaction(ctx, tx)
 		},
 	)
@@ -747,7 +843,7 @@ func (c *ClientWithFallback) CallContext(ctx context.Context, result interface{}
 	rpcstats.CountCall("eth_CallContext")
 
 	_, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return nil, client.CallContext(ctx, result, method, args...)
 		},
 	)
@@ -761,7 +857,7 @@ func (c *ClientWithFallback) BatchCallContext(ctx context.Context, b []rpc.Batch
 	rpcstats.CountCall("eth_BatchCallContext")
 
 	_, err := c.makeCall(
-		ctx, c.ethClients, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 			return nil, client.BatchCallContext(ctx, b)
 		},
 	)
@@ -806,7 +902,7 @@ func (c *ClientWithFallback) SetWalletNotifier(notifier func(chainId uint64, mes
 func (c *ClientWithFallback) toggleConnectionState(err error) {
 	connected := true
 	if err != nil {
-		if !isNotFoundError(err) && !isVMError(err) && !errors.Is(err, rpclimiter.ErrRequestsOverLimit) && !errors.Is(err, context.Canceled) {
+		if !isNotFoundError(err) && !isVMError(err) && !errors.Is(err, rpclimiter.ErrRequestsOverLimit) && !errors.Is(err, context.Canceled) && !errors.Is(err, ErrQuorumNotReached) {
 			logutils.ZapLogger().Warn("Error not in chain call", zap.Uint64("chain", c.ChainID), zap.Error(err))
 			connected = false
 		} else {
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc/chain/ethclient"
+	"github.com/status-im/status-go/rpc/chain/rpccache"
+	"github.com/status-im/status-go/services/rpcstats"
+)
+
+// finalizedCheckInterval limits how often the finalized block is requested when a newer block is queried
+const finalizedCheckInterval = time.Minute
+
+// finalizedBlock is the last finalized block of the chain, the responses about blocks up to it can't change
+type finalizedBlock struct {
+	mu        sync.Mutex
+	number    uint64
+	checkedAt time.Time
+}
+
+// SetCache enables caching the responses that can't change anymore, for the client and its copies
+func (c *ClientWithFallback) SetCache(cache *rpccache.Cache) {
+	c.cache = cache
+	if c.finalized == nil {
+		c.finalized = &finalizedBlock{}
+	}
+}
+
+// isFinalized returns true if the block can't be reorganized anymore. The finalized block is requested at most
+// once per finalizedCheckInterval, the chains not supporting the `finalized` tag are never cached.
+func (c *ClientWithFallback) isFinalized(ctx context.Context, number *big.Int) bool {
+	if c.cache == nil || number == nil || number.Sign() < 0 || !number.IsUint64() {
+		return false
+	}
+
+	c.finalized.mu.Lock()
+	if number.Uint64() <= c.finalized.number {
+		c.finalized.mu.Unlock()
+		return true
+	}
+	if time.Since(c.finalized.checkedAt) < finalizedCheckInterval {
+		c.finalized.mu.Unlock()
+		return false
+	}
+	c.finalized.checkedAt = time.Now()
+	c.finalized.mu.Unlock()
+
+	rpcstats.CountCallWithTag("eth_HeaderByNumber", c.tag)
+	res, err := c.makeCall(
+		ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+			return client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		},
+	)
+	if err != nil {
+		logutils.ZapLogger().Debug("could not get the finalized block", zap.Uint64("chain", c.ChainID), zap.Error(err))
+		return false
+	}
+	finalized := res.(*types.Header).Number
+
+	c.finalized.mu.Lock()
+	defer c.finalized.mu.Unlock()
+	if finalized.IsUint64() && finalized.Uint64() > c.finalized.number {
+		c.finalized.number = finalized.Uint64()
+	}
+	return number.Uint64() <= c.finalized.number
+}
+
+// getCached decodes the cached value of the key, false on a cache miss
+func (c *ClientWithFallback) getCached(method string, key string, decode func([]byte) error) bool {
+	if c.cache == nil {
+		return false
+	}
+
+	value, ok, err := c.cache.Get(c.ChainID, key)
+	if err != nil {
+		logutils.ZapLogger().Warn("could not read the rpc cache", zap.Uint64("chain", c.ChainID), zap.String("key", key), zap.Error(err))
+	}
+	if ok {
+		if err = decode(value); err == nil {
+			rpcstats.CountCacheHit(method)
+			return true
+		}
+		logutils.ZapLogger().Warn("could not decode the rpc cache", zap.Uint64("chain", c.ChainID), zap.String("key", key), zap.Error(err))
+	}
+	rpcstats.CountCacheMiss(method)
+	return false
+}
+
+func (c *ClientWithFallback) putCached(key string, encode func() ([]byte, error)) {
+	if c.cache == nil {
+		return
+	}
+
+	value, err := encode()
+	if err == nil {
+		err = c.cache.Put(c.ChainID, key, value)
+	}
+	if err != nil {
+		logutils.ZapLogger().Warn("could not write the rpc cache", zap.Uint64("chain", c.ChainID), zap.String("key", key), zap.Error(err))
+	}
+}
+
+func blockCacheKey(number *big.Int) string {
+	return "block:" + number.String()
+}
+
+func headerCacheKey(number *big.Int) string {
+	return "header:" + number.String()
+}
+
+func headerByHashCacheKey(hash common.Hash) string {
+	return "header:" + hash.Hex()
+}
+
+func receiptCacheKey(txHash common.Hash) string {
+	return "receipt:" + txHash.Hex()
+}
+
+func callCacheKey(msg ethereum.CallMsg, blockNumber *big.Int) (string, error) {
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("call:%s:%s", blockNumber, crypto.Keccak256Hash(encoded).Hex()), nil
+}
@@ -0,0 +1,69 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/status-go/appdatabase"
+	"github.com/status-im/status-go/rpc/chain/rpccache"
+	"github.com/status-im/status-go/t/helpers"
+)
+
+func TestClient_CachesFinalizedBlocks(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+
+	db, closeDB, err := helpers.SetupTestSQLDB(appdatabase.DbInitializer{}, "rpc-chain-cache-tests")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, closeDB()) }()
+	client.SetCache(rpccache.NewCache(db, rpccache.DefaultMaxSize))
+
+	ctx := context.Background()
+	finalized := &types.Header{Number: big.NewInt(100)}
+	// The finalized block is requested once, the next check is delayed
+	ethClients[0].EXPECT().HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber))).Return(finalized, nil).Times(1)
+
+	header := &types.Header{Number: big.NewInt(50), Time: 1}
+	ethClients[0].EXPECT().HeaderByNumber(ctx, big.NewInt(50)).Return(header, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		res, err := client.HeaderByNumber(ctx, big.NewInt(50))
+		require.NoError(t, err)
+		require.Equal(t, header.Hash(), res.Hash())
+	}
+
+	// Blocks after the finalized one are always requested
+	latest := &types.Header{Number: big.NewInt(150)}
+	ethClients[0].EXPECT().HeaderByNumber(ctx, big.NewInt(150)).Return(latest, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := client.HeaderByNumber(ctx, big.NewInt(150))
+		require.NoError(t, err)
+	}
+
+	// Receipts are cached once their block is finalized
+	finalizedReceipt := &types.Receipt{TxHash: common.HexToHash("0x1"), BlockNumber: big.NewInt(60), Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}}
+	ethClients[0].EXPECT().TransactionReceipt(ctx, finalizedReceipt.TxHash).Return(finalizedReceipt, nil).Times(1)
+	latestReceipt := &types.Receipt{TxHash: common.HexToHash("0x2"), BlockNumber: big.NewInt(150), Logs: []*types.Log{}}
+	ethClients[0].EXPECT().TransactionReceipt(ctx, latestReceipt.TxHash).Return(latestReceipt, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		res, err := client.TransactionReceipt(ctx, finalizedReceipt.TxHash)
+		require.NoError(t, err)
+		require.Equal(t, finalizedReceipt.BlockNumber, res.BlockNumber)
+		require.Equal(t, finalizedReceipt.Status, res.Status)
+
+		_, err = client.TransactionReceipt(ctx, latestReceipt.TxHash)
+		require.NoError(t, err)
+	}
+
+	// The copies share the cache
+	copied := client.Copy().(*ClientWithFallback)
+	res, err := copied.HeaderByNumber(ctx, big.NewInt(50))
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), res.Hash())
+}
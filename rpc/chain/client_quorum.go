@@ -0,0 +1,319 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/healthmanager/rpcstatus"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc/chain/ethclient"
+	"github.com/status-im/status-go/services/rpcstats"
+)
+
+var (
+	ErrQuorumNotReached = errors.New("rpc providers did not reach a quorum")
+	ErrDivergentResult  = errors.New("rpc provider result diverges from the quorum")
+	ErrNoTrustedHeaders = errors.New("no quorum set for the trusted headers")
+)
+
+// Operations that don't trust a single provider, they require a quorum when one is configured for the chain
+const (
+	// QuorumOperationTokenPermissions are the balances checked for the token permissions of the communities
+	QuorumOperationTokenPermissions = "tokenPermissions"
+	// QuorumOperationSigningNonce is the nonce read before signing a transaction
+	QuorumOperationSigningNonce = "signingNonce"
+	// QuorumOperationTrustedHeaders are the headers the proof-verified client checks the responses against
+	QuorumOperationTrustedHeaders = "trustedHeaders"
+)
+
+type quorumOperationKey struct{}
+
+// WithQuorumOperation marks the calls made with the returned context as part of the operation, they require the
+// quorum set for it
+func WithQuorumOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, quorumOperationKey{}, operation)
+}
+
+func quorumOperation(ctx context.Context) string {
+	operation, _ := ctx.Value(quorumOperationKey{}).(string)
+	return operation
+}
+
+type quorumResponse struct {
+	name   string
+	result interface{}
+	err    error
+	key    string // empty when the provider failed
+}
+
+// SetQuorums requires the providers to agree on the results of the calls made for the operations, keyed by the
+// operation names, e.g. QuorumOperationSigningNonce. The copies of the client share the quorums set before copying.
+func (c *ClientWithFallback) SetQuorums(quorums map[string]params.RpcQuorumConfig) {
+	c.quorums = quorums
+}
+
+func quorumThreshold(quorum params.RpcQuorumConfig) int {
+	if quorum.Threshold > 0 {
+		return quorum.Threshold
+	}
+	return quorum.Providers/2 + 1
+}
+
+// quorumProviders returns the first n providers, the ones that are down are only used if there are not
+// enough other providers
+func (c *ClientWithFallback) quorumProviders(n int) []ethclient.RPSLimitedEthClientInterface {
+	var statuses map[string]rpcstatus.ProviderStatus
+	if c.providersHealthManager != nil {
+		statuses = c.providersHealthManager.GetStatuses()
+	}
+
+	var healthy, down []ethclient.RPSLimitedEthClientInterface
+	for _, provider := range c.getEthClients() {
+		if status, ok := statuses[provider.GetName()]; ok && status.Status == rpcstatus.StatusDown {
+			down = append(down, provider)
+		} else {
+			healthy = append(healthy, provider)
+		}
+	}
+	providers := append(healthy, down...)
+	if len(providers) > n {
+		providers = providers[:n]
+	}
+	return providers
+}
+
+// callProviders calls the providers in parallel
+func callProviders(providers []ethclient.RPSLimitedEthClientInterface, f func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error)) []quorumResponse {
+	responses := make([]quorumResponse, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider ethclient.RPSLimitedEthClientInterface) {
+			defer gocommon.LogOnPanic()
+			defer wg.Done()
+			res, err := callProvider(provider, f)
+			responses[i] = quorumResponse{name: provider.GetName(), result: res, err: err}
+		}(i, provider)
+	}
+	wg.Wait()
+	return responses
+}
+
+// pendingBlock is given to makeQuorumCall for the calls on the pending state
+var pendingBlock = big.NewInt(int64(rpc.PendingBlockNumber))
+
+func isLatestBlock(blockNumber *big.Int) bool {
+	return blockNumber == nil || blockNumber.Cmp(big.NewInt(int64(rpc.LatestBlockNumber))) == 0
+}
+
+// pinQuorumBlock returns the highest block known by threshold of the providers and the providers knowing it, so that
+// the providers lagging behind the head are neither asked for a block they don't have nor reported as divergent.
+// The providers that could not answer are returned in the statuses.
+func pinQuorumBlock(ctx context.Context, providers []ethclient.RPSLimitedEthClientInterface, threshold int) (*big.Int, []ethclient.RPSLimitedEthClientInterface, []rpcstatus.RpcProviderCallStatus, []error) {
+	responses := callProviders(providers, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		return client.BlockNumber(ctx)
+	})
+
+	var statuses []rpcstatus.RpcProviderCallStatus
+	var errs []error
+	heads := make([]uint64, 0, len(responses))
+	for _, response := range responses {
+		if response.err != nil {
+			statuses = append(statuses, rpcstatus.RpcProviderCallStatus{Name: response.name, Timestamp: time.Now(), Err: response.err})
+			errs = append(errs, fmt.Errorf("%s.error: %w", response.name, response.err))
+			continue
+		}
+		heads = append(heads, response.result.(uint64))
+	}
+	if len(heads) < threshold {
+		return nil, nil, statuses, errs
+	}
+
+	sort.Slice(heads, func(i, j int) bool { return heads[i] > heads[j] })
+	pinned := heads[threshold-1]
+
+	synced := make([]ethclient.RPSLimitedEthClientInterface, 0, len(providers))
+	for i, response := range responses {
+		if response.err == nil && response.result.(uint64) >= pinned {
+			synced = append(synced, providers[i])
+		}
+	}
+	return new(big.Int).SetUint64(pinned), synced, statuses, errs
+}
+
+// makeQuorumCall calls the providers in parallel when the call is made for an operation with a quorum, see
+// WithQuorumOperation. The result must be the same for the threshold of the providers, the other providers are
+// reported as down to the health manager. The latest block is pinned first, the pending state is not compared
+// against the lagging providers as it differs until they are in sync.
+// Without a quorum, the providers are called one after the other until one succeeds.
+func (c *ClientWithFallback) makeQuorumCall(ctx context.Context, method string, blockNumber *big.Int, f func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error)) (interface{}, error) {
+	quorum, ok := c.quorums[quorumOperation(ctx)]
+	if !ok || quorum.Providers < 2 {
+		return c.makeCall(ctx, c.getEthClients(), func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+			return f(client, blockNumber)
+		})
+	}
+
+	if err := c.allowCall(); err != nil {
+		return nil, err
+	}
+
+	c.LastCheckedAt = time.Now().Unix()
+
+	threshold := quorumThreshold(quorum)
+	providers := c.quorumProviders(quorum.Providers)
+	if len(providers) < threshold {
+		return nil, fmt.Errorf("%w: %s requires %d providers, %d available", ErrQuorumNotReached, method, threshold, len(providers))
+	}
+
+	statuses := make([]rpcstatus.RpcProviderCallStatus, 0, len(providers))
+	updateStatuses := func() {
+		if c.providersHealthManager != nil && len(statuses) > 0 {
+			c.providersHealthManager.Update(ctx, statuses)
+		}
+	}
+
+	reportDivergence := true
+	if isLatestBlock(blockNumber) {
+		pinned, synced, headStatuses, errs := pinQuorumBlock(ctx, providers, threshold)
+		statuses = append(statuses, headStatuses...)
+		if pinned == nil {
+			updateStatuses()
+			if len(errs) == len(providers) {
+				return nil, errors.Join(errs...)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrQuorumNotReached, method)
+		}
+		blockNumber, providers = pinned, synced
+	} else if blockNumber.Sign() < 0 {
+		reportDivergence = false
+	}
+
+	responses := callProviders(providers, func(client ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+		return f(client, blockNumber)
+	})
+
+	agreed, errs := agreedResult(responses, threshold)
+
+	for _, response := range responses {
+		err := response.err
+		if response.key != "" {
+			// A revert is an answer of the provider
+			err = nil
+			if agreed != "" && response.key != agreed && reportDivergence {
+				err = fmt.Errorf("%w: %s", ErrDivergentResult, method)
+				logutils.ZapLogger().Warn("divergent rpc provider", zap.Uint64("chain", c.ChainID), zap.String("provider", response.name), zap.String("method", method))
+			}
+		}
+		statuses = append(statuses, rpcstatus.RpcProviderCallStatus{Name: response.name, Timestamp: time.Now(), Err: err})
+	}
+	updateStatuses()
+
+	if agreed == "" {
+		if len(errs) == len(responses) {
+			// None of the providers answered
+			return nil, errors.Join(errs...)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrQuorumNotReached, method)
+	}
+	for _, response := range responses {
+		if response.key == agreed {
+			return response.result, response.err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrQuorumNotReached, method)
+}
+
+// agreedResult sets the keys of the responses and returns the key of the result given by at least threshold
+// providers, empty if there is none or if several results have the same count. The errors of the providers
+// that could not answer are returned.
+func agreedResult(responses []quorumResponse, threshold int) (string, []error) {
+	var errs []error
+	counts := make(map[string]int)
+	for i := range responses {
+		response := &responses[i]
+		switch {
+		case response.err == nil:
+			encoded, err := json.Marshal(response.result)
+			if err != nil {
+				response.err = err
+				errs = append(errs, err)
+				continue
+			}
+			response.key = string(encoded)
+		case isVMError(response.err):
+			response.key = "error:" + response.err.Error()
+		default:
+			errs = append(errs, fmt.Errorf("%s.error: %w", response.name, response.err))
+			continue
+		}
+		counts[response.key]++
+	}
+
+	agreed, best, ties := "", 0, 0
+	for key, count := range counts {
+		switch {
+		case count > best:
+			agreed, best, ties = key, count, 1
+		case count == best:
+			ties++
+		}
+	}
+	if best < threshold || ties > 1 {
+		return "", errs
+	}
+	return agreed, errs
+}
+
+// HeaderSource gives the headers trusted by the proof-verified client, a nil number is the latest header
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// quorumHeaderSource gives the headers agreed by the quorum of providers set for QuorumOperationTrustedHeaders.
+// The cache is not used, it keeps the headers given by a single provider.
+type quorumHeaderSource struct {
+	client *ClientWithFallback
+}
+
+func (s *quorumHeaderSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c := s.client
+	if quorum, ok := c.quorums[QuorumOperationTrustedHeaders]; !ok || quorum.Providers < 2 {
+		return nil, ErrNoTrustedHeaders
+	}
+
+	rpcstats.CountCallWithTag("eth_HeaderByNumber", c.tag)
+	res, err := c.makeQuorumCall(
+		WithQuorumOperation(ctx, QuorumOperationTrustedHeaders), "eth_HeaderByNumber", number,
+		func(client ethclient.RPSLimitedEthClientInterface, blockNumber *big.Int) (interface{}, error) {
+			return client.HeaderByNumber(ctx, blockNumber)
+		},
+	)
+
+	c.toggleConnectionState(err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*types.Header), nil
+}
+
+// TrustedHeaders returns the headers agreed by the quorum set for QuorumOperationTrustedHeaders, they fail with
+// ErrNoTrustedHeaders without it as the headers of a single provider can't verify the responses of the providers
+func (c *ClientWithFallback) TrustedHeaders() HeaderSource {
+	return &quorumHeaderSource{client: c}
+}
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/healthmanager"
+	"github.com/status-im/status-go/healthmanager/rpcstatus"
+	"github.com/status-im/status-go/params"
+)
+
+func TestClient_QuorumReads(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+
+	client.providersHealthManager = healthmanager.NewProvidersHealthManager(0)
+	client.SetQuorums(map[string]params.RpcQuorumConfig{
+		QuorumOperationTokenPermissions: {Providers: 3},
+	})
+
+	ctx := WithQuorumOperation(context.Background(), QuorumOperationTokenPermissions)
+	account := common.HexToAddress("0x1")
+	head := big.NewInt(100)
+
+	// A provider lagging behind the head is not asked for the pinned block
+	ethClients[0].EXPECT().BlockNumber(ctx).Return(uint64(101), nil).Times(1)
+	ethClients[1].EXPECT().BlockNumber(ctx).Return(uint64(100), nil).Times(1)
+	ethClients[2].EXPECT().BlockNumber(ctx).Return(uint64(99), nil).Times(1)
+	ethClients[0].EXPECT().BalanceAt(ctx, account, head).Return(big.NewInt(10), nil).Times(1)
+	ethClients[1].EXPECT().BalanceAt(ctx, account, head).Return(big.NewInt(10), nil).Times(1)
+	balance, err := client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), balance)
+
+	statuses := client.providersHealthManager.GetStatuses()
+	require.NotEqual(t, rpcstatus.StatusDown, statuses["test2"].Status)
+
+	// The majority of the providers agree
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().BlockNumber(ctx).Return(uint64(100), nil).Times(1)
+	}
+	ethClients[0].EXPECT().BalanceAt(ctx, account, head).Return(big.NewInt(10), nil).Times(1)
+	ethClients[1].EXPECT().BalanceAt(ctx, account, head).Return(big.NewInt(10), nil).Times(1)
+	ethClients[2].EXPECT().BalanceAt(ctx, account, head).Return(big.NewInt(11), nil).Times(1)
+	balance, err = client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), balance)
+
+	statuses = client.providersHealthManager.GetStatuses()
+	require.Equal(t, rpcstatus.StatusUp, statuses["test0"].Status)
+	require.Equal(t, rpcstatus.StatusDown, statuses["test2"].Status)
+	require.ErrorIs(t, statuses["test2"].LastError, ErrDivergentResult)
+
+	// No result is given by the majority
+	block := big.NewInt(90)
+	ethClients[0].EXPECT().BalanceAt(ctx, account, block).Return(big.NewInt(10), nil).Times(1)
+	ethClients[1].EXPECT().BalanceAt(ctx, account, block).Return(nil, errors.New("some error")).Times(1)
+	ethClients[2].EXPECT().BalanceAt(ctx, account, block).Return(big.NewInt(11), nil).Times(1)
+	_, err = client.BalanceAt(ctx, account, block)
+	require.ErrorIs(t, err, ErrQuorumNotReached)
+	// A disagreement is not a connection error
+	require.True(t, client.IsConnected())
+
+	// The pending state of the providers is compared without reporting the divergent ones
+	client.providersHealthManager = healthmanager.NewProvidersHealthManager(0)
+	ethClients[0].EXPECT().PendingNonceAt(ctx, account).Return(uint64(5), nil).Times(1)
+	ethClients[1].EXPECT().PendingNonceAt(ctx, account).Return(uint64(5), nil).Times(1)
+	ethClients[2].EXPECT().PendingNonceAt(ctx, account).Return(uint64(4), nil).Times(1)
+	nonce, err := client.PendingNonceAt(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+	require.NotEqual(t, rpcstatus.StatusDown, client.providersHealthManager.GetStatuses()["test2"].Status)
+
+	// Calls made for other operations use the first provider answering
+	otherCtx := context.Background()
+	ethClients[0].EXPECT().BalanceAt(otherCtx, account, nil).Return(big.NewInt(1), nil).Times(1)
+	balance, err = client.BalanceAt(otherCtx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), balance)
+}
+
+func TestClient_TrustedHeaders(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	headers := client.TrustedHeaders()
+
+	// The header of a single provider is not trusted
+	_, err := headers.HeaderByNumber(ctx, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeaders)
+
+	client.SetQuorums(map[string]params.RpcQuorumConfig{
+		QuorumOperationTrustedHeaders: {Providers: 3},
+	})
+
+	head := big.NewInt(100)
+	header := &types.Header{Number: head, Difficulty: new(big.Int)}
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(100), nil).Times(1)
+	}
+	ethClients[0].EXPECT().HeaderByNumber(gomock.Any(), head).Return(header, nil).Times(1)
+	ethClients[1].EXPECT().HeaderByNumber(gomock.Any(), head).Return(header, nil).Times(1)
+	ethClients[2].EXPECT().HeaderByNumber(gomock.Any(), head).Return(&types.Header{Number: head, Difficulty: big.NewInt(1)}, nil).Times(1)
+	trusted, err := headers.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), trusted.Hash())
+}
+
+func TestAgreedResult(t *testing.T) {
+	responses := []quorumResponse{
+		{name: "a", result: uint64(1)},
+		{name: "b", result: uint64(2)},
+		{name: "c", err: errors.New("some error")},
+		{name: "d", result: uint64(2)},
+	}
+	agreed, errs := agreedResult(responses, 2)
+	require.Equal(t, "2", agreed)
+	require.Len(t, errs, 1)
+	require.Equal(t, "1", responses[0].key)
+	require.Empty(t, responses[2].key)
+
+	// Results with the same count can't be told apart
+	responses = []quorumResponse{
+		{name: "a", result: uint64(1)},
+		{name: "b", result: uint64(2)},
+	}
+	agreed, _ = agreedResult(responses, 1)
+	require.Empty(t, agreed)
+}
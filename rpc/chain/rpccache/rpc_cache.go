@@ -0,0 +1,119 @@
+package rpccache
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// DefaultMaxSize is the default size of the cached values, in bytes
+const DefaultMaxSize = 64 * 1024 * 1024
+
+// Cache persists the responses of RPC calls that can't change anymore, e.g. the blocks that are finalized.
+// The size of the cached values is bounded, the oldest entries are evicted first.
+type Cache struct {
+	db      *sql.DB
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64 // -1 until read from the database
+}
+
+func NewCache(db *sql.DB, maxSize int64) *Cache {
+	return &Cache{
+		db:      db,
+		maxSize: maxSize,
+		size:    -1,
+	}
+}
+
+// Get returns the cached value of the key, false if the key is not cached
+func (c *Cache) Get(chainID uint64, key string) ([]byte, bool, error) {
+	var value []byte
+	err := c.db.QueryRow("SELECT value FROM rpc_cache WHERE chain_id = ? AND cache_key = ?", chainID, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Put caches the value of the key, an already cached value is kept as it can't be different
+func (c *Cache) Put(chainID uint64, key string, value []byte) error {
+	if int64(len(value)) > c.maxSize {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.loadSize(); err != nil {
+		return err
+	}
+
+	res, err := c.db.Exec("INSERT OR IGNORE INTO rpc_cache (chain_id, cache_key, value) VALUES (?, ?, ?)", chainID, key, value)
+	if err != nil {
+		return err
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return nil
+	}
+
+	c.size += int64(len(value))
+	if c.size > c.maxSize {
+		return c.evict(c.size - c.maxSize*3/4)
+	}
+	return nil
+}
+
+// Clear removes the cached values of the chain
+func (c *Cache) Clear(chainID uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.db.Exec("DELETE FROM rpc_cache WHERE chain_id = ?", chainID)
+	// Read again on the next write
+	c.size = -1
+	return err
+}
+
+func (c *Cache) loadSize() error {
+	if c.size >= 0 {
+		return nil
+	}
+	return c.db.QueryRow("SELECT COALESCE(SUM(LENGTH(value)), 0) FROM rpc_cache").Scan(&c.size)
+}
+
+// evict removes the oldest entries until at least size bytes are freed
+func (c *Cache) evict(size int64) error {
+	rows, err := c.db.Query("SELECT id, LENGTH(value) FROM rpc_cache ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	var lastID, freed int64
+	for freed < size && rows.Next() {
+		var valueSize int64
+		if err := rows.Scan(&lastID, &valueSize); err != nil {
+			rows.Close()
+			return err
+		}
+		freed += valueSize
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	if _, err := c.db.Exec("DELETE FROM rpc_cache WHERE id <= ?", lastID); err != nil {
+		return err
+	}
+	c.size -= freed
+	return nil
+}
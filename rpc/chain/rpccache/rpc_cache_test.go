@@ -0,0 +1,64 @@
+package rpccache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/appdatabase"
+	"github.com/status-im/status-go/t/helpers"
+)
+
+func setupTestCache(t *testing.T, maxSize int64) *Cache {
+	db, cleanup, err := helpers.SetupTestSQLDB(appdatabase.DbInitializer{}, "rpc-cache-tests")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cleanup()) })
+	return NewCache(db, maxSize)
+}
+
+func TestCachePutGet(t *testing.T) {
+	cache := setupTestCache(t, DefaultMaxSize)
+
+	_, ok, err := cache.Get(1, "block:1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, cache.Put(1, "block:1", []byte("one")))
+	// Already cached values are kept
+	require.NoError(t, cache.Put(1, "block:1", []byte("other")))
+
+	value, ok, err := cache.Get(1, "block:1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("one"), value)
+
+	// Keys are per chain
+	_, ok, err = cache.Get(10, "block:1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, cache.Clear(1))
+	_, ok, err = cache.Get(1, "block:1")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := setupTestCache(t, 10)
+
+	require.NoError(t, cache.Put(1, "a", []byte("aaaa")))
+	require.NoError(t, cache.Put(10, "b", []byte("bbbb")))
+	// Values larger than the cache are not cached
+	require.NoError(t, cache.Put(1, "large", []byte("0123456789a")))
+	// Over the limit, the oldest entries are evicted
+	require.NoError(t, cache.Put(1, "c", []byte("cccc")))
+
+	for key, cached := range map[string]bool{"a": false, "large": false, "c": true} {
+		_, ok, err := cache.Get(1, key)
+		require.NoError(t, err)
+		require.Equal(t, cached, ok, key)
+	}
+	_, ok, err := cache.Get(10, "b")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
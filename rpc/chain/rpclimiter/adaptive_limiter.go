@@ -0,0 +1,325 @@
+package rpclimiter
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	minAdaptiveRate = 1
+	// The rate recovers by a tenth of the maximum rate per interval while the provider accepts the calls
+	recoveryInterval = 10 * time.Second
+	recoveryRatio    = 0.1
+	// Used when the provider rejects a call without telling when to retry
+	defaultRetryAfter = time.Second
+	maxRetryAfter     = 5 * time.Minute
+)
+
+// AdaptiveLimiter is a token bucket shared by all the calls to a provider. Its rate is halved when the provider
+// rejects a call because of its limits, the calls are held back until the provider accepts them again, and the
+// rate recovers gradually to the maximum rate while the provider accepts the calls.
+type AdaptiveLimiter struct {
+	mu sync.Mutex
+
+	maxRate      float64
+	rate         float64
+	tokens       float64
+	refilledAt   time.Time
+	rateChangeAt time.Time
+	blockedUntil time.Time
+
+	now func() time.Time
+}
+
+func NewAdaptiveLimiter(maxRequestsPerSecond int) *AdaptiveLimiter {
+	l := &AdaptiveLimiter{now: time.Now}
+	l.maxRate = float64(maxRequestsPerSecond)
+	l.rate = l.maxRate
+	l.tokens = l.maxRate
+	l.refilledAt = l.now()
+	return l
+}
+
+// SetMaxRequestsPerSecond overrides the default limit, for providers with a known limit
+func (l *AdaptiveLimiter) SetMaxRequestsPerSecond(maxRequestsPerSecond int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.maxRate = math.Max(float64(maxRequestsPerSecond), minAdaptiveRate)
+	if l.rateChangeAt.IsZero() {
+		// Never throttled
+		l.rate = l.maxRate
+	} else {
+		l.rate = math.Min(l.rate, l.maxRate)
+	}
+}
+
+// RequestsPerSecond returns the current rate of the limiter
+func (l *AdaptiveLimiter) RequestsPerSecond() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rate
+}
+
+// refill adds the tokens accumulated since the last refill, up to one second of calls
+func (l *AdaptiveLimiter) refill(now time.Time) {
+	burst := math.Max(l.rate, 1)
+	l.tokens = math.Min(burst, l.tokens+now.Sub(l.refilledAt).Seconds()*l.rate)
+	l.refilledAt = now
+}
+
+// Wait blocks until the call can be made. ErrRequestsOverLimit is returned right away when the context expires
+// before, so that the caller can use another provider.
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	l.refill(now)
+
+	var delay time.Duration
+	if l.tokens < 1 {
+		delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	if blocked := l.blockedUntil.Sub(now); blocked > delay {
+		delay = blocked
+	}
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		l.mu.Unlock()
+		return ErrRequestsOverLimit
+	}
+	// The token is reserved, the calls waiting after this one wait longer
+	l.tokens--
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// OnResponse adapts the limiter to the HTTP response of the provider, see parseResponseLimits
+func (l *AdaptiveLimiter) OnResponse(statusCode int, header http.Header) {
+	now := l.now()
+	switch limit, holdBack := parseResponseLimits(statusCode, header, now); limit {
+	case limitRejected:
+		l.Throttle(holdBack)
+	case limitExhausted:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		// The quota is used up, the rate is kept as it is restored at the reset
+		l.blockUntil(now.Add(holdBack))
+	case limitAccepted:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.recover(now)
+	}
+}
+
+// Throttle halves the rate and holds the calls back for the given duration, e.g. when the provider rejected a call
+// because of its limits
+func (l *AdaptiveLimiter) Throttle(retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+	// The calls made before the provider rejected the first one are likely rejected too, the rate is halved once
+	if !now.Before(l.blockedUntil) {
+		l.rate = math.Max(l.rate/2, minAdaptiveRate)
+		l.rateChangeAt = now
+	}
+	l.tokens = math.Min(l.tokens, 0)
+	l.blockUntil(now.Add(retryAfter))
+}
+
+func (l *AdaptiveLimiter) blockUntil(until time.Time) {
+	if maxUntil := l.now().Add(maxRetryAfter); until.After(maxUntil) {
+		until = maxUntil
+	}
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// recover increases the rate gradually after the provider rejected calls
+func (l *AdaptiveLimiter) recover(now time.Time) {
+	if l.rate >= l.maxRate || now.Before(l.blockedUntil) || now.Sub(l.rateChangeAt) < recoveryInterval {
+		return
+	}
+	l.refill(now)
+	l.rate = math.Min(l.rate+math.Max(l.maxRate*recoveryRatio, 1), l.maxRate)
+	l.rateChangeAt = now
+}
+
+type responseLimit int
+
+const (
+	limitNone responseLimit = iota
+	// The provider accepted the call
+	limitAccepted
+	// The provider rejected the call because of its limits
+	limitRejected
+	// The provider accepted the call but its quota is used up
+	limitExhausted
+)
+
+// parseResponseLimits tells how the limits of the provider apply to its HTTP response and for how long the calls
+// must be held back. Besides 429 Too Many Requests, the Retry-After header and the X-RateLimit-Remaining/X-RateLimit-Reset
+// or RateLimit-Remaining/RateLimit-Reset quota headers are used.
+func parseResponseLimits(statusCode int, header http.Header, now time.Time) (responseLimit, time.Duration) {
+	retryAfter, hasRetryAfter := parseRetryAfter(header.Get("Retry-After"), now)
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		if !hasRetryAfter {
+			retryAfter, hasRetryAfter = parseQuotaReset(header, now)
+		}
+		if !hasRetryAfter {
+			retryAfter = defaultRetryAfter
+		}
+		return limitRejected, retryAfter
+	case statusCode == http.StatusServiceUnavailable && hasRetryAfter:
+		return limitRejected, retryAfter
+	case statusCode < http.StatusBadRequest:
+		if reset, exhausted := parseQuotaReset(header, now); exhausted {
+			return limitExhausted, reset
+		}
+		return limitAccepted, 0
+	}
+	return limitNone, 0
+}
+
+// parseRetryAfter parses the Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// parseQuotaReset returns the time until the quota of the provider is reset when the quota is used up
+func parseQuotaReset(header http.Header, now time.Time) (time.Duration, bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.ParseFloat(header.Get(prefix+"Remaining"), 64)
+		if err != nil || remaining > 0 {
+			continue
+		}
+		reset, err := strconv.ParseFloat(header.Get(prefix+"Reset"), 64)
+		if err != nil || reset < 0 {
+			return defaultRetryAfter, true
+		}
+		// Some providers give the reset as a unix timestamp instead of a number of seconds
+		if reset > float64(now.Unix()/2) {
+			return max(time.Unix(int64(reset), 0).Sub(now), 0), true
+		}
+		return time.Duration(reset * float64(time.Second)), true
+	}
+	return 0, false
+}
+
+// AdaptiveLimiters holds the limiter of each third-party API, by host. The RPC providers are limited by their
+// RPCRpsLimiter, see RPSLimitedTransport.
+type AdaptiveLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*AdaptiveLimiter
+}
+
+func NewAdaptiveLimiters() *AdaptiveLimiters {
+	return &AdaptiveLimiters{
+		limiters: make(map[string]*AdaptiveLimiter),
+	}
+}
+
+var sharedAdaptiveLimiters = NewAdaptiveLimiters()
+
+// SharedAdaptiveLimiters returns the limiters used by the third-party HTTP clients, so that the calls to an API
+// respect the same budget whichever client makes them
+func SharedAdaptiveLimiters() *AdaptiveLimiters {
+	return sharedAdaptiveLimiters
+}
+
+// Get returns the limiter of the host, it is created with the default limit
+func (l *AdaptiveLimiters) Get(host string) *AdaptiveLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = NewAdaptiveLimiter(defaultMaxRequestsPerSecond)
+		l.limiters[host] = limiter
+	}
+	return limiter
+}
+
+// LimitedTransport waits for the limiter of the host before each request and adapts it to the responses
+type LimitedTransport struct {
+	Base     http.RoundTripper
+	Limiters *AdaptiveLimiters
+}
+
+func NewLimitedTransport(limiters *AdaptiveLimiters) *LimitedTransport {
+	return &LimitedTransport{
+		Base:     http.DefaultTransport,
+		Limiters: limiters,
+	}
+}
+
+func (t *LimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.Limiters.Get(req.URL.Host)
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	limiter.OnResponse(resp.StatusCode, resp.Header)
+	return resp, nil
+}
+
+// RPSLimitedTransport adapts the RPS limiter of an RPC provider to its HTTP responses. The limiter stays the only
+// token bucket of the provider, the chain client waits for it before each call.
+type RPSLimitedTransport struct {
+	Base    http.RoundTripper
+	Limiter *RPCRpsLimiter
+}
+
+func NewRPSLimitedTransport(limiter *RPCRpsLimiter) *RPSLimitedTransport {
+	return &RPSLimitedTransport{
+		Base:    http.DefaultTransport,
+		Limiter: limiter,
+	}
+}
+
+func (t *RPSLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.Limiter != nil {
+		t.Limiter.OnResponse(resp.StatusCode, resp.Header)
+	}
+	return resp, nil
+}
@@ -0,0 +1,148 @@
+package rpclimiter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func setupAdaptiveLimiter(maxRequestsPerSecond int) (*AdaptiveLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	limiter := NewAdaptiveLimiter(maxRequestsPerSecond)
+	limiter.now = clock.Now
+	limiter.refilledAt = clock.now
+	return limiter, clock
+}
+
+func TestAdaptiveLimiter_Wait(t *testing.T) {
+	limiter, _ := setupAdaptiveLimiter(2)
+
+	// Two calls per second are allowed, the third one waits for a token
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	require.ErrorIs(t, limiter.Wait(ctx), ErrRequestsOverLimit)
+}
+
+func TestAdaptiveLimiter_TooManyRequests(t *testing.T) {
+	limiter, clock := setupAdaptiveLimiter(40)
+
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+	limiter.OnResponse(http.StatusTooManyRequests, header)
+	require.Equal(t, float64(20), limiter.RequestsPerSecond())
+
+	// The calls are held back until the provider accepts them again
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	require.ErrorIs(t, limiter.Wait(ctx), ErrRequestsOverLimit)
+
+	// The calls rejected meanwhile don't reduce the rate again
+	limiter.OnResponse(http.StatusTooManyRequests, http.Header{})
+	require.Equal(t, float64(20), limiter.RequestsPerSecond())
+
+	// The rate recovers gradually
+	clock.now = clock.now.Add(30 * time.Second)
+	require.NoError(t, limiter.Wait(ctx))
+	limiter.OnResponse(http.StatusOK, http.Header{})
+	require.Equal(t, float64(24), limiter.RequestsPerSecond())
+	limiter.OnResponse(http.StatusOK, http.Header{})
+	require.Equal(t, float64(24), limiter.RequestsPerSecond())
+
+	for i := 0; i < 10; i++ {
+		clock.now = clock.now.Add(recoveryInterval)
+		limiter.OnResponse(http.StatusOK, http.Header{})
+	}
+	require.Equal(t, float64(40), limiter.RequestsPerSecond())
+}
+
+func TestAdaptiveLimiter_QuotaHeaders(t *testing.T) {
+	limiter, clock := setupAdaptiveLimiter(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The reset is given in seconds
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", "10")
+	limiter.OnResponse(http.StatusOK, header)
+	require.Equal(t, float64(10), limiter.RequestsPerSecond())
+	require.ErrorIs(t, limiter.Wait(ctx), ErrRequestsOverLimit)
+
+	clock.now = clock.now.Add(10 * time.Second)
+	require.NoError(t, limiter.Wait(ctx))
+
+	// The reset is given as a timestamp
+	header = http.Header{}
+	header.Set("RateLimit-Remaining", "0")
+	header.Set("RateLimit-Reset", strconv.FormatInt(clock.now.Add(time.Minute).Unix(), 10))
+	limiter.OnResponse(http.StatusOK, header)
+	require.ErrorIs(t, limiter.Wait(ctx), ErrRequestsOverLimit)
+
+	clock.now = clock.now.Add(time.Minute)
+	require.NoError(t, limiter.Wait(ctx))
+
+	// Providers overloaded with a Retry-After are throttled
+	header = http.Header{}
+	header.Set("Retry-After", clock.now.Add(time.Minute).UTC().Format(http.TimeFormat))
+	limiter.OnResponse(http.StatusServiceUnavailable, header)
+	require.Equal(t, float64(5), limiter.RequestsPerSecond())
+	require.ErrorIs(t, limiter.Wait(ctx), ErrRequestsOverLimit)
+}
+
+func TestLimitedTransport(t *testing.T) {
+	var rejected atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rejected.Load() {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Two clients share the budget of the provider
+	limiters := NewAdaptiveLimiters()
+	client1 := &http.Client{Transport: NewLimitedTransport(limiters)}
+	client2 := &http.Client{Transport: NewLimitedTransport(limiters)}
+
+	get := func(client *http.Client) (*http.Response, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	resp, err := get(client1)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	rejected.Store(true)
+	resp, err = get(client1)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	_, err = get(client2)
+	require.ErrorIs(t, err, ErrRequestsOverLimit)
+}
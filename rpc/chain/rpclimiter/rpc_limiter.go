@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -224,6 +225,11 @@ type RPCRpsLimiter struct {
 
 	maxRequestsPerSecond      int
 	maxRequestsPerSecondMutex sync.RWMutex
+	// configuredRequestsPerSecond is the limit recovered after the provider rejected calls
+	configuredRequestsPerSecond int
+	// The calls are refused until blockedUntil when the provider rejects them, limitChangedAt paces the recovery
+	blockedUntil   time.Time
+	limitChangedAt time.Time
 
 	requestsMadeWithinSecond      int
 	requestsMadeWithinSecondMutex sync.RWMutex
@@ -237,9 +243,10 @@ type RPCRpsLimiter struct {
 func NewRPCRpsLimiter() *RPCRpsLimiter {
 
 	limiter := RPCRpsLimiter{
-		uuid:                 uuid.New(),
-		maxRequestsPerSecond: defaultMaxRequestsPerSecond,
-		quit:                 make(chan bool),
+		uuid:                        uuid.New(),
+		maxRequestsPerSecond:        defaultMaxRequestsPerSecond,
+		configuredRequestsPerSecond: defaultMaxRequestsPerSecond,
+		quit:                        make(chan bool),
 	}
 
 	limiter.start()
@@ -247,6 +254,58 @@ func NewRPCRpsLimiter() *RPCRpsLimiter {
 	return &limiter
 }
 
+// SetMaxRequestsPerSecond overrides the default limit, for providers with a known limit
+func (rl *RPCRpsLimiter) SetMaxRequestsPerSecond(maxRequestsPerSecond int) {
+	rl.maxRequestsPerSecondMutex.Lock()
+	defer rl.maxRequestsPerSecondMutex.Unlock()
+	rl.maxRequestsPerSecond = maxRequestsPerSecond
+	rl.configuredRequestsPerSecond = maxRequestsPerSecond
+}
+
+// OnResponse adapts the limiter to the HTTP response of the provider. When the provider rejects a call because of
+// its limits, the limit is halved and the calls are refused until the provider accepts them again, so that they go
+// to the other providers. The limit then recovers gradually while the provider accepts the calls.
+func (rl *RPCRpsLimiter) OnResponse(statusCode int, header http.Header) {
+	now := time.Now()
+	limit, holdBack := parseResponseLimits(statusCode, header, now)
+
+	rl.maxRequestsPerSecondMutex.Lock()
+	defer rl.maxRequestsPerSecondMutex.Unlock()
+
+	switch limit {
+	case limitRejected:
+		// The calls made before the provider rejected the first one are likely rejected too, the limit is halved once
+		if !now.Before(rl.blockedUntil) {
+			floor := min(minRequestsPerSecond, rl.configuredRequestsPerSecond)
+			rl.maxRequestsPerSecond = max(rl.maxRequestsPerSecond/2, floor)
+			rl.limitChangedAt = now
+		}
+		rl.blockUntil(now, holdBack)
+	case limitExhausted:
+		rl.blockUntil(now, holdBack)
+	case limitAccepted:
+		if rl.maxRequestsPerSecond >= rl.configuredRequestsPerSecond || now.Before(rl.blockedUntil) || now.Sub(rl.limitChangedAt) < recoveryInterval {
+			return
+		}
+		step := max(int(float64(rl.configuredRequestsPerSecond)*recoveryRatio), 1)
+		rl.maxRequestsPerSecond = min(rl.maxRequestsPerSecond+step, rl.configuredRequestsPerSecond)
+		rl.limitChangedAt = now
+	}
+}
+
+func (rl *RPCRpsLimiter) blockUntil(now time.Time, holdBack time.Duration) {
+	until := now.Add(min(holdBack, maxRetryAfter))
+	if until.After(rl.blockedUntil) {
+		rl.blockedUntil = until
+	}
+}
+
+func (rl *RPCRpsLimiter) isBlocked() bool {
+	rl.maxRequestsPerSecondMutex.RLock()
+	defer rl.maxRequestsPerSecondMutex.RUnlock()
+	return time.Now().Before(rl.blockedUntil)
+}
+
 func (rl *RPCRpsLimiter) ReduceLimit() {
 	rl.maxRequestsPerSecondMutex.Lock()
 	defer rl.maxRequestsPerSecondMutex.Unlock()
@@ -320,6 +379,10 @@ func (rl *RPCRpsLimiter) Stop() {
 }
 
 func (rl *RPCRpsLimiter) WaitForRequestsAvailability(requests int) error {
+	if rl.isBlocked() {
+		return ErrRequestsOverLimit
+	}
+
 	if requests > rl.maxRequestsPerSecond {
 		return ErrRequestsOverLimit
 	}
@@ -1,6 +1,7 @@
 package rpclimiter
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -193,3 +194,25 @@ func TestAllowWhenLimitNotReachedForInfinitePeriod(t *testing.T) {
 	// Verify the result
 	require.True(t, allow)
 }
+
+func TestRPCRpsLimiterOnResponse(t *testing.T) {
+	rl := NewRPCRpsLimiter()
+	defer rl.Stop()
+	rl.SetMaxRequestsPerSecond(100)
+
+	header := http.Header{}
+	header.Set("Retry-After", "60")
+	rl.OnResponse(http.StatusTooManyRequests, header)
+	require.ErrorIs(t, rl.WaitForRequestsAvailability(1), ErrRequestsOverLimit)
+	require.Equal(t, 50, rl.maxRequestsPerSecond)
+
+	// The calls rejected within the blocked window halve the limit only once
+	rl.OnResponse(http.StatusTooManyRequests, header)
+	require.Equal(t, 50, rl.maxRequestsPerSecond)
+
+	rl.blockedUntil = time.Now().Add(-time.Second)
+	rl.limitChangedAt = time.Now().Add(-recoveryInterval)
+	rl.OnResponse(http.StatusOK, http.Header{})
+	require.Equal(t, 60, rl.maxRequestsPerSecond)
+	require.NoError(t, rl.WaitForRequestsAvailability(1))
+}
@@ -0,0 +1,301 @@
+package verified
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	gethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// precompiles are touched by the calls without being in their access lists
+var precompiles = []common.Address{
+	common.BytesToAddress([]byte{1}), common.BytesToAddress([]byte{2}), common.BytesToAddress([]byte{3}),
+	common.BytesToAddress([]byte{4}), common.BytesToAddress([]byte{5}), common.BytesToAddress([]byte{6}),
+	common.BytesToAddress([]byte{7}), common.BytesToAddress([]byte{8}), common.BytesToAddress([]byte{9}),
+}
+
+var errBlockHashUsed = errors.New("call uses block hashes")
+
+type accessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// CallContract executes the call locally on the state proven against the trusted header and compares the
+// result with the one of the provider. The errors of the provider are returned as they are.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	header, err := c.trustedHeader(ctx, blockNumber)
+	if err != nil {
+		if err := c.unverified("eth_call", err); err != nil {
+			return nil, err
+		}
+		return c.ClientInterface.CallContract(ctx, msg, blockNumber)
+	}
+
+	result, err := c.ClientInterface.CallContract(ctx, msg, header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	returned, err := c.executeCall(ctx, msg, header)
+	if err != nil {
+		if errors.Is(err, ErrVerificationFailed) {
+			return nil, err
+		}
+		if err := c.unverified("eth_call", err); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	if !bytes.Equal(returned, result) {
+		return nil, fmt.Errorf("%w: eth_call result differs from the local execution at block %d", ErrVerificationFailed, header.Number)
+	}
+	return returned, nil
+}
+
+// executeCall runs the call on the state of the trusted header, the nodes of the state trie are fetched with
+// eth_getProof for the accounts of the access list of the call. Nodes are stored by their hash so the ones not
+// matching the state root are never used, a missing node makes the call unverifiable.
+func (c *Client) executeCall(ctx context.Context, msg ethereum.CallMsg, header *types.Header) ([]byte, error) {
+	db := rawdb.NewMemoryDatabase()
+	if err := c.prefetchState(ctx, db, msg, header); err != nil {
+		return nil, err
+	}
+
+	stateDB := &provenDatabase{Database: state.NewDatabase(db)}
+	statedb, err := state.New(header.Root, stateDB, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHashUsed := false
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash: func(uint64) common.Hash {
+			// Older block hashes are not proven by the header
+			blockHashUsed = true
+			return common.Hash{}
+		},
+		Coinbase:    header.Coinbase,
+		GasLimit:    header.GasLimit,
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        new(big.Int).SetUint64(header.Time),
+		Difficulty:  header.Difficulty,
+		BaseFee:     new(big.Int),
+	}
+	// The rules of the local EVM always include London
+	if header.BaseFee != nil {
+		blockCtx.BaseFee.Set(header.BaseFee)
+	}
+	if header.Difficulty == nil || header.Difficulty.Sign() == 0 {
+		random := header.MixDigest
+		blockCtx.Random = &random
+	}
+
+	chainConfig := *gethparams.AllEthashProtocolChanges
+	chainConfig.ChainID = new(big.Int).SetUint64(c.NetworkID())
+
+	gas := msg.Gas
+	if gas == 0 {
+		gas = header.GasLimit
+	}
+	gasPrice := msg.GasPrice
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+	value := msg.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	// The messages of this EVM are always nonce checked, the proven nonce of the sender is used
+	message := types.NewMessage(msg.From, msg.To, statedb.GetNonce(msg.From), value, gas, gasPrice, gasPrice, gasPrice, msg.Data, msg.AccessList, false)
+
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(message), statedb, &chainConfig, vm.Config{NoBaseFee: true})
+	res, err := core.ApplyMessage(evm, message, new(core.GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	if err := statedb.Error(); err != nil {
+		return nil, err
+	}
+	if stateDB.err != nil {
+		return nil, stateDB.err
+	}
+	if blockHashUsed {
+		return nil, errBlockHashUsed
+	}
+	if res.Err != nil {
+		// The provider answered, reverts and other failures are errors of eth_call
+		if errors.Is(res.Err, vm.ErrExecutionReverted) {
+			return nil, fmt.Errorf("%w: eth_call reverts in the local execution at block %d", ErrVerificationFailed, header.Number)
+		}
+		// E.g. opcodes unknown to the local EVM
+		return nil, res.Err
+	}
+	return res.ReturnData, nil
+}
+
+// prefetchState writes the trie nodes and the code of the accounts used by the call in the database
+func (c *Client) prefetchState(ctx context.Context, db ethdb.KeyValueWriter, msg ethereum.CallMsg, header *types.Header) error {
+	blockNumber := hexutil.EncodeBig(header.Number)
+
+	slots := make(map[common.Address][]common.Hash)
+	addAccount := func(account common.Address, keys ...common.Hash) {
+		slots[account] = append(slots[account], keys...)
+	}
+	addAccount(msg.From)
+	if msg.To != nil {
+		addAccount(*msg.To)
+	}
+	addAccount(header.Coinbase)
+	for _, precompile := range precompiles {
+		addAccount(precompile)
+	}
+	for _, tuple := range msg.AccessList {
+		addAccount(tuple.Address, tuple.StorageKeys...)
+	}
+
+	// The access list misses the accounts when the provider doesn't support it, the call is then
+	// unverifiable if they are used
+	var accessList accessListResult
+	if err := c.CallContext(ctx, &accessList, "eth_createAccessList", toCallArg(msg), blockNumber); err == nil && accessList.AccessList != nil {
+		for _, tuple := range *accessList.AccessList {
+			addAccount(tuple.Address, tuple.StorageKeys...)
+		}
+	}
+
+	accounts := make([]accountResult, len(slots))
+	batch := make([]rpc.BatchElem, 0, len(slots))
+	for account, keys := range slots {
+		batch = append(batch, proofElem(account, keys, header.Number, &accounts[len(batch)]))
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+
+	var codeBatch []rpc.BatchElem
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return elem.Error
+		}
+		account := &accounts[i]
+		if err := writeProof(db, account.AccountProof); err != nil {
+			return err
+		}
+		for _, storage := range account.StorageProof {
+			if err := writeProof(db, storage.Proof); err != nil {
+				return err
+			}
+		}
+		if account.CodeHash != types.EmptyCodeHash && account.CodeHash != (common.Hash{}) {
+			codeBatch = append(codeBatch, rpc.BatchElem{
+				Method: "eth_getCode",
+				Args:   []interface{}{account.Address, blockNumber},
+				Result: new(hexutil.Bytes),
+			})
+		}
+	}
+
+	if len(codeBatch) == 0 {
+		return nil
+	}
+	if err := c.BatchCallContext(ctx, codeBatch); err != nil {
+		return err
+	}
+	for _, elem := range codeBatch {
+		if elem.Error != nil {
+			return elem.Error
+		}
+		code := *elem.Result.(*hexutil.Bytes)
+		rawdb.WriteCode(db, crypto.Keccak256Hash(code), code)
+	}
+	return nil
+}
+
+// provenDatabase records the errors reading the storage and the code of the accounts, e.g. the nodes missing
+// from the proofs. The state keeps them until it is committed.
+type provenDatabase struct {
+	state.Database
+	err error
+}
+
+func (db *provenDatabase) setError(err error) {
+	if err != nil && db.err == nil {
+		db.err = err
+	}
+}
+
+func (db *provenDatabase) OpenStorageTrie(stateRoot common.Hash, addrHash, root common.Hash) (state.Trie, error) {
+	tr, err := db.Database.OpenStorageTrie(stateRoot, addrHash, root)
+	if err != nil {
+		db.setError(err)
+		return nil, err
+	}
+	return &provenTrie{Trie: tr, db: db}, nil
+}
+
+func (db *provenDatabase) CopyTrie(tr state.Trie) state.Trie {
+	if proven, ok := tr.(*provenTrie); ok {
+		return &provenTrie{Trie: db.Database.CopyTrie(proven.Trie), db: db}
+	}
+	return db.Database.CopyTrie(tr)
+}
+
+func (db *provenDatabase) ContractCode(addrHash, codeHash common.Hash) ([]byte, error) {
+	code, err := db.Database.ContractCode(addrHash, codeHash)
+	db.setError(err)
+	return code, err
+}
+
+func (db *provenDatabase) ContractCodeSize(addrHash, codeHash common.Hash) (int, error) {
+	size, err := db.Database.ContractCodeSize(addrHash, codeHash)
+	db.setError(err)
+	return size, err
+}
+
+type provenTrie struct {
+	state.Trie
+	db *provenDatabase
+}
+
+func (t *provenTrie) TryGet(key []byte) ([]byte, error) {
+	value, err := t.Trie.TryGet(key)
+	t.db.setError(err)
+	return value, err
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
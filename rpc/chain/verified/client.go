@@ -0,0 +1,129 @@
+package verified
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc/chain"
+)
+
+var (
+	// ErrVerificationFailed is returned when the response of the provider doesn't match the proofs or the
+	// trusted headers
+	ErrVerificationFailed = errors.New("rpc response verification failed")
+	// ErrUnverifiable is returned in strict mode when the response of the provider could not be verified
+	ErrUnverifiable = errors.New("rpc response could not be verified")
+)
+
+// Client verifies the state and the receipts returned by the wrapped client against the eth_getProof Merkle
+// proofs and the trusted headers. The other calls are passed to the wrapped client.
+type Client struct {
+	chain.ClientInterface
+	headers        chain.HeaderSource
+	strict         bool
+	onUnverifiable func(method string, err error)
+}
+
+// NewClient wraps the client, the headers are usually the ones agreed by a quorum of providers, see
+// chain.ClientInterface.TrustedHeaders. In strict mode the responses that could not be verified are rejected,
+// otherwise they are returned as given by the provider and reported to the unverifiable handler.
+func NewClient(client chain.ClientInterface, headers chain.HeaderSource, strict bool) *Client {
+	return &Client{
+		ClientInterface: client,
+		headers:         headers,
+		strict:          strict,
+	}
+}
+
+// SetUnverifiableHandler sets the function called with the responses that could not be verified
+func (c *Client) SetUnverifiableHandler(handler func(method string, err error)) {
+	c.onUnverifiable = handler
+}
+
+// unverified reports that the response of the method could not be verified, the error is only returned in
+// strict mode
+func (c *Client) unverified(method string, reason error) error {
+	err := fmt.Errorf("%w: %s: %v", ErrUnverifiable, method, reason)
+	logutils.ZapLogger().Warn("unverified rpc response", zap.Uint64("chain", c.NetworkID()), zap.String("method", method), zap.Error(reason))
+	if c.onUnverifiable != nil {
+		c.onUnverifiable(method, err)
+	}
+	if c.strict {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) trustedHeader(ctx context.Context, blockNumber *big.Int) (*types.Header, error) {
+	header, err := c.headers.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil || header.Number == nil {
+		return nil, fmt.Errorf("no trusted header for block %v", blockNumber)
+	}
+	return header, nil
+}
+
+// verifiedAccount returns the account proven against the trusted header of the block
+func (c *Client) verifiedAccount(ctx context.Context, account common.Address, keys []common.Hash, blockNumber *big.Int) (*accountResult, error) {
+	header, err := c.trustedHeader(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.getProof(ctx, account, keys, header.Number)
+	if err != nil {
+		return nil, err
+	}
+	if result.Address != account {
+		return nil, fmt.Errorf("%w: proof of %s given for %s", ErrVerificationFailed, account.Hex(), result.Address.Hex())
+	}
+	if err := verifyAccount(header.Root, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	result, err := c.verifiedAccount(ctx, account, nil, blockNumber)
+	if err != nil {
+		if errors.Is(err, ErrVerificationFailed) {
+			return nil, err
+		}
+		if err := c.unverified("eth_getBalance", err); err != nil {
+			return nil, err
+		}
+		return c.ClientInterface.BalanceAt(ctx, account, blockNumber)
+	}
+	return result.Balance.ToInt(), nil
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	result, err := c.verifiedAccount(ctx, account, []common.Hash{key}, blockNumber)
+	if err == nil && len(result.StorageProof) != 1 {
+		err = fmt.Errorf("%d storage proofs given", len(result.StorageProof))
+	}
+	if err != nil {
+		if errors.Is(err, ErrVerificationFailed) {
+			return nil, err
+		}
+		if err := c.unverified("eth_getStorageAt", err); err != nil {
+			return nil, err
+		}
+		return c.ClientInterface.StorageAt(ctx, account, key, blockNumber)
+	}
+
+	value, err := verifyStorage(result.StorageHash, key, result.StorageProof[0])
+	if err != nil {
+		return nil, err
+	}
+	return value.Bytes(), nil
+}
@@ -0,0 +1,311 @@
+package verified
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/status-im/status-go/rpc/chain"
+)
+
+var (
+	eoa      = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	contract = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	// Returns the storage slot 0
+	contractCode = common.FromHex("0x60005460005260206000f3")
+	slot0        = common.Hash{}
+	slot0Value   = common.HexToHash("0x2a")
+)
+
+type fakeHeaders struct {
+	header *types.Header
+	err    error
+}
+
+func (h *fakeHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return h.header, h.err
+}
+
+// fakeClient answers from the state like a provider, the tamper functions make it lie
+type fakeClient struct {
+	chain.ClientInterface
+	state        *state.StateDB
+	block        *types.Block
+	receipts     types.Receipts
+	callResult   []byte
+	noAccessList bool
+	tamperProof  func(*accountResult)
+}
+
+func (c *fakeClient) NetworkID() uint64 {
+	return 1
+}
+
+func (c *fakeClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.state.GetBalance(account), nil
+}
+
+func (c *fakeClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.callResult, nil
+}
+
+func (c *fakeClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return c.block, nil
+}
+
+func (c *fakeClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	for _, receipt := range c.receipts {
+		if receipt.TxHash == txHash {
+			return receipt, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *fakeClient) proof(account common.Address, keys []common.Hash) (*accountResult, error) {
+	accountProof, err := c.state.GetProof(account)
+	if err != nil {
+		return nil, err
+	}
+	storageHash := types.EmptyRootHash
+	if storageTrie := c.state.StorageTrie(account); storageTrie != nil {
+		storageHash = storageTrie.Hash()
+	}
+	result := &accountResult{
+		Address:      account,
+		AccountProof: toBytes(accountProof),
+		Balance:      (*hexutil.Big)(c.state.GetBalance(account)),
+		CodeHash:     c.state.GetCodeHash(account),
+		Nonce:        hexutil.Uint64(c.state.GetNonce(account)),
+		StorageHash:  storageHash,
+	}
+	for _, key := range keys {
+		storageProof, err := c.state.GetStorageProof(account, key)
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof = append(result.StorageProof, storageResult{
+			Key:   key.Hex(),
+			Value: (*hexutil.Big)(c.state.GetState(account, key).Big()),
+			Proof: toBytes(storageProof),
+		})
+	}
+	if c.tamperProof != nil {
+		c.tamperProof(result)
+	}
+	return result, nil
+}
+
+func (c *fakeClient) call(result interface{}, method string, args ...interface{}) error {
+	var value interface{}
+	switch method {
+	case "eth_getProof":
+		proof, err := c.proof(args[0].(common.Address), args[1].([]common.Hash))
+		if err != nil {
+			return err
+		}
+		value = proof
+	case "eth_getCode":
+		value = hexutil.Bytes(c.state.GetCode(args[0].(common.Address)))
+	case "eth_createAccessList":
+		if c.noAccessList {
+			return errors.New("the method eth_createAccessList does not exist/is not available")
+		}
+		value = accessListResult{AccessList: &types.AccessList{{Address: contract, StorageKeys: []common.Hash{slot0}}}}
+	case "eth_getTransactionReceipt":
+		receipt, err := c.TransactionReceipt(context.Background(), args[0].(common.Hash))
+		if err != nil {
+			return err
+		}
+		value = receipt
+	default:
+		return fmt.Errorf("unexpected method %s", method)
+	}
+	// Encoded like an RPC response
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, result)
+}
+
+func (c *fakeClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.call(result, method, args...)
+}
+
+func (c *fakeClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = c.call(b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func toBytes(proof [][]byte) []hexutil.Bytes {
+	result := make([]hexutil.Bytes, len(proof))
+	for i, node := range proof {
+		result[i] = node
+	}
+	return result
+}
+
+func setupTestState(t *testing.T) (*state.StateDB, common.Hash) {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, err := state.New(types.EmptyRootHash, db, nil)
+	require.NoError(t, err)
+
+	statedb.SetBalance(eoa, big.NewInt(100))
+	statedb.SetNonce(eoa, 3)
+	statedb.SetCode(contract, contractCode)
+	statedb.SetState(contract, slot0, slot0Value)
+
+	root, err := statedb.Commit(false)
+	require.NoError(t, err)
+	require.NoError(t, db.TrieDB().Commit(root, false, nil))
+
+	statedb, err = state.New(root, db, nil)
+	require.NoError(t, err)
+	return statedb, root
+}
+
+func setupTestClient(t *testing.T, strict bool) (*Client, *fakeClient, *fakeHeaders) {
+	statedb, root := setupTestState(t)
+	provider := &fakeClient{state: statedb}
+	headers := &fakeHeaders{header: &types.Header{Number: big.NewInt(10), Root: root, Difficulty: new(big.Int), GasLimit: 30000000}}
+	return NewClient(provider, headers, strict), provider, headers
+}
+
+func TestClient_BalanceAndStorageAt(t *testing.T) {
+	client, provider, _ := setupTestClient(t, true)
+	ctx := context.Background()
+
+	balance, err := client.BalanceAt(ctx, eoa, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	// Accounts not in the state are proven empty
+	balance, err = client.BalanceAt(ctx, common.HexToAddress("0x3"), nil)
+	require.NoError(t, err)
+	require.Zero(t, balance.Sign())
+
+	value, err := client.StorageAt(ctx, contract, slot0, nil)
+	require.NoError(t, err)
+	require.Equal(t, slot0Value.Bytes(), value)
+
+	provider.tamperProof = func(result *accountResult) {
+		result.Balance = (*hexutil.Big)(big.NewInt(1000))
+		for i := range result.StorageProof {
+			result.StorageProof[i].Value = (*hexutil.Big)(big.NewInt(1))
+		}
+	}
+	_, err = client.BalanceAt(ctx, eoa, nil)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	provider.tamperProof = func(result *accountResult) {
+		for i := range result.StorageProof {
+			result.StorageProof[i].Value = (*hexutil.Big)(big.NewInt(1))
+		}
+	}
+	_, err = client.StorageAt(ctx, contract, slot0, nil)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
+
+func TestClient_Unverifiable(t *testing.T) {
+	client, _, headers := setupTestClient(t, false)
+	ctx := context.Background()
+
+	var reported []string
+	client.SetUnverifiableHandler(func(method string, err error) {
+		require.ErrorIs(t, err, ErrUnverifiable)
+		reported = append(reported, method)
+	})
+
+	// Without a trusted header the response of the provider is flagged
+	headers.err = errors.New("no header")
+	balance, err := client.BalanceAt(ctx, eoa, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+	require.Equal(t, []string{"eth_getBalance"}, reported)
+
+	// Strict clients reject it
+	client.strict = true
+	_, err = client.BalanceAt(ctx, eoa, nil)
+	require.ErrorIs(t, err, ErrUnverifiable)
+}
+
+func TestClient_CallContract(t *testing.T) {
+	client, provider, _ := setupTestClient(t, true)
+	ctx := context.Background()
+	msg := ethereum.CallMsg{From: eoa, To: &contract}
+
+	provider.callResult = slot0Value.Bytes()
+	result, err := client.CallContract(ctx, msg, nil)
+	require.NoError(t, err)
+	require.Equal(t, slot0Value.Bytes(), result)
+
+	provider.callResult = common.HexToHash("0x1").Bytes()
+	_, err = client.CallContract(ctx, msg, nil)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	// Without the access list the storage used by the call is not proven
+	provider.callResult = slot0Value.Bytes()
+	provider.noAccessList = true
+	_, err = client.CallContract(ctx, msg, nil)
+	require.ErrorIs(t, err, ErrUnverifiable)
+}
+
+func TestClient_TransactionReceipt(t *testing.T) {
+	client, provider, headers := setupTestClient(t, true)
+	ctx := context.Background()
+
+	txs := types.Transactions{
+		types.NewTransaction(0, contract, big.NewInt(1), 21000, big.NewInt(1), nil),
+		types.NewTransaction(1, contract, big.NewInt(2), 21000, big.NewInt(1), nil),
+	}
+	receipts := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{}},
+		{Status: types.ReceiptStatusFailed, CumulativeGasUsed: 42000, Logs: []*types.Log{}},
+	}
+	for i, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipt.TxHash = txs[i].Hash()
+		receipt.GasUsed = 21000
+		receipt.TransactionIndex = uint(i)
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(10)}, txs, nil, receipts, trie.NewStackTrie(nil))
+	for _, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		receipt.BlockNumber = block.Number()
+	}
+	provider.block = block
+	provider.receipts = receipts
+	headers.header = block.Header()
+
+	receipt, err := client.TransactionReceipt(ctx, txs[1].Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	require.Equal(t, txs[1].Hash(), receipt.TxHash)
+
+	// A receipt changed by the provider doesn't match the receipts root
+	receipts[1].Status = types.ReceiptStatusSuccessful
+	_, err = client.TransactionReceipt(ctx, txs[1].Hash())
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	// Receipts of other blocks are rejected
+	headers.header = &types.Header{Number: big.NewInt(10)}
+	_, err = client.TransactionReceipt(ctx, txs[0].Hash())
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
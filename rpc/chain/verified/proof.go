@@ -0,0 +1,125 @@
+package verified
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// accountResult is the response of eth_getProof
+type accountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []storageResult `json:"storageProof"`
+}
+
+type storageResult struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+func proofElem(account common.Address, keys []common.Hash, blockNumber *big.Int, result *accountResult) rpc.BatchElem {
+	if keys == nil {
+		keys = []common.Hash{}
+	}
+	return rpc.BatchElem{
+		Method: "eth_getProof",
+		Args:   []interface{}{account, keys, hexutil.EncodeBig(blockNumber)},
+		Result: result,
+	}
+}
+
+func (c *Client) getProof(ctx context.Context, account common.Address, keys []common.Hash, blockNumber *big.Int) (*accountResult, error) {
+	var result accountResult
+	elem := proofElem(account, keys, blockNumber, &result)
+	if err := c.CallContext(ctx, elem.Result, elem.Method, elem.Args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// writeProof stores the trie nodes of the proof by their hash
+func writeProof(db ethdb.KeyValueWriter, proof []hexutil.Bytes) error {
+	for _, node := range proof {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyAccount checks the account of the proof against the state root
+func verifyAccount(root common.Hash, result *accountResult) error {
+	proofDB := memorydb.New()
+	if err := writeProof(proofDB, result.AccountProof); err != nil {
+		return err
+	}
+	value, err := trie.VerifyProof(root, crypto.Keccak256(result.Address.Bytes()), proofDB)
+	if err != nil {
+		return fmt.Errorf("%w: account proof of %s: %v", ErrVerificationFailed, result.Address.Hex(), err)
+	}
+
+	account := types.StateAccount{
+		Balance:  new(big.Int),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}
+	// An account not in the trie is empty, some providers give it a zero code hash
+	if value != nil {
+		if err := rlp.DecodeBytes(value, &account); err != nil {
+			return fmt.Errorf("%w: account of %s: %v", ErrVerificationFailed, result.Address.Hex(), err)
+		}
+	} else if result.CodeHash == (common.Hash{}) {
+		account.CodeHash = result.CodeHash.Bytes()
+	}
+
+	if result.Balance == nil || account.Balance.Cmp(result.Balance.ToInt()) != 0 ||
+		account.Nonce != uint64(result.Nonce) ||
+		account.Root != result.StorageHash ||
+		common.BytesToHash(account.CodeHash) != result.CodeHash {
+		return fmt.Errorf("%w: account of %s doesn't match its proof", ErrVerificationFailed, result.Address.Hex())
+	}
+	return nil
+}
+
+// verifyStorage checks the storage slot of the proof against the storage root of the verified account
+func verifyStorage(storageRoot common.Hash, key common.Hash, result storageResult) (common.Hash, error) {
+	proofDB := memorydb.New()
+	if err := writeProof(proofDB, result.Proof); err != nil {
+		return common.Hash{}, err
+	}
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(key.Bytes()), proofDB)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: storage proof of %s: %v", ErrVerificationFailed, key.Hex(), err)
+	}
+
+	// Slots not in the trie are zero
+	slot := new(big.Int)
+	if value != nil {
+		var content []byte
+		if err := rlp.DecodeBytes(value, &content); err != nil {
+			return common.Hash{}, fmt.Errorf("%w: storage of %s: %v", ErrVerificationFailed, key.Hex(), err)
+		}
+		slot.SetBytes(content)
+	}
+
+	if result.Value == nil || slot.Cmp(result.Value.ToInt()) != 0 {
+		return common.Hash{}, fmt.Errorf("%w: storage of %s doesn't match its proof", ErrVerificationFailed, key.Hex())
+	}
+	return common.BigToHash(slot), nil
+}
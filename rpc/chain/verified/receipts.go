@@ -0,0 +1,86 @@
+package verified
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := c.ClientInterface.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := c.verifyReceipt(ctx, receipt)
+	if err != nil {
+		if errors.Is(err, ErrVerificationFailed) {
+			return nil, err
+		}
+		if err := c.unverified("eth_getTransactionReceipt", err); err != nil {
+			return nil, err
+		}
+		return receipt, nil
+	}
+	return verified, nil
+}
+
+// verifyReceipt checks the receipts of the block against the receipts root of the trusted header, all the
+// receipts of the block are needed to rebuild the trie
+func (c *Client) verifyReceipt(ctx context.Context, receipt *types.Receipt) (*types.Receipt, error) {
+	if receipt.BlockNumber == nil {
+		return nil, errors.New("receipt without block")
+	}
+	header, err := c.trustedHeader(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header.Hash() != receipt.BlockHash {
+		return nil, fmt.Errorf("%w: receipt of %s is not in the trusted block %d", ErrVerificationFailed, receipt.TxHash.Hex(), header.Number)
+	}
+
+	block, err := c.ClientInterface.BlockByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if types.DeriveSha(txs, trie.NewStackTrie(nil)) != header.TxHash {
+		return nil, fmt.Errorf("%w: transactions of block %d", ErrVerificationFailed, header.Number)
+	}
+	if int(receipt.TransactionIndex) >= len(txs) || txs[receipt.TransactionIndex].Hash() != receipt.TxHash {
+		return nil, fmt.Errorf("%w: receipt of %s is not at its index", ErrVerificationFailed, receipt.TxHash.Hex())
+	}
+
+	receipts := make(types.Receipts, len(txs))
+	batch := make([]rpc.BatchElem, len(txs))
+	for i, tx := range txs {
+		receipts[i] = new(types.Receipt)
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{tx.Hash()},
+			Result: receipts[i],
+		}
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, elem.Error
+		}
+	}
+	if types.DeriveSha(receipts, trie.NewStackTrie(nil)) != header.ReceiptHash {
+		return nil, fmt.Errorf("%w: receipts of block %d", ErrVerificationFailed, header.Number)
+	}
+
+	verified := receipts[receipt.TransactionIndex]
+	if verified.TxHash != receipt.TxHash {
+		return nil, fmt.Errorf("%w: receipt of %s is not at its index", ErrVerificationFailed, receipt.TxHash.Hex())
+	}
+	return verified, nil
+}
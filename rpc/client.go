@@ -5,7 +5,6 @@ package rpc
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,15 +20,20 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/status-im/status-go/circuitbreaker"
 	appCommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/healthmanager"
 	"github.com/status-im/status-go/internal/version"
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/logutils/tracing"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc/chain"
 	"github.com/status-im/status-go/rpc/chain/ethclient"
+	"github.com/status-im/status-go/rpc/chain/rpccache"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
+	"github.com/status-im/status-go/rpc/chain/verified"
 	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/rpc/subscriptions"
 	"github.com/status-im/status-go/services/rpcstats"
 	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/walletevent"
@@ -51,6 +55,7 @@ const (
 	rpcUserAgentUpstreamFormat = "procuratee-%s-upstream/%s"
 
 	EventBlockchainHealthChanged walletevent.EventType = "wallet-blockchain-health-changed" // Full status of the blockchain (including provider statuses)
+	EventCircuitStateChanged     walletevent.EventType = "wallet-circuit-state-changed"     // A circuit of the circuit breakers opened or closed
 )
 
 // List of RPC client errors.
@@ -100,6 +105,7 @@ type Client struct {
 	rpcClients         map[uint64]chain.ClientInterface
 	rpsLimiterMutex    sync.RWMutex
 	limiterPerProvider map[string]*rpclimiter.RPCRpsLimiter
+	rpcCache           *rpccache.Cache
 
 	router         *router
 	NetworkManager *network.Manager
@@ -114,6 +120,8 @@ type Client struct {
 
 	walletNotifier  func(chainID uint64, message string)
 	providerConfigs []params.ProviderConfig
+	quorums         map[uint64]map[string]params.RpcQuorumConfig
+	subscriptions   *subscriptions.Manager
 }
 
 // Is initialized in a build-tag-dependent module
@@ -127,6 +135,8 @@ type ClientConfig struct {
 	DB              *sql.DB
 	WalletFeed      *event.Feed
 	ProviderConfigs []params.ProviderConfig
+	// Quorums are the quorums required per chain and per operation, see chain.WithQuorumOperation
+	Quorums map[uint64]map[string]params.RpcQuorumConfig
 }
 
 // NewClient initializes Client
@@ -153,14 +163,28 @@ func NewClient(config ClientConfig) (*Client, error) {
 		handlers:           make(map[string]Handler),
 		rpcClients:         make(map[uint64]chain.ClientInterface),
 		limiterPerProvider: make(map[string]*rpclimiter.RPCRpsLimiter),
+		rpcCache:           rpccache.NewCache(config.DB, rpccache.DefaultMaxSize),
 		logger:             logger,
 		providerConfigs:    config.ProviderConfigs,
+		quorums:            config.Quorums,
 		healthMgr:          healthmanager.NewBlockchainHealthManager(),
 		walletFeed:         config.WalletFeed,
 	}
 
 	c.UpstreamChainID = config.UpstreamChainID
 	c.router = newRouter(true)
+	c.subscriptions = subscriptions.NewManager(&c)
+
+	// The circuits known to be open are not used right away
+	circuitMonitor := circuitbreaker.DefaultMonitor()
+	if config.DB != nil {
+		if err := circuitMonitor.SetDB(config.DB); err != nil {
+			logger.Warn("could not restore the circuit states", zap.Error(err))
+		}
+	}
+	if c.walletFeed != nil {
+		circuitMonitor.SetStateChangedHandler(c.sendCircuitStateChanged)
+	}
 
 	if verifProxyInitFn != nil {
 		verifProxyInitFn(&c)
@@ -183,6 +207,7 @@ func (c *Client) Start(ctx context.Context) {
 
 func (c *Client) Stop() {
 	c.healthMgr.Stop()
+	c.subscriptions.Stop()
 	if c.stopMonitoringFunc == nil {
 		return
 	}
@@ -190,6 +215,19 @@ func (c *Client) Stop() {
 	c.stopMonitoringFunc = nil
 }
 
+func (c *Client) sendCircuitStateChanged(status circuitbreaker.CircuitStatus) {
+	encodedMessage, err := json.Marshal(status)
+	if err != nil {
+		c.logger.Warn("could not marshal circuit status", zap.Error(err))
+		return
+	}
+	c.walletFeed.Send(walletevent.Event{
+		Type:    EventCircuitStateChanged,
+		Message: string(encodedMessage),
+		At:      time.Now().Unix(),
+	})
+}
+
 func (c *Client) monitorHealth(ctx context.Context, statusCh chan struct{}) {
 	defer appCommon.LogOnPanic()
 	sendFullStatusEventFunc := func() {
@@ -238,14 +276,18 @@ func extractHostFromURL(inputURL string) (string, error) {
 	return parsedURL.Host, nil
 }
 
-func (c *Client) getRPCRpsLimiter(key string) (*rpclimiter.RPCRpsLimiter, error) {
+// getRPCRpsLimiter returns the limiter of the provider, maxRequestsPerSecond overrides the default limit when set
+func (c *Client) getRPCRpsLimiter(key string, maxRequestsPerSecond int) (*rpclimiter.RPCRpsLimiter, error) {
 	c.rpsLimiterMutex.Lock()
 	defer c.rpsLimiterMutex.Unlock()
-	if limiter, ok := c.limiterPerProvider[key]; ok {
-		return limiter, nil
+	limiter, ok := c.limiterPerProvider[key]
+	if !ok {
+		limiter = rpclimiter.NewRPCRpsLimiter()
+		c.limiterPerProvider[key] = limiter
+	}
+	if maxRequestsPerSecond > 0 {
+		limiter.SetMaxRequestsPerSecond(maxRequestsPerSecond)
 	}
-	limiter := rpclimiter.NewRPCRpsLimiter()
-	c.limiterPerProvider[key] = limiter
 	return limiter, nil
 }
 
@@ -277,6 +319,8 @@ func (c *Client) getClientUsingCache(chainID uint64) (chain.ClientInterface, err
 
 	client := chain.NewClient(ethClients, chainID, phm)
 	client.SetWalletNotifier(c.walletNotifier)
+	client.SetCache(c.rpcCache)
+	client.SetQuorums(c.quorums[chainID])
 	c.rpcClients[chainID] = client
 	return client, nil
 }
@@ -289,39 +333,23 @@ func (c *Client) getEthClients(network *params.Network) []ethclient.RPSLimitedEt
 		var rpcClient *gethrpc.Client
 		var rpcLimiter *rpclimiter.RPCRpsLimiter
 		var err error
-		var hostPort string
 
 		if len(provider.URL) > 0 {
-			// For now, we only support auth for status-proxy.
-			var opts []gethrpc.ClientOption
-			if provider.authenticationNeeded() {
-				authEncoded := base64.StdEncoding.EncodeToString([]byte(provider.Auth))
-				opts = append(opts,
-					gethrpc.WithHeaders(http.Header{
-						"Authorization": {"Basic " + authEncoded},
-						"User-Agent":    {rpcUserAgentName},
-					}),
-				)
-			}
-
-			rpcClient, err = gethrpc.DialOptions(context.Background(), provider.URL, opts...)
+			circuitKey := provider.circuitKey(index)
+			rpcLimiter, err = c.getRPCRpsLimiter(circuitKey, provider.MaxRequestsPerSecond)
 			if err != nil {
-				c.logger.Error("dial server "+provider.Key, zap.Error(err))
+				c.logger.Error("get RPC limiter "+provider.Key, zap.Error(err))
 			}
 
-			// If using the status-proxy, consider each endpoint as a separate provider
-			circuitKey := fmt.Sprintf("%s-%d", provider.Key, index)
-			// Otherwise host is good enough
-			if !strings.Contains(provider.URL, "status.im") {
-				hostPort, err = extractHostFromURL(provider.URL)
-				if err == nil {
-					circuitKey = hostPort
-				}
+			// The limiter of the provider adapts to its HTTP responses, the HTTP client is not used by WebSocket providers
+			opts := []gethrpc.ClientOption{gethrpc.WithHTTPClient(&http.Client{Transport: rpclimiter.NewRPSLimitedTransport(rpcLimiter)})}
+			if provider.authenticationNeeded() {
+				opts = append(opts, gethrpc.WithHeaders(provider.headers()))
 			}
 
-			rpcLimiter, err = c.getRPCRpsLimiter(circuitKey)
+			rpcClient, err = gethrpc.DialOptions(context.Background(), provider.URL, opts...)
 			if err != nil {
-				c.logger.Error("get RPC limiter "+provider.Key, zap.Error(err))
+				c.logger.Error("dial server "+provider.Key, zap.Error(err))
 			}
 
 			ethClients = append(ethClients, ethclient.NewRPSLimitedEthClient(rpcClient, rpcLimiter, circuitKey))
@@ -351,6 +379,53 @@ func (c *Client) AbstractEthClient(chainID common.ChainID) (ethclient.BatchCallC
 	return client, nil
 }
 
+// Subscriptions returns the manager of the WebSocket subscriptions of the chains
+func (c *Client) Subscriptions() *subscriptions.Manager {
+	return c.subscriptions
+}
+
+// DialWebSocket connects to the first provider of the chain with a ws:// or wss:// URL that answers
+func (c *Client) DialWebSocket(ctx context.Context, chainID uint64) (*gethrpc.Client, error) {
+	network := c.NetworkManager.Find(chainID)
+	if network == nil {
+		return nil, fmt.Errorf("could not find network: %d", chainID)
+	}
+
+	var errs []error
+	for _, provider := range c.prepareProviders(network) {
+		if !strings.HasPrefix(provider.URL, "ws://") && !strings.HasPrefix(provider.URL, "wss://") {
+			continue
+		}
+
+		var opts []gethrpc.ClientOption
+		if provider.authenticationNeeded() {
+			opts = append(opts, gethrpc.WithHeaders(provider.headers()))
+		}
+		client, err := gethrpc.DialOptions(ctx, provider.URL, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("dial %s: %w", provider.Key, err))
+			continue
+		}
+		return client, nil
+	}
+
+	if len(errs) == 0 {
+		return nil, subscriptions.ErrNoWebSocketProvider
+	}
+	return nil, errors.Join(errs...)
+}
+
+// VerifiedEthClient returns the client of the chain verifying the state and the receipts against the headers
+// agreed by the quorum of providers set for chain.QuorumOperationTrustedHeaders
+func (c *Client) VerifiedEthClient(chainID uint64, strict bool) (*verified.Client, error) {
+	client, err := c.getClientUsingCache(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return verified.NewClient(client, client.TrustedHeaders(), strict), nil
+}
+
 func (c *Client) EthClients(chainIDs []uint64) (map[uint64]chain.ClientInterface, error) {
 	clients := make(map[uint64]chain.ClientInterface, 0)
 	for _, chainID := range chainIDs {
@@ -364,6 +439,75 @@ func (c *Client) EthClients(chainIDs []uint64) (map[uint64]chain.ClientInterface
 	return clients, nil
 }
 
+// ReloadProviders rebuilds the providers of the chain client after the user providers of the chain changed
+func (c *Client) ReloadProviders(chainID uint64) error {
+	c.rpcClientsMutex.Lock()
+	defer c.rpcClientsMutex.Unlock()
+
+	// The limiters are created again with the new limits
+	c.rpsLimiterMutex.Lock()
+	for key := range c.limiterPerProvider {
+		if strings.HasPrefix(key, ProviderUser+"-") {
+			delete(c.limiterPerProvider, key)
+		}
+	}
+	c.rpsLimiterMutex.Unlock()
+
+	c.subscriptions.Reconnect(chainID)
+
+	rpcClient, ok := c.rpcClients[chainID]
+	if !ok {
+		// Providers are read when the client is first used
+		return nil
+	}
+	client, ok := rpcClient.(*chain.ClientWithFallback)
+	if !ok {
+		return nil
+	}
+
+	network := c.NetworkManager.Find(chainID)
+	if network == nil {
+		return fmt.Errorf("could not find network: %d", chainID)
+	}
+	ethClients := c.getEthClients(network)
+	if len(ethClients) == 0 {
+		return fmt.Errorf("could not find any RPC URL for chain: %d", chainID)
+	}
+
+	previous := client.SetEthClients(ethClients)
+	// Let the calls in progress finish before closing the previous providers
+	time.AfterFunc(DefaultCallTimeout, func() {
+		for _, ethClient := range previous {
+			ethClient.Close()
+		}
+	})
+	return nil
+}
+
+// GetProvidersStatus returns the providers of the chain in the order they are used, with their health status
+func (c *Client) GetProvidersStatus(chainID uint64) ([]RpcProviderStatus, error) {
+	network := c.NetworkManager.Find(chainID)
+	if network == nil {
+		return nil, fmt.Errorf("could not find network: %d", chainID)
+	}
+
+	statuses := c.healthMgr.GetFullStatus().StatusPerChainPerProvider[chainID]
+	providers := c.prepareProviders(network)
+	result := make([]RpcProviderStatus, 0, len(providers))
+	for index, provider := range providers {
+		if len(provider.URL) == 0 {
+			continue
+		}
+		result = append(result, newRpcProviderStatus(provider, provider.circuitKey(index), statuses))
+	}
+	return result, nil
+}
+
+// ClearCache removes the cached responses of the chain, e.g. when the network is deleted
+func (c *Client) ClearCache(chainID uint64) error {
+	return c.rpcCache.Clear(chainID)
+}
+
 // SetClient strictly for testing purposes
 func (c *Client) SetClient(chainID uint64, client chain.ClientInterface) {
 	c.rpcClientsMutex.Lock()
@@ -391,7 +535,10 @@ func (c *Client) Call(result interface{}, chainID uint64, method string, args ..
 //
 // It uses custom routing scheme for calls.
 // If there are any local handlers registered for this call, they will handle it.
-func (c *Client) CallContext(ctx context.Context, result interface{}, chainID uint64, method string, args ...interface{}) error {
+func (c *Client) CallContext(ctx context.Context, result interface{}, chainID uint64, method string, args ...interface{}) (err error) {
+	ctx, span := tracing.Start(ctx, "rpc.CallContext", tracing.String("method", method), tracing.Uint64("chainID", chainID))
+	defer func() { span.End(err) }()
+
 	rpcstats.CountCall(method)
 	if c.router.routeBlocked(method) {
 		return ErrMethodNotFound
@@ -5,6 +5,7 @@ package network
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/status-im/status-go/multiaccounts/accounts"
@@ -18,7 +19,7 @@ type CombinedNetwork struct {
 	Test *params.Network
 }
 
-const baseQuery = "SELECT chain_id, chain_name, rpc_url, original_rpc_url, fallback_url, original_fallback_url, block_explorer_url, icon_url, native_currency_name, native_currency_symbol, native_currency_decimals, is_test, layer, enabled, chain_color, short_name, related_chain_id FROM networks"
+const baseQuery = "SELECT chain_id, chain_name, rpc_url, original_rpc_url, fallback_url, original_fallback_url, block_explorer_url, icon_url, native_currency_name, native_currency_symbol, native_currency_decimals, is_test, layer, enabled, chain_color, short_name, related_chain_id, capabilities FROM networks"
 
 func newNetworksQuery() *networksQuery {
 	buf := bytes.NewBuffer(nil)
@@ -65,16 +66,25 @@ func (nq *networksQuery) exec(db *sql.DB) ([]*params.Network, error) {
 	defer rows.Close()
 	for rows.Next() {
 		network := params.Network{}
+		var capabilities sql.NullString
 		err := rows.Scan(
 			&network.ChainID, &network.ChainName, &network.RPCURL, &network.OriginalRPCURL, &network.FallbackURL, &network.OriginalFallbackURL,
 			&network.BlockExplorerURL, &network.IconURL, &network.NativeCurrencyName, &network.NativeCurrencySymbol,
 			&network.NativeCurrencyDecimals, &network.IsTest, &network.Layer, &network.Enabled, &network.ChainColor, &network.ShortName,
-			&network.RelatedChainID,
+			&network.RelatedChainID, &capabilities,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		if capabilities.Valid {
+			network.Capabilities = &params.NetworkCapabilities{}
+			err = json.Unmarshal([]byte(capabilities.String), network.Capabilities)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		res = append(res, &network)
 	}
 
@@ -191,19 +201,31 @@ func (nm *Manager) Init(networks []params.Network) error {
 }
 
 func (nm *Manager) Upsert(network *params.Network) error {
+	var capabilities sql.NullString
+	if network.Capabilities != nil {
+		encoded, err := json.Marshal(network.Capabilities)
+		if err != nil {
+			return err
+		}
+		capabilities = sql.NullString{String: string(encoded), Valid: true}
+	}
+
 	_, err := nm.db.Exec(
-		"INSERT OR REPLACE INTO networks (chain_id, chain_name, rpc_url, original_rpc_url, fallback_url, original_fallback_url, block_explorer_url, icon_url, native_currency_name, native_currency_symbol, native_currency_decimals, is_test, layer, enabled, chain_color, short_name, related_chain_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"INSERT OR REPLACE INTO networks (chain_id, chain_name, rpc_url, original_rpc_url, fallback_url, original_fallback_url, block_explorer_url, icon_url, native_currency_name, native_currency_symbol, native_currency_decimals, is_test, layer, enabled, chain_color, short_name, related_chain_id, capabilities) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		network.ChainID, network.ChainName, network.RPCURL, network.OriginalRPCURL, network.FallbackURL, network.OriginalFallbackURL, network.BlockExplorerURL, network.IconURL,
 		network.NativeCurrencyName, network.NativeCurrencySymbol, network.NativeCurrencyDecimals,
 		network.IsTest, network.Layer, network.Enabled, network.ChainColor, network.ShortName,
-		network.RelatedChainID,
+		network.RelatedChainID, capabilities,
 	)
 	return err
 }
 
 func (nm *Manager) Delete(chainID uint64) error {
 	_, err := nm.db.Exec("DELETE FROM networks WHERE chain_id = ?", chainID)
-	return err
+	if err != nil {
+		return err
+	}
+	return nm.deleteRpcProviders(chainID)
 }
 
 func (nm *Manager) UpdateRelatedChainID(chainID uint64, relatedChainID uint64) error {
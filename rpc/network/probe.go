@@ -0,0 +1,103 @@
+package network
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	gaspriceoracle "github.com/status-im/status-go/contracts/gas-price-oracle"
+	"github.com/status-im/status-go/contracts/multicall3"
+	"github.com/status-im/status-go/contracts/resolver"
+	"github.com/status-im/status-go/params"
+)
+
+const probeTimeout = 20 * time.Second
+
+// archiveProbeDepth is the age of the block whose state is requested to detect an archive node, the full nodes
+// keep the state of the last 128 blocks
+const archiveProbeDepth = 1024
+
+// ArbGasInfo precompile of the Arbitrum chains
+var arbitrumGasInfoAddress = common.HexToAddress("0x000000000000000000000000000000000000006C")
+
+type ProbeResult struct {
+	ChainID      uint64                     `json:"chainId"`
+	Capabilities params.NetworkCapabilities `json:"capabilities"`
+}
+
+// ProbeRPC detects the chain ID and the capabilities of the chain served by the RPC url, except TokenLists which
+// does not depend on the RPC. It is a variable to be mocked in tests.
+var ProbeRPC = func(ctx context.Context, rpcURL string) (*ProbeResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	client, err := gethrpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return probe(ctx, client)
+}
+
+type probeHeader struct {
+	Number        *hexutil.Big `json:"number"`
+	BaseFeePerGas *hexutil.Big `json:"baseFeePerGas"`
+}
+
+func probe(ctx context.Context, client *gethrpc.Client) (*ProbeResult, error) {
+	var chainID hexutil.Uint64
+	err := client.CallContext(ctx, &chainID, "eth_chainId")
+	if err != nil {
+		return nil, err
+	}
+
+	var head probeHeader
+	err = client.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProbeResult{
+		ChainID: uint64(chainID),
+		Capabilities: params.NetworkCapabilities{
+			EIP1559:  head.BaseFeePerGas != nil,
+			ProbedAt: time.Now().Unix(),
+		},
+	}
+	capabilities := &result.Capabilities
+
+	// The errors of the calls below mean that the feature is missing
+	if hasCode(ctx, client, gaspriceoracle.PredeployAddress) {
+		capabilities.L2FeeModel = params.OptimismL2FeeModel
+	} else if hasCode(ctx, client, arbitrumGasInfoAddress) {
+		capabilities.L2FeeModel = params.ArbitrumL2FeeModel
+	}
+	// Multicall3 and the ENS registry are deployed at the same address on most of the chains
+	capabilities.Multicall3 = hasCode(ctx, client, multicall3.ContractAddress)
+	capabilities.ENS = hasCode(ctx, client, resolver.RegistryAddress)
+
+	if head.Number != nil {
+		block := new(big.Int).Sub(head.Number.ToInt(), big.NewInt(archiveProbeDepth))
+		if block.Sign() <= 0 {
+			// All the state of a young chain is available
+			capabilities.Archive = true
+		} else {
+			var balance hexutil.Big
+			err = client.CallContext(ctx, &balance, "eth_getBalance", common.Address{}, hexutil.EncodeBig(block))
+			capabilities.Archive = err == nil
+		}
+	}
+
+	return result, nil
+}
+
+func hasCode(ctx context.Context, client *gethrpc.Client, address common.Address) bool {
+	var code hexutil.Bytes
+	err := client.CallContext(ctx, &code, "eth_getCode", address, "latest")
+	return err == nil && len(code) > 0
+}
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	gaspriceoracle "github.com/status-im/status-go/contracts/gas-price-oracle"
+	"github.com/status-im/status-go/contracts/multicall3"
+	"github.com/status-im/status-go/contracts/resolver"
+	"github.com/status-im/status-go/params"
+)
+
+// probeTestEth serves the eth methods used by the probe
+type probeTestEth struct {
+	chainID uint64
+	head    uint64
+	baseFee *big.Int
+	code    map[common.Address]hexutil.Bytes
+	archive bool
+}
+
+// ChainId serves eth_chainId, the server derives the method names from the Go names
+func (e *probeTestEth) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(e.chainID)
+}
+
+func (e *probeTestEth) GetBlockByNumber(number string, fullTx bool) map[string]interface{} {
+	block := map[string]interface{}{"number": hexutil.EncodeUint64(e.head)}
+	if e.baseFee != nil {
+		block["baseFeePerGas"] = (*hexutil.Big)(e.baseFee)
+	}
+	return block
+}
+
+func (e *probeTestEth) GetCode(address common.Address, block string) hexutil.Bytes {
+	return e.code[address]
+}
+
+func (e *probeTestEth) GetBalance(address common.Address, block string) (*hexutil.Big, error) {
+	if !e.archive && block != "latest" {
+		return nil, errors.New("missing trie node")
+	}
+	return (*hexutil.Big)(big.NewInt(0)), nil
+}
+
+func probeTestServer(t *testing.T, eth *probeTestEth) string {
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", eth))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func TestProbeRPC(t *testing.T) {
+	url := probeTestServer(t, &probeTestEth{
+		chainID: 8453,
+		head:    100_000,
+		baseFee: big.NewInt(1_000_000),
+		code: map[common.Address]hexutil.Bytes{
+			gaspriceoracle.PredeployAddress: {0x60, 0x80},
+			multicall3.ContractAddress:      {0x60, 0x80},
+		},
+	})
+
+	result, err := ProbeRPC(context.Background(), url)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8453), result.ChainID)
+	require.NotZero(t, result.Capabilities.ProbedAt)
+	result.Capabilities.ProbedAt = 0
+	require.Equal(t, params.NetworkCapabilities{
+		EIP1559:    true,
+		L2FeeModel: params.OptimismL2FeeModel,
+		Multicall3: true,
+	}, result.Capabilities)
+}
+
+func TestProbeRPC_LegacyChain(t *testing.T) {
+	url := probeTestServer(t, &probeTestEth{
+		chainID: 777,
+		head:    100,
+		code: map[common.Address]hexutil.Bytes{
+			arbitrumGasInfoAddress:   {0xfe},
+			resolver.RegistryAddress: {0x60, 0x80},
+		},
+	})
+
+	result, err := ProbeRPC(context.Background(), url)
+	require.NoError(t, err)
+	require.Equal(t, uint64(777), result.ChainID)
+	require.False(t, result.Capabilities.EIP1559)
+	require.Equal(t, params.ArbitrumL2FeeModel, result.Capabilities.L2FeeModel)
+	require.True(t, result.Capabilities.ENS)
+	require.False(t, result.Capabilities.Multicall3)
+	// The state of the young chains is available
+	require.True(t, result.Capabilities.Archive)
+}
+
+func TestProbeRPC_Archive(t *testing.T) {
+	eth := &probeTestEth{chainID: 1, head: 20_000_000, baseFee: big.NewInt(1)}
+	url := probeTestServer(t, eth)
+
+	result, err := ProbeRPC(context.Background(), url)
+	require.NoError(t, err)
+	require.False(t, result.Capabilities.Archive)
+
+	eth.archive = true
+	result, err = ProbeRPC(context.Background(), url)
+	require.NoError(t, err)
+	require.True(t, result.Capabilities.Archive)
+}
+
+func TestManager_UpsertCapabilities(t *testing.T) {
+	db, stop := setupTestNetworkDB(t)
+	defer stop()
+
+	nm := &Manager{db: db}
+	network := initNetworks[0]
+	require.NoError(t, nm.Upsert(&network))
+	require.Nil(t, nm.Find(network.ChainID).Capabilities)
+
+	network.Capabilities = &params.NetworkCapabilities{EIP1559: true, L2FeeModel: params.OptimismL2FeeModel, ProbedAt: 10}
+	require.NoError(t, nm.Upsert(&network))
+	require.Equal(t, network.Capabilities, nm.Find(network.ChainID).Capabilities)
+}
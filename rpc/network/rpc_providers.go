@@ -0,0 +1,150 @@
+package network
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/status-im/status-go/params"
+)
+
+var (
+	ErrInvalidRpcProvider  = errors.New("invalid rpc provider")
+	ErrRpcProviderNotFound = errors.New("rpc provider not found")
+)
+
+const rpcProvidersQuery = "SELECT id, chain_id, name, url, enabled, priority, auth_type, auth_login, auth_password, auth_token, max_requests_per_second FROM rpc_providers"
+
+func scanRpcProviders(rows *sql.Rows) ([]params.RpcProvider, error) {
+	defer rows.Close()
+
+	providers := make([]params.RpcProvider, 0)
+	for rows.Next() {
+		var provider params.RpcProvider
+		err := rows.Scan(&provider.ID, &provider.ChainID, &provider.Name, &provider.URL, &provider.Enabled, &provider.Priority,
+			&provider.AuthType, &provider.AuthLogin, &provider.AuthPassword, &provider.AuthToken, &provider.MaxRequestsPerSecond)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	return providers, rows.Err()
+}
+
+// GetRpcProviders returns the providers added by the user to the network, ordered by priority
+func (nm *Manager) GetRpcProviders(chainID uint64) ([]params.RpcProvider, error) {
+	rows, err := nm.db.Query(rpcProvidersQuery+" WHERE chain_id = ? ORDER BY priority, id", chainID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRpcProviders(rows)
+}
+
+func (nm *Manager) GetRpcProvider(id int64) (*params.RpcProvider, error) {
+	rows, err := nm.db.Query(rpcProvidersQuery+" WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := scanRpcProviders(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(providers) == 0 {
+		return nil, ErrRpcProviderNotFound
+	}
+	return &providers[0], nil
+}
+
+// UpsertRpcProvider adds the provider when its ID is 0, the ID of the added provider is set
+func (nm *Manager) UpsertRpcProvider(provider *params.RpcProvider) error {
+	if err := nm.validateRpcProvider(provider); err != nil {
+		return err
+	}
+
+	if provider.ID == 0 {
+		res, err := nm.db.Exec(`INSERT INTO rpc_providers (chain_id, name, url, enabled, priority, auth_type, auth_login, auth_password, auth_token, max_requests_per_second)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			provider.ChainID, provider.Name, provider.URL, provider.Enabled, provider.Priority, provider.AuthType,
+			provider.AuthLogin, provider.AuthPassword, provider.AuthToken, provider.MaxRequestsPerSecond)
+		if err != nil {
+			return err
+		}
+		provider.ID, err = res.LastInsertId()
+		return err
+	}
+
+	res, err := nm.db.Exec(`UPDATE rpc_providers SET chain_id = ?, name = ?, url = ?, enabled = ?, priority = ?, auth_type = ?, auth_login = ?, auth_password = ?, auth_token = ?, max_requests_per_second = ?
+		WHERE id = ?`,
+		provider.ChainID, provider.Name, provider.URL, provider.Enabled, provider.Priority, provider.AuthType,
+		provider.AuthLogin, provider.AuthPassword, provider.AuthToken, provider.MaxRequestsPerSecond, provider.ID)
+	if err != nil {
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrRpcProviderNotFound
+	}
+	return nil
+}
+
+func (nm *Manager) DeleteRpcProvider(id int64) error {
+	_, err := nm.db.Exec("DELETE FROM rpc_providers WHERE id = ?", id)
+	return err
+}
+
+func (nm *Manager) deleteRpcProviders(chainID uint64) error {
+	_, err := nm.db.Exec("DELETE FROM rpc_providers WHERE chain_id = ?", chainID)
+	return err
+}
+
+func (nm *Manager) validateRpcProvider(provider *params.RpcProvider) error {
+	if nm.Find(provider.ChainID) == nil {
+		return fmt.Errorf("%w: unknown chain %d", ErrInvalidRpcProvider, provider.ChainID)
+	}
+	if provider.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRpcProvider)
+	}
+	parsedURL, err := url.Parse(provider.URL)
+	if err != nil || parsedURL.Host == "" {
+		return fmt.Errorf("%w: invalid url", ErrInvalidRpcProvider)
+	}
+	switch parsedURL.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("%w: unsupported url scheme %q", ErrInvalidRpcProvider, parsedURL.Scheme)
+	}
+	if provider.MaxRequestsPerSecond < 0 {
+		return fmt.Errorf("%w: negative requests limit", ErrInvalidRpcProvider)
+	}
+
+	switch provider.AuthType {
+	case "", params.NoAuth:
+		provider.AuthType = params.NoAuth
+		provider.AuthLogin = ""
+		provider.AuthPassword = ""
+		provider.AuthToken = ""
+	case params.BasicAuth:
+		if provider.AuthLogin == "" {
+			return fmt.Errorf("%w: basic auth requires a login", ErrInvalidRpcProvider)
+		}
+		provider.AuthToken = ""
+	case params.HeaderAuth:
+		if provider.AuthLogin == "" || provider.AuthToken == "" {
+			return fmt.Errorf("%w: header auth requires a header name and a token", ErrInvalidRpcProvider)
+		}
+		provider.AuthPassword = ""
+	case params.URLTokenAuth:
+		if provider.AuthToken == "" {
+			return fmt.Errorf("%w: url token auth requires a token", ErrInvalidRpcProvider)
+		}
+		provider.AuthLogin = ""
+		provider.AuthPassword = ""
+	default:
+		return fmt.Errorf("%w: unsupported auth type %q", ErrInvalidRpcProvider, provider.AuthType)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/params"
+)
+
+func TestUpsertRpcProvider(t *testing.T) {
+	db, stop := setupTestNetworkDB(t)
+	defer stop()
+
+	nm := NewManager(db)
+	err := nm.Init(initNetworks)
+	require.NoError(t, err)
+
+	provider := params.RpcProvider{
+		ChainID:              1,
+		Name:                 "My node",
+		URL:                  "https://node.example.com",
+		Enabled:              true,
+		Priority:             5,
+		AuthType:             params.BasicAuth,
+		AuthLogin:            "user",
+		AuthPassword:         "pass",
+		AuthToken:            "unused",
+		MaxRequestsPerSecond: 10,
+	}
+	err = nm.UpsertRpcProvider(&provider)
+	require.NoError(t, err)
+	require.NotZero(t, provider.ID)
+	// Fields not used by the auth type are not kept
+	require.Empty(t, provider.AuthToken)
+
+	second := params.RpcProvider{
+		ChainID:   1,
+		Name:      "Keyed node",
+		URL:       "wss://keyed.example.com/v1/{token}",
+		Enabled:   true,
+		Priority:  1,
+		AuthType:  params.URLTokenAuth,
+		AuthToken: "secret",
+	}
+	err = nm.UpsertRpcProvider(&second)
+	require.NoError(t, err)
+
+	providers, err := nm.GetRpcProviders(1)
+	require.NoError(t, err)
+	require.Equal(t, []params.RpcProvider{second, provider}, providers)
+
+	provider.Priority = 0
+	provider.Enabled = false
+	err = nm.UpsertRpcProvider(&provider)
+	require.NoError(t, err)
+
+	saved, err := nm.GetRpcProvider(provider.ID)
+	require.NoError(t, err)
+	require.Equal(t, provider, *saved)
+
+	providers, err = nm.GetRpcProviders(10)
+	require.NoError(t, err)
+	require.Empty(t, providers)
+
+	provider.ID = 1000
+	err = nm.UpsertRpcProvider(&provider)
+	require.ErrorIs(t, err, ErrRpcProviderNotFound)
+}
+
+func TestUpsertInvalidRpcProvider(t *testing.T) {
+	db, stop := setupTestNetworkDB(t)
+	defer stop()
+
+	nm := NewManager(db)
+	err := nm.Init(initNetworks)
+	require.NoError(t, err)
+
+	valid := params.RpcProvider{
+		ChainID: 1,
+		Name:    "My node",
+		URL:     "https://node.example.com",
+	}
+	tests := []struct {
+		name   string
+		update func(provider *params.RpcProvider)
+	}{
+		{"unknown chain", func(p *params.RpcProvider) { p.ChainID = 12345 }},
+		{"missing name", func(p *params.RpcProvider) { p.Name = "" }},
+		{"missing host", func(p *params.RpcProvider) { p.URL = "https://" }},
+		{"unsupported scheme", func(p *params.RpcProvider) { p.URL = "ftp://node.example.com" }},
+		{"negative limit", func(p *params.RpcProvider) { p.MaxRequestsPerSecond = -1 }},
+		{"basic auth without login", func(p *params.RpcProvider) { p.AuthType = params.BasicAuth }},
+		{"header auth without token", func(p *params.RpcProvider) {
+			p.AuthType = params.HeaderAuth
+			p.AuthLogin = "X-Api-Key"
+		}},
+		{"url token auth without token", func(p *params.RpcProvider) { p.AuthType = params.URLTokenAuth }},
+		{"unsupported auth", func(p *params.RpcProvider) { p.AuthType = "oauth" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := valid
+			tt.update(&provider)
+			err := nm.UpsertRpcProvider(&provider)
+			require.ErrorIs(t, err, ErrInvalidRpcProvider)
+		})
+	}
+
+	provider := valid
+	err = nm.UpsertRpcProvider(&provider)
+	require.NoError(t, err)
+	require.Equal(t, params.NoAuth, provider.AuthType)
+}
+
+func TestDeleteRpcProvider(t *testing.T) {
+	db, stop := setupTestNetworkDB(t)
+	defer stop()
+
+	nm := NewManager(db)
+	err := nm.Init(initNetworks)
+	require.NoError(t, err)
+
+	provider := params.RpcProvider{ChainID: 1, Name: "My node", URL: "https://node.example.com"}
+	err = nm.UpsertRpcProvider(&provider)
+	require.NoError(t, err)
+
+	err = nm.DeleteRpcProvider(provider.ID)
+	require.NoError(t, err)
+	_, err = nm.GetRpcProvider(provider.ID)
+	require.ErrorIs(t, err, ErrRpcProviderNotFound)
+
+	// The providers of a deleted network are deleted with it
+	provider = params.RpcProvider{ChainID: 10, Name: "My node", URL: "https://node.example.com"}
+	err = nm.UpsertRpcProvider(&provider)
+	require.NoError(t, err)
+
+	err = nm.Delete(10)
+	require.NoError(t, err)
+	_, err = nm.GetRpcProvider(provider.ID)
+	require.ErrorIs(t, err, ErrRpcProviderNotFound)
+}
@@ -1,12 +1,18 @@
 package rpc
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/status-im/status-go/circuitbreaker"
+	"github.com/status-im/status-go/healthmanager/rpcstatus"
 	"github.com/status-im/status-go/params"
 )
 
@@ -16,17 +22,59 @@ const (
 	ProviderStatusProxy          = "status-proxy"
 	ProviderStatusProxyFallback  = ProviderStatusProxy + "-fallback"
 	ProviderStatusProxyFallback2 = ProviderStatusProxy + "-fallback2"
+	// ProviderUser prefixes the keys of the providers added by the user, followed by their ID
+	ProviderUser = "user"
+
+	urlTokenPlaceholder = "{token}"
 )
 
 type Provider struct {
 	Key      string
+	Name     string
 	URL      string
 	Auth     string
 	Priority int
+	// HeaderName and HeaderValue authenticate the requests of user providers using an API key header
+	HeaderName  string
+	HeaderValue string
+	// MaxRequestsPerSecond is 0 for the default limit
+	MaxRequestsPerSecond int
+	// RpcProviderID is the ID of the user provider, 0 for the configured ones
+	RpcProviderID int64
 }
 
 func (p Provider) authenticationNeeded() bool {
-	return len(p.Auth) > 0
+	return len(p.Auth) > 0 || len(p.HeaderName) > 0
+}
+
+func (p Provider) headers() http.Header {
+	headers := http.Header{
+		"User-Agent": {rpcUserAgentName},
+	}
+	if len(p.Auth) > 0 {
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(p.Auth)))
+	}
+	if len(p.HeaderName) > 0 {
+		headers.Set(p.HeaderName, p.HeaderValue)
+	}
+	return headers
+}
+
+// circuitKey names the provider in the circuit breaker, the RPS limiters and the health statuses
+func (p Provider) circuitKey(index int) string {
+	// User providers can share a host with different credentials
+	if p.RpcProviderID != 0 {
+		return p.Key
+	}
+	// If using the status-proxy, consider each endpoint as a separate provider
+	if strings.Contains(p.URL, "status.im") {
+		return fmt.Sprintf("%s-%d", p.Key, index)
+	}
+	// Otherwise host is good enough
+	if hostPort, err := extractHostFromURL(p.URL); err == nil {
+		return hostPort
+	}
+	return fmt.Sprintf("%s-%d", p.Key, index)
 }
 
 func getProviderPriorityByURL(url string) int {
@@ -62,15 +110,56 @@ func createProvider(key, url, credentials string, providers *[]Provider) {
 	priority := getProviderPriorityByURL(url)
 	*providers = append(*providers, Provider{
 		Key:      key,
+		Name:     key,
 		URL:      url,
 		Auth:     credentials,
 		Priority: priority,
 	})
 }
 
+func userProviderURL(provider params.RpcProvider) string {
+	if provider.AuthType != params.URLTokenAuth {
+		return provider.URL
+	}
+	if strings.Contains(provider.URL, urlTokenPlaceholder) {
+		return strings.ReplaceAll(provider.URL, urlTokenPlaceholder, url.PathEscape(provider.AuthToken))
+	}
+	return strings.TrimSuffix(provider.URL, "/") + "/" + url.PathEscape(provider.AuthToken)
+}
+
+func createUserProvider(provider params.RpcProvider, providers *[]Provider) {
+	p := Provider{
+		Key:                  fmt.Sprintf("%s-%d", ProviderUser, provider.ID),
+		Name:                 provider.Name,
+		URL:                  userProviderURL(provider),
+		Priority:             provider.Priority,
+		MaxRequestsPerSecond: provider.MaxRequestsPerSecond,
+		RpcProviderID:        provider.ID,
+	}
+	switch provider.AuthType {
+	case params.BasicAuth:
+		p.Auth = provider.AuthLogin + ":" + provider.AuthPassword
+	case params.HeaderAuth:
+		p.HeaderName = provider.AuthLogin
+		p.HeaderValue = provider.AuthToken
+	}
+	*providers = append(*providers, p)
+}
+
 func (c *Client) prepareProviders(network *params.Network) []Provider {
 	var providers []Provider
 
+	// User providers come first, so they are used before the configured ones of the same priority
+	userProviders, err := c.NetworkManager.GetRpcProviders(network.ChainID)
+	if err != nil {
+		c.logger.Warn("could not get the user rpc providers", zap.Uint64("chainID", network.ChainID), zap.Error(err))
+	}
+	for _, provider := range userProviders {
+		if provider.Enabled {
+			createUserProvider(provider, &providers)
+		}
+	}
+
 	// Retrieve the proxy provider configuration
 	proxyProvider, err := getProviderConfig(c.providerConfigs, ProviderStatusProxy)
 	if err != nil {
@@ -90,9 +179,52 @@ func (c *Client) prepareProviders(network *params.Network) []Provider {
 	}
 
 	// Sort providers by priority
-	sort.Slice(providers, func(i, j int) bool {
+	sort.SliceStable(providers, func(i, j int) bool {
 		return providers[i].Priority < providers[j].Priority
 	})
 
 	return providers
 }
+
+// RpcProviderStatus is the live status of a provider of a chain, credentials are not included
+type RpcProviderStatus struct {
+	Key           string               `json:"key"`
+	Name          string               `json:"name"`
+	Host          string               `json:"host"`
+	Priority      int                  `json:"priority"`
+	RpcProviderID int64                `json:"rpcProviderId,omitempty"`
+	Status        rpcstatus.StatusType `json:"status"`
+	LastSuccessAt time.Time            `json:"lastSuccessAt"`
+	LastErrorAt   time.Time            `json:"lastErrorAt"`
+	LastError     string               `json:"lastError,omitempty"`
+	// Circuit is the state of the circuit of the provider in the circuit breaker
+	Circuit circuitbreaker.CircuitState `json:"circuit"`
+}
+
+func newRpcProviderStatus(provider Provider, circuitKey string, statuses map[string]rpcstatus.ProviderStatus) RpcProviderStatus {
+	result := RpcProviderStatus{
+		Key:           circuitKey,
+		Name:          provider.Name,
+		Priority:      provider.Priority,
+		RpcProviderID: provider.RpcProviderID,
+		Status:        rpcstatus.StatusUnknown,
+		Circuit:       circuitbreaker.CircuitClosed,
+	}
+	// The host only, the path of the URL can hold a token
+	if host, err := extractHostFromURL(provider.URL); err == nil {
+		result.Host = host
+	}
+	if circuit, ok := circuitbreaker.DefaultMonitor().Status(circuitKey); ok {
+		result.Circuit = circuit.State
+	}
+	if status, ok := statuses[circuitKey]; ok {
+		result.Status = status.Status
+		result.LastSuccessAt = status.LastSuccessAt
+		result.LastErrorAt = status.LastErrorAt
+		if status.LastError != nil {
+			// Transport errors quote the URL
+			result.LastError = strings.ReplaceAll(status.LastError.Error(), provider.URL, result.Host)
+		}
+	}
+	return result
+}
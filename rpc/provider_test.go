@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/healthmanager/rpcstatus"
+	"github.com/status-im/status-go/params"
+)
+
+func newTestClient(t *testing.T, networks []params.Network) *Client {
+	db, close := setupTestNetworkDB(t)
+	t.Cleanup(close)
+
+	c, err := NewClient(ClientConfig{
+		UpstreamChainID: 1,
+		Networks:        networks,
+		DB:              db,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestPrepareProvidersWithUserProviders(t *testing.T) {
+	c := newTestClient(t, []params.Network{
+		{
+			ChainID:     1,
+			RPCURL:      "http://anvil:8545",
+			FallbackURL: "https://mainnet.infura.io/v3/key",
+		},
+	})
+
+	userProviders := []params.RpcProvider{
+		{ChainID: 1, Name: "Token node", URL: "https://token.example.com/v1/{token}/rpc", Enabled: true, Priority: 1,
+			AuthType: params.URLTokenAuth, AuthToken: "secret"},
+		{ChainID: 1, Name: "Header node", URL: "https://header.example.com", Enabled: true, Priority: 2,
+			AuthType: params.HeaderAuth, AuthLogin: "X-Api-Key", AuthToken: "key", MaxRequestsPerSecond: 3},
+		{ChainID: 1, Name: "Disabled node", URL: "https://disabled.example.com", Priority: 0},
+	}
+	for i := range userProviders {
+		require.NoError(t, c.NetworkManager.UpsertRpcProvider(&userProviders[i]))
+	}
+
+	providers := c.prepareProviders(c.NetworkManager.Find(1))
+	require.Len(t, providers, 4)
+
+	require.Equal(t, ProviderMain, providers[0].Key)
+
+	require.Equal(t, fmt.Sprintf("%s-%d", ProviderUser, userProviders[0].ID), providers[1].Key)
+	require.Equal(t, "Token node", providers[1].Name)
+	require.Equal(t, "https://token.example.com/v1/secret/rpc", providers[1].URL)
+	require.False(t, providers[1].authenticationNeeded())
+
+	// User providers are used before the configured ones of the same priority
+	require.Equal(t, fmt.Sprintf("%s-%d", ProviderUser, userProviders[1].ID), providers[2].Key)
+	require.Equal(t, userProviders[1].ID, providers[2].RpcProviderID)
+	require.Equal(t, 3, providers[2].MaxRequestsPerSecond)
+	require.True(t, providers[2].authenticationNeeded())
+	require.Equal(t, "key", providers[2].headers().Get("X-Api-Key"))
+	require.Equal(t, providers[2].Key, providers[2].circuitKey(2))
+
+	require.Equal(t, ProviderFallback, providers[3].Key)
+	require.Equal(t, "mainnet.infura.io", providers[3].circuitKey(3))
+}
+
+func TestUserProviderURL(t *testing.T) {
+	provider := params.RpcProvider{URL: "https://node.example.com/", AuthType: params.URLTokenAuth, AuthToken: "a/b"}
+	require.Equal(t, "https://node.example.com/a%2Fb", userProviderURL(provider))
+
+	provider.AuthType = params.NoAuth
+	require.Equal(t, "https://node.example.com/", userProviderURL(provider))
+}
+
+func TestProviderHeaders(t *testing.T) {
+	provider := Provider{Auth: "user:pass"}
+	headers := provider.headers()
+	require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), headers.Get("Authorization"))
+	require.Equal(t, rpcUserAgentName, headers.Get("User-Agent"))
+}
+
+func TestReloadProviders(t *testing.T) {
+	var userCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user" {
+			require.Equal(t, "key", r.Header.Get("X-Api-Key"))
+			userCalls.Add(1)
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x1"}`, req.ID)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, []params.Network{{ChainID: 1, RPCURL: server.URL + "/default"}})
+
+	chainClient, err := c.getClientUsingCache(1)
+	require.NoError(t, err)
+	_, err = chainClient.BalanceAt(context.Background(), common.Address{0x1}, nil)
+	require.NoError(t, err)
+	require.Zero(t, userCalls.Load())
+
+	provider := params.RpcProvider{ChainID: 1, Name: "My node", URL: server.URL + "/user", Enabled: true,
+		AuthType: params.HeaderAuth, AuthLogin: "X-Api-Key", AuthToken: "key"}
+	require.NoError(t, c.NetworkManager.UpsertRpcProvider(&provider))
+	require.NoError(t, c.ReloadProviders(1))
+
+	// The client already in use picks up the new provider
+	_, err = chainClient.BalanceAt(context.Background(), common.Address{0x1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), userCalls.Load())
+
+	statuses, err := c.GetProvidersStatus(1)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, fmt.Sprintf("%s-%d", ProviderUser, provider.ID), statuses[0].Key)
+	require.Equal(t, "My node", statuses[0].Name)
+	require.Equal(t, provider.ID, statuses[0].RpcProviderID)
+	require.Equal(t, rpcstatus.StatusUp, statuses[0].Status)
+	// The default provider was not used since the reload
+	require.Equal(t, rpcstatus.StatusUnknown, statuses[1].Status)
+}
@@ -0,0 +1,327 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+)
+
+// ErrNoWebSocketProvider is returned by the dialers when none of the providers of the chain supports WebSockets
+var ErrNoWebSocketProvider = errors.New("no websocket rpc provider")
+
+const (
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 5 * time.Minute
+)
+
+// Dialer connects to a WebSocket provider of the chain
+type Dialer interface {
+	DialWebSocket(ctx context.Context, chainID uint64) (*gethrpc.Client, error)
+}
+
+// Manager keeps a WebSocket connection per chain while the chain has subscriptions, the connection is restored
+// with a backoff when it is lost. Nothing is pushed while the chain is disconnected, e.g. when none of its
+// providers supports WebSockets, so the subscribers keep polling as a fallback.
+type Manager struct {
+	dialer Dialer
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	chains map[uint64]*chainConnection
+}
+
+type chainConnection struct {
+	chainID   uint64
+	cancel    context.CancelFunc
+	restartCh chan struct{}
+	connected atomic.Bool
+
+	// guarded by the mutex of the manager
+	heads map[*subscription]chan<- *types.Header
+	logs  map[*subscription]*logSubscription
+}
+
+type logSubscription struct {
+	sub   *subscription
+	query ethereum.FilterQuery
+	ch    chan<- types.Log
+}
+
+// subscription implements event.Subscription, its error channel is closed when unsubscribing
+type subscription struct {
+	once        sync.Once
+	err         chan error
+	unsubscribe func()
+}
+
+func (s *subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.unsubscribe()
+		close(s.err)
+	})
+}
+
+func (s *subscription) Err() <-chan error {
+	return s.err
+}
+
+func NewManager(dialer Dialer) *Manager {
+	return &Manager{
+		dialer: dialer,
+		logger: logutils.ZapLogger().Named("rpcSubscriptions"),
+		chains: make(map[uint64]*chainConnection),
+	}
+}
+
+// SubscribeNewHeads pushes the new headers of the chain, they are dropped when the channel is not ready
+func (m *Manager) SubscribeNewHeads(chainID uint64, ch chan<- *types.Header) event.Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.newSubscription(chainID)
+	m.connection(chainID).heads[sub] = ch
+	return sub
+}
+
+// SubscribeFilterLogs pushes the logs of the chain matching the query
+func (m *Manager) SubscribeFilterLogs(chainID uint64, query ethereum.FilterQuery, ch chan<- types.Log) event.Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.newSubscription(chainID)
+	conn := m.connection(chainID)
+	conn.logs[sub] = &logSubscription{sub: sub, query: query, ch: ch}
+	// The logs are subscribed when connecting
+	conn.restart()
+	return sub
+}
+
+// IsConnected returns true when the subscriptions of the chain are pushed
+func (m *Manager) IsConnected(chainID uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conn, ok := m.chains[chainID]
+	return ok && conn.connected.Load()
+}
+
+// Reconnect connects the chain again right away, e.g. when its providers changed
+func (m *Manager) Reconnect(chainID uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if conn, ok := m.chains[chainID]; ok {
+		conn.restart()
+	}
+}
+
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for chainID, conn := range m.chains {
+		conn.cancel()
+		delete(m.chains, chainID)
+	}
+}
+
+func (m *Manager) newSubscription(chainID uint64) *subscription {
+	sub := &subscription{err: make(chan error)}
+	sub.unsubscribe = func() {
+		m.remove(chainID, sub)
+	}
+	return sub
+}
+
+// connection returns the connection of the chain, it is started with the first subscription
+func (m *Manager) connection(chainID uint64) *chainConnection {
+	if conn, ok := m.chains[chainID]; ok {
+		return conn
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := &chainConnection{
+		chainID:   chainID,
+		cancel:    cancel,
+		restartCh: make(chan struct{}, 1),
+		heads:     make(map[*subscription]chan<- *types.Header),
+		logs:      make(map[*subscription]*logSubscription),
+	}
+	m.chains[chainID] = conn
+	go m.run(ctx, conn)
+	return conn
+}
+
+func (m *Manager) remove(chainID uint64, sub *subscription) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conn, ok := m.chains[chainID]
+	if !ok {
+		return
+	}
+	delete(conn.heads, sub)
+	if _, ok := conn.logs[sub]; ok {
+		delete(conn.logs, sub)
+		conn.restart()
+	}
+	// The connection is closed with the last subscription
+	if len(conn.heads) == 0 && len(conn.logs) == 0 {
+		conn.cancel()
+		delete(m.chains, chainID)
+	}
+}
+
+func (c *chainConnection) restart() {
+	select {
+	case c.restartCh <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) run(ctx context.Context, conn *chainConnection) {
+	defer gocommon.LogOnPanic()
+
+	delay := reconnectMinDelay
+	for {
+		connected, err := m.session(ctx, conn)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = reconnectMinDelay
+		}
+		if err == nil {
+			// Restarted
+			continue
+		}
+
+		if errors.Is(err, ErrNoWebSocketProvider) {
+			// Only a provider change can fix it
+			delay = reconnectMaxDelay
+		} else {
+			m.logger.Warn("websocket subscriptions lost", zap.Uint64("chain", conn.chainID), zap.Duration("retryIn", delay), zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-conn.restartCh:
+			delay = reconnectMinDelay
+		case <-time.After(delay):
+			delay = min(2*delay, reconnectMaxDelay)
+		}
+	}
+}
+
+// session connects the chain and pushes its subscriptions until the connection fails or a restart is requested.
+// It returns whether the connection succeeded, and a nil error when restarted.
+func (m *Manager) session(ctx context.Context, conn *chainConnection) (bool, error) {
+	client, err := m.dialer.DialWebSocket(ctx, conn.chainID)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	ethClient := ethclient.NewClient(client)
+
+	heads := make(chan *types.Header, 16)
+	headsSub, err := ethClient.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return false, err
+	}
+	defer headsSub.Unsubscribe()
+
+	m.mu.Lock()
+	logSubs := make([]*logSubscription, 0, len(conn.logs))
+	for _, logSub := range conn.logs {
+		logSubs = append(logSubs, logSub)
+	}
+	m.mu.Unlock()
+
+	// Stops forwarding the logs of the session
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	for _, logSub := range logSubs {
+		logs := make(chan types.Log, 16)
+		sub, err := ethClient.SubscribeFilterLogs(ctx, logSub.query, logs)
+		if err != nil {
+			return false, err
+		}
+		defer sub.Unsubscribe()
+		go forwardLogs(sessionCtx, sub, logs, logSub, errCh)
+	}
+
+	conn.connected.Store(true)
+	defer conn.connected.Store(false)
+	m.logger.Debug("websocket subscriptions connected", zap.Uint64("chain", conn.chainID), zap.Int("logs", len(logSubs)))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case <-conn.restartCh:
+			return true, nil
+		case head := <-heads:
+			m.sendHead(conn, head)
+		case err := <-headsSub.Err():
+			return true, err
+		case err := <-errCh:
+			return true, err
+		}
+	}
+}
+
+func (m *Manager) sendHead(conn *chainConnection, head *types.Header) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ch := range conn.heads {
+		select {
+		case ch <- head:
+		default:
+		}
+	}
+}
+
+func forwardLogs(ctx context.Context, sub ethereum.Subscription, logs <-chan types.Log, logSub *logSubscription, errCh chan<- error) {
+	defer gocommon.LogOnPanic()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-logSub.sub.err:
+			// Unsubscribed
+			return
+		case err := <-sub.Err():
+			select {
+			case errCh <- err:
+			default:
+			}
+			return
+		case log := <-logs:
+			select {
+			case logSub.ch <- log:
+			case <-ctx.Done():
+				return
+			case <-logSub.sub.err:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package subscriptions
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeEthAPI pushes the heads and the logs sent to its channels
+type fakeEthAPI struct {
+	heads chan *types.Header
+	logs  chan types.Log
+}
+
+func push[T any](ctx context.Context, values <-chan T) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case value := <-values:
+				_ = notifier.Notify(sub.ID, value)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func (api *fakeEthAPI) NewHeads(ctx context.Context) (*gethrpc.Subscription, error) {
+	return push(ctx, api.heads)
+}
+
+func (api *fakeEthAPI) Logs(ctx context.Context, query map[string]interface{}) (*gethrpc.Subscription, error) {
+	return push(ctx, api.logs)
+}
+
+type fakeDialer struct {
+	server *gethrpc.Server
+	dials  atomic.Int32
+}
+
+func (d *fakeDialer) DialWebSocket(ctx context.Context, chainID uint64) (*gethrpc.Client, error) {
+	d.dials.Add(1)
+	if d.server == nil {
+		return nil, ErrNoWebSocketProvider
+	}
+	return gethrpc.DialInProc(d.server), nil
+}
+
+func setupTestManager(t *testing.T) (*Manager, *fakeEthAPI, *fakeDialer) {
+	api := &fakeEthAPI{heads: make(chan *types.Header), logs: make(chan types.Log)}
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	t.Cleanup(server.Stop)
+
+	dialer := &fakeDialer{server: server}
+	manager := NewManager(dialer)
+	t.Cleanup(manager.Stop)
+	return manager, api, dialer
+}
+
+func TestManager_NewHeads(t *testing.T) {
+	manager, api, _ := setupTestManager(t)
+
+	heads := make(chan *types.Header, 1)
+	sub := manager.SubscribeNewHeads(1, heads)
+	require.Eventually(t, func() bool { return manager.IsConnected(1) }, time.Second, 10*time.Millisecond)
+	require.False(t, manager.IsConnected(10))
+
+	api.heads <- &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}
+	select {
+	case head := <-heads:
+		require.Equal(t, big.NewInt(100), head.Number)
+	case <-time.After(time.Second):
+		require.Fail(t, "head not pushed")
+	}
+
+	// The connection is closed with the last subscription
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok)
+	require.False(t, manager.IsConnected(1))
+}
+
+func TestManager_Logs(t *testing.T) {
+	manager, api, dialer := setupTestManager(t)
+
+	heads := make(chan *types.Header, 1)
+	headsSub := manager.SubscribeNewHeads(1, heads)
+	defer headsSub.Unsubscribe()
+	require.Eventually(t, func() bool { return manager.IsConnected(1) }, time.Second, 10*time.Millisecond)
+
+	// The chain is connected again with the logs subscription
+	logs := make(chan types.Log, 1)
+	logsSub := manager.SubscribeFilterLogs(1, ethereum.FilterQuery{Addresses: []common.Address{{0x1}}}, logs)
+	defer logsSub.Unsubscribe()
+	require.Eventually(t, func() bool { return dialer.dials.Load() == 2 && manager.IsConnected(1) }, time.Second, 10*time.Millisecond)
+
+	api.logs <- types.Log{Address: common.Address{0x1}, Topics: []common.Hash{}, BlockNumber: 100}
+	select {
+	case log := <-logs:
+		require.Equal(t, uint64(100), log.BlockNumber)
+	case <-time.After(time.Second):
+		require.Fail(t, "log not pushed")
+	}
+}
+
+func TestManager_NoWebSocketProvider(t *testing.T) {
+	dialer := &fakeDialer{}
+	manager := NewManager(dialer)
+	defer manager.Stop()
+
+	sub := manager.SubscribeNewHeads(1, make(chan *types.Header))
+	require.Eventually(t, func() bool { return dialer.dials.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.False(t, manager.IsConnected(1))
+
+	// Dialing again is delayed until the providers change
+	manager.Reconnect(1)
+	require.Eventually(t, func() bool { return dialer.dials.Load() == 2 }, time.Second, 10*time.Millisecond)
+	sub.Unsubscribe()
+}
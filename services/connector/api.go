@@ -12,6 +12,7 @@ import (
 
 var (
 	ErrInvalidResponseFromForwardedRpc = errors.New("invalid response from forwarded RPC")
+	ErrInvalidPolicyMethodSelector     = errors.New("policy methods must be 4 bytes selectors")
 )
 
 type API struct {
@@ -29,16 +30,55 @@ func NewAPI(s *Service) *API {
 		RpcClient:     s.rpc,
 		Db:            s.db,
 		ClientHandler: c,
+		TxDecoder:     s.txDecoder,
 	})
 	r.Register("personal_sign", &commands.SignCommand{
 		Db:            s.db,
 		ClientHandler: c,
 	})
 	r.Register("eth_signTypedData_v4", &commands.SignCommand{
+		Db:                s.db,
+		ClientHandler:     c,
+		TypedDataAnalyzer: s.typedDataAnalyzer,
+	})
+	r.Register("eth_signTypedData_v3", &commands.SignCommand{
+		Db:                s.db,
+		ClientHandler:     c,
+		TypedDataAnalyzer: s.typedDataAnalyzer,
+	})
+	r.Register("eth_signTypedData", &commands.SignCommand{
+		Db:            s.db,
+		ClientHandler: c,
+	})
+
+	// Encryption with the shared account key
+	r.Register("eth_getEncryptionPublicKey", &commands.GetEncryptionPublicKeyCommand{
+		Db:            s.db,
+		ClientHandler: c,
+	})
+	r.Register("eth_decrypt", &commands.DecryptCommand{
 		Db:            s.db,
 		ClientHandler: c,
 	})
 
+	// EIP-5792 batched calls
+	r.Register("wallet_sendCalls", &commands.SendCallsCommand{
+		RpcClient:      s.rpc,
+		Db:             s.db,
+		ClientHandler:  c,
+		PendingTracker: s.pendingTracker,
+		TxDecoder:      s.txDecoder,
+	})
+	r.Register("wallet_getCallsStatus", &commands.GetCallsStatusCommand{
+		RpcClient:      s.rpc,
+		Db:             s.db,
+		PendingTracker: s.pendingTracker,
+	})
+	r.Register("wallet_getCapabilities", &commands.GetCapabilitiesCommand{
+		Db:             s.db,
+		NetworkManager: s.nm,
+	})
+
 	// Accounts query and dapp permissions
 	// NOTE: Some dApps expect same behavior for both eth_accounts and eth_requestAccounts
 	accountsCommand := &commands.RequestAccountsCommand{
@@ -57,6 +97,19 @@ func NewAPI(s *Service) *API {
 		Db:             s.db,
 		NetworkManager: s.nm,
 	})
+	r.Register("wallet_addEthereumChain", &commands.AddEthereumChainCommand{
+		Db:             s.db,
+		ClientHandler:  c,
+		NetworkManager: s.nm,
+		TokenManager:   s.tokenManager,
+	})
+
+	// Custom tokens
+	r.Register("wallet_watchAsset", &commands.WatchAssetCommand{
+		Db:            s.db,
+		ClientHandler: c,
+		TokenManager:  s.tokenManager,
+	})
 
 	// Permissions
 	r.Register("wallet_requestPermissions", &commands.RequestPermissionsCommand{})
@@ -110,12 +163,37 @@ func (api *API) forwardRPC(URL string, request commands.RPCRequest) (interface{}
 	return nil, ErrInvalidResponseFromForwardedRpc
 }
 
+// checkDAppConnection revokes the permissions of a dApp once its connection expired,
+// the dApp has to request the accounts again
+func (api *API) checkDAppConnection(URL string) error {
+	if URL == "" {
+		return nil
+	}
+
+	err := commands.CheckDAppConnection(api.s.db, URL)
+	if !errors.Is(err, commands.ErrDAppConnectionExpired) {
+		return err
+	}
+
+	recallErr := api.c.RecallDAppPermissions(commands.RecallDAppPermissionsArgs{URL: URL})
+	if recallErr != nil && !errors.Is(recallErr, commands.ErrDAppDoesNotHavePermissions) {
+		return recallErr
+	}
+
+	return err
+}
+
 func (api *API) CallRPC(ctx context.Context, inputJSON string) (interface{}, error) {
 	request, err := commands.RPCRequestFromJSON(inputJSON)
 	if err != nil {
 		return "", err
 	}
 
+	err = api.checkDAppConnection(request.URL)
+	if err != nil {
+		return "", err
+	}
+
 	if command, exists := api.r.GetCommand(request.Method); exists {
 		return command.Execute(ctx, request)
 	}
@@ -132,6 +210,35 @@ func (api *API) GetPermittedDAppsList() ([]persistence.DApp, error) {
 	return persistence.SelectAllDApps(api.s.db)
 }
 
+func (api *API) GetDAppPolicy(url string) (*persistence.DAppPolicy, error) {
+	return persistence.SelectDAppPolicy(api.s.db, url)
+}
+
+// SetDAppPolicy restricts the transactions of a connected dApp, replacing its previous policy.
+// The value limits only apply to the native token, not to the ERC-20 transfers and approvals
+func (api *API) SetDAppPolicy(policy persistence.DAppPolicy) error {
+	dApp, err := persistence.SelectDAppByUrl(api.s.db, policy.URL)
+	if err != nil {
+		return err
+	}
+
+	if dApp == nil {
+		return commands.ErrDAppIsNotPermittedByUser
+	}
+
+	for _, method := range policy.AllowedMethods {
+		if len(method) != 4 {
+			return ErrInvalidPolicyMethodSelector
+		}
+	}
+
+	return persistence.UpsertDAppPolicy(api.s.db, &policy)
+}
+
+func (api *API) RemoveDAppPolicy(url string) error {
+	return persistence.DeleteDAppPolicy(api.s.db, url)
+}
+
 func (api *API) RequestAccountsAccepted(args commands.RequestAccountsAcceptedArgs) error {
 	return api.c.RequestAccountsAccepted(args)
 }
@@ -148,6 +255,14 @@ func (api *API) SendTransactionRejected(args commands.RejectedArgs) error {
 	return api.c.SendTransactionRejected(args)
 }
 
+func (api *API) SendCallsAccepted(args commands.SendCallsAcceptedArgs) error {
+	return api.c.SendCallsAccepted(args)
+}
+
+func (api *API) SendCallsRejected(args commands.RejectedArgs) error {
+	return api.c.SendCallsRejected(args)
+}
+
 func (api *API) SignAccepted(args commands.SignAcceptedArgs) error {
 	return api.c.SignAccepted(args)
 }
@@ -155,3 +270,27 @@ func (api *API) SignAccepted(args commands.SignAcceptedArgs) error {
 func (api *API) SignRejected(args commands.RejectedArgs) error {
 	return api.c.SignRejected(args)
 }
+
+func (api *API) AddEthereumChainAccepted(args commands.AcceptedArgs) error {
+	return api.c.AddEthereumChainAccepted(args)
+}
+
+func (api *API) AddEthereumChainRejected(args commands.RejectedArgs) error {
+	return api.c.AddEthereumChainRejected(args)
+}
+
+func (api *API) WatchAssetAccepted(args commands.AcceptedArgs) error {
+	return api.c.WatchAssetAccepted(args)
+}
+
+func (api *API) WatchAssetRejected(args commands.RejectedArgs) error {
+	return api.c.WatchAssetRejected(args)
+}
+
+func (api *API) KeyAccessAccepted(args commands.KeyAccessAcceptedArgs) error {
+	return api.c.KeyAccessAccepted(args)
+}
+
+func (api *API) KeyAccessRejected(args commands.RejectedArgs) error {
+	return api.c.KeyAccessRejected(args)
+}
@@ -2,11 +2,16 @@ package connector
 
 import (
 	"context"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/services/connector/commands"
+	persistence "github.com/status-im/status-go/services/connector/database"
 )
 
 func TestCallRPC(t *testing.T) {
@@ -48,3 +53,66 @@ func TestCallRPC(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDAppPolicy(t *testing.T) {
+	state, closeFn := setupTests(t)
+	t.Cleanup(closeFn)
+
+	policy := persistence.DAppPolicy{
+		URL:            "http://testDAppURL",
+		AllowedMethods: []types.HexBytes{{0xa9, 0x05, 0x9c, 0xbb}},
+		MaxValuePerTx:  (*hexutil.Big)(big.NewInt(100)),
+	}
+
+	err := state.api.SetDAppPolicy(policy)
+	require.Equal(t, commands.ErrDAppIsNotPermittedByUser, err)
+
+	err = persistence.UpsertDApp(state.walletDb, &persistence.DApp{URL: policy.URL, Name: "testDAppName"})
+	require.NoError(t, err)
+
+	invalidPolicy := policy
+	invalidPolicy.AllowedMethods = []types.HexBytes{{0xa9, 0x05}}
+	err = state.api.SetDAppPolicy(invalidPolicy)
+	require.Equal(t, ErrInvalidPolicyMethodSelector, err)
+
+	err = state.api.SetDAppPolicy(policy)
+	require.NoError(t, err)
+
+	policyBack, err := state.api.GetDAppPolicy(policy.URL)
+	require.NoError(t, err)
+	require.Equal(t, &policy, policyBack)
+
+	err = state.api.RemoveDAppPolicy(policy.URL)
+	require.NoError(t, err)
+
+	policyBack, err = state.api.GetDAppPolicy(policy.URL)
+	require.NoError(t, err)
+	require.Nil(t, policyBack)
+}
+
+func TestCallRPCRevokesExpiredDApp(t *testing.T) {
+	state, closeFn := setupTests(t)
+	t.Cleanup(closeFn)
+
+	dApp := persistence.DApp{URL: "http://testDAppURL", Name: "testDAppName", SharedAccount: types.Address{0x01}, ChainID: 1}
+	err := persistence.UpsertDApp(state.walletDb, &dApp)
+	require.NoError(t, err)
+
+	err = persistence.UpsertDAppPolicy(state.walletDb, &persistence.DAppPolicy{
+		URL:       dApp.URL,
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	request := "{\"method\": \"eth_accounts\", \"params\": [], \"url\": \"http://testDAppURL\", \"name\": \"testDAppName\"}"
+	_, err = state.api.CallRPC(context.Background(), request)
+	require.Equal(t, commands.ErrDAppConnectionExpired, err)
+
+	dAppBack, err := persistence.SelectDAppByUrl(state.walletDb, dApp.URL)
+	require.NoError(t, err)
+	require.Nil(t, dAppBack)
+
+	policyBack, err := state.api.GetDAppPolicy(dApp.URL)
+	require.NoError(t, err)
+	require.Nil(t, policyBack)
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc/network"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/signal"
+)
+
+var (
+	ErrNoAddEthereumChainParamsFound = errors.New("no add ethereum chain in params found")
+	ErrInvalidChainParams            = errors.New("invalid chain params")
+	ErrInvalidRPCURL                 = errors.New("rpc urls must be https urls")
+	ErrInvalidNativeCurrency         = errors.New("invalid native currency, the symbol must be 2 to 6 characters with 18 decimals")
+	ErrRPCChainIDMismatch            = errors.New("rpc url returned a different chain id")
+)
+
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint64 `json:"decimals"`
+}
+
+// AddEthereumChainParams is the EIP-3085 wallet_addEthereumChain request
+type AddEthereumChainParams struct {
+	ChainID           hexutil.Uint64  `json:"chainId"`
+	ChainName         string          `json:"chainName"`
+	NativeCurrency    *NativeCurrency `json:"nativeCurrency"`
+	RPCURLs           []string        `json:"rpcUrls"`
+	BlockExplorerURLs []string        `json:"blockExplorerUrls"`
+	IconURLs          []string        `json:"iconUrls"`
+}
+
+type AddEthereumChainCommand struct {
+	NetworkManager *network.Manager
+	Db             *sql.DB
+	ClientHandler  ClientSideHandlerInterface
+	TokenManager   TokenManagerInterface
+}
+
+func (r *RPCRequest) getAddEthereumChainParams() (*AddEthereumChainParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoAddEthereumChainParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling add ethereum chain param: %v", err)
+	}
+
+	var chainParams AddEthereumChainParams
+	err = json.Unmarshal(paramBytes, &chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling add ethereum chain param to AddEthereumChainParams: %v", err)
+	}
+
+	return &chainParams, nil
+}
+
+func (p *AddEthereumChainParams) Validate() error {
+	if p.ChainID == 0 || p.ChainName == "" || len(p.RPCURLs) == 0 {
+		return ErrInvalidChainParams
+	}
+
+	for _, rpcURL := range p.RPCURLs {
+		parsedURL, err := url.Parse(rpcURL)
+		if err != nil || parsedURL.Scheme != "https" || parsedURL.Host == "" {
+			return ErrInvalidRPCURL
+		}
+	}
+
+	if p.NativeCurrency == nil || len(p.NativeCurrency.Symbol) < 2 || len(p.NativeCurrency.Symbol) > 6 || p.NativeCurrency.Decimals != 18 {
+		return ErrInvalidNativeCurrency
+	}
+
+	return nil
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c *AddEthereumChainCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	chainParams, err := request.getAddEthereumChainParams()
+	if err != nil {
+		return "", err
+	}
+
+	err = chainParams.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	// The dApp is expected to follow up with wallet_switchEthereumChain
+	if c.NetworkManager.Find(uint64(chainParams.ChainID)) != nil {
+		return nil, nil
+	}
+
+	probeResult, err := network.ProbeRPC(ctx, chainParams.RPCURLs[0])
+	if err != nil {
+		return "", err
+	}
+	if probeResult.ChainID != uint64(chainParams.ChainID) {
+		return "", ErrRPCChainIDMismatch
+	}
+	probeResult.Capabilities.TokenLists = c.TokenManager.HasListedTokens(probeResult.ChainID)
+
+	// Add the network to the current mode, so that it can be used right away
+	isTest, err := c.NetworkManager.GetTestNetworksEnabled()
+	if err != nil {
+		return "", err
+	}
+
+	var fallbackURL string
+	if len(chainParams.RPCURLs) > 1 {
+		fallbackURL = chainParams.RPCURLs[1]
+	}
+
+	newNetwork := &params.Network{
+		ChainID:                uint64(chainParams.ChainID),
+		ChainName:              chainParams.ChainName,
+		RPCURL:                 chainParams.RPCURLs[0],
+		OriginalRPCURL:         chainParams.RPCURLs[0],
+		FallbackURL:            fallbackURL,
+		OriginalFallbackURL:    fallbackURL,
+		BlockExplorerURL:       firstOrEmpty(chainParams.BlockExplorerURLs),
+		IconURL:                firstOrEmpty(chainParams.IconURLs),
+		NativeCurrencyName:     chainParams.NativeCurrency.Name,
+		NativeCurrencySymbol:   chainParams.NativeCurrency.Symbol,
+		NativeCurrencyDecimals: chainParams.NativeCurrency.Decimals,
+		IsTest:                 isTest,
+		Enabled:                true,
+		Capabilities:           &probeResult.Capabilities,
+	}
+
+	err = c.ClientHandler.RequestAddEthereumChain(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, newNetwork)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.NetworkManager.Upsert(newNetwork)
+	if err != nil {
+		return "", err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/signal"
+)
+
+const testAddedChainID = uint64(0x2105)
+
+func prepareAddEthereumChainRequest(dApp signal.ConnectorDApp, rpcURL string) (RPCRequest, error) {
+	return ConstructRPCRequest(Method_AddEthereumChain, []interface{}{
+		map[string]interface{}{
+			"chainId":   "0x2105",
+			"chainName": "Base",
+			"nativeCurrency": map[string]interface{}{
+				"name":     "Ether",
+				"symbol":   "ETH",
+				"decimals": 18,
+			},
+			"rpcUrls":           []interface{}{rpcURL},
+			"blockExplorerUrls": []interface{}{"https://basescan.org"},
+		},
+	}, &dApp)
+}
+
+func mockProbeRPC(t *testing.T, chainID uint64) {
+	backupProbeRPC := network.ProbeRPC
+	network.ProbeRPC = func(ctx context.Context, rpcURL string) (*network.ProbeResult, error) {
+		return &network.ProbeResult{
+			ChainID:      chainID,
+			Capabilities: params.NetworkCapabilities{EIP1559: true, L2FeeModel: params.OptimismL2FeeModel},
+		}, nil
+	}
+	t.Cleanup(func() { network.ProbeRPC = backupProbeRPC })
+}
+
+func TestFailToAddEthereumChainForUnpermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "https://mainnet.base.org")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToAddEthereumChainWithInvalidParams(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "http://mainnet.base.org")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrInvalidRPCURL, err)
+
+	request, err = prepareAddEthereumChainRequest(testDAppData, "https://mainnet.base.org")
+	assert.NoError(t, err)
+	delete(request.Params[0].(map[string]interface{}), "nativeCurrency")
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrInvalidNativeCurrency, err)
+}
+
+func TestFailToAddEthereumChainWithMismatchingRPC(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	mockProbeRPC(t, 0x1)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "https://mainnet.base.org")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrRPCChainIDMismatch, err)
+	assert.Nil(t, state.networkManager.Find(testAddedChainID))
+}
+
+func TestAddEthereumChainWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	mockProbeRPC(t, testAddedChainID)
+	state.tokenManager.listedChains[testAddedChainID] = true
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "https://mainnet.base.org")
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var network params.Network
+			err = json.Unmarshal([]byte(ev.Network), &network)
+			assert.NoError(t, err)
+			assert.Equal(t, testAddedChainID, network.ChainID)
+			assert.Equal(t, "ETH", network.NativeCurrencySymbol)
+
+			err = state.handler.AddEthereumChainAccepted(AcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+
+	network := state.networkManager.Find(testAddedChainID)
+	assert.NotNil(t, network)
+	assert.Equal(t, "https://mainnet.base.org", network.RPCURL)
+	assert.Equal(t, "https://basescan.org", network.BlockExplorerURL)
+	assert.True(t, network.Enabled)
+	assert.Equal(t, &params.NetworkCapabilities{EIP1559: true, L2FeeModel: params.OptimismL2FeeModel, TokenLists: true}, network.Capabilities)
+
+	// Adding a known chain succeeds without asking the user
+	signal.ResetMobileSignalHandler()
+	response, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+}
+
+func TestAddEthereumChainWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	mockProbeRPC(t, testAddedChainID)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "https://mainnet.base.org")
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.AddEthereumChainRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAddEthereumChainRejectedByUser, err)
+	assert.Nil(t, state.networkManager.Find(testAddedChainID))
+}
@@ -10,7 +10,11 @@ import (
 	"time"
 
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/eip712"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
 )
@@ -23,6 +27,10 @@ var (
 	ErrRequestAccountsRejectedByUser          = fmt.Errorf("request accounts was rejected by user")
 	ErrSendTransactionRejectedByUser          = fmt.Errorf("send transaction was rejected by user")
 	ErrSignRejectedByUser                     = fmt.Errorf("sign was rejected by user")
+	ErrSendCallsRejectedByUser                = fmt.Errorf("send calls was rejected by user")
+	ErrAddEthereumChainRejectedByUser         = fmt.Errorf("add ethereum chain was rejected by user")
+	ErrWatchAssetRejectedByUser               = fmt.Errorf("watch asset was rejected by user")
+	ErrKeyAccessRejectedByUser                = fmt.Errorf("key access was rejected by user")
 	ErrEmptyRequestID                         = fmt.Errorf("empty requestID")
 	ErrAnotherConnectorOperationIsAwaitingFor = fmt.Errorf("another connector operation is awaiting for user input")
 	ErrEmptyUrl                               = fmt.Errorf("empty URL")
@@ -36,6 +44,10 @@ const (
 	SendTransactionAccepted
 	SignAccepted
 	Rejected
+	SendCallsAccepted
+	AddEthereumChainAccepted
+	WatchAssetAccepted
+	KeyAccessAccepted
 )
 
 type Message struct {
@@ -148,7 +160,7 @@ func (c *ClientSideHandler) RecallDAppPermissions(args RecallDAppPermissionsArgs
 	return nil
 }
 
-func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs) (types.Hash, error) {
+func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs, decodedCall *txdecoder.DecodedCall) (types.Hash, error) {
 	if !c.setRequestRunning() {
 		return types.Hash{}, ErrAnotherConnectorOperationIsAwaitingFor
 	}
@@ -159,8 +171,16 @@ func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, ch
 		return types.Hash{}, fmt.Errorf("failed to marshal txArgs: %v", err)
 	}
 
+	var decodedCallJson []byte
+	if decodedCall != nil {
+		decodedCallJson, err = json.Marshal(decodedCall)
+		if err != nil {
+			return types.Hash{}, fmt.Errorf("failed to marshal decodedCall: %v", err)
+		}
+	}
+
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), requestID)
+	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), string(decodedCallJson), requestID)
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -203,14 +223,83 @@ func (c *ClientSideHandler) SendTransactionRejected(args RejectedArgs) error {
 	return nil
 }
 
-func (c *ClientSideHandler) RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string) (string, error) {
+func (c *ClientSideHandler) RequestSendCalls(dApp signal.ConnectorDApp, chainID uint64, txArgs []*wallettypes.SendTxArgs, decodedCalls []*txdecoder.DecodedCall) ([]types.Hash, error) {
+	if !c.setRequestRunning() {
+		return nil, ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	txArgsJson, err := json.Marshal(txArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal txArgs: %v", err)
+	}
+
+	decodedCallsJson, err := json.Marshal(decodedCalls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal decodedCalls: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorSendCalls(dApp, chainID, string(txArgsJson), string(decodedCallsJson), requestID)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case SendCallsAccepted:
+				response := msg.Data.(SendCallsAcceptedArgs)
+				if response.RequestID == requestID {
+					return response.Hashes, nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return nil, ErrSendCallsRejectedByUser
+				}
+			}
+		case <-timeout:
+			return nil, ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) SendCallsAccepted(args SendCallsAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: SendCallsAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) SendCallsRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string, analysis *eip712.Analysis) (string, error) {
 	if !c.setRequestRunning() {
 		return "", ErrAnotherConnectorOperationIsAwaitingFor
 	}
 	defer c.clearRequestRunning()
 
+	var analysisJson []byte
+	if analysis != nil {
+		var err error
+		analysisJson, err = json.Marshal(analysis)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal typed data analysis: %v", err)
+		}
+	}
+
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorSign(dApp, requestID, challenge, address, method)
+	signal.SendConnectorSign(dApp, requestID, challenge, address, method, string(analysisJson))
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -252,3 +341,143 @@ func (c *ClientSideHandler) SignRejected(args RejectedArgs) error {
 	c.responseChannel <- Message{Type: Rejected, Data: args}
 	return nil
 }
+
+// awaitResponse waits for the user to accept or reject the request, returning the accepted message
+func (c *ClientSideHandler) awaitResponse(requestID string, acceptedType MessageType, rejectedErr error) (Message, error) {
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case acceptedType:
+				if responseRequestID(msg) == requestID {
+					return msg, nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return Message{}, rejectedErr
+				}
+			}
+		case <-timeout:
+			return Message{}, ErrWalletResponseTimeout
+		}
+	}
+}
+
+func responseRequestID(msg Message) string {
+	switch response := msg.Data.(type) {
+	case AcceptedArgs:
+		return response.RequestID
+	case KeyAccessAcceptedArgs:
+		return response.RequestID
+	}
+	return ""
+}
+
+func (c *ClientSideHandler) RequestAddEthereumChain(dApp signal.ConnectorDApp, network *params.Network) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	networkJson, err := json.Marshal(network)
+	if err != nil {
+		return fmt.Errorf("failed to marshal network: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorAddEthereumChain(dApp, requestID, string(networkJson))
+
+	_, err = c.awaitResponse(requestID, AddEthereumChainAccepted, ErrAddEthereumChainRejectedByUser)
+	return err
+}
+
+func (c *ClientSideHandler) AddEthereumChainAccepted(args AcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: AddEthereumChainAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) AddEthereumChainRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestWatchAsset(dApp signal.ConnectorDApp, asset *token.Token) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	assetJson, err := json.Marshal(asset)
+	if err != nil {
+		return fmt.Errorf("failed to marshal asset: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorWatchAsset(dApp, requestID, string(assetJson))
+
+	_, err = c.awaitResponse(requestID, WatchAssetAccepted, ErrWatchAssetRejectedByUser)
+	return err
+}
+
+func (c *ClientSideHandler) WatchAssetAccepted(args AcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: WatchAssetAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) WatchAssetRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestKeyAccess(dApp signal.ConnectorDApp, address types.Address, method string, data string) (string, error) {
+	if !c.setRequestRunning() {
+		return "", ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorKeyAccess(dApp, requestID, address.Hex(), method, data)
+
+	msg, err := c.awaitResponse(requestID, KeyAccessAccepted, ErrKeyAccessRejectedByUser)
+	if err != nil {
+		return "", err
+	}
+	return msg.Data.(KeyAccessAcceptedArgs).Result, nil
+}
+
+func (c *ClientSideHandler) KeyAccessAccepted(args KeyAccessAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: KeyAccessAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) KeyAccessRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
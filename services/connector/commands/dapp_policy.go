@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+var (
+	ErrDAppConnectionExpired      = errors.New("dApp connection expired")
+	ErrContractNotAllowedByPolicy = errors.New("contract is not allowed by dApp's policy")
+	ErrMethodNotAllowedByPolicy   = errors.New("contract method is not allowed by dApp's policy")
+	ErrValueExceedsTxLimit        = errors.New("value exceeds dApp's limit per transaction")
+	ErrValueExceedsDailyLimit     = errors.New("value exceeds dApp's daily limit")
+)
+
+const methodSelectorLength = 4
+
+// dailySpendingWindow is the sliding window of the dApp's daily limit
+const dailySpendingWindow = 24 * time.Hour
+
+// dAppSpendingLocks holds a mutex per dApp url, so that concurrent requests of a dApp
+// can't both pass the daily limit check before any of them records its spending
+var dAppSpendingLocks sync.Map // [url, *sync.Mutex]
+
+// lockDAppSpending must be held from checkDAppPolicy to recordDAppSpending, it returns the unlock function
+func lockDAppSpending(url string) func() {
+	lock, _ := dAppSpendingLocks.LoadOrStore(url, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+func isContractAllowed(policy *persistence.DAppPolicy, args *wallettypes.SendTxArgs) bool {
+	if len(policy.AllowedContracts) == 0 {
+		return true
+	}
+	if args.To == nil {
+		return false
+	}
+	for _, contract := range policy.AllowedContracts {
+		if contract == *args.To {
+			return true
+		}
+	}
+	return false
+}
+
+// isMethodAllowed checks the called contract method, plain value transfers don't call any method
+func isMethodAllowed(policy *persistence.DAppPolicy, args *wallettypes.SendTxArgs) bool {
+	input := args.GetInput()
+	if len(policy.AllowedMethods) == 0 || len(input) == 0 {
+		return true
+	}
+	if len(input) < methodSelectorLength {
+		return false
+	}
+	for _, method := range policy.AllowedMethods {
+		if bytes.Equal(method, input[:methodSelectorLength]) {
+			return true
+		}
+	}
+	return false
+}
+
+// txValue is the native token value of the transaction, the tokens moved by the called contract are not decoded
+func txValue(args *wallettypes.SendTxArgs) *big.Int {
+	if args.Value == nil {
+		return big.NewInt(0)
+	}
+	return args.Value.ToInt()
+}
+
+// CheckDAppConnection fails once the connection of the dApp expired according to its policy,
+// it applies to every request of the dApp
+func CheckDAppConnection(db *sql.DB, url string) error {
+	policy, err := persistence.SelectDAppPolicy(db, url)
+	if err != nil {
+		return err
+	}
+
+	if policy != nil && policy.ExpiresAt != 0 && time.Now().Unix() >= policy.ExpiresAt {
+		return ErrDAppConnectionExpired
+	}
+
+	return nil
+}
+
+// checkDAppPolicy verifies that the transactions requested by the dApp are allowed by its policy,
+// it returns the total native token value of the transactions. The caller holds lockDAppSpending
+func checkDAppPolicy(db *sql.DB, url string, txArgs []*wallettypes.SendTxArgs) (*big.Int, error) {
+	total := big.NewInt(0)
+	for _, args := range txArgs {
+		total.Add(total, txValue(args))
+	}
+
+	policy, err := persistence.SelectDAppPolicy(db, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return total, nil
+	}
+
+	for _, args := range txArgs {
+		if !isContractAllowed(policy, args) {
+			return nil, ErrContractNotAllowedByPolicy
+		}
+		if !isMethodAllowed(policy, args) {
+			return nil, ErrMethodNotAllowedByPolicy
+		}
+		if policy.MaxValuePerTx != nil && txValue(args).Cmp(policy.MaxValuePerTx.ToInt()) > 0 {
+			return nil, ErrValueExceedsTxLimit
+		}
+	}
+
+	if policy.MaxValuePerDay != nil {
+		spent, err := persistence.SelectDAppSpendingSince(db, url, time.Now().Add(-dailySpendingWindow).Unix())
+		if err != nil {
+			return nil, err
+		}
+		if spent.Add(spent, total).Cmp(policy.MaxValuePerDay.ToInt()) > 0 {
+			return nil, ErrValueExceedsDailyLimit
+		}
+	}
+
+	return total, nil
+}
+
+// recordDAppSpending keeps the value sent by the dApp for its daily limit, dropping the expired spendings
+func recordDAppSpending(db *sql.DB, url string, value *big.Int) error {
+	now := time.Now()
+	err := persistence.DeleteDAppSpendingsBefore(db, url, now.Add(-dailySpendingWindow).Unix())
+	if err != nil {
+		return err
+	}
+
+	if value.Sign() == 0 {
+		return nil
+	}
+
+	return persistence.InsertDAppSpending(db, url, value, now.Unix())
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+var testPolicyContract = types.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+
+func setupDAppPolicyTest(t *testing.T, policy persistence.DAppPolicy) testState {
+	state, close := setupCommand(t, Method_EthSendTransaction)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	policy.URL = testDAppData.URL
+	err = persistence.UpsertDAppPolicy(state.walletDb, &policy)
+	assert.NoError(t, err)
+
+	return state
+}
+
+func prepareSendTransactionToContract(t *testing.T, to types.Address, value int64, data string) RPCRequest {
+	request, err := ConstructRPCRequest(Method_EthSendTransaction, []interface{}{
+		map[string]interface{}{
+			"from":  types.Address{0x01}.Hex(),
+			"to":    to.Hex(),
+			"value": hexutil.EncodeBig(big.NewInt(value)),
+			"data":  data,
+		},
+	}, &testDAppData)
+	assert.NoError(t, err)
+	return request
+}
+
+func TestCheckDAppConnectionExpired(t *testing.T) {
+	state := setupDAppPolicyTest(t, persistence.DAppPolicy{
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+
+	err := CheckDAppConnection(state.walletDb, testDAppData.URL)
+	assert.Equal(t, ErrDAppConnectionExpired, err)
+
+	err = persistence.UpsertDAppPolicy(state.walletDb, &persistence.DAppPolicy{
+		URL:       testDAppData.URL,
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	assert.NoError(t, err)
+
+	err = CheckDAppConnection(state.walletDb, testDAppData.URL)
+	assert.NoError(t, err)
+}
+
+func TestFailToSendTransactionNotAllowedByPolicy(t *testing.T) {
+	state := setupDAppPolicyTest(t, persistence.DAppPolicy{
+		AllowedContracts: []types.Address{testPolicyContract},
+		AllowedMethods:   []types.HexBytes{{0xa9, 0x05, 0x9c, 0xbb}},
+		MaxValuePerTx:    (*hexutil.Big)(big.NewInt(100)),
+	})
+
+	_, err := state.cmd.Execute(state.ctx, prepareSendTransactionToContract(t, types.Address{0x02}, 0, "0x"))
+	assert.Equal(t, ErrContractNotAllowedByPolicy, err)
+
+	// approve(address,uint256)
+	_, err = state.cmd.Execute(state.ctx, prepareSendTransactionToContract(t, testPolicyContract, 0, "0x095ea7b3"))
+	assert.Equal(t, ErrMethodNotAllowedByPolicy, err)
+
+	_, err = state.cmd.Execute(state.ctx, prepareSendTransactionToContract(t, testPolicyContract, 101, "0xa9059cbb"))
+	assert.Equal(t, ErrValueExceedsTxLimit, err)
+}
+
+func TestFailToSendTransactionOverDailyLimit(t *testing.T) {
+	state := setupDAppPolicyTest(t, persistence.DAppPolicy{
+		MaxValuePerDay: (*hexutil.Big)(big.NewInt(100)),
+	})
+
+	// Spendings older than a day don't count
+	err := persistence.InsertDAppSpending(state.walletDb, testDAppData.URL, big.NewInt(100), time.Now().Add(-25*time.Hour).Unix())
+	assert.NoError(t, err)
+	err = recordDAppSpending(state.walletDb, testDAppData.URL, big.NewInt(60))
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, prepareSendTransactionToContract(t, testPolicyContract, 41, "0x"))
+	assert.Equal(t, ErrValueExceedsDailyLimit, err)
+
+	spent, err := persistence.SelectDAppSpendingSince(state.walletDb, testDAppData.URL, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(60), spent)
+}
+
+func TestCheckDAppPolicyOfSeveralTransactions(t *testing.T) {
+	state := setupDAppPolicyTest(t, persistence.DAppPolicy{
+		AllowedContracts: []types.Address{testPolicyContract},
+		MaxValuePerDay:   (*hexutil.Big)(big.NewInt(100)),
+	})
+
+	txArgs := []*wallettypes.SendTxArgs{
+		{From: types.Address{0x01}, To: &testPolicyContract, Value: (*hexutil.Big)(big.NewInt(50))},
+		{From: types.Address{0x01}, To: &testPolicyContract, Value: (*hexutil.Big)(big.NewInt(51))},
+	}
+
+	_, err := checkDAppPolicy(state.walletDb, testDAppData.URL, txArgs)
+	assert.Equal(t, ErrValueExceedsDailyLimit, err)
+
+	value, err := checkDAppPolicy(state.walletDb, testDAppData.URL, txArgs[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(50), value)
+}
+
+func TestLockDAppSpending(t *testing.T) {
+	unlock := lockDAppSpending(testDAppData.URL)
+
+	// Other dApps are not blocked
+	lockDAppSpending("https://other.dapp")()
+
+	locked := make(chan struct{})
+	go func() {
+		lockDAppSpending(testDAppData.URL)()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the spending of the dApp is locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("the spending of the dApp is not unlocked")
+	}
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/walletconnect"
+	"github.com/status-im/status-go/signal"
+)
+
+var (
+	ErrNoEncryptedDataFound = errors.New("no encrypted data in params found")
+	ErrEmptyKeyAccessResult = errors.New("no result for the key access request")
+)
+
+// requestKeyAccess returns the result of the method computed by the client with the key of the dApp's shared account,
+// like for personal_sign the client unlocks the key with the wallet API so the password never reaches the connector
+func requestKeyAccess(db *sql.DB, clientHandler ClientSideHandlerInterface, request RPCRequest, address types.Address, data string) (string, error) {
+	dApp, err := persistence.SelectDAppByUrl(db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	if address != dApp.SharedAccount {
+		return "", ErrAccountIsNotShared
+	}
+
+	result, err := clientHandler.RequestKeyAccess(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, address, request.Method, data)
+	if err != nil {
+		return "", err
+	}
+
+	if result == "" {
+		return "", ErrEmptyKeyAccessResult
+	}
+
+	return result, nil
+}
+
+func (r *RPCRequest) getAddressParam(index int) (types.Address, error) {
+	if len(r.Params) <= index {
+		return types.Address{}, ErrNoAccountParamsFound
+	}
+
+	address, ok := r.Params[index].(string)
+	if !ok || !types.IsHexAddress(address) {
+		return types.Address{}, ErrNoAccountParamsFound
+	}
+
+	return types.HexToAddress(address), nil
+}
+
+// GetEncryptionPublicKeyCommand returns the base64 encoded encryption public key of the shared account
+type GetEncryptionPublicKeyCommand struct {
+	Db            *sql.DB
+	ClientHandler ClientSideHandlerInterface
+}
+
+func (c *GetEncryptionPublicKeyCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	address, err := request.getAddressParam(0)
+	if err != nil {
+		return "", err
+	}
+
+	return requestKeyAccess(c.Db, c.ClientHandler, request, address, "")
+}
+
+// DecryptCommand decrypts a message encrypted for the encryption public key of the shared account
+type DecryptCommand struct {
+	Db            *sql.DB
+	ClientHandler ClientSideHandlerInterface
+}
+
+// getEncryptedData returns the JSON encoded message, the dApp hex encodes it
+func (r *RPCRequest) getEncryptedData() (string, *walletconnect.EncryptedData, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return "", nil, ErrEmptyRPCParams
+	}
+
+	encryptedHex, ok := r.Params[0].(string)
+	if !ok {
+		return "", nil, ErrNoEncryptedDataFound
+	}
+
+	encryptedBytes, err := hexutil.Decode(encryptedHex)
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding encrypted data: %v", err)
+	}
+
+	var encryptedData walletconnect.EncryptedData
+	err = json.Unmarshal(encryptedBytes, &encryptedData)
+	if err != nil {
+		return "", nil, fmt.Errorf("error unmarshalling encrypted data to EncryptedData: %v", err)
+	}
+
+	return string(encryptedBytes), &encryptedData, nil
+}
+
+func (c *DecryptCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	encryptedJson, encryptedData, err := request.getEncryptedData()
+	if err != nil {
+		return "", err
+	}
+
+	// Fail before prompting the user for a message that can't be decrypted
+	if encryptedData.Version != walletconnect.EncryptionVersionX25519 {
+		return "", walletconnect.ErrUnsupportedEncryptionVersion
+	}
+
+	address, err := request.getAddressParam(1)
+	if err != nil {
+		return "", err
+	}
+
+	return requestKeyAccess(c.Db, c.ClientHandler, request, address, encryptedJson)
+}
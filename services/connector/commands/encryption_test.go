@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/walletconnect"
+	"github.com/status-im/status-go/signal"
+)
+
+var testSharedAccount = types.Address{0x01}
+
+func setupEncryptionTest(t *testing.T, method string) testState {
+	state, close := setupCommand(t, method)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, testSharedAccount, uint64(0x1))
+	assert.NoError(t, err)
+
+	return state
+}
+
+// acceptKeyAccess answers with the result the client computed with the wallet API
+func acceptKeyAccess(t *testing.T, state testState, method string, data string, result string) {
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorKeyAccess:
+			var ev signal.ConnectorKeyAccessSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, method, ev.Method)
+			assert.Equal(t, testSharedAccount.Hex(), ev.Address)
+			assert.Equal(t, data, ev.Data)
+
+			err = state.handler.KeyAccessAccepted(KeyAccessAcceptedArgs{
+				RequestID: ev.RequestID,
+				Result:    result,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+}
+
+func TestFailToGetEncryptionPublicKeyOfNotSharedAccount(t *testing.T) {
+	state := setupEncryptionTest(t, Method_GetEncryptionPubKey)
+
+	request, err := ConstructRPCRequest(Method_GetEncryptionPubKey, []interface{}{types.Address{0x02}.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAccountIsNotShared, err)
+}
+
+func TestFailToGetEncryptionPublicKeyWithSignalRejected(t *testing.T) {
+	state := setupEncryptionTest(t, Method_GetEncryptionPubKey)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorKeyAccess:
+			var ev signal.ConnectorKeyAccessSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.KeyAccessRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	request, err := ConstructRPCRequest(Method_GetEncryptionPubKey, []interface{}{testSharedAccount.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrKeyAccessRejectedByUser, err)
+}
+
+func TestFailToGetEncryptionPublicKeyWithEmptyResult(t *testing.T) {
+	state := setupEncryptionTest(t, Method_GetEncryptionPubKey)
+	acceptKeyAccess(t, state, Method_GetEncryptionPubKey, "", "")
+
+	request, err := ConstructRPCRequest(Method_GetEncryptionPubKey, []interface{}{testSharedAccount.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrEmptyKeyAccessResult, err)
+}
+
+func TestGetEncryptionPublicKeyWithSignalAccepted(t *testing.T) {
+	state := setupEncryptionTest(t, Method_GetEncryptionPubKey)
+	publicKey := "mtrHOBWZrDRlr4zAoGzqvlnWMbeJQfmRSsYeHFl84xM="
+	acceptKeyAccess(t, state, Method_GetEncryptionPubKey, "", publicKey)
+
+	request, err := ConstructRPCRequest(Method_GetEncryptionPubKey, []interface{}{testSharedAccount.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, response)
+}
+
+func TestDecryptWithSignalAccepted(t *testing.T) {
+	state := setupEncryptionTest(t, Method_Decrypt)
+
+	encrypted, err := json.Marshal(walletconnect.EncryptedData{
+		Version:        walletconnect.EncryptionVersionX25519,
+		Nonce:          "1dvWO7uOnBnO7iNDJ9kO9pTasLuKNlej",
+		EphemPublicKey: "FBH1/pAEHOOW14Lu3FWkgV3qOEcuL78Zy+qW1RwzMXQ=",
+		Ciphertext:     "f8kBcl/NCyf3sybfbwAKk/np2Bzt9lRVkZejr6uh5FgnNlH/ic62DZzy",
+	})
+	assert.NoError(t, err)
+
+	// The client decrypts the JSON encoded message, not the hex encoded one sent by the dApp
+	message := "Hello, Bob!"
+	acceptKeyAccess(t, state, Method_Decrypt, string(encrypted), message)
+
+	request, err := ConstructRPCRequest(Method_Decrypt, []interface{}{hexutil.Encode(encrypted), testSharedAccount.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, message, response)
+}
+
+func TestFailToDecryptUnsupportedVersion(t *testing.T) {
+	state := setupEncryptionTest(t, Method_Decrypt)
+
+	encrypted, err := json.Marshal(walletconnect.EncryptedData{Version: "x25519-chacha20-poly1305"})
+	assert.NoError(t, err)
+
+	request, err := ConstructRPCRequest(Method_Decrypt, []interface{}{hexutil.Encode(encrypted), testSharedAccount.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, walletconnect.ErrUnsupportedEncryptionVersion, err)
+}
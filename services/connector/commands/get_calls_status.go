@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/rpc"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/transactions"
+)
+
+var (
+	ErrNoCallsBatchIDFound = errors.New("no calls batch id in params found")
+	ErrCallsBatchNotFound  = errors.New("calls batch not found")
+)
+
+// EIP-5792 batch status codes
+const (
+	CallsStatusPending           = 100
+	CallsStatusConfirmed         = 200
+	CallsStatusReverted          = 500
+	CallsStatusPartiallyReverted = 600
+)
+
+type CallsReceiptLog struct {
+	Address common.Address `json:"address"`
+	Data    hexutil.Bytes  `json:"data"`
+	Topics  []common.Hash  `json:"topics"`
+}
+
+type CallsReceipt struct {
+	Logs            []CallsReceiptLog `json:"logs"`
+	Status          hexutil.Uint64    `json:"status"`
+	BlockHash       common.Hash       `json:"blockHash"`
+	BlockNumber     *hexutil.Big      `json:"blockNumber"`
+	GasUsed         hexutil.Uint64    `json:"gasUsed"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+}
+
+// CallsStatusResponse is the EIP-5792 wallet_getCallsStatus response. The receipts are
+// only available once all the transactions of the batch are mined
+type CallsStatusResponse struct {
+	Version  string         `json:"version"`
+	ID       string         `json:"id"`
+	ChainID  hexutil.Uint64 `json:"chainId"`
+	Status   int            `json:"status"`
+	Atomic   bool           `json:"atomic"`
+	Receipts []CallsReceipt `json:"receipts,omitempty"`
+}
+
+type GetCallsStatusCommand struct {
+	RpcClient      rpc.ClientInterface
+	Db             *sql.DB
+	PendingTracker PendingTxTrackerInterface
+}
+
+func (r *RPCRequest) getCallsBatchID() (string, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return "", ErrEmptyRPCParams
+	}
+
+	id, ok := r.Params[0].(string)
+	if !ok || id == "" {
+		return "", ErrNoCallsBatchIDFound
+	}
+
+	return id, nil
+}
+
+func toCallsReceipt(receipt *gethtypes.Receipt) CallsReceipt {
+	logs := make([]CallsReceiptLog, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		logs = append(logs, CallsReceiptLog{
+			Address: log.Address,
+			Data:    log.Data,
+			Topics:  log.Topics,
+		})
+	}
+
+	return CallsReceipt{
+		Logs:            logs,
+		Status:          hexutil.Uint64(receipt.Status),
+		BlockHash:       receipt.BlockHash,
+		BlockNumber:     (*hexutil.Big)(receipt.BlockNumber),
+		GasUsed:         hexutil.Uint64(receipt.GasUsed),
+		TransactionHash: receipt.TxHash,
+	}
+}
+
+func (c *GetCallsStatusCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	batchID, err := request.getCallsBatchID()
+	if err != nil {
+		return "", err
+	}
+
+	// A dApp can only query its own batches
+	batch, err := persistence.SelectCallsBatch(c.Db, dApp.URL, batchID)
+	if err != nil {
+		return "", err
+	}
+
+	if batch == nil {
+		return "", ErrCallsBatchNotFound
+	}
+
+	response := CallsStatusResponse{
+		Version: CallsBatchVersion,
+		ID:      batch.ID,
+		ChainID: hexutil.Uint64(batch.ChainID),
+		Status:  CallsStatusPending,
+		Atomic:  batch.Atomic,
+	}
+
+	receipts, err := c.getReceipts(ctx, batch)
+	if err != nil {
+		return "", err
+	}
+
+	if receipts == nil {
+		return response, nil
+	}
+
+	succeeded := 0
+	for _, receipt := range receipts {
+		if receipt.Status == gethtypes.ReceiptStatusSuccessful {
+			succeeded++
+		}
+		response.Receipts = append(response.Receipts, toCallsReceipt(receipt))
+	}
+
+	// The calls not sent after a failing one count as failed
+	switch succeeded {
+	case max(batch.CallsCount, len(receipts)):
+		response.Status = CallsStatusConfirmed
+	case 0:
+		response.Status = CallsStatusReverted
+	default:
+		response.Status = CallsStatusPartiallyReverted
+	}
+
+	return response, nil
+}
+
+// getReceipts returns nil if any transaction of the batch is still pending
+func (c *GetCallsStatusCommand) getReceipts(ctx context.Context, batch *persistence.CallsBatch) ([]*gethtypes.Receipt, error) {
+	for _, hash := range batch.TxHashes {
+		entry, err := c.PendingTracker.GetPendingEntry(walletCommon.ChainID(batch.ChainID), common.Hash(hash))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil && entry.Status != nil && *entry.Status == transactions.Pending {
+			return nil, nil
+		}
+	}
+
+	ethClient, err := c.RpcClient.EthClient(batch.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*gethtypes.Receipt, 0, len(batch.TxHashes))
+	for _, hash := range batch.TxHashes {
+		receipt, err := ethClient.TransactionReceipt(ctx, common.Hash(hash))
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
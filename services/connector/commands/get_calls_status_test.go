@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/eth-node/types"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/transactions"
+)
+
+var testCallsBatch = persistence.CallsBatch{
+	ID:         "0xba7c4",
+	URL:        testDAppData.URL,
+	ChainID:    1,
+	From:       types.Address{0x01},
+	TxHashes:   []types.Hash{{0x51}, {0x52}},
+	CallsCount: 2,
+	CreatedAt:  100,
+}
+
+func setupCallsStatusTest(t *testing.T) testState {
+	state, close := setupCommand(t, Method_GetCallsStatus)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, testCallsBatch.From, testCallsBatch.ChainID)
+	assert.NoError(t, err)
+
+	err = persistence.InsertCallsBatch(state.walletDb, &testCallsBatch)
+	assert.NoError(t, err)
+
+	return state
+}
+
+func TestFailToGetStatusOfUnknownCallsBatch(t *testing.T) {
+	state := setupCallsStatusTest(t)
+
+	request, err := ConstructRPCRequest(Method_GetCallsStatus, []interface{}{"0x01"}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrCallsBatchNotFound, err)
+
+	// The batch of another dApp is not visible
+	otherDApp := testDAppData
+	otherDApp.URL = "http://otherDAppURL"
+	err = PersistDAppData(state.walletDb, otherDApp, testCallsBatch.From, testCallsBatch.ChainID)
+	assert.NoError(t, err)
+
+	request, err = ConstructRPCRequest(Method_GetCallsStatus, []interface{}{testCallsBatch.ID}, &otherDApp)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrCallsBatchNotFound, err)
+}
+
+func TestGetStatusOfPendingCallsBatch(t *testing.T) {
+	state := setupCallsStatusTest(t)
+
+	err := state.pendingTracker.TrackPendingTransaction(1, common.Hash(testCallsBatch.TxHashes[1]), common.Address{}, common.Address{},
+		transactions.ConnectorSendCalls, transactions.AutoDelete, testCallsBatch.ID)
+	assert.NoError(t, err)
+
+	request, err := ConstructRPCRequest(Method_GetCallsStatus, []interface{}{testCallsBatch.ID}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	status := response.(CallsStatusResponse)
+	assert.Equal(t, CallsStatusPending, status.Status)
+	assert.Equal(t, testCallsBatch.ID, status.ID)
+	assert.Empty(t, status.Receipts)
+}
+
+func TestGetStatusOfMinedCallsBatch(t *testing.T) {
+	state := setupCallsStatusTest(t)
+
+	receipt := func(hash types.Hash, status uint64) *gethtypes.Receipt {
+		return &gethtypes.Receipt{
+			Status:      status,
+			TxHash:      common.Hash(hash),
+			BlockNumber: big.NewInt(100),
+			GasUsed:     21000,
+			Logs: []*gethtypes.Log{
+				{Address: common.Address{0x02}, Topics: []common.Hash{{0x03}}, Data: []byte{0x04}},
+			},
+		}
+	}
+
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(2).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().TransactionReceipt(state.ctx, common.Hash(testCallsBatch.TxHashes[0])).Times(2).
+		Return(receipt(testCallsBatch.TxHashes[0], gethtypes.ReceiptStatusSuccessful), nil)
+	failedReceiptCall := mockedChainClient.EXPECT().TransactionReceipt(state.ctx, common.Hash(testCallsBatch.TxHashes[1])).Times(1).
+		Return(receipt(testCallsBatch.TxHashes[1], gethtypes.ReceiptStatusFailed), nil)
+	mockedChainClient.EXPECT().TransactionReceipt(state.ctx, common.Hash(testCallsBatch.TxHashes[1])).Times(1).After(failedReceiptCall).
+		Return(receipt(testCallsBatch.TxHashes[1], gethtypes.ReceiptStatusSuccessful), nil)
+
+	request, err := ConstructRPCRequest(Method_GetCallsStatus, []interface{}{testCallsBatch.ID}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	status := response.(CallsStatusResponse)
+	assert.Equal(t, CallsStatusPartiallyReverted, status.Status)
+	assert.Len(t, status.Receipts, 2)
+	assert.Equal(t, common.Hash(testCallsBatch.TxHashes[0]), status.Receipts[0].TransactionHash)
+	assert.Equal(t, []CallsReceiptLog{{Address: common.Address{0x02}, Topics: []common.Hash{{0x03}}, Data: []byte{0x04}}},
+		status.Receipts[0].Logs)
+
+	response, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, CallsStatusConfirmed, response.(CallsStatusResponse).Status)
+}
+
+func TestGetStatusOfPartiallySentCallsBatch(t *testing.T) {
+	state := setupCallsStatusTest(t)
+
+	// The second call failed to be sent, the third one was not sent after it
+	batch := testCallsBatch
+	batch.ID = "0xba7c5"
+	batch.TxHashes = []types.Hash{{0x53}}
+	batch.CallsCount = 3
+	err := persistence.InsertCallsBatch(state.walletDb, &batch)
+	assert.NoError(t, err)
+
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(1).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().TransactionReceipt(state.ctx, common.Hash(batch.TxHashes[0])).Times(1).
+		Return(&gethtypes.Receipt{Status: gethtypes.ReceiptStatusSuccessful, TxHash: common.Hash(batch.TxHashes[0]), BlockNumber: big.NewInt(100)}, nil)
+
+	request, err := ConstructRPCRequest(Method_GetCallsStatus, []interface{}{batch.ID}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	status := response.(CallsStatusResponse)
+	assert.Equal(t, CallsStatusPartiallyReverted, status.Status)
+	assert.Len(t, status.Receipts, 1)
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/connector/chainutils"
+	persistence "github.com/status-im/status-go/services/connector/database"
+)
+
+var (
+	ErrNoAccountParamsFound  = errors.New("no account in params found")
+	ErrAccountIsNotShared    = errors.New("account is not dApp's shared account")
+	ErrInvalidChainIDsParams = errors.New("invalid chain ids in params")
+)
+
+// EIP-5792 atomic capability statuses
+const (
+	AtomicStatusSupported   = "supported"
+	AtomicStatusUnsupported = "unsupported"
+)
+
+type AtomicCapability struct {
+	Status string `json:"status"`
+}
+
+type Capabilities struct {
+	Atomic AtomicCapability `json:"atomic"`
+}
+
+type GetCapabilitiesCommand struct {
+	Db             *sql.DB
+	NetworkManager *network.Manager
+}
+
+// getCapabilitiesParams returns the account and the optional list of chains to report
+func (r *RPCRequest) getCapabilitiesParams() (types.Address, []uint64, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return types.Address{}, nil, ErrEmptyRPCParams
+	}
+
+	account, ok := r.Params[0].(string)
+	if !ok || !types.IsHexAddress(account) {
+		return types.Address{}, nil, ErrNoAccountParamsFound
+	}
+
+	if len(r.Params) < 2 {
+		return types.HexToAddress(account), nil, nil
+	}
+
+	rawChainIDs, ok := r.Params[1].([]interface{})
+	if !ok {
+		return types.Address{}, nil, ErrInvalidChainIDsParams
+	}
+
+	chainIDs := make([]uint64, 0, len(rawChainIDs))
+	for _, rawChainID := range rawChainIDs {
+		hexChainID, ok := rawChainID.(string)
+		if !ok {
+			return types.Address{}, nil, ErrInvalidChainIDsParams
+		}
+		chainID, err := hexStringToUint64(hexChainID)
+		if err != nil {
+			return types.Address{}, nil, ErrInvalidChainIDsParams
+		}
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	return types.HexToAddress(account), chainIDs, nil
+}
+
+func (c *GetCapabilitiesCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	account, requestedChainIDs, err := request.getCapabilitiesParams()
+	if err != nil {
+		return "", err
+	}
+
+	if account != dApp.SharedAccount {
+		return "", ErrAccountIsNotShared
+	}
+
+	chainIDs, err := chainutils.GetSupportedChainIDs(c.NetworkManager)
+	if err != nil {
+		return "", err
+	}
+
+	capabilities := make(map[string]Capabilities)
+	for _, chainID := range chainIDs {
+		if requestedChainIDs != nil && !slices.Contains(requestedChainIDs, chainID) {
+			continue
+		}
+
+		// The calls of a batch are always sent as sequential transactions
+		capabilities[hexutil.EncodeUint64(chainID)] = Capabilities{
+			Atomic: AtomicCapability{Status: AtomicStatusUnsupported},
+		}
+	}
+
+	return capabilities, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+func TestFailToGetCapabilitiesOfNotSharedAccount(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCapabilities)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := ConstructRPCRequest(Method_GetCapabilities, []interface{}{types.Address{0x02}.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAccountIsNotShared, err)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCapabilities)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	// The calls are sent sequentially on every chain
+	request, err := ConstructRPCRequest(Method_GetCapabilities, []interface{}{accountAddress.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Capabilities{
+		"0x1": {Atomic: AtomicCapability{Status: AtomicStatusUnsupported}},
+		"0xa": {Atomic: AtomicCapability{Status: AtomicStatusUnsupported}},
+	}, response)
+
+	request, err = ConstructRPCRequest(Method_GetCapabilities, []interface{}{accountAddress.Hex(), []interface{}{"0xa"}}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Capabilities{
+		"0xa": {Atomic: AtomicCapability{Status: AtomicStatusUnsupported}},
+	}, response)
+}
@@ -1,15 +1,24 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/eip712"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
 )
 
 const (
@@ -18,10 +27,19 @@ const (
 	Method_EthChainId          = "eth_chainId"
 	Method_PersonalSign        = "personal_sign"
 	Method_SignTypedDataV4     = "eth_signTypedData_v4"
+	Method_SignTypedDataV3     = "eth_signTypedData_v3"
+	Method_SignTypedData       = "eth_signTypedData"
 	Method_EthSendTransaction  = "eth_sendTransaction"
 	Method_RequestPermissions  = "wallet_requestPermissions"
 	Method_RevokePermissions   = "wallet_revokePermissions"
 	Method_SwitchEthereumChain = "wallet_switchEthereumChain"
+	Method_SendCalls           = "wallet_sendCalls"
+	Method_GetCallsStatus      = "wallet_getCallsStatus"
+	Method_GetCapabilities     = "wallet_getCapabilities"
+	Method_AddEthereumChain    = "wallet_addEthereumChain"
+	Method_WatchAsset          = "wallet_watchAsset"
+	Method_GetEncryptionPubKey = "eth_getEncryptionPublicKey"
+	Method_Decrypt             = "eth_decrypt"
 )
 
 // errors
@@ -57,11 +75,30 @@ type SendTransactionAcceptedArgs struct {
 	Hash      types.Hash `json:"hash"`
 }
 
+// SendCallsAcceptedArgs carries the hashes of the sent transactions, in the order of the calls.
+// The client stops at the first call failing to be sent, the hashes are the ones of the calls before it
+type SendCallsAcceptedArgs struct {
+	RequestID string       `json:"requestId"`
+	Hashes    []types.Hash `json:"hashes"`
+}
+
 type SignAcceptedArgs struct {
 	RequestID string `json:"requestId"`
 	Signature string `json:"signature"`
 }
 
+// AcceptedArgs approves a request that doesn't return data to the dApp
+type AcceptedArgs struct {
+	RequestID string `json:"requestId"`
+}
+
+// KeyAccessAcceptedArgs approves a request that needs the private key of the shared account,
+// Result is the encryption public key for eth_getEncryptionPublicKey or the decrypted message for eth_decrypt
+type KeyAccessAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+	Result    string `json:"result"`
+}
+
 type RejectedArgs struct {
 	RequestID string `json:"requestId"`
 }
@@ -76,23 +113,94 @@ type ClientSideHandlerInterface interface {
 	RequestAccountsRejected(args RejectedArgs) error
 	RecallDAppPermissions(args RecallDAppPermissionsArgs) error
 
-	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs) (types.Hash, error)
+	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs, decodedCall *txdecoder.DecodedCall) (types.Hash, error)
 	SendTransactionAccepted(args SendTransactionAcceptedArgs) error
 	SendTransactionRejected(args RejectedArgs) error
 
-	RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string) (string, error)
+	RequestSendCalls(dApp signal.ConnectorDApp, chainID uint64, txArgs []*wallettypes.SendTxArgs, decodedCalls []*txdecoder.DecodedCall) ([]types.Hash, error)
+	SendCallsAccepted(args SendCallsAcceptedArgs) error
+	SendCallsRejected(args RejectedArgs) error
+
+	RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string, analysis *eip712.Analysis) (string, error)
 	SignAccepted(args SignAcceptedArgs) error
 	SignRejected(args RejectedArgs) error
+
+	RequestAddEthereumChain(dApp signal.ConnectorDApp, network *params.Network) error
+	AddEthereumChainAccepted(args AcceptedArgs) error
+	AddEthereumChainRejected(args RejectedArgs) error
+
+	RequestWatchAsset(dApp signal.ConnectorDApp, asset *token.Token) error
+	WatchAssetAccepted(args AcceptedArgs) error
+	WatchAssetRejected(args RejectedArgs) error
+
+	// RequestKeyAccess returns the result of the method computed by the client, method is eth_getEncryptionPublicKey or eth_decrypt
+	RequestKeyAccess(dApp signal.ConnectorDApp, address types.Address, method string, data string) (string, error)
+	KeyAccessAccepted(args KeyAccessAcceptedArgs) error
+	KeyAccessRejected(args RejectedArgs) error
 }
 
 type NetworkManagerInterface interface {
 	GetActiveNetworks() ([]*params.Network, error)
 }
 
+type TokenManagerInterface interface {
+	FindTokenByAddress(chainID uint64, address common.Address) *token.Token
+	DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error)
+	UpsertCustom(token token.Token) error
+	HasListedTokens(chainID uint64) bool
+}
+
+// TxDecoderInterface is implemented by txdecoder.Decoder
+type TxDecoderInterface interface {
+	Decode(ctx context.Context, chainID uint64, to *common.Address, data []byte, value *big.Int) (*txdecoder.DecodedCall, error)
+}
+
+// TypedDataAnalyzerInterface is implemented by eip712.Analyzer
+type TypedDataAnalyzerInterface interface {
+	Analyze(ctx context.Context, typedJson string, chainID uint64) (*eip712.Analysis, error)
+}
+
+type PendingTxTrackerInterface interface {
+	TrackPendingTransaction(chainID walletCommon.ChainID, hash common.Hash, from common.Address, to common.Address, trType transactions.PendingTrxType, autoDelete transactions.AutoDeleteType, additionalData string) error
+	GetPendingEntry(chainID walletCommon.ChainID, hash common.Hash) (*transactions.PendingTransaction, error)
+}
+
 type RPCClientInterface interface {
 	CallRaw(body string) string
 }
 
+// UnmarshalJSON accepts params sent as an object, like wallet_watchAsset (EIP-747) does,
+// by wrapping them in a single element array
+func (r *RPCRequest) UnmarshalJSON(data []byte) error {
+	type rpcRequest RPCRequest
+	aux := struct {
+		*rpcRequest
+		Params json.RawMessage `json:"params"`
+	}{
+		rpcRequest: (*rpcRequest)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Params = nil
+	params := bytes.TrimSpace(aux.Params)
+	if len(params) == 0 || bytes.Equal(params, []byte("null")) {
+		return nil
+	}
+
+	if params[0] == '{' {
+		var paramsObject map[string]interface{}
+		if err := json.Unmarshal(params, &paramsObject); err != nil {
+			return err
+		}
+		r.Params = []interface{}{paramsObject}
+		return nil
+	}
+
+	return json.Unmarshal(params, &r.Params)
+}
+
 func RPCRequestFromJSON(inputJSON string) (RPCRequest, error) {
 	var request RPCRequest
 
@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
+)
+
+var (
+	ErrNoSendCallsParamsFound   = errors.New("no send calls in params found")
+	ErrEmptyCalls               = errors.New("no calls to send")
+	ErrCallsChainIDMismatch     = errors.New("calls chain id is not dApp's active chain")
+	ErrAtomicBatchNotSupported  = errors.New("atomic batches are not supported")
+	ErrCallsBatchIDAlreadyUsed  = errors.New("calls batch id already used")
+	ErrInvalidSendCallsResponse = errors.New("unexpected number of transaction hashes for the calls")
+)
+
+// CallsBatchVersion is the EIP-5792 version of the batch requests and responses
+const CallsBatchVersion = "2.0.0"
+
+type Call struct {
+	To    *types.Address `json:"to"`
+	Data  types.HexBytes `json:"data"`
+	Value *hexutil.Big   `json:"value"`
+}
+
+// SendCallsParams is the EIP-5792 wallet_sendCalls request
+type SendCallsParams struct {
+	Version        string         `json:"version"`
+	ID             string         `json:"id"`
+	From           *types.Address `json:"from"`
+	ChainID        hexutil.Uint64 `json:"chainId"`
+	AtomicRequired bool           `json:"atomicRequired"`
+	Calls          []Call         `json:"calls"`
+}
+
+type SendCallsResponse struct {
+	ID string `json:"id"`
+}
+
+// SendCallsCommand sends the calls sequentially, one transaction per call with consecutive nonces.
+// Atomic batches are not supported. The client sends the calls in order and stops at the first one
+// failing to be sent, the following calls would be stuck behind the nonce gap.
+type SendCallsCommand struct {
+	RpcClient      rpc.ClientInterface
+	Db             *sql.DB
+	ClientHandler  ClientSideHandlerInterface
+	PendingTracker PendingTxTrackerInterface
+	TxDecoder      TxDecoderInterface
+}
+
+func (r *RPCRequest) getSendCallsParams() (*SendCallsParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoSendCallsParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling send calls param: %v", err)
+	}
+
+	var sendCallsParams SendCallsParams
+	err = json.Unmarshal(paramBytes, &sendCallsParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling send calls param to SendCallsParams: %v", err)
+	}
+
+	return &sendCallsParams, nil
+}
+
+func generateCallsBatchID() (string, error) {
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(id), nil
+}
+
+func (c *SendCallsCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	params, err := request.getSendCallsParams()
+	if err != nil {
+		return "", err
+	}
+
+	if params.From != nil && *params.From != dApp.SharedAccount {
+		return "", ErrParamsFromAddressIsNotShared
+	}
+
+	if uint64(params.ChainID) != dApp.ChainID {
+		return "", ErrCallsChainIDMismatch
+	}
+
+	if len(params.Calls) == 0 {
+		return "", ErrEmptyCalls
+	}
+
+	if params.AtomicRequired {
+		return "", ErrAtomicBatchNotSupported
+	}
+
+	batchID := params.ID
+	if batchID == "" {
+		batchID, err = generateCallsBatchID()
+		if err != nil {
+			return "", err
+		}
+	} else {
+		existingBatch, err := persistence.SelectCallsBatch(c.Db, dApp.URL, batchID)
+		if err != nil {
+			return "", err
+		}
+		if existingBatch != nil {
+			return "", ErrCallsBatchIDAlreadyUsed
+		}
+	}
+
+	fetchedFees, err := suggestFees(ctx, c.RpcClient, dApp.ChainID)
+	if err != nil {
+		return "", err
+	}
+
+	txArgs := make([]*wallettypes.SendTxArgs, 0, len(params.Calls))
+	for _, call := range params.Calls {
+		args := &wallettypes.SendTxArgs{
+			From:  dApp.SharedAccount,
+			To:    call.To,
+			Value: call.Value,
+			Data:  call.Data,
+		}
+		if args.Value == nil {
+			args.Value = (*hexutil.Big)(big.NewInt(0))
+		}
+		setFees(args, fetchedFees)
+		txArgs = append(txArgs, args)
+	}
+
+	unlockSpending := lockDAppSpending(dApp.URL)
+	defer unlockSpending()
+
+	_, err = checkDAppPolicy(c.Db, dApp.URL, txArgs)
+	if err != nil {
+		return "", err
+	}
+
+	// The calls are consecutive transactions of the shared account
+	ethClient, err := c.RpcClient.EthClient(dApp.ChainID)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := ethClient.PendingNonceAt(ctx, common.Address(dApp.SharedAccount))
+	if err != nil {
+		return "", err
+	}
+
+	for i, args := range txArgs {
+		callNonce := nonce + uint64(i)
+		args.Nonce = (*hexutil.Uint64)(&callNonce)
+	}
+
+	decodedCalls := make([]*txdecoder.DecodedCall, 0, len(txArgs))
+	for _, args := range txArgs {
+		decodedCalls = append(decodedCalls, decodeCall(ctx, c.TxDecoder, dApp.ChainID, args))
+	}
+
+	hashes, err := c.ClientHandler.RequestSendCalls(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, dApp.ChainID, txArgs, decodedCalls)
+	if err != nil {
+		return "", err
+	}
+
+	// The hashes are the ones of the calls sent before the first failing one
+	if len(hashes) == 0 || len(hashes) > len(txArgs) {
+		return "", ErrInvalidSendCallsResponse
+	}
+
+	sentValue := big.NewInt(0)
+	for _, args := range txArgs[:len(hashes)] {
+		sentValue.Add(sentValue, txValue(args))
+	}
+
+	err = recordDAppSpending(c.Db, dApp.URL, sentValue)
+	if err != nil {
+		return "", err
+	}
+
+	for i, hash := range hashes {
+		to := dApp.SharedAccount
+		if txArgs[i].To != nil {
+			to = *txArgs[i].To
+		}
+		err = c.trackTransaction(dApp.ChainID, hash, dApp.SharedAccount, to, batchID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = persistence.InsertCallsBatch(c.Db, &persistence.CallsBatch{
+		ID:         batchID,
+		URL:        dApp.URL,
+		ChainID:    dApp.ChainID,
+		From:       dApp.SharedAccount,
+		TxHashes:   hashes,
+		CallsCount: len(txArgs),
+		CreatedAt:  time.Now().Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return SendCallsResponse{ID: batchID}, nil
+}
+
+// trackTransaction tracks the transaction unless the client already did it when sending it
+func (c *SendCallsCommand) trackTransaction(chainID uint64, hash types.Hash, from types.Address, to types.Address, batchID string) error {
+	_, err := c.PendingTracker.GetPendingEntry(walletCommon.ChainID(chainID), common.Hash(hash))
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return c.PendingTracker.TrackPendingTransaction(walletCommon.ChainID(chainID), common.Hash(hash), common.Address(from),
+		common.Address(to), transactions.ConnectorSendCalls, transactions.AutoDelete, batchID)
+}
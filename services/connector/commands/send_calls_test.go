@@ -0,0 +1,313 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/eth-node/types"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
+)
+
+func prepareSendCallsRequest(dApp signal.ConnectorDApp, from types.Address, chainID string, atomicRequired bool) (RPCRequest, error) {
+	params := []interface{}{
+		map[string]interface{}{
+			"version":        CallsBatchVersion,
+			"from":           from.Hex(),
+			"chainId":        chainID,
+			"atomicRequired": atomicRequired,
+			"calls": []interface{}{
+				map[string]interface{}{
+					"to":    types.Address{0x02}.Hex(),
+					"value": "0x1",
+				},
+				map[string]interface{}{
+					"to":   types.Address{0x03}.Hex(),
+					"data": "0xa9059cbb",
+				},
+			},
+		},
+	}
+
+	return ConstructRPCRequest(Method_SendCalls, params, &dApp)
+}
+
+func expectSendCallsChainCalls(state testState, account types.Address) {
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).AnyTimes().Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().SuggestGasPrice(state.ctx).Times(1).Return(big.NewInt(1), nil)
+	mockedChainClient.EXPECT().SuggestGasTipCap(state.ctx).Times(1).Return(big.NewInt(0), errors.New("EIP-1559 is not enabled"))
+	mockedChainClient.EXPECT().PendingNonceAt(state.ctx, common.Address(account)).Times(1).Return(uint64(10), nil)
+}
+
+func TestFailToSendCallsWithoutPermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	// Don't save dApp in the database
+	request, err := prepareSendCallsRequest(testDAppData, types.Address{0x01}, "0x1", false)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToSendCallsWithWrongAddressOrChain(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, types.Address{0x02}, "0x1", false)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrParamsFromAddressIsNotShared, err)
+
+	request, err = prepareSendCallsRequest(testDAppData, types.Address{0x01}, "0xa", false)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrCallsChainIDMismatch, err)
+}
+
+func TestFailToSendAtomicCalls(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", true)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAtomicBatchNotSupported, err)
+}
+
+func TestSendCallsWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	fakedTransactionHashes := []types.Hash{{0x51}, {0x52}}
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", false)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var txArgs []wallettypes.SendTxArgs
+			err = json.Unmarshal([]byte(ev.TxArgs), &txArgs)
+			assert.NoError(t, err)
+			assert.Len(t, txArgs, 2)
+			// The calls are consecutive transactions
+			assert.Equal(t, uint64(10), uint64(*txArgs[0].Nonce))
+			assert.Equal(t, uint64(11), uint64(*txArgs[1].Nonce))
+			assert.Equal(t, big.NewInt(1), txArgs[0].Value.ToInt())
+			assert.Equal(t, big.NewInt(0), txArgs[1].Value.ToInt())
+
+			err = state.handler.SendCallsAccepted(SendCallsAcceptedArgs{
+				Hashes:    fakedTransactionHashes,
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsChainCalls(state, accountAddress)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	batchID := response.(SendCallsResponse).ID
+	assert.NotEmpty(t, batchID)
+
+	batch, err := persistence.SelectCallsBatch(state.walletDb, testDAppData.URL, batchID)
+	assert.NoError(t, err)
+	assert.Equal(t, testDAppData.URL, batch.URL)
+	assert.Equal(t, fakedTransactionHashes, batch.TxHashes)
+	assert.Equal(t, 2, batch.CallsCount)
+
+	for _, hash := range fakedTransactionHashes {
+		tracked, ok := state.pendingTracker.tracked[common.Hash(hash)]
+		assert.True(t, ok)
+		assert.Equal(t, transactions.ConnectorSendCalls, tracked.Type)
+		assert.Equal(t, batchID, tracked.AdditionalData)
+	}
+}
+
+func TestSendCallsFailingInTheMiddle(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	// The second call failed to be sent, only the first one is mined
+	fakedTransactionHash := types.Hash{0x51}
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", false)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.SendCallsAccepted(SendCallsAcceptedArgs{
+				Hashes:    []types.Hash{fakedTransactionHash},
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsChainCalls(state, accountAddress)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	batchID := response.(SendCallsResponse).ID
+
+	batch, err := persistence.SelectCallsBatch(state.walletDb, testDAppData.URL, batchID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{fakedTransactionHash}, batch.TxHashes)
+	assert.Equal(t, 2, batch.CallsCount)
+
+	assert.Len(t, state.pendingTracker.tracked, 1)
+	tracked, ok := state.pendingTracker.tracked[common.Hash(fakedTransactionHash)]
+	assert.True(t, ok)
+	assert.Equal(t, common.Address{0x02}, tracked.To)
+
+	// Only the value of the sent call counts for the daily limit
+	spent, err := persistence.SelectDAppSpendingSince(state.walletDb, testDAppData.URL, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), spent)
+}
+
+func TestFailToSendCallsWithTooManyHashes(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", false)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.SendCallsAccepted(SendCallsAcceptedArgs{
+				Hashes:    []types.Hash{{0x51}, {0x52}, {0x53}},
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsChainCalls(state, accountAddress)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrInvalidSendCallsResponse, err)
+	assert.Empty(t, state.pendingTracker.tracked)
+}
+
+func TestSendCallsWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", false)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.SendCallsRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsChainCalls(state, accountAddress)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSendCallsRejectedByUser, err)
+	assert.Empty(t, state.pendingTracker.tracked)
+}
+
+func TestSendCallsWithSignalTimeout(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "0x1", false)
+	assert.NoError(t, err)
+
+	backupWalletResponseMaxInterval := WalletResponseMaxInterval
+	WalletResponseMaxInterval = 1 * time.Millisecond
+
+	expectSendCallsChainCalls(state, accountAddress)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWalletResponseTimeout, err)
+	WalletResponseMaxInterval = backupWalletResponseMaxInterval
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/status-im/status-go/rpc"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
 )
@@ -27,6 +28,7 @@ type SendTransactionCommand struct {
 	RpcClient     rpc.ClientInterface
 	Db            *sql.DB
 	ClientHandler ClientSideHandlerInterface
+	TxDecoder     TxDecoderInterface
 }
 
 func (r *RPCRequest) getSendTransactionParams() (*wallettypes.SendTxArgs, error) {
@@ -53,6 +55,40 @@ func (r *RPCRequest) getSendTransactionParams() (*wallettypes.SendTxArgs, error)
 	return &sendTxArgs, nil
 }
 
+func suggestFees(ctx context.Context, rpcClient rpc.ClientInterface, chainID uint64) (*fees.SuggestedFees, error) {
+	feeManager := &fees.FeeManager{
+		RPCClient: rpcClient,
+	}
+	return feeManager.SuggestedFees(ctx, chainID)
+}
+
+func setFees(params *wallettypes.SendTxArgs, suggestedFees *fees.SuggestedFees) {
+	if !suggestedFees.EIP1559Enabled {
+		params.GasPrice = (*hexutil.Big)(suggestedFees.GasPrice)
+	} else {
+		params.MaxFeePerGas = (*hexutil.Big)(suggestedFees.FeeFor(fees.GasFeeMedium))
+		params.MaxPriorityFeePerGas = (*hexutil.Big)(suggestedFees.MaxPriorityFeePerGas)
+	}
+}
+
+// decodeCall decodes the calldata shown to the user, a call that can't be decoded is still sent
+func decodeCall(ctx context.Context, txDecoder TxDecoderInterface, chainID uint64, args *wallettypes.SendTxArgs) *txdecoder.DecodedCall {
+	if txDecoder == nil {
+		return nil
+	}
+
+	var to *common.Address
+	if args.To != nil {
+		to = (*common.Address)(args.To)
+	}
+
+	decodedCall, err := txDecoder.Decode(ctx, chainID, to, args.GetInput(), txValue(args))
+	if err != nil {
+		return nil
+	}
+	return decodedCall
+}
+
 func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
 	err := request.Validate()
 	if err != nil {
@@ -85,21 +121,20 @@ func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest
 		params.Value = (*hexutil.Big)(big.NewInt(0))
 	}
 
+	unlockSpending := lockDAppSpending(dApp.URL)
+	defer unlockSpending()
+
+	value, err := checkDAppPolicy(c.Db, dApp.URL, []*wallettypes.SendTxArgs{params})
+	if err != nil {
+		return "", err
+	}
+
 	if params.GasPrice == nil || (params.MaxFeePerGas == nil && params.MaxPriorityFeePerGas == nil) {
-		feeManager := &fees.FeeManager{
-			RPCClient: c.RpcClient,
-		}
-		fetchedFees, err := feeManager.SuggestedFees(ctx, dApp.ChainID)
+		fetchedFees, err := suggestFees(ctx, c.RpcClient, dApp.ChainID)
 		if err != nil {
 			return "", err
 		}
-
-		if !fetchedFees.EIP1559Enabled {
-			params.GasPrice = (*hexutil.Big)(fetchedFees.GasPrice)
-		} else {
-			params.MaxFeePerGas = (*hexutil.Big)(fetchedFees.FeeFor(fees.GasFeeMedium))
-			params.MaxPriorityFeePerGas = (*hexutil.Big)(fetchedFees.MaxPriorityFeePerGas)
-		}
+		setFees(params, fetchedFees)
 	}
 
 	if params.Nonce == nil {
@@ -120,9 +155,15 @@ func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, dApp.ChainID, params)
+	}, dApp.ChainID, params, decodeCall(ctx, c.TxDecoder, dApp.ChainID, params))
+	if err != nil {
+		return "", err
+	}
+
+	err = recordDAppSpending(c.Db, dApp.URL, value)
 	if err != nil {
 		return "", err
 	}
+
 	return hash.String(), nil
 }
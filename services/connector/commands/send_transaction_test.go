@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/eth-node/types"
 	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
 )
@@ -178,3 +179,57 @@ func TestSendTransactionWithSignalRejected(t *testing.T) {
 	_, err = state.cmd.Execute(state.ctx, request)
 	assert.Equal(t, ErrSendTransactionRejectedByUser, err)
 }
+
+func TestSendTransactionWithDecodedCall(t *testing.T) {
+	state, close := setupCommand(t, Method_EthSendTransaction)
+	t.Cleanup(close)
+
+	state.tokenManager.customs[testWatchedToken.Address] = &testWatchedToken
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	// transfer(0x02..., 1.5 DAI)
+	data := "0xa9059cbb" +
+		"0000000000000000000000000200000000000000000000000000000000000000" +
+		"00000000000000000000000000000000000000000000000014d1120d7b160000"
+	request := prepareSendTransactionToContract(t, types.Address(testWatchedToken.Address), 0, data)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendTransaction:
+			var ev signal.ConnectorSendTransactionSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var decodedCall txdecoder.DecodedCall
+			err = json.Unmarshal([]byte(ev.DecodedCall), &decodedCall)
+			assert.NoError(t, err)
+			assert.Equal(t, "transfer", decodedCall.Method)
+			assert.Equal(t, txdecoder.SummaryTransfer, decodedCall.Summary.Kind)
+			assert.Equal(t, "Send 1.5 DAI to "+common.Address{0x02}.Hex(), decodedCall.Summary.Text)
+
+			err = state.handler.SendTransactionAccepted(SendTransactionAcceptedArgs{
+				Hash:      types.Hash{0x051},
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(1).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().SuggestGasPrice(state.ctx).Times(1).Return(big.NewInt(1), nil)
+	mockedChainClient.EXPECT().SuggestGasTipCap(state.ctx).Times(1).Return(big.NewInt(0), errors.New("EIP-1559 is not enabled"))
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(1).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().PendingNonceAt(state.ctx, common.Address(accountAddress)).Times(1).Return(uint64(10), nil)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+}
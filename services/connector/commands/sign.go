@@ -3,10 +3,13 @@ package commands
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/typeddata"
+	"github.com/status-im/status-go/services/wallet/eip712"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -16,8 +19,9 @@ var (
 )
 
 type SignCommand struct {
-	Db            *sql.DB
-	ClientHandler ClientSideHandlerInterface
+	Db                *sql.DB
+	ClientHandler     ClientSideHandlerInterface
+	TypedDataAnalyzer TypedDataAnalyzerInterface
 }
 
 type SignParams struct {
@@ -27,7 +31,9 @@ type SignParams struct {
 }
 
 func (r *RPCRequest) getSignParams() (*SignParams, error) {
-	if r.Method != Method_PersonalSign && r.Method != Method_SignTypedDataV4 {
+	switch r.Method {
+	case Method_PersonalSign, Method_SignTypedData, Method_SignTypedDataV3, Method_SignTypedDataV4:
+	default:
 		return nil, ErrInvalidMethod
 	}
 
@@ -42,7 +48,8 @@ func (r *RPCRequest) getSignParams() (*SignParams, error) {
 	challengeIndex := 0
 	addressIndex := 1
 
-	if r.Method == Method_SignTypedDataV4 {
+	// eth_signTypedData (v1) keeps the personal_sign order
+	if r.Method == Method_SignTypedDataV3 || r.Method == Method_SignTypedDataV4 {
 		challengeIndex = 1
 		addressIndex = 0
 	}
@@ -50,7 +57,15 @@ func (r *RPCRequest) getSignParams() (*SignParams, error) {
 	// Extract the Challenge and Address fields from paramsArray
 	challenge, ok := r.Params[challengeIndex].(string)
 	if !ok {
-		return nil, fmt.Errorf("missing or invalid 'challenge' field")
+		// Typed data can be sent as a JSON value instead of a JSON string
+		if r.Method == Method_PersonalSign || r.Params[challengeIndex] == nil {
+			return nil, fmt.Errorf("missing or invalid 'challenge' field")
+		}
+		challengeBytes, err := json.Marshal(r.Params[challengeIndex])
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'challenge' field")
+		}
+		challenge = string(challengeBytes)
 	}
 
 	address, ok := r.Params[addressIndex].(string)
@@ -58,6 +73,16 @@ func (r *RPCRequest) getSignParams() (*SignParams, error) {
 		return nil, fmt.Errorf("missing or invalid 'address' field")
 	}
 
+	if r.Method == Method_SignTypedData {
+		var fields []typeddata.LegacyField
+		if err := json.Unmarshal([]byte(challenge), &fields); err != nil {
+			return nil, fmt.Errorf("invalid legacy typed data: %v", err)
+		}
+		if _, err := typeddata.HashLegacy(fields); err != nil {
+			return nil, fmt.Errorf("invalid legacy typed data: %v", err)
+		}
+	}
+
 	// Create and return the PersonalSignParams
 	return &SignParams{
 		Challenge: challenge,
@@ -66,6 +91,23 @@ func (r *RPCRequest) getSignParams() (*SignParams, error) {
 	}, nil
 }
 
+// analyzeTypedData breaks down EIP-712 messages for the user, the request is still sent to the client
+// when the message can't be analyzed, like it is when the calldata of a transaction can't be decoded
+func analyzeTypedData(ctx context.Context, analyzer TypedDataAnalyzerInterface, chainID uint64, params *SignParams) *eip712.Analysis {
+	if analyzer == nil {
+		return nil
+	}
+	if params.Method != Method_SignTypedDataV3 && params.Method != Method_SignTypedDataV4 {
+		return nil
+	}
+
+	analysis, err := analyzer.Analyze(ctx, params.Challenge, chainID)
+	if err != nil {
+		return nil
+	}
+	return analysis
+}
+
 func (c *SignCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
 	err := request.Validate()
 	if err != nil {
@@ -90,5 +132,5 @@ func (c *SignCommand) Execute(ctx context.Context, request RPCRequest) (interfac
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, params.Challenge, params.Address, params.Method)
+	}, params.Challenge, params.Address, params.Method, analyzeTypedData(ctx, c.TypedDataAnalyzer, dApp.ChainID, params))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/eip712"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -232,6 +233,90 @@ func TestTypedDataV4SignRequestWithSignalRejected(t *testing.T) {
 	assert.Equal(t, ErrSignRejectedByUser, err)
 }
 
+func TestTypedDataV4SignRequestWithAnalysis(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	// Unlimited EIP-2612 permit of an unknown spender, for another chain than the dApp's one
+	challenge := "{\"domain\":{\"name\":\"USD Coin\",\"version\":\"2\",\"chainId\":10,\"verifyingContract\":\"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48\"},\"message\":{\"owner\":\"0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7\",\"spender\":\"0x000000000000000000000000000000000000bad0\",\"value\":\"115792089237316195423570985008687907853269984665640564039457584007913129639935\",\"nonce\":\"0\",\"deadline\":\"1767225600\"},\"primaryType\":\"Permit\",\"types\":{\"EIP712Domain\":[{\"name\":\"name\",\"type\":\"string\"},{\"name\":\"version\",\"type\":\"string\"},{\"name\":\"chainId\",\"type\":\"uint256\"},{\"name\":\"verifyingContract\",\"type\":\"address\"}],\"Permit\":[{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"spender\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"nonce\",\"type\":\"uint256\"},{\"name\":\"deadline\",\"type\":\"uint256\"}]}}"
+	address := "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7"
+	request, err := prepareTypedDataV4SignRequest(testDAppData, challenge, address)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSign:
+			var ev signal.ConnectorSignSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, ev.Challenge, challenge)
+
+			var analysis eip712.Analysis
+			err = json.Unmarshal([]byte(ev.TypedDataAnalysis), &analysis)
+			assert.NoError(t, err)
+			assert.Equal(t, eip712.KindPermit, analysis.Kind)
+			assert.Len(t, analysis.Approvals, 1)
+			assert.True(t, analysis.Approvals[0].Unlimited)
+
+			codes := make([]eip712.WarningCode, 0, len(analysis.Warnings))
+			for _, warning := range analysis.Warnings {
+				codes = append(codes, warning.Code)
+			}
+			assert.Equal(t, []eip712.WarningCode{eip712.WarningChainMismatch, eip712.WarningUnlimitedAllowance, eip712.WarningUnknownSpender}, codes)
+
+			err = state.handler.SignRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSignRejectedByUser, err)
+}
+
+func TestPersonalSignRequestHasNoAnalysis(t *testing.T) {
+	state, close := setupCommand(t, Method_PersonalSign)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := preparePersonalSignRequest(testDAppData, "0x48656c6c6f", "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7")
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSign:
+			var ev signal.ConnectorSignSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Empty(t, ev.TypedDataAnalysis)
+
+			err = state.handler.SignRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSignRejectedByUser, err)
+}
+
 func TestUnsupportedSignMethod(t *testing.T) {
 	state, close := setupCommand(t, Method_PersonalSign)
 	t.Cleanup(close)
@@ -244,7 +329,7 @@ func TestUnsupportedSignMethod(t *testing.T) {
 	request, err := preparePersonalSignRequest(testDAppData, challenge, address)
 	assert.NoError(t, err)
 
-	request.Method = "eth_signTypedData"
+	request.Method = "eth_sign"
 	fakedSignature := "0x051"
 
 	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
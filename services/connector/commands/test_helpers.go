@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/appdatabase"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
@@ -16,8 +19,12 @@ import (
 	"github.com/status-im/status-go/rpc/network"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/eip712"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/transactions"
 	"github.com/status-im/status-go/walletdatabase"
 )
 
@@ -46,14 +53,95 @@ func createWalletDB(t *testing.T) (db *sql.DB, close func()) {
 	}
 }
 
+// fakePendingTracker keeps the tracked transactions in memory
+type fakePendingTracker struct {
+	tracked map[common.Hash]*transactions.PendingTransaction
+}
+
+func newFakePendingTracker() *fakePendingTracker {
+	return &fakePendingTracker{
+		tracked: make(map[common.Hash]*transactions.PendingTransaction),
+	}
+}
+
+func (f *fakePendingTracker) TrackPendingTransaction(chainID walletCommon.ChainID, hash common.Hash, from common.Address, to common.Address, trType transactions.PendingTrxType, autoDelete transactions.AutoDeleteType, additionalData string) error {
+	status := transactions.Pending
+	f.tracked[hash] = &transactions.PendingTransaction{
+		ChainID:        chainID,
+		Hash:           hash,
+		From:           from,
+		To:             to,
+		Type:           trType,
+		AdditionalData: additionalData,
+		Status:         &status,
+		AutoDelete:     &autoDelete,
+	}
+	return nil
+}
+
+func (f *fakePendingTracker) GetPendingEntry(chainID walletCommon.ChainID, hash common.Hash) (*transactions.PendingTransaction, error) {
+	tx, ok := f.tracked[hash]
+	if !ok || tx.ChainID != chainID {
+		return nil, sql.ErrNoRows
+	}
+	return tx, nil
+}
+
+// fakeTokenManager discovers the tokens set in contracts, keeps the custom tokens in memory and lists the tokens
+// of listedChains
+type fakeTokenManager struct {
+	contracts    map[common.Address]*token.Token
+	customs      map[common.Address]*token.Token
+	listedChains map[uint64]bool
+}
+
+func newFakeTokenManager() *fakeTokenManager {
+	return &fakeTokenManager{
+		contracts:    make(map[common.Address]*token.Token),
+		customs:      make(map[common.Address]*token.Token),
+		listedChains: make(map[uint64]bool),
+	}
+}
+
+func (f *fakeTokenManager) FindTokenByAddress(chainID uint64, address common.Address) *token.Token {
+	t, ok := f.customs[address]
+	if !ok || t.ChainID != chainID {
+		return nil
+	}
+	return t
+}
+
+func (f *fakeTokenManager) DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error) {
+	t, ok := f.contracts[address]
+	if !ok || t.ChainID != chainID {
+		return nil, errors.New("no token contract found")
+	}
+	discovered := *t
+	return &discovered, nil
+}
+
+func (f *fakeTokenManager) UpsertCustom(t token.Token) error {
+	f.customs[t.Address] = &t
+	return nil
+}
+
+func (f *fakeTokenManager) HasListedTokens(chainID uint64) bool {
+	return f.listedChains[chainID]
+}
+
 type testState struct {
-	ctx       context.Context
-	db        *sql.DB
-	walletDb  *sql.DB
-	cmd       RPCCommand
-	handler   *ClientSideHandler
-	mockCtrl  *gomock.Controller
-	rpcClient *mock_rpcclient.MockClientInterface
+	ctx            context.Context
+	db             *sql.DB
+	walletDb       *sql.DB
+	cmd            RPCCommand
+	handler        *ClientSideHandler
+	mockCtrl       *gomock.Controller
+	rpcClient      *mock_rpcclient.MockClientInterface
+	pendingTracker *fakePendingTracker
+	networkManager *network.Manager
+	tokenManager   *fakeTokenManager
+	txDecoder      *txdecoder.Decoder
+	analyzer       *eip712.Analyzer
 }
 
 func setupCommand(t *testing.T, method string) (state testState, close func()) {
@@ -85,6 +173,11 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 
 	state.mockCtrl = gomock.NewController(t)
 	state.rpcClient = mock_rpcclient.NewMockClientInterface(state.mockCtrl)
+	state.pendingTracker = newFakePendingTracker()
+	state.networkManager = networkManager
+	state.tokenManager = newFakeTokenManager()
+	state.txDecoder = txdecoder.NewDecoder(txdecoder.NewDatabase(state.walletDb), nil, state.tokenManager)
+	state.analyzer = eip712.NewAnalyzer(state.tokenManager, nil)
 
 	switch method {
 	case Method_EthAccounts:
@@ -106,16 +199,37 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 			Db:            state.walletDb,
 			ClientHandler: state.handler,
 		}
-	case Method_SignTypedDataV4:
+	case Method_SignTypedData, Method_SignTypedDataV3, Method_SignTypedDataV4:
 		state.cmd = &SignCommand{
-			Db:            state.walletDb,
-			ClientHandler: state.handler,
+			Db:                state.walletDb,
+			ClientHandler:     state.handler,
+			TypedDataAnalyzer: state.analyzer,
 		}
 	case Method_EthSendTransaction:
 		state.cmd = &SendTransactionCommand{
 			Db:            state.walletDb,
 			ClientHandler: state.handler,
 			RpcClient:     state.rpcClient,
+			TxDecoder:     state.txDecoder,
+		}
+	case Method_SendCalls:
+		state.cmd = &SendCallsCommand{
+			Db:             state.walletDb,
+			ClientHandler:  state.handler,
+			RpcClient:      state.rpcClient,
+			PendingTracker: state.pendingTracker,
+			TxDecoder:      state.txDecoder,
+		}
+	case Method_GetCallsStatus:
+		state.cmd = &GetCallsStatusCommand{
+			Db:             state.walletDb,
+			RpcClient:      state.rpcClient,
+			PendingTracker: state.pendingTracker,
+		}
+	case Method_GetCapabilities:
+		state.cmd = &GetCapabilitiesCommand{
+			Db:             state.walletDb,
+			NetworkManager: networkManager,
 		}
 	case Method_RequestPermissions:
 		state.cmd = &RequestPermissionsCommand{}
@@ -128,6 +242,29 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 			Db:             state.walletDb,
 			NetworkManager: networkManager,
 		}
+	case Method_AddEthereumChain:
+		state.cmd = &AddEthereumChainCommand{
+			Db:             state.walletDb,
+			ClientHandler:  state.handler,
+			NetworkManager: networkManager,
+			TokenManager:   state.tokenManager,
+		}
+	case Method_WatchAsset:
+		state.cmd = &WatchAssetCommand{
+			Db:            state.walletDb,
+			ClientHandler: state.handler,
+			TokenManager:  state.tokenManager,
+		}
+	case Method_GetEncryptionPubKey:
+		state.cmd = &GetEncryptionPublicKeyCommand{
+			Db:            state.walletDb,
+			ClientHandler: state.handler,
+		}
+	case Method_Decrypt:
+		state.cmd = &DecryptCommand{
+			Db:            state.walletDb,
+			ClientHandler: state.handler,
+		}
 	}
 
 	return state, func() {
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/signal"
+)
+
+var (
+	ErrNoWatchAssetParamsFound = errors.New("no watch asset in params found")
+	ErrUnsupportedAssetType    = errors.New("only ERC20 assets are supported")
+	ErrAssetSymbolMismatch     = errors.New("asset symbol doesn't match the token contract")
+	ErrAssetDecimalsMismatch   = errors.New("asset decimals don't match the token contract")
+)
+
+const AssetTypeERC20 = "ERC20"
+
+type WatchAssetOptions struct {
+	Address  common.Address `json:"address"`
+	Symbol   string         `json:"symbol"`
+	Decimals *uint          `json:"decimals"`
+	Image    string         `json:"image"`
+}
+
+// WatchAssetParams is the EIP-747 wallet_watchAsset request
+type WatchAssetParams struct {
+	Type    string            `json:"type"`
+	Options WatchAssetOptions `json:"options"`
+}
+
+// WatchAssetCommand adds an ERC20 token of the dApp's chain to the custom tokens
+type WatchAssetCommand struct {
+	Db            *sql.DB
+	ClientHandler ClientSideHandlerInterface
+	TokenManager  TokenManagerInterface
+}
+
+func (r *RPCRequest) getWatchAssetParams() (*WatchAssetParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoWatchAssetParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling watch asset param: %v", err)
+	}
+
+	var watchAssetParams WatchAssetParams
+	err = json.Unmarshal(paramBytes, &watchAssetParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling watch asset param to WatchAssetParams: %v", err)
+	}
+
+	return &watchAssetParams, nil
+}
+
+func (c *WatchAssetCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	params, err := request.getWatchAssetParams()
+	if err != nil {
+		return "", err
+	}
+
+	if params.Type != AssetTypeERC20 {
+		return "", ErrUnsupportedAssetType
+	}
+
+	if c.TokenManager.FindTokenByAddress(dApp.ChainID, params.Options.Address) != nil {
+		return true, nil
+	}
+
+	// The token details shown to the user come from the contract, not from the dApp
+	asset, err := c.TokenManager.DiscoverToken(ctx, dApp.ChainID, params.Options.Address)
+	if err != nil {
+		return "", err
+	}
+
+	if params.Options.Symbol != "" && !strings.EqualFold(params.Options.Symbol, asset.Symbol) {
+		return "", ErrAssetSymbolMismatch
+	}
+
+	if params.Options.Decimals != nil && *params.Options.Decimals != asset.Decimals {
+		return "", ErrAssetDecimalsMismatch
+	}
+
+	asset.Image = params.Options.Image
+
+	err = c.ClientHandler.RequestWatchAsset(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, asset)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.TokenManager.UpsertCustom(*asset)
+	if err != nil {
+		return "", err
+	}
+
+	return true, nil
+}
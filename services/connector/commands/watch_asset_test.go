@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/signal"
+)
+
+var testWatchedToken = token.Token{
+	Address:  common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"),
+	Name:     "Dai Stablecoin",
+	Symbol:   "DAI",
+	Decimals: 18,
+	ChainID:  1,
+}
+
+func prepareWatchAssetRequest(dApp signal.ConnectorDApp, symbol string, decimals uint) (RPCRequest, error) {
+	// wallet_watchAsset params are an object instead of an array
+	request, err := RPCRequestFromJSON(fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "wallet_watchAsset",
+		"params": {
+			"type": "ERC20",
+			"options": {
+				"address": "%s",
+				"symbol": "%s",
+				"decimals": %d,
+				"image": "https://example.com/dai.png"
+			}
+		},
+		"url": "%s",
+		"name": "%s",
+		"iconUrl": "%s"
+	}`, testWatchedToken.Address.Hex(), symbol, decimals, dApp.URL, dApp.Name, dApp.IconURL))
+	return request, err
+}
+
+func TestFailToWatchAssetForUnpermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "DAI", 18)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToWatchAssetWithMismatchingDetails(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	state.tokenManager.contracts[testWatchedToken.Address] = &testWatchedToken
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "USDC", 18)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAssetSymbolMismatch, err)
+
+	request, err = prepareWatchAssetRequest(testDAppData, "DAI", 6)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAssetDecimalsMismatch, err)
+	assert.Empty(t, state.tokenManager.customs)
+}
+
+func TestWatchAssetWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	state.tokenManager.contracts[testWatchedToken.Address] = &testWatchedToken
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "DAI", 18)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var asset token.Token
+			err = json.Unmarshal([]byte(ev.Token), &asset)
+			assert.NoError(t, err)
+			assert.Equal(t, testWatchedToken.Address, asset.Address)
+			assert.Equal(t, "https://example.com/dai.png", asset.Image)
+
+			err = state.handler.WatchAssetAccepted(AcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, true, response)
+	assert.NotNil(t, state.tokenManager.FindTokenByAddress(1, testWatchedToken.Address))
+
+	// Watching a known token succeeds without asking the user
+	signal.ResetMobileSignalHandler()
+	response, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, true, response)
+}
+
+func TestWatchAssetWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	state.tokenManager.contracts[testWatchedToken.Address] = &testWatchedToken
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "DAI", 18)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.WatchAssetRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWatchAssetRejectedByUser, err)
+	assert.Empty(t, state.tokenManager.customs)
+}
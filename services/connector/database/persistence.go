@@ -2,7 +2,11 @@ package persistence
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/eth-node/types"
 )
 
@@ -10,6 +14,46 @@ const upsertDAppQuery = "INSERT INTO connector_dapps (url, name, icon_url, share
 const selectDAppByUrlQuery = "SELECT name, icon_url, shared_account, chain_id FROM connector_dapps WHERE url = ?"
 const selectDAppsQuery = "SELECT url, name, icon_url, shared_account, chain_id FROM connector_dapps"
 const deleteDAppQuery = "DELETE FROM connector_dapps WHERE url = ?"
+const upsertDAppPolicyQuery = "INSERT INTO connector_dapp_policies (url, allowed_contracts, allowed_methods, max_value_per_tx, max_value_per_day, expires_at) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT(url) DO UPDATE SET allowed_contracts = excluded.allowed_contracts, allowed_methods = excluded.allowed_methods, max_value_per_tx = excluded.max_value_per_tx, max_value_per_day = excluded.max_value_per_day, expires_at = excluded.expires_at"
+const selectDAppPolicyQuery = "SELECT allowed_contracts, allowed_methods, max_value_per_tx, max_value_per_day, expires_at FROM connector_dapp_policies WHERE url = ?"
+const deleteDAppPolicyQuery = "DELETE FROM connector_dapp_policies WHERE url = ?"
+const insertDAppSpendingQuery = "INSERT INTO connector_dapp_spendings (url, value, created_at) VALUES (?, ?, ?)"
+const selectDAppSpendingsQuery = "SELECT value FROM connector_dapp_spendings WHERE url = ? AND created_at >= ?"
+const deleteDAppSpendingsQuery = "DELETE FROM connector_dapp_spendings WHERE url = ?"
+const deleteDAppSpendingsBeforeQuery = "DELETE FROM connector_dapp_spendings WHERE url = ? AND created_at < ?"
+const insertCallsBatchQuery = "INSERT INTO connector_call_batches (id, url, chain_id, from_address, atomic, tx_hashes, calls_count, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+const selectCallsBatchQuery = "SELECT chain_id, from_address, atomic, tx_hashes, calls_count, created_at FROM connector_call_batches WHERE url = ? AND id = ?"
+
+var errInvalidStoredValue = errors.New("invalid stored value")
+
+// CallsBatch is a batch of calls sent by a dApp with wallet_sendCalls
+type CallsBatch struct {
+	ID       string        `json:"id"`
+	URL      string        `json:"url"`
+	ChainID  uint64        `json:"chainId"`
+	From     types.Address `json:"from"`
+	Atomic   bool          `json:"atomic"`
+	TxHashes []types.Hash  `json:"txHashes"`
+	// CallsCount can be more than the sent transactions, the calls after a failing one are not sent
+	CallsCount int `json:"callsCount"`
+	// CreatedAt is a unix timestamp in seconds
+	CreatedAt int64 `json:"createdAt"`
+}
+
+// DAppPolicy restricts the transactions a connected dApp can request, empty fields don't restrict anything
+type DAppPolicy struct {
+	URL              string          `json:"url"`
+	AllowedContracts []types.Address `json:"allowedContracts"`
+	// AllowedMethods are the 4 bytes selectors of the contract methods the dApp can call
+	AllowedMethods []types.HexBytes `json:"allowedMethods"`
+	// MaxValuePerTx and MaxValuePerDay limit the native token value of the transactions, in wei. The tokens
+	// moved by the called contracts, like the ERC-20 transfer and approve amounts, don't count toward them,
+	// AllowedContracts and AllowedMethods restrict those calls
+	MaxValuePerTx  *hexutil.Big `json:"maxValuePerTx,omitempty"`
+	MaxValuePerDay *hexutil.Big `json:"maxValuePerDay,omitempty"`
+	// ExpiresAt is a unix timestamp in seconds after which the dApp connection expires, 0 never expires
+	ExpiresAt int64 `json:"expiresAt"`
+}
 
 type DApp struct {
 	URL           string        `json:"url"`
@@ -54,7 +98,144 @@ func SelectAllDApps(db *sql.DB) ([]DApp, error) {
 	return dApps, nil
 }
 
+// DeleteDApp removes the dApp with its policy and spendings
 func DeleteDApp(db *sql.DB, url string) error {
 	_, err := db.Exec(deleteDAppQuery, url)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(deleteDAppSpendingsQuery, url)
+	if err != nil {
+		return err
+	}
+	return DeleteDAppPolicy(db, url)
+}
+
+func nullableBigString(value *hexutil.Big) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value.ToInt().String(), Valid: true}
+}
+
+func parseNullableBig(value sql.NullString) (*hexutil.Big, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	result, ok := new(big.Int).SetString(value.String, 10)
+	if !ok {
+		return nil, errInvalidStoredValue
+	}
+	return (*hexutil.Big)(result), nil
+}
+
+func UpsertDAppPolicy(db *sql.DB, policy *DAppPolicy) error {
+	allowedContracts, err := json.Marshal(policy.AllowedContracts)
+	if err != nil {
+		return err
+	}
+	allowedMethods, err := json.Marshal(policy.AllowedMethods)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(upsertDAppPolicyQuery, policy.URL, string(allowedContracts), string(allowedMethods),
+		nullableBigString(policy.MaxValuePerTx), nullableBigString(policy.MaxValuePerDay), policy.ExpiresAt)
 	return err
 }
+
+func SelectDAppPolicy(db *sql.DB, url string) (*DAppPolicy, error) {
+	policy := &DAppPolicy{
+		URL: url,
+	}
+	var allowedContracts, allowedMethods string
+	var maxValuePerTx, maxValuePerDay sql.NullString
+	err := db.QueryRow(selectDAppPolicyQuery, url).Scan(&allowedContracts, &allowedMethods, &maxValuePerTx, &maxValuePerDay, &policy.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(allowedContracts), &policy.AllowedContracts); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(allowedMethods), &policy.AllowedMethods); err != nil {
+		return nil, err
+	}
+	if policy.MaxValuePerTx, err = parseNullableBig(maxValuePerTx); err != nil {
+		return nil, err
+	}
+	if policy.MaxValuePerDay, err = parseNullableBig(maxValuePerDay); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func DeleteDAppPolicy(db *sql.DB, url string) error {
+	_, err := db.Exec(deleteDAppPolicyQuery, url)
+	return err
+}
+
+// InsertDAppSpending records the value sent by the dApp at the given unix timestamp
+func InsertDAppSpending(db *sql.DB, url string, value *big.Int, timestamp int64) error {
+	_, err := db.Exec(insertDAppSpendingQuery, url, value.String(), timestamp)
+	return err
+}
+
+// SelectDAppSpendingSince returns the total value sent by the dApp since the given unix timestamp
+func SelectDAppSpendingSince(db *sql.DB, url string, since int64) (*big.Int, error) {
+	rows, err := db.Query(selectDAppSpendingsQuery, url, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	total := big.NewInt(0)
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		spending, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, errInvalidStoredValue
+		}
+		total.Add(total, spending)
+	}
+	return total, rows.Err()
+}
+
+// DeleteDAppSpendingsBefore removes the spendings of the dApp older than the given unix timestamp
+func DeleteDAppSpendingsBefore(db *sql.DB, url string, before int64) error {
+	_, err := db.Exec(deleteDAppSpendingsBeforeQuery, url, before)
+	return err
+}
+
+func InsertCallsBatch(db *sql.DB, batch *CallsBatch) error {
+	txHashes, err := json.Marshal(batch.TxHashes)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(insertCallsBatchQuery, batch.ID, batch.URL, batch.ChainID, batch.From, batch.Atomic, string(txHashes), batch.CallsCount, batch.CreatedAt)
+	return err
+}
+
+// SelectCallsBatch returns the batch sent by the dApp with the given id, batch ids are only unique per dApp
+func SelectCallsBatch(db *sql.DB, url string, id string) (*CallsBatch, error) {
+	batch := &CallsBatch{
+		ID:  id,
+		URL: url,
+	}
+	var txHashes string
+	err := db.QueryRow(selectCallsBatchQuery, url, id).Scan(&batch.ChainID, &batch.From, &batch.Atomic, &txHashes, &batch.CallsCount, &batch.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(txHashes), &batch.TxHashes); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
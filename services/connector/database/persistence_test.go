@@ -1,10 +1,12 @@
 package persistence
 
 import (
+	"math/big"
 	"testing"
 
 	"database/sql"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/t/helpers"
 	"github.com/status-im/status-go/walletdatabase"
@@ -93,3 +95,123 @@ func TestSelectAllDApps(t *testing.T) {
 	require.Len(t, dApps, 1)
 	require.Equal(t, testDApp, dApps[0])
 }
+
+func TestInsertAndSelectCallsBatch(t *testing.T) {
+	db, close := setupTestDB(t)
+	defer close()
+
+	batch := CallsBatch{
+		ID:         "0x01",
+		URL:        testDApp.URL,
+		ChainID:    testDApp.ChainID,
+		From:       testDApp.SharedAccount,
+		Atomic:     false,
+		TxHashes:   []types.Hash{types.HexToHash("0x11"), types.HexToHash("0x12")},
+		CallsCount: 3,
+		CreatedAt:  100,
+	}
+
+	batchBack, err := SelectCallsBatch(db, batch.URL, batch.ID)
+	require.NoError(t, err)
+	require.Nil(t, batchBack)
+
+	err = InsertCallsBatch(db, &batch)
+	require.NoError(t, err)
+
+	batchBack, err = SelectCallsBatch(db, batch.URL, batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, &batch, batchBack)
+
+	// Batch IDs are unique per dApp
+	err = InsertCallsBatch(db, &batch)
+	require.Error(t, err)
+
+	batchBack, err = SelectCallsBatch(db, "https://other.dapp", batch.ID)
+	require.NoError(t, err)
+	require.Nil(t, batchBack)
+
+	otherBatch := batch
+	otherBatch.URL = "https://other.dapp"
+	err = InsertCallsBatch(db, &otherBatch)
+	require.NoError(t, err)
+}
+
+func TestUpsertSelectAndDeleteDAppPolicy(t *testing.T) {
+	db, close := setupTestDB(t)
+	defer close()
+
+	policyBack, err := SelectDAppPolicy(db, testDApp.URL)
+	require.NoError(t, err)
+	require.Nil(t, policyBack)
+
+	policy := DAppPolicy{
+		URL:              testDApp.URL,
+		AllowedContracts: []types.Address{types.HexToAddress("0x01")},
+		AllowedMethods:   []types.HexBytes{{0xa9, 0x05, 0x9c, 0xbb}},
+		MaxValuePerTx:    (*hexutil.Big)(big.NewInt(1000)),
+		ExpiresAt:        100,
+	}
+	err = UpsertDAppPolicy(db, &policy)
+	require.NoError(t, err)
+
+	policyBack, err = SelectDAppPolicy(db, testDApp.URL)
+	require.NoError(t, err)
+	require.Equal(t, &policy, policyBack)
+
+	policy.MaxValuePerTx = nil
+	policy.MaxValuePerDay = (*hexutil.Big)(big.NewInt(5000))
+	err = UpsertDAppPolicy(db, &policy)
+	require.NoError(t, err)
+
+	policyBack, err = SelectDAppPolicy(db, testDApp.URL)
+	require.NoError(t, err)
+	require.Equal(t, &policy, policyBack)
+
+	// The policy is removed with the dApp
+	err = UpsertDApp(db, &testDApp)
+	require.NoError(t, err)
+	err = DeleteDApp(db, testDApp.URL)
+	require.NoError(t, err)
+
+	policyBack, err = SelectDAppPolicy(db, testDApp.URL)
+	require.NoError(t, err)
+	require.Nil(t, policyBack)
+}
+
+func TestDAppSpendings(t *testing.T) {
+	db, close := setupTestDB(t)
+	defer close()
+
+	err := InsertDAppSpending(db, testDApp.URL, big.NewInt(10), 100)
+	require.NoError(t, err)
+	err = InsertDAppSpending(db, testDApp.URL, big.NewInt(20), 200)
+	require.NoError(t, err)
+	err = InsertDAppSpending(db, "https://other-dapp-url.com", big.NewInt(40), 200)
+	require.NoError(t, err)
+
+	spent, err := SelectDAppSpendingSince(db, testDApp.URL, 100)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), spent)
+
+	spent, err = SelectDAppSpendingSince(db, testDApp.URL, 101)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20), spent)
+
+	err = DeleteDAppSpendingsBefore(db, testDApp.URL, 200)
+	require.NoError(t, err)
+
+	spent, err = SelectDAppSpendingSince(db, testDApp.URL, 0)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20), spent)
+
+	err = DeleteDApp(db, testDApp.URL)
+	require.NoError(t, err)
+
+	spent, err = SelectDAppSpendingSince(db, testDApp.URL, 0)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), spent)
+
+	spent, err = SelectDAppSpendingSince(db, "https://other-dapp-url.com", 0)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(40), spent)
+}
@@ -8,20 +8,37 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/wallet/eip712"
+	"github.com/status-im/status-go/services/wallet/thirdparty/fourbyte"
+	"github.com/status-im/status-go/services/wallet/thirdparty/fourbytegithub"
+	"github.com/status-im/status-go/services/wallet/thirdparty/sourcify"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
+	"github.com/status-im/status-go/transactions"
 )
 
-func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager) *Service {
+func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager, pendingTracker *transactions.PendingTxTracker) *Service {
+	tokenManager := token.NewTokenManager(db, rpc, nil, nm, nil, nil, nil, nil, nil, token.NewPersistence(db))
 	return &Service{
-		db:  db,
-		rpc: rpc,
-		nm:  nm,
+		db:             db,
+		rpc:            rpc,
+		nm:             nm,
+		pendingTracker: pendingTracker,
+		tokenManager:   tokenManager,
+		txDecoder: txdecoder.NewDecoder(txdecoder.NewDatabase(db), sourcify.NewClient(), tokenManager,
+			fourbytegithub.NewClient(), fourbyte.NewClient()),
+		typedDataAnalyzer: eip712.NewAnalyzer(tokenManager, nil),
 	}
 }
 
 type Service struct {
-	db  *sql.DB
-	rpc rpc.ClientInterface
-	nm  *network.Manager
+	db                *sql.DB
+	rpc               rpc.ClientInterface
+	nm                *network.Manager
+	pendingTracker    *transactions.PendingTxTracker
+	tokenManager      *token.Manager
+	txDecoder         *txdecoder.Decoder
+	typedDataAnalyzer *eip712.Analyzer
 }
 
 func (s *Service) Start() error {
@@ -19,6 +19,7 @@ import (
 	"github.com/status-im/status-go/contracts"
 	"github.com/status-im/status-go/contracts/registrar"
 	"github.com/status-im/status-go/contracts/resolver"
+	gethens "github.com/status-im/status-go/eth-node/bridge/geth/ens"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/rpc"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
@@ -30,7 +31,8 @@ func NewEnsResolver(rpcClient *rpc.Client) *EnsResolver {
 		contractMaker: &contracts.ContractMaker{
 			RPCClient: rpcClient,
 		},
-		addrPerChain: make(map[uint64]common.Address),
+		addrPerChain:      make(map[uint64]common.Address),
+		resolversPerChain: make(map[uint64]*gethens.Resolver),
 
 		quit: make(chan struct{}),
 	}
@@ -42,6 +44,9 @@ type EnsResolver struct {
 	addrPerChain      map[uint64]common.Address
 	addrPerChainMutex sync.Mutex
 
+	resolversPerChain      map[uint64]*gethens.Resolver
+	resolversPerChainMutex sync.Mutex
+
 	quitOnce sync.Once
 	quit     chan struct{}
 }
@@ -77,6 +82,11 @@ func (e *EnsResolver) Resolver(ctx context.Context, chainID uint64, username str
 }
 
 func (e *EnsResolver) GetName(ctx context.Context, chainID uint64, address common.Address) (string, error) {
+	_, err := e.contractMaker.ENSRegistryAddress(chainID)
+	if err != nil {
+		return "", err
+	}
+
 	backend, err := e.contractMaker.RPCClient.EthClient(chainID)
 	if err != nil {
 		return "", err
@@ -110,18 +120,12 @@ func (e *EnsResolver) ContentHash(ctx context.Context, chainID uint64, username
 		return nil, err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
+	nameResolver, err := e.nameResolver(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
-	if err != nil {
-		return nil, err
-	}
-
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	contentHash, err := resolver.Contenthash(callOpts, walletCommon.NameHash(username))
+	contentHash, err := nameResolver.ContentHash(ctx, username)
 	if err != nil {
 		return nil, nil
 	}
@@ -135,22 +139,16 @@ func (e *EnsResolver) PublicKeyOf(ctx context.Context, chainID uint64, username
 		return "", err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
-	if err != nil {
-		return "", err
-	}
-
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
+	nameResolver, err := e.nameResolver(chainID)
 	if err != nil {
 		return "", err
 	}
 
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	pubKey, err := resolver.Pubkey(callOpts, walletCommon.NameHash(username))
+	x, y, err := nameResolver.PubKey(ctx, username)
 	if err != nil {
 		return "", err
 	}
-	return "0x04" + hex.EncodeToString(pubKey.X[:]) + hex.EncodeToString(pubKey.Y[:]), nil
+	return "0x04" + hex.EncodeToString(x[:]) + hex.EncodeToString(y[:]), nil
 }
 
 func (e *EnsResolver) AddressOf(ctx context.Context, chainID uint64, username string) (*common.Address, error) {
@@ -159,23 +157,40 @@ func (e *EnsResolver) AddressOf(ctx context.Context, chainID uint64, username st
 		return nil, err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
+	nameResolver, err := e.nameResolver(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
+	addr, err := nameResolver.Addr(ctx, username)
 	if err != nil {
 		return nil, err
 	}
 
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	addr, err := resolver.Addr(callOpts, walletCommon.NameHash(username))
+	return &addr, nil
+}
+
+// nameResolver returns the resolver following ENSIP-10 wildcard resolution and CCIP-Read offchain lookups
+func (e *EnsResolver) nameResolver(chainID uint64) (*gethens.Resolver, error) {
+	e.resolversPerChainMutex.Lock()
+	defer e.resolversPerChainMutex.Unlock()
+	if r, ok := e.resolversPerChain[chainID]; ok {
+		return r, nil
+	}
+
+	registryAddr, err := e.contractMaker.ENSRegistryAddress(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &addr, nil
+	backend, err := e.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := gethens.NewResolver(backend, registryAddr)
+	e.resolversPerChain[chainID] = r
+	return r, nil
 }
 
 func (e *EnsResolver) usernameRegistrarAddr(ctx context.Context, chainID uint64) (common.Address, error) {
@@ -6,7 +6,9 @@ const (
 	CategoryGroupInvite            PushCategory = "groupInvite"
 	CategoryCommunityRequestToJoin              = "communityRequestToJoin"
 	CategoryCommunityJoined                     = "communityJoined"
+	CategoryWalletAlert            PushCategory = "walletAlert"
 
 	TypeTransaction NotificationType = "transaction"
 	TypeMessage     NotificationType = "message"
+	TypeWalletAlert NotificationType = "walletAlert"
 )
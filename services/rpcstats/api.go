@@ -23,6 +23,8 @@ func (api *PublicAPI) Reset(context context.Context) {
 type RPCStats struct {
 	Total            uint            `json:"total"`
 	CounterPerMethod map[string]uint `json:"methods"`
+	CacheHits        map[string]uint `json:"cacheHits"`
+	CacheMisses      map[string]uint `json:"cacheMisses"`
 }
 
 // GetStats returns RPC usage stats
@@ -46,8 +48,21 @@ func (api *PublicAPI) GetStats(context context.Context) (RPCStats, error) {
 		return true
 	})
 
+	cacheHits, cacheMisses := getCacheStats()
+
 	return RPCStats{
 		Total:            total,
 		CounterPerMethod: counterPerMethod,
+		CacheHits:        toCounters(cacheHits),
+		CacheMisses:      toCounters(cacheMisses),
 	}, nil
 }
+
+func toCounters(perMethod *sync.Map) map[string]uint {
+	counters := make(map[string]uint)
+	perMethod.Range(func(key, value interface{}) bool {
+		counters[key.(string)] = value.(uint)
+		return true
+	})
+	return counters
+}
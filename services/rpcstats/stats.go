@@ -8,6 +8,8 @@ type RPCUsageStats struct {
 	total                  uint
 	counterPerMethod       *sync.Map
 	counterPerMethodPerTag *sync.Map
+	cacheHitsPerMethod     *sync.Map
+	cacheMissesPerMethod   *sync.Map
 }
 
 var stats *RPCUsageStats
@@ -21,6 +23,8 @@ func getInstance() *RPCUsageStats {
 		stats = &RPCUsageStats{}
 		stats.counterPerMethod = &sync.Map{}
 		stats.counterPerMethodPerTag = &sync.Map{}
+		stats.cacheHitsPerMethod = &sync.Map{}
+		stats.cacheMissesPerMethod = &sync.Map{}
 	}
 	return stats
 }
@@ -30,11 +34,18 @@ func getStats() (uint, *sync.Map, *sync.Map) {
 	return stats.total, stats.counterPerMethod, stats.counterPerMethodPerTag
 }
 
+func getCacheStats() (*sync.Map, *sync.Map) {
+	stats := getInstance()
+	return stats.cacheHitsPerMethod, stats.cacheMissesPerMethod
+}
+
 func resetStats() {
 	stats := getInstance()
 	stats.total = 0
 	stats.counterPerMethod = &sync.Map{}
 	stats.counterPerMethodPerTag = &sync.Map{}
+	stats.cacheHitsPerMethod = &sync.Map{}
+	stats.cacheMissesPerMethod = &sync.Map{}
 }
 
 func CountCall(method string) {
@@ -57,3 +68,17 @@ func CountCallWithTag(method string, tag string) {
 	methodMap.Store(method, value.(uint)+1)
 	stats.total++
 }
+
+// CountCacheHit counts a call answered from the cache, it is not counted as a call
+func CountCacheHit(method string) {
+	stats := getInstance()
+	value, _ := stats.cacheHitsPerMethod.LoadOrStore(method, uint(0))
+	stats.cacheHitsPerMethod.Store(method, value.(uint)+1)
+}
+
+// CountCacheMiss counts a call that could not be answered from the cache
+func CountCacheMiss(method string) {
+	stats := getInstance()
+	value, _ := stats.cacheMissesPerMethod.LoadOrStore(method, uint(0))
+	stats.cacheMissesPerMethod.Store(method, value.(uint)+1)
+}
@@ -0,0 +1,156 @@
+package typeddata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	errEmptyLegacyTypedData  = errors.New("legacy typed data is empty")
+	errUnsupportedLegacyType = errors.New("unsupported legacy typed data type")
+)
+
+// LegacyField is an entry of the legacy typed data signed with eth_signTypedData (v1)
+type LegacyField struct {
+	Type  string          `json:"type"`
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// HashLegacy hashes the legacy typed data as keccak256(keccak256(schema) ‖ keccak256(values)),
+// the schema being the packed "type name" strings and the values being tightly packed.
+func HashLegacy(fields []LegacyField) (common.Hash, error) {
+	if len(fields) == 0 {
+		return common.Hash{}, errEmptyLegacyTypedData
+	}
+
+	var schema, values []byte
+	for _, f := range fields {
+		if len(f.Name) == 0 || len(f.Type) == 0 {
+			return common.Hash{}, errors.New("`name` and `type` are required")
+		}
+		schema = append(schema, []byte(f.Type+" "+f.Name)...)
+
+		packed, err := packLegacyValue(f.Type, f.Value)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		values = append(values, packed...)
+	}
+
+	return crypto.Keccak256Hash(crypto.Keccak256(schema), crypto.Keccak256(values)), nil
+}
+
+func unmarshalLegacyHex(data json.RawMessage) ([]byte, error) {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(value)
+}
+
+// unmarshalLegacyInteger accepts JSON numbers as well as decimal or hex strings
+func unmarshalLegacyInteger(data json.RawMessage) (*big.Int, error) {
+	value := string(data)
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+	}
+
+	rst, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, errNotInteger
+	}
+	return rst, nil
+}
+
+// packLegacyValue encodes the value like solidity's abi.encodePacked
+func packLegacyValue(typ string, data json.RawMessage) ([]byte, error) {
+	switch {
+	case typ == "string":
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	case typ == "bytes":
+		return unmarshalLegacyHex(data)
+	case typ == "bool":
+		var value bool
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		if value {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case typ == "address":
+		value, err := unmarshalLegacyHex(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(value) != common.AddressLength {
+			return nil, errors.New("invalid address")
+		}
+		return value, nil
+	case strings.HasSuffix(typ, "]"):
+		return nil, errUnsupportedLegacyType
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, errUnsupportedLegacyType
+		}
+		value, err := unmarshalLegacyHex(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(value) > size {
+			return nil, fmt.Errorf("value doesn't fit %s", typ)
+		}
+		return common.RightPadBytes(value, size), nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+		bits := 256
+		if size := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); size != "" {
+			var err error
+			bits, err = strconv.Atoi(size)
+			if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+				return nil, errUnsupportedLegacyType
+			}
+		}
+		value, err := unmarshalLegacyInteger(data)
+		if err != nil {
+			return nil, err
+		}
+		return packLegacyInteger(value, bits, signed)
+	}
+	return nil, errUnsupportedLegacyType
+}
+
+// packLegacyInteger returns the big endian two's complement of the value on bits/8 bytes
+func packLegacyInteger(value *big.Int, bits int, signed bool) ([]byte, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	min := big.NewInt(0)
+	max := new(big.Int).Set(limit)
+	if signed {
+		max.Rsh(limit, 1)
+		min.Neg(max)
+	}
+	if value.Cmp(min) < 0 || value.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("value doesn't fit %d bits", bits)
+	}
+
+	if value.Sign() < 0 {
+		value = new(big.Int).Add(value, limit)
+	}
+	return common.LeftPadBytes(value.Bytes(), bits/8), nil
+}
@@ -0,0 +1,60 @@
+package typeddata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashLegacy(t *testing.T) {
+	var fields []LegacyField
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type": "string", "name": "message", "value": "Hi, Alice!"},
+		{"type": "uint", "name": "value", "value": 42}
+	]`), &fields))
+
+	hash, err := HashLegacy(fields)
+	require.NoError(t, err)
+	schemaHash := crypto.Keccak256([]byte("string messageuint value"))
+	valuesHash := crypto.Keccak256([]byte("Hi, Alice!"), common.LeftPadBytes([]byte{42}, 32))
+	require.Equal(t, crypto.Keccak256Hash(schemaHash, valuesHash), hash)
+
+	_, err = HashLegacy(nil)
+	require.ErrorIs(t, err, errEmptyLegacyTypedData)
+
+	_, err = HashLegacy([]LegacyField{{Type: "uint8[]", Name: "values", Value: json.RawMessage(`[1]`)}})
+	require.ErrorIs(t, err, errUnsupportedLegacyType)
+}
+
+func TestPackLegacyValue(t *testing.T) {
+	for _, tc := range []struct {
+		typ      string
+		value    string
+		expected []byte
+		err      bool
+	}{
+		{typ: "bool", value: `true`, expected: []byte{1}},
+		{typ: "bytes", value: `"0x0102"`, expected: []byte{1, 2}},
+		{typ: "bytes4", value: `"0x0102"`, expected: []byte{1, 2, 0, 0}},
+		{typ: "bytes1", value: `"0x0102"`, err: true},
+		{typ: "address", value: `"0x0000000000000000000000000000000000000001"`, expected: common.Address{19: 1}.Bytes()},
+		{typ: "address", value: `"0x01"`, err: true},
+		{typ: "uint16", value: `"0x0102"`, expected: []byte{1, 2}},
+		{typ: "uint16", value: `65536`, err: true},
+		{typ: "uint8", value: `-1`, err: true},
+		{typ: "int16", value: `-2`, expected: []byte{0xff, 0xfe}},
+		{typ: "int8", value: `"128"`, err: true},
+		{typ: "uint7", value: `1`, err: true},
+	} {
+		packed, err := packLegacyValue(tc.typ, json.RawMessage(tc.value))
+		if tc.err {
+			require.Error(t, err, tc.typ+" "+tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.typ+" "+tc.value)
+		require.Equal(t, tc.expected, packed, tc.typ+" "+tc.value)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/addressbook"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
@@ -81,6 +82,8 @@ type Entry struct {
 	communityID               *string
 	interactedContractAddress *eth.Address
 	approvalSpender           *eth.Address
+	senderLabel               *addressbook.Label
+	recipientLabel            *addressbook.Label
 
 	isNew bool // isNew is used to indicate if the entry is newer than session start (changed state also)
 }
@@ -121,6 +124,8 @@ type EntryData struct {
 	CommunityID               *string                         `json:"communityId,omitempty"`
 	InteractedContractAddress *eth.Address                    `json:"interactedContractAddress,omitempty"`
 	ApprovalSpender           *eth.Address                    `json:"approvalSpender,omitempty"`
+	SenderLabel               *addressbook.Label              `json:"senderLabel,omitempty"`
+	RecipientLabel            *addressbook.Label              `json:"recipientLabel,omitempty"`
 
 	IsNew *bool `json:"isNew,omitempty"`
 
@@ -149,6 +154,8 @@ func (e *Entry) MarshalJSON() ([]byte, error) {
 		CommunityID:               e.communityID,
 		InteractedContractAddress: e.interactedContractAddress,
 		ApprovalSpender:           e.approvalSpender,
+		SenderLabel:               e.senderLabel,
+		RecipientLabel:            e.recipientLabel,
 	}
 
 	if e.payloadType == MultiTransactionPT {
@@ -200,6 +207,8 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 	e.communityID = aux.CommunityID
 	e.interactedContractAddress = aux.InteractedContractAddress
 	e.approvalSpender = aux.ApprovalSpender
+	e.senderLabel = aux.SenderLabel
+	e.recipientLabel = aux.RecipientLabel
 
 	e.isNew = aux.IsNew != nil && *aux.IsNew
 
@@ -388,6 +397,10 @@ type FilterDependencies struct {
 	tokenFromSymbol func(chainID *common.ChainID, symbol string) *Token
 	// use to get current timestamp
 	currentTimestamp func() int64
+	// optional, resolves the address book labels of the given addresses
+	resolveLabels func(ctx context.Context, addresses []eth.Address) map[eth.Address]*addressbook.Label
+	// optional, returns the addresses matching the address book labels or contacts
+	addressesForLabels func(ctx context.Context, labels []string, contactIDs []string) []eth.Address
 }
 
 // getActivityEntries queries the transfers, pending_transactions, and multi_transactions tables based on filter parameters and arguments
@@ -403,6 +416,14 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 		return nil, errors.New("no addresses provided")
 	}
 
+	filter, matchesNothing, err := resolveLabelsFilter(ctx, deps, filter)
+	if err != nil {
+		return nil, err
+	}
+	if matchesNothing {
+		return []Entry{}, nil
+	}
+
 	includeAllTokenTypeAssets := len(filter.Assets) == 0 && !filter.FilterOutAssets
 
 	// Used for symbol bearing tables multi_transactions and pending_transactions
@@ -511,7 +532,7 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 	// The duplicated temporary table UNION with CTE acts as an optimization
 	// As soon as we use filter_addresses CTE or filter_addresses_table temp table
 	// or switch them alternatively for JOIN or IN clauses the performance drops significantly
-	_, err := deps.db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS filter_addresses_table; CREATE TEMP TABLE filter_addresses_table (address VARCHAR PRIMARY KEY); INSERT INTO filter_addresses_table (address) VALUES %s;\n", involvedAddresses))
+	_, err = deps.db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS filter_addresses_table; CREATE TEMP TABLE filter_addresses_table (address VARCHAR PRIMARY KEY); INSERT INTO filter_addresses_table (address) VALUES %s;\n", involvedAddresses))
 	if err != nil {
 		return nil, err
 	}
@@ -741,9 +762,58 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 		return nil, err
 	}
 
+	fillInLabels(ctx, deps, entries)
+
 	return entries, nil
 }
 
+// resolveLabelsFilter adds the addresses of the labels and contacts of the filter to its counterparty addresses,
+// matchesNothing is true when neither the labels nor the counterparty addresses of the filter have an address, in which
+// case the filter must not be dropped but yield no entries
+func resolveLabelsFilter(ctx context.Context, deps FilterDependencies, filter Filter) (resolved Filter, matchesNothing bool, err error) {
+	if len(filter.CounterpartyLabels) == 0 && len(filter.CounterpartyContactIDs) == 0 {
+		return filter, false, nil
+	}
+	if deps.addressesForLabels == nil {
+		return filter, false, errors.New("filtering by labels is not supported")
+	}
+	labelAddresses := deps.addressesForLabels(ctx, filter.CounterpartyLabels, filter.CounterpartyContactIDs)
+	if len(labelAddresses) == 0 {
+		return filter, len(filter.CounterpartyAddresses) == 0, nil
+	}
+	counterparties := make([]eth.Address, 0, len(filter.CounterpartyAddresses)+len(labelAddresses))
+	counterparties = append(counterparties, filter.CounterpartyAddresses...)
+	filter.CounterpartyAddresses = append(counterparties, labelAddresses...)
+	return filter, false, nil
+}
+
+// fillInLabels sets the address book labels for senders and recipients of the entries
+func fillInLabels(ctx context.Context, deps FilterDependencies, entries []Entry) {
+	if deps.resolveLabels == nil || len(entries) == 0 {
+		return
+	}
+
+	addresses := make([]eth.Address, 0, len(entries)*2)
+	for i := range entries {
+		if entries[i].sender != nil {
+			addresses = append(addresses, *entries[i].sender)
+		}
+		if entries[i].recipient != nil {
+			addresses = append(addresses, *entries[i].recipient)
+		}
+	}
+
+	labels := deps.resolveLabels(ctx, addresses)
+	for i := range entries {
+		if entries[i].sender != nil {
+			entries[i].senderLabel = labels[*entries[i].sender]
+		}
+		if entries[i].recipient != nil {
+			entries[i].recipientLabel = labels[*entries[i].recipient]
+		}
+	}
+}
+
 func getTrInAndOutAmounts(activityType Type, trAmount sql.NullString, pTrAmount *big.Int) (inAmount *hexutil.Big, outAmount *hexutil.Big) {
 	var amount *big.Int
 	ok := false
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/status-im/status-go/services/wallet/addressbook"
 	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/testutils"
 	"github.com/status-im/status-go/services/wallet/transfer"
@@ -1190,6 +1191,94 @@ func TestGetActivityEntriesFilterByToAddresses(t *testing.T) {
 	require.Equal(t, 2, len(entries))
 }
 
+func TestGetActivityEntriesFilterByCounterpartyLabels(t *testing.T) {
+	deps, close := setupTestActivityDB(t)
+	defer close()
+
+	// Adds 4 extractable transactions
+	td, fromTds, toTds := fillTestData(t, deps.db)
+	allAddresses := append(fromTds, toTds...)
+
+	labeled := map[eth.Address]*addressbook.Label{
+		td.pendingTr.To:       {Address: td.pendingTr.To, Name: "Alice", Source: addressbook.LabelSourceSavedAddress},
+		td.multiTx2.ToAddress: {Address: td.multiTx2.ToAddress, Name: "Bob", Source: addressbook.LabelSourceContact, ContactID: "0x04bb"},
+	}
+	deps.resolveLabels = func(ctx context.Context, addresses []eth.Address) map[eth.Address]*addressbook.Label {
+		return labeled
+	}
+	deps.addressesForLabels = func(ctx context.Context, labels []string, contactIDs []string) []eth.Address {
+		var res []eth.Address
+		for address, label := range labeled {
+			if (len(labels) > 0 && label.Name == labels[0]) || (len(contactIDs) > 0 && label.ContactID == contactIDs[0]) {
+				res = append(res, address)
+			}
+		}
+		return res
+	}
+
+	var filter Filter
+	filter.CounterpartyLabels = []string{"Alice"}
+	entries, err := getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, td.pendingTr.To, *entries[0].recipient)
+	require.NotNil(t, entries[0].recipientLabel)
+	require.Equal(t, "Alice", entries[0].recipientLabel.Name)
+
+	filter = Filter{CounterpartyContactIDs: []string{"0x04bb"}}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, "Bob", entries[0].recipientLabel.Name)
+
+	filter = Filter{CounterpartyLabels: []string{"Unknown"}}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.NotNil(t, entries)
+	require.Equal(t, 0, len(entries))
+
+	// The counterparty addresses still apply when the labels match nothing
+	filter = Filter{CounterpartyAddresses: []eth.Address{td.pendingTr.To}, CounterpartyLabels: []string{"Unknown"}}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, td.pendingTr.To, *entries[0].recipient)
+}
+
+func TestResolveLabelsFilter(t *testing.T) {
+	alice := eth.Address{1}
+	deps := FilterDependencies{
+		addressesForLabels: func(ctx context.Context, labels []string, contactIDs []string) []eth.Address {
+			if len(labels) > 0 && labels[0] == "Alice" {
+				return []eth.Address{alice}
+			}
+			return nil
+		},
+	}
+
+	resolved, matchesNothing, err := resolveLabelsFilter(context.Background(), deps, Filter{CounterpartyLabels: []string{"Alice"}})
+	require.NoError(t, err)
+	require.False(t, matchesNothing)
+	require.Equal(t, []eth.Address{alice}, resolved.CounterpartyAddresses)
+
+	// A label without addresses must not drop the filter
+	resolved, matchesNothing, err = resolveLabelsFilter(context.Background(), deps, Filter{CounterpartyLabels: []string{"Unknown"}})
+	require.NoError(t, err)
+	require.True(t, matchesNothing)
+	require.Empty(t, resolved.CounterpartyAddresses)
+
+	resolved, matchesNothing, err = resolveLabelsFilter(context.Background(), deps, Filter{
+		CounterpartyAddresses: []eth.Address{{2}},
+		CounterpartyLabels:    []string{"Unknown"},
+	})
+	require.NoError(t, err)
+	require.False(t, matchesNothing)
+	require.Equal(t, []eth.Address{{2}}, resolved.CounterpartyAddresses)
+
+	_, _, err = resolveLabelsFilter(context.Background(), FilterDependencies{}, Filter{CounterpartyLabels: []string{"Alice"}})
+	require.Error(t, err)
+}
+
 func TestGetActivityEntriesFilterByNetworks(t *testing.T) {
 	deps, close := setupTestActivityDB(t)
 	defer close()
@@ -66,6 +66,17 @@ func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresse
 	qConditions = append(qConditions, sq.Eq{"rpt.chain_id": chainIDs})
 	qConditions = append(qConditions, sq.Eq{"rip.from_address": addresses})
 
+	filter, matchesNothing, err := resolveLabelsFilter(ctx, deps, filter)
+	if err != nil {
+		return nil, err
+	}
+	if matchesNothing {
+		return []Entry{}, nil
+	}
+	if len(filter.CounterpartyAddresses) > 0 {
+		qConditions = append(qConditions, sq.Eq{"rip.to_address": filter.CounterpartyAddresses})
+	}
+
 	q = q.Where(qConditions)
 
 	if limit != NoLimit {
@@ -95,7 +106,14 @@ func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresse
 		return nil, err
 	}
 
-	return dataToEntriesV2(deps, data)
+	entries, err := dataToEntriesV2(deps, data)
+	if err != nil {
+		return nil, err
+	}
+
+	fillInLabels(ctx, deps, entries)
+
+	return entries, nil
 }
 
 type entryDataV2 struct {
@@ -238,7 +256,7 @@ func getActivityTypeV2(processorName string, isApproval bool) Type {
 		return SendAT
 	case pathProcessorCommon.ProcessorBridgeHopName, pathProcessorCommon.ProcessorBridgeCelerName:
 		return BridgeAT
-	case pathProcessorCommon.ProcessorSwapParaswapName:
+	case pathProcessorCommon.ProcessorSwapParaswapName, pathProcessorCommon.ProcessorSwapKyberName, pathProcessorCommon.ProcessorSwapUniswapName:
 		return SwapAT
 	}
 	return UnknownAT
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/sqlite"
 )
 
@@ -29,6 +30,8 @@ type EntryChainDetails struct {
 	BlockNumber int64        `json:"blockNumber"`
 	Hash        eth.Hash     `json:"hash"`
 	Contract    *eth.Address `json:"contractAddress,omitempty"`
+	// Fee is paid in the native token of the chain
+	Fee *hexutil.Big `json:"fee,omitempty"`
 }
 
 type EntryDetails struct {
@@ -41,6 +44,12 @@ type EntryDetails struct {
 	MaxFeePerGas *hexutil.Big        `json:"maxFeePerGas"`
 	GasLimit     uint64              `json:"gasLimit"`
 	TotalFees    *hexutil.Big        `json:"totalFees,omitempty"`
+	// Decoded is the decoded Input, nil if it couldn't be decoded
+	Decoded *txdecoder.DecodedCall `json:"decoded,omitempty"`
+
+	// tx is the transaction of Input, sent on txChainID
+	tx        *types.Transaction
+	txChainID int64
 }
 
 //go:embed multiTxDetails.sql
@@ -83,6 +92,10 @@ func getMultiTxDetails(ctx context.Context, db *sql.DB, multiTxID int) (*EntryDe
 	var nonce, gasLimit uint64
 	var totalFees *hexutil.Big
 	var chainDetailsList []EntryChainDetails
+	var inputTx *types.Transaction
+	var inputChainID int64
+	// transfers holds a row per transfer, the fee of a transaction is only counted once
+	feesCounted := make(map[eth.Hash]bool)
 	for rows.Next() {
 		var contractTypeDB sql.NullString
 		var chainIDDB, nonceDB, blockNumber sql.NullInt64
@@ -126,12 +139,18 @@ func getMultiTxDetails(ctx context.Context, db *sql.DB, multiTxID int) (*EntryDe
 		}
 
 		if nullableTx.Valid {
+			inputTx = tx
+			inputChainID = chainID
 			input = "0x" + hex.EncodeToString(tx.Data())
 			maxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
 			gasLimit = tx.Gas()
 			if baseGasFees != nil {
 				baseGasFees, _ := new(big.Int).SetString(*baseGasFees, 0)
 				totalFees = (*hexutil.Big)(getTotalFees(tx, baseGasFees))
+				if !feesCounted[tx.Hash()] {
+					feesCounted[tx.Hash()] = true
+					addChainFee(chainDetails, totalFees)
+				}
 			}
 		}
 	}
@@ -156,6 +175,8 @@ func getMultiTxDetails(ctx context.Context, db *sql.DB, multiTxID int) (*EntryDe
 		GasLimit:     gasLimit,
 		ChainDetails: chainDetailsList,
 		TotalFees:    totalFees,
+		tx:           inputTx,
+		txChainID:    inputChainID,
 	}, nil
 }
 
@@ -211,16 +232,30 @@ func getTxDetails(ctx context.Context, db *sql.DB, id string) (*EntryDetails, er
 	}
 
 	if nullableTx.Valid {
+		details.tx = tx
+		details.txChainID = chainID
 		details.Input = "0x" + hex.EncodeToString(tx.Data())
 		details.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
 		details.GasLimit = tx.Gas()
 		baseGasFees, _ := new(big.Int).SetString(baseGasFees, 0)
 		details.TotalFees = (*hexutil.Big)(getTotalFees(tx, baseGasFees))
+		addChainFee(chainDetails, details.TotalFees)
 	}
 
 	return details, nil
 }
 
+// addChainFee adds the fee of a transaction sent on the chain
+func addChainFee(chainDetails *EntryChainDetails, fee *hexutil.Big) {
+	if fee == nil || fee.ToInt() == nil {
+		return
+	}
+	if chainDetails.Fee == nil {
+		chainDetails.Fee = (*hexutil.Big)(new(big.Int))
+	}
+	chainDetails.Fee.ToInt().Add(chainDetails.Fee.ToInt(), fee.ToInt())
+}
+
 func getTotalFees(tx *types.Transaction, baseFee *big.Int) *big.Int {
 	if tx.Type() == types.DynamicFeeTxType {
 		// EIP-1559 transaction
@@ -0,0 +1,520 @@
+package activity
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/async"
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// EventActivityExportProgress contains an ExportProgress payload
+	EventActivityExportProgress walletevent.EventType = "wallet-activity-export-progress"
+	// EventActivityExportDone contains an ExportResponse payload
+	EventActivityExportDone walletevent.EventType = "wallet-activity-export-done"
+)
+
+const (
+	// exportPageSize is the number of entries fetched from the DB at once while exporting
+	exportPageSize = 200
+	// maxExportDecimals matches the highest token precision we support (18 for ETH and most ERC20s)
+	maxExportDecimals = 18
+)
+
+var exportTask = async.TaskType{
+	ID:     5,
+	Policy: async.ReplacementPolicyCancelOld,
+}
+
+type ExportFormat string
+
+const (
+	// ExportFormatCSV is a generic CSV with one row per activity entry
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatKoinly follows the Koinly universal import format, also accepted by most crypto-tax tools
+	ExportFormatKoinly ExportFormat = "koinly"
+)
+
+// HistoricalPricesProvider is implemented by market.Manager
+type HistoricalPricesProvider interface {
+	FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error)
+}
+
+type ExportParams struct {
+	Addresses []eth.Address    `json:"addresses"`
+	ChainIDs  []common.ChainID `json:"chainIds"`
+	Filter    Filter           `json:"filter"`
+	Format    ExportFormat     `json:"format"`
+	Path      string           `json:"path"`
+	Currency  string           `json:"currency"`
+}
+
+type ExportProgress struct {
+	Exported int `json:"exported"`
+}
+
+type ExportResponse struct {
+	Path      string    `json:"path"`
+	Exported  int       `json:"exported"`
+	ErrorCode ErrorCode `json:"errorCode"`
+}
+
+// ExportRow is the format agnostic representation of an exported activity entry. Fee and FeeSymbol list the fees summed
+// per native token separated by spaces, in the order of the chains
+type ExportRow struct {
+	Timestamp      int64
+	ActivityType   Type
+	ActivityStatus Status
+	ChainIDOut     *common.ChainID
+	ChainIDIn      *common.ChainID
+	Sender         *eth.Address
+	Recipient      *eth.Address
+	AmountOut      string
+	SymbolOut      string
+	AmountIn       string
+	SymbolIn       string
+	Fee            string
+	FeeSymbol      string
+	FiatValue      string
+	Currency       string
+	TxHashes       []eth.Hash
+	MultiTxID      common.MultiTransactionIDType
+}
+
+type exportWriter interface {
+	writeHeader() error
+	writeRow(row *ExportRow) error
+	flush() error
+}
+
+// ExportActivityAsync streams all the activity entries matching the filter to params.Path
+//
+// Progress is reported with EventActivityExportProgress events and the final result with an EventActivityExportDone event
+func (s *Service) ExportActivityAsync(requestID int32, params ExportParams) {
+	s.scheduler.Enqueue(requestID, exportTask, func(ctx context.Context) (interface{}, error) {
+		return s.exportActivity(ctx, requestID, params)
+	}, func(result interface{}, taskType async.TaskType, err error) {
+		res := ExportResponse{
+			Path:      params.Path,
+			ErrorCode: ErrorCodeFailed,
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, async.ErrTaskOverwritten) {
+			res.ErrorCode = ErrorCodeTaskCanceled
+		} else if err == nil {
+			res.Exported = result.(int)
+			res.ErrorCode = ErrorCodeSuccess
+		}
+
+		sendResponseEvent(s.eventFeed, &requestID, EventActivityExportDone, res, err)
+	})
+}
+
+func (s *Service) exportActivity(ctx context.Context, requestID int32, params ExportParams) (exported int, err error) {
+	if len(params.Path) == 0 {
+		return 0, errors.New("no export path provided")
+	}
+
+	file, err := os.Create(params.Path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave a partial export behind
+			_ = os.Remove(params.Path)
+		}
+	}()
+
+	writer, err := newExportWriter(params.Format, file)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = writer.writeHeader(); err != nil {
+		return 0, err
+	}
+
+	deps := s.getDeps()
+	allAddresses := s.areAllAddresses(params.Addresses)
+	prices := newHistoricalPricesCache(s.pricesProvider, params.Currency)
+
+	for offset := 0; ; offset += exportPageSize {
+		entries, err := getActivityEntries(ctx, deps, params.Addresses, allAddresses, params.ChainIDs, params.Filter, offset, exportPageSize)
+		if err != nil {
+			return exported, err
+		}
+
+		for i := range entries {
+			row := s.buildExportRow(ctx, &entries[i], prices)
+			if err = writer.writeRow(row); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if err = writer.flush(); err != nil {
+			return exported, err
+		}
+
+		sendResponseEvent(s.eventFeed, &requestID, EventActivityExportProgress, ExportProgress{Exported: exported}, nil)
+
+		if len(entries) < exportPageSize {
+			break
+		}
+	}
+
+	return exported, nil
+}
+
+func (s *Service) buildExportRow(ctx context.Context, e *Entry, prices *historicalPricesCache) *ExportRow {
+	row := &ExportRow{
+		Timestamp:      e.timestamp,
+		ActivityType:   e.activityType,
+		ActivityStatus: e.activityStatus,
+		ChainIDOut:     e.chainIDOut,
+		ChainIDIn:      e.chainIDIn,
+		Sender:         e.sender,
+		Recipient:      e.recipient,
+		Currency:       prices.currency,
+	}
+
+	var fiatValue *big.Rat
+	if e.amountOut != nil && e.symbolOut != nil {
+		amount := s.tokenAmount(e.tokenOut, e.amountOut.ToInt())
+		row.AmountOut = formatAmount(amount, maxExportDecimals)
+		row.SymbolOut = *e.symbolOut
+		fiatValue = prices.valueAt(row.SymbolOut, e.timestamp, amount)
+	}
+	if e.amountIn != nil && e.symbolIn != nil {
+		amount := s.tokenAmount(e.tokenIn, e.amountIn.ToInt())
+		row.AmountIn = formatAmount(amount, maxExportDecimals)
+		row.SymbolIn = *e.symbolIn
+		if fiatValue == nil {
+			fiatValue = prices.valueAt(row.SymbolIn, e.timestamp, amount)
+		}
+	}
+	if fiatValue != nil {
+		row.FiatValue = fiatValue.FloatString(2)
+	}
+
+	var details *EntryDetails
+	var err error
+	if e.payloadType == MultiTransactionPT {
+		row.MultiTxID = e.id
+		details, err = getMultiTxDetails(ctx, s.db, int(e.id))
+	} else if e.payloadType == SimpleTransactionPT && e.transaction != nil {
+		details, err = getTxDetails(ctx, s.db, e.transaction.Hash.Hex())
+	} else if e.transaction != nil {
+		// Pending transactions are stored by their transaction hash and have no fees yet
+		row.TxHashes = append(row.TxHashes, e.transaction.Hash)
+	}
+	if err != nil {
+		logutils.ZapLogger().Warn("wallet.activity.Service export: failed to get entry details", zap.Error(err))
+	}
+
+	if details != nil {
+		for _, chainDetails := range details.ChainDetails {
+			if chainDetails.Hash != (eth.Hash{}) {
+				row.TxHashes = append(row.TxHashes, chainDetails.Hash)
+			}
+		}
+
+		fees := make([]nativeFee, 0, len(details.ChainDetails))
+		for _, chainDetails := range details.ChainDetails {
+			if chainDetails.Fee == nil {
+				continue
+			}
+			nativeToken := &Token{TokenType: Native, ChainID: common.ChainID(chainDetails.ChainID)}
+			fees = append(fees, nativeFee{
+				symbol: s.getDeps().tokenSymbol(*nativeToken),
+				amount: s.tokenAmount(nativeToken, chainDetails.Fee.ToInt()),
+			})
+		}
+		row.Fee, row.FeeSymbol = sumFees(fees)
+	}
+
+	return row
+}
+
+type nativeFee struct {
+	symbol string
+	amount *big.Rat
+}
+
+// sumFees sums the fees per native token, the chains sharing a native token (e.g. ETH on the rollups) are summed together
+func sumFees(fees []nativeFee) (amounts string, symbols string) {
+	var order []string
+	sums := make(map[string]*big.Rat)
+	for _, fee := range fees {
+		sum, ok := sums[fee.symbol]
+		if !ok {
+			sum = new(big.Rat)
+			sums[fee.symbol] = sum
+			order = append(order, fee.symbol)
+		}
+		sum.Add(sum, fee.amount)
+	}
+
+	formatted := make([]string, 0, len(order))
+	for _, symbol := range order {
+		formatted = append(formatted, formatAmount(sums[symbol], maxExportDecimals))
+	}
+	return strings.Join(formatted, " "), strings.Join(order, " ")
+}
+
+// tokenAmount converts the raw on-chain amount to a decimal amount using the token decimals
+func (s *Service) tokenAmount(t *Token, amount *big.Int) *big.Rat {
+	decimals := uint(0)
+	if t != nil && (t.TokenType == Native || t.TokenType == Erc20) {
+		info := s.tokenManager.LookupTokenIdentity(uint64(t.ChainID), t.Address, t.TokenType == Native)
+		if info != nil {
+			decimals = info.Decimals
+		}
+	}
+	return toDecimalAmount(amount, decimals)
+}
+
+func toDecimalAmount(amount *big.Int, decimals uint) *big.Rat {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(amount, unit)
+}
+
+// formatAmount prints the amount with up to maxDecimals digits and no trailing zeros
+func formatAmount(amount *big.Rat, maxDecimals int) string {
+	res := amount.FloatString(maxDecimals)
+	if strings.Contains(res, ".") {
+		res = strings.TrimRight(strings.TrimRight(res, "0"), ".")
+	}
+	return res
+}
+
+// historicalPricesCache fetches the daily prices of each symbol only once per export
+type historicalPricesCache struct {
+	provider HistoricalPricesProvider
+	currency string
+	prices   map[string][]thirdparty.HistoricalPrice
+}
+
+func newHistoricalPricesCache(provider HistoricalPricesProvider, currency string) *historicalPricesCache {
+	return &historicalPricesCache{
+		provider: provider,
+		currency: strings.ToUpper(currency),
+		prices:   make(map[string][]thirdparty.HistoricalPrice),
+	}
+}
+
+func (c *historicalPricesCache) priceAt(symbol string, timestamp int64) (float64, bool) {
+	if c.provider == nil || len(c.currency) == 0 || len(symbol) == 0 {
+		return 0, false
+	}
+
+	prices, ok := c.prices[symbol]
+	if !ok {
+		var err error
+		prices, err = c.provider.FetchHistoricalDailyPrices(symbol, c.currency, 0, true, 1)
+		if err != nil {
+			logutils.ZapLogger().Warn("wallet.activity.Service export: failed to fetch historical prices",
+				zap.String("symbol", symbol), zap.Error(err))
+		}
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].Timestamp < prices[j].Timestamp
+		})
+		c.prices[symbol] = prices
+	}
+
+	return closestPrice(prices, timestamp)
+}
+
+func (c *historicalPricesCache) valueAt(symbol string, timestamp int64, amount *big.Rat) *big.Rat {
+	price, ok := c.priceAt(symbol, timestamp)
+	if !ok {
+		return nil
+	}
+	priceRat := new(big.Rat)
+	if priceRat.SetFloat64(price) == nil {
+		return nil
+	}
+	return priceRat.Mul(priceRat, amount)
+}
+
+// closestPrice returns the last daily price at or before timestamp, none before the first known price. prices must be
+// sorted by timestamp
+func closestPrice(prices []thirdparty.HistoricalPrice, timestamp int64) (float64, bool) {
+	idx := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Timestamp > timestamp
+	})
+	if idx == 0 {
+		return 0, false
+	}
+	return prices[idx-1].Value, true
+}
+
+func newExportWriter(format ExportFormat, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV, "":
+		return &csvExportWriter{w: csv.NewWriter(w)}, nil
+	case ExportFormatKoinly:
+		return &koinlyExportWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) writeHeader() error {
+	return c.w.Write([]string{
+		"Date", "Type", "Status", "Chain ID Out", "Chain ID In", "From", "To",
+		"Amount Out", "Symbol Out", "Amount In", "Symbol In",
+		"Fee", "Fee Symbol", "Fiat Value", "Fiat Currency", "Transaction Hashes", "Multi Transaction ID",
+	})
+}
+
+func (c *csvExportWriter) writeRow(row *ExportRow) error {
+	multiTxID := ""
+	if row.MultiTxID != common.NoMultiTransactionID {
+		multiTxID = strconv.FormatInt(int64(row.MultiTxID), 10)
+	}
+	return c.w.Write([]string{
+		time.Unix(row.Timestamp, 0).UTC().Format(time.RFC3339),
+		activityTypeName(row.ActivityType),
+		activityStatusName(row.ActivityStatus),
+		chainIDToString(row.ChainIDOut),
+		chainIDToString(row.ChainIDIn),
+		addressToString(row.Sender),
+		addressToString(row.Recipient),
+		row.AmountOut,
+		row.SymbolOut,
+		row.AmountIn,
+		row.SymbolIn,
+		row.Fee,
+		row.FeeSymbol,
+		row.FiatValue,
+		row.Currency,
+		hashesToString(row.TxHashes),
+		multiTxID,
+	})
+}
+
+func (c *csvExportWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type koinlyExportWriter struct {
+	w *csv.Writer
+}
+
+func (k *koinlyExportWriter) writeHeader() error {
+	return k.w.Write([]string{
+		"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+		"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash",
+	})
+}
+
+func (k *koinlyExportWriter) writeRow(row *ExportRow) error {
+	netWorthCurrency := ""
+	if len(row.FiatValue) > 0 {
+		netWorthCurrency = row.Currency
+	}
+	return k.w.Write([]string{
+		time.Unix(row.Timestamp, 0).UTC().Format("2006-01-02 15:04:05 UTC"),
+		row.AmountOut,
+		row.SymbolOut,
+		row.AmountIn,
+		row.SymbolIn,
+		row.Fee,
+		row.FeeSymbol,
+		row.FiatValue,
+		netWorthCurrency,
+		"",
+		activityTypeName(row.ActivityType),
+		hashesToString(row.TxHashes),
+	})
+}
+
+func (k *koinlyExportWriter) flush() error {
+	k.w.Flush()
+	return k.w.Error()
+}
+
+func activityTypeName(t Type) string {
+	switch t {
+	case SendAT:
+		return "send"
+	case ReceiveAT:
+		return "receive"
+	case BuyAT:
+		return "buy"
+	case SwapAT:
+		return "swap"
+	case BridgeAT:
+		return "bridge"
+	case ContractDeploymentAT:
+		return "contract-deployment"
+	case MintAT:
+		return "mint"
+	case ApproveAT:
+		return "approve"
+	}
+	return "unknown"
+}
+
+func activityStatusName(s Status) string {
+	switch s {
+	case FailedAS:
+		return "failed"
+	case PendingAS:
+		return "pending"
+	case CompleteAS:
+		return "complete"
+	case FinalizedAS:
+		return "finalized"
+	}
+	return "unknown"
+}
+
+func chainIDToString(chainID *common.ChainID) string {
+	if chainID == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*chainID), 10)
+}
+
+func addressToString(address *eth.Address) string {
+	if address == nil {
+		return ""
+	}
+	return address.Hex()
+}
+
+func hashesToString(hashes []eth.Hash) string {
+	res := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		res = append(res, h.Hex())
+	}
+	return strings.Join(res, " ")
+}
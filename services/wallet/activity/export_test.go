@@ -0,0 +1,161 @@
+package activity
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockHistoricalPricesProvider struct {
+	prices map[string][]thirdparty.HistoricalPrice
+	calls  map[string]int
+}
+
+func (m *mockHistoricalPricesProvider) FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	m.calls[symbol]++
+	return m.prices[symbol], nil
+}
+
+func TestToDecimalAmount(t *testing.T) {
+	amount, ok := new(big.Int).SetString("1500000000000000000", 10)
+	require.True(t, ok)
+	require.Equal(t, "1.5", formatAmount(toDecimalAmount(amount, 18), maxExportDecimals))
+	require.Equal(t, "2", formatAmount(toDecimalAmount(big.NewInt(2), 0), maxExportDecimals))
+	require.Equal(t, "0.000001", formatAmount(toDecimalAmount(big.NewInt(1), 6), maxExportDecimals))
+	require.Equal(t, "0", formatAmount(toDecimalAmount(big.NewInt(0), 18), maxExportDecimals))
+}
+
+func TestClosestPrice(t *testing.T) {
+	_, ok := closestPrice(nil, 100)
+	require.False(t, ok)
+
+	prices := []thirdparty.HistoricalPrice{
+		{Timestamp: 100, Value: 1},
+		{Timestamp: 200, Value: 2},
+		{Timestamp: 300, Value: 3},
+	}
+
+	// No price is made up before the first known one
+	_, ok = closestPrice(prices, 50)
+	require.False(t, ok)
+
+	for _, tc := range []struct {
+		timestamp int64
+		expected  float64
+	}{
+		{100, 1},
+		{199, 1},
+		{200, 2},
+		{1000, 3},
+	} {
+		price, ok := closestPrice(prices, tc.timestamp)
+		require.True(t, ok)
+		require.Equal(t, tc.expected, price, "timestamp %d", tc.timestamp)
+	}
+}
+
+func TestHistoricalPricesCacheFetchesOnce(t *testing.T) {
+	provider := &mockHistoricalPricesProvider{
+		prices: map[string][]thirdparty.HistoricalPrice{
+			"ETH": {{Timestamp: 200, Value: 2000}, {Timestamp: 100, Value: 1000}},
+		},
+		calls: make(map[string]int),
+	}
+	cache := newHistoricalPricesCache(provider, "usd")
+	require.Equal(t, "USD", cache.currency)
+
+	value := cache.valueAt("ETH", 150, big.NewRat(3, 2))
+	require.NotNil(t, value)
+	require.Equal(t, "1500.00", value.FloatString(2))
+
+	value = cache.valueAt("ETH", 250, big.NewRat(1, 1))
+	require.NotNil(t, value)
+	require.Equal(t, "2000.00", value.FloatString(2))
+	require.Equal(t, 1, provider.calls["ETH"])
+
+	require.Nil(t, cache.valueAt("ETH", 50, big.NewRat(1, 1)))
+	require.Nil(t, cache.valueAt("DAI", 150, big.NewRat(1, 1)))
+	require.Nil(t, newHistoricalPricesCache(nil, "USD").valueAt("ETH", 150, big.NewRat(1, 1)))
+}
+
+func TestSumFees(t *testing.T) {
+	amount, symbol := sumFees(nil)
+	require.Empty(t, amount)
+	require.Empty(t, symbol)
+
+	// The bridge fees are paid on both chains, the chains sharing the native token are summed
+	amount, symbol = sumFees([]nativeFee{
+		{symbol: "ETH", amount: big.NewRat(1, 1000)},
+		{symbol: "POL", amount: big.NewRat(1, 2)},
+		{symbol: "ETH", amount: big.NewRat(2, 1000)},
+	})
+	require.Equal(t, "0.003 0.5", amount)
+	require.Equal(t, "ETH POL", symbol)
+}
+
+func testExportRow() *ExportRow {
+	chainID := common.ChainID(1)
+	sender := eth.HexToAddress("0x1")
+	recipient := eth.HexToAddress("0x2")
+	return &ExportRow{
+		Timestamp:      1700000000,
+		ActivityType:   SendAT,
+		ActivityStatus: FinalizedAS,
+		ChainIDOut:     &chainID,
+		Sender:         &sender,
+		Recipient:      &recipient,
+		AmountOut:      "1.5",
+		SymbolOut:      "ETH",
+		Fee:            "0.001",
+		FeeSymbol:      "ETH",
+		FiatValue:      "3000.00",
+		Currency:       "USD",
+		TxHashes:       []eth.Hash{eth.HexToHash("0x3")},
+		MultiTxID:      common.MultiTransactionIDType(7),
+	}
+}
+
+func TestCSVExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newExportWriter(ExportFormatCSV, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.writeHeader())
+	require.NoError(t, writer.writeRow(testExportRow()))
+	require.NoError(t, writer.flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "Date,Type,Status"))
+	require.Equal(t, "2023-11-14T22:13:20Z,send,finalized,1,,"+
+		eth.HexToAddress("0x1").Hex()+","+eth.HexToAddress("0x2").Hex()+
+		",1.5,ETH,,,0.001,ETH,3000.00,USD,"+eth.HexToHash("0x3").Hex()+",7", lines[1])
+}
+
+func TestKoinlyExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newExportWriter(ExportFormatKoinly, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.writeHeader())
+	require.NoError(t, writer.writeRow(testExportRow()))
+	require.NoError(t, writer.flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, "Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash", lines[0])
+	require.Equal(t, "2023-11-14 22:13:20 UTC,1.5,ETH,,,0.001,ETH,3000.00,USD,,send,"+eth.HexToHash("0x3").Hex(), lines[1])
+}
+
+func TestUnsupportedExportFormat(t *testing.T) {
+	_, err := newExportWriter(ExportFormat("xls"), &bytes.Buffer{})
+	require.Error(t, err)
+}
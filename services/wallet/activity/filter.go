@@ -94,6 +94,10 @@ type Filter struct {
 	Types                 []Type        `json:"types"`
 	Statuses              []Status      `json:"statuses"`
 	CounterpartyAddresses []eth.Address `json:"counterpartyAddresses"`
+	// CounterpartyLabels and CounterpartyContactIDs are resolved to addresses using the address book
+	// and then applied as CounterpartyAddresses
+	CounterpartyLabels     []string `json:"counterpartyLabels"`
+	CounterpartyContactIDs []string `json:"counterpartyContactIds"`
 
 	// Tokens
 	Assets                []Token `json:"assets"`
@@ -108,6 +112,8 @@ func (f *Filter) IsEmpty() bool {
 		len(f.Types) == 0 &&
 		len(f.Statuses) == 0 &&
 		len(f.CounterpartyAddresses) == 0 &&
+		len(f.CounterpartyLabels) == 0 &&
+		len(f.CounterpartyContactIDs) == 0 &&
 		len(f.Assets) == 0 &&
 		len(f.Collectibles) == 0 &&
 		!f.FilterOutAssets &&
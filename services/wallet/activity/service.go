@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"math/big"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -17,11 +18,13 @@ import (
 
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/services/wallet/addressbook"
 	"github.com/status-im/status-go/services/wallet/async"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	w_common "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/transactions"
 )
@@ -77,13 +80,27 @@ type Service struct {
 	debounceDuration time.Duration
 
 	pendingTracker *transactions.PendingTxTracker
+	pricesProvider HistoricalPricesProvider
+	labelResolver  LabelResolver
+	txDecoder      TxDecoder
+}
+
+// LabelResolver is implemented by addressbook.Resolver
+type LabelResolver interface {
+	ResolveLabels(ctx context.Context, addresses []common.Address) map[common.Address]*addressbook.Label
+	FindAddresses(ctx context.Context, names []string, contactIDs []string) []common.Address
 }
 
 func (s *Service) nextSessionID() SessionID {
 	return SessionID(s.lastSessionID.Add(1))
 }
 
-func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed, pendingTracker *transactions.PendingTxTracker) *Service {
+// TxDecoder is implemented by txdecoder.Decoder
+type TxDecoder interface {
+	Decode(ctx context.Context, chainID uint64, to *common.Address, data []byte, value *big.Int) (*txdecoder.DecodedCall, error)
+}
+
+func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed, pendingTracker *transactions.PendingTxTracker, pricesProvider HistoricalPricesProvider, labelResolver LabelResolver, txDecoder TxDecoder) *Service {
 	return &Service{
 		db:           db,
 		accountsDB:   accountsDB,
@@ -97,6 +114,9 @@ func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.Ma
 		debounceDuration: 1 * time.Second,
 
 		pendingTracker: pendingTracker,
+		pricesProvider: pricesProvider,
+		labelResolver:  labelResolver,
+		txDecoder:      txDecoder,
 	}
 }
 
@@ -208,11 +228,38 @@ func (s *Service) GetActivityCollectiblesAsync(requestID int32, chainIDs []w_com
 }
 
 func (s *Service) GetMultiTxDetails(ctx context.Context, multiTxID int) (*EntryDetails, error) {
-	return getMultiTxDetails(ctx, s.db, multiTxID)
+	details, err := getMultiTxDetails(ctx, s.db, multiTxID)
+	if err != nil {
+		return nil, err
+	}
+	s.decodeDetails(ctx, details)
+	return details, nil
 }
 
 func (s *Service) GetTxDetails(ctx context.Context, id string) (*EntryDetails, error) {
-	return getTxDetails(ctx, s.db, id)
+	details, err := getTxDetails(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	s.decodeDetails(ctx, details)
+	return details, nil
+}
+
+// decodeDetails decodes the input of the entry's transaction, the details are still returned if it can't be decoded
+func (s *Service) decodeDetails(ctx context.Context, details *EntryDetails) {
+	if s.txDecoder == nil || details.tx == nil {
+		return
+	}
+
+	decoded, err := s.txDecoder.Decode(ctx, uint64(details.txChainID), details.tx.To(), details.tx.Data(), details.tx.Value())
+	if err != nil {
+		logutils.ZapLogger().Debug("wallet.activity.Service failed to decode tx input",
+			zap.Int64("chainID", details.txChainID),
+			zap.Error(err),
+		)
+		return
+	}
+	details.Decoded = decoded
 }
 
 // getActivityDetails check if any of the entries have details that are not loaded then fetch and emit result
@@ -366,7 +413,7 @@ func (s *Service) Stop() {
 }
 
 func (s *Service) getDeps() FilterDependencies {
-	return FilterDependencies{
+	deps := FilterDependencies{
 		db: s.db,
 		tokenSymbol: func(t Token) string {
 			info := s.tokenManager.LookupTokenIdentity(uint64(t.ChainID), t.Address, t.TokenType == Native)
@@ -399,6 +446,11 @@ func (s *Service) getDeps() FilterDependencies {
 			return time.Now().Unix()
 		},
 	}
+	if s.labelResolver != nil {
+		deps.resolveLabels = s.labelResolver.ResolveLabels
+		deps.addressesForLabels = s.labelResolver.FindAddresses
+	}
+	return deps
 }
 
 func sendResponseEvent(eventFeed *event.Feed, requestID *int32, eventType walletevent.EventType, payloadObj interface{}, resErr error) {
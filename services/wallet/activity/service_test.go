@@ -10,6 +10,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/status-im/status-go/appdatabase"
@@ -22,6 +23,7 @@ import (
 	"github.com/status-im/status-go/services/wallet/token"
 	mock_token "github.com/status-im/status-go/services/wallet/token/mock/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/t/helpers"
 	"github.com/status-im/status-go/transactions"
@@ -91,7 +93,7 @@ func setupTestService(tb testing.TB) (state testState) {
 	pendingCheckInterval := time.Second
 	state.pendingTracker = transactions.NewPendingTxTracker(db, state.rpcClient, nil, state.eventFeed, pendingCheckInterval)
 
-	state.service = NewService(db, accountsDB, state.tokenMock, state.collectiblesMock, state.eventFeed, state.pendingTracker)
+	state.service = NewService(db, accountsDB, state.tokenMock, state.collectiblesMock, state.eventFeed, state.pendingTracker, nil, nil, nil)
 	state.service.debounceDuration = 0
 	state.close = func() {
 		require.NoError(tb, state.pendingTracker.Stop())
@@ -413,3 +415,52 @@ func validateFilteringDone(t *testing.T, ch chan walletevent.Event, resCount int
 	}
 	return
 }
+
+// fakeTxDecoder decodes any input as the same call, keeping the decoded transaction
+type fakeTxDecoder struct {
+	chainID uint64
+	to      *eth.Address
+	data    []byte
+	err     error
+}
+
+func (f *fakeTxDecoder) Decode(ctx context.Context, chainID uint64, to *eth.Address, data []byte, value *big.Int) (*txdecoder.DecodedCall, error) {
+	f.chainID = chainID
+	f.to = to
+	f.data = data
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &txdecoder.DecodedCall{ChainID: chainID, Contract: *to, Method: "transfer", Source: txdecoder.SourceBundled}, nil
+}
+
+func TestService_GetTxDetailsDecodesInput(t *testing.T) {
+	state := setupTestService(t)
+	defer state.close()
+
+	decoder := &fakeTxDecoder{}
+	state.service.txDecoder = decoder
+
+	trs, _, _ := transfer.GenerateTestTransfers(t, state.service.db, 0, 1)
+	data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	contract := eth.Address{0x0c}
+	trs[0].ChainID = common.ChainID(10)
+	transfer.InsertTestTransferWithOptions(t, state.service.db, trs[0].To, &trs[0], &transfer.TestTransferOptions{
+		Tx: types.NewTx(&types.LegacyTx{To: &contract, Data: data, GasPrice: big.NewInt(1)}),
+	})
+
+	details, err := state.service.GetTxDetails(context.Background(), trs[0].Hash.String())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), decoder.chainID)
+	require.Equal(t, contract, *decoder.to)
+	require.Equal(t, data, decoder.data)
+	require.NotNil(t, details.Decoded)
+	require.Equal(t, "transfer", details.Decoded.Method)
+
+	// Details are returned even if the input can't be decoded
+	decoder.err = txdecoder.ErrUnknownMethod
+	details, err = state.service.GetTxDetails(context.Background(), trs[0].Hash.String())
+	require.NoError(t, err)
+	require.Nil(t, details.Decoded)
+	require.Equal(t, "0xa9059cbb", details.Input)
+}
@@ -287,6 +287,7 @@ func (s *Service) processEvents(ctx context.Context) {
 	eventCount := 0
 	changedTxs := make([]TransactionID, 0)
 	newTxs := false
+	reorganized := false
 
 	var debounceTimer *time.Timer
 	debouncerCh := make(chan struct{})
@@ -332,6 +333,10 @@ func (s *Service) processEvents(ctx context.Context) {
 				// the detection of new entries
 				newTxs = true
 				debounceProcessChangesFn()
+			case transfer.EventChainReorganized:
+				// Entries may have been removed, which the updates don't report
+				reorganized = true
+				debounceProcessChangesFn()
 			case routeexecution.EventRouteExecutionTransactionSent:
 				sentTxs, ok := event.EventParams.(*responses.RouterSentTransactions)
 				if ok && sentTxs != nil {
@@ -345,7 +350,14 @@ func (s *Service) processEvents(ctx context.Context) {
 				debounceProcessChangesFn()
 			}
 		case <-debouncerCh:
-			if eventCount > 0 || newTxs || len(changedTxs) > 0 {
+			if reorganized {
+				s.reloadSessions()
+				reorganized = false
+				eventCount = 0
+				newTxs = false
+				changedTxs = nil
+				debounceTimer = nil
+			} else if eventCount > 0 || newTxs || len(changedTxs) > 0 {
 				s.processChanges(eventCount, changedTxs)
 				eventCount = 0
 				newTxs = false
@@ -425,6 +437,20 @@ func (s *Service) processChanges(eventCount int, changedTxs []TransactionID) {
 	}
 }
 
+// reloadSessions sends the entries of the sessions again, as after a ResetFilterSession
+func (s *Service) reloadSessions() {
+	for _, session := range s.getAllSessions() {
+		session.mu.RLock()
+		count := len(session.new) + len(session.model)
+		session.mu.RUnlock()
+
+		err := s.ResetFilterSession(session.id, max(count, 1))
+		if err != nil {
+			logutils.ZapLogger().Error("Error reloading session", zap.Int32("session", int32(session.id)), zap.Error(err))
+		}
+	}
+}
+
 func (s *Service) processEntryDataUpdates(sessionID SessionID, entries []Entry, changedTxs []TransactionID) {
 	updateData := make([]*EntryData, 0, len(changedTxs))
 
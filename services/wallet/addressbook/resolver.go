@@ -0,0 +1,204 @@
+package addressbook
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const (
+	ensCacheTTL      = 24 * time.Hour
+	ensCacheCapacity = 2000
+	// maxENSLookupsPerCall bounds the RPC calls started for a single resolve request, the rest will be resolved on later calls
+	maxENSLookupsPerCall = 20
+	ensLookupTimeout     = time.Minute
+)
+
+// ENSNameResolver is implemented by ensresolver.EnsResolver
+type ENSNameResolver interface {
+	GetName(ctx context.Context, chainID uint64, address common.Address) (string, error)
+}
+
+// Resolver resolves addresses to labels using the sources in priority order (first source wins)
+// and falls back to ENS reverse records for the addresses no source knows about
+type Resolver struct {
+	sources []Source
+	ens     ENSNameResolver
+
+	// ensCache holds the ENS names of the addresses, empty when the address has no reverse record
+	ensCache       *ttlcache.Cache[common.Address, string]
+	pendingMutex   sync.Mutex
+	pendingLookups map[common.Address]bool
+	lookups        sync.WaitGroup
+}
+
+func NewResolver(sources []Source, ens ENSNameResolver) *Resolver {
+	return &Resolver{
+		sources: sources,
+		ens:     ens,
+		ensCache: ttlcache.New[common.Address, string](
+			ttlcache.WithTTL[common.Address, string](ensCacheTTL),
+			ttlcache.WithCapacity[common.Address, string](ensCacheCapacity),
+			ttlcache.WithDisableTouchOnHit[common.Address, string](),
+		),
+		pendingLookups: make(map[common.Address]bool),
+	}
+}
+
+// allLabels returns the labels from all sources, keeping the label of the highest priority source for each address
+func (r *Resolver) allLabels(ctx context.Context) map[common.Address]*Label {
+	res := make(map[common.Address]*Label)
+	for _, source := range r.sources {
+		labels, err := source.Labels(ctx)
+		if err != nil {
+			logutils.ZapLogger().Warn("addressbook: failed to load labels", zap.Error(err))
+			continue
+		}
+		for _, label := range labels {
+			if _, ok := res[label.Address]; !ok {
+				res[label.Address] = label
+			}
+		}
+	}
+	return res
+}
+
+// ResolveLabels returns the labels for the given addresses. Addresses without a label are not part of the result.
+// The ENS names which are not cached yet are looked up in the background and returned by later calls.
+func (r *Resolver) ResolveLabels(ctx context.Context, addresses []common.Address) map[common.Address]*Label {
+	res := make(map[common.Address]*Label, len(addresses))
+	if len(addresses) == 0 {
+		return res
+	}
+
+	all := r.allLabels(ctx)
+	var missing []common.Address
+	for _, address := range addresses {
+		if address == (common.Address{}) {
+			continue
+		}
+		if label, ok := all[address]; ok {
+			res[address] = label
+		} else {
+			missing = append(missing, address)
+		}
+	}
+
+	for address, name := range r.ensNames(missing) {
+		res[address] = &Label{
+			Address: address,
+			Name:    name,
+			Source:  LabelSourceENS,
+			ENSName: name,
+		}
+	}
+
+	return res
+}
+
+// ensNames returns the cached ENS reverse records for the addresses and starts the lookup of the others
+func (r *Resolver) ensNames(addresses []common.Address) map[common.Address]string {
+	res := make(map[common.Address]string)
+	if r.ens == nil {
+		return res
+	}
+
+	var toLookup []common.Address
+	r.pendingMutex.Lock()
+	for _, address := range addresses {
+		if item := r.ensCache.Get(address); item != nil {
+			if len(item.Value()) > 0 {
+				res[address] = item.Value()
+			}
+			continue
+		}
+		if !r.pendingLookups[address] && len(toLookup) < maxENSLookupsPerCall {
+			r.pendingLookups[address] = true
+			toLookup = append(toLookup, address)
+		}
+	}
+	r.pendingMutex.Unlock()
+
+	if len(toLookup) > 0 {
+		r.lookups.Add(1)
+		go r.lookupENSNames(toLookup)
+	}
+
+	return res
+}
+
+func (r *Resolver) lookupENSNames(addresses []common.Address) {
+	defer gocommon.LogOnPanic()
+	defer r.lookups.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ensLookupTimeout)
+	defer cancel()
+
+	for _, address := range addresses {
+		if ctx.Err() == nil {
+			// Missing reverse records are reported as errors and cached as empty names
+			name, _ := r.ens.GetName(ctx, walletCommon.EthereumMainnet, address)
+			r.ensCache.Set(address, name, ttlcache.DefaultTTL)
+		}
+
+		r.pendingMutex.Lock()
+		delete(r.pendingLookups, address)
+		r.pendingMutex.Unlock()
+	}
+}
+
+// FindAddresses returns the addresses whose label name or ENS name contains one of the names (case insensitive)
+// or that belong to one of the contacts
+func (r *Resolver) FindAddresses(ctx context.Context, names []string, contactIDs []string) []common.Address {
+	lowerNames := make([]string, 0, len(names))
+	for _, name := range names {
+		if len(name) > 0 {
+			lowerNames = append(lowerNames, strings.ToLower(name))
+		}
+	}
+
+	contacts := make(map[string]bool, len(contactIDs))
+	for _, id := range contactIDs {
+		contacts[id] = true
+	}
+
+	matches := func(label *Label) bool {
+		if len(label.ContactID) > 0 && contacts[label.ContactID] {
+			return true
+		}
+		for _, name := range lowerNames {
+			if strings.Contains(strings.ToLower(label.Name), name) ||
+				(len(label.ENSName) > 0 && strings.Contains(strings.ToLower(label.ENSName), name)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var res []common.Address
+	all := r.allLabels(ctx)
+	for address, label := range all {
+		if matches(label) {
+			res = append(res, address)
+		}
+	}
+
+	r.ensCache.Range(func(item *ttlcache.Item[common.Address, string]) bool {
+		if _, labeled := all[item.Key()]; !labeled && len(item.Value()) > 0 && matches(&Label{Name: item.Value()}) {
+			res = append(res, item.Key())
+		}
+		return true
+	})
+
+	return res
+}
@@ -0,0 +1,134 @@
+package addressbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+type staticSource struct {
+	labels []*Label
+}
+
+func (s *staticSource) Labels(ctx context.Context) ([]*Label, error) {
+	return s.labels, nil
+}
+
+type mockENSResolver struct {
+	names map[common.Address]string
+	calls int
+}
+
+func (m *mockENSResolver) GetName(ctx context.Context, chainID uint64, address common.Address) (string, error) {
+	m.calls++
+	if name, ok := m.names[address]; ok {
+		return name, nil
+	}
+	return "", errors.New("no reverse record")
+}
+
+func TestResolveLabelsPriority(t *testing.T) {
+	own := &staticSource{labels: []*Label{{Address: common.Address{1}, Name: "Main", Source: LabelSourceOwnAccount}}}
+	saved := &staticSource{labels: []*Label{
+		{Address: common.Address{1}, Name: "Saved main", Source: LabelSourceSavedAddress},
+		{Address: common.Address{2}, Name: "Alice", Source: LabelSourceSavedAddress},
+	}}
+	ens := &mockENSResolver{names: map[common.Address]string{{3}: "bob.eth"}}
+
+	resolver := NewResolver([]Source{own, saved}, ens)
+	labels := resolver.ResolveLabels(context.Background(), []common.Address{{1}, {2}, {3}, {4}, {}})
+
+	// ENS names are looked up in the background
+	require.Len(t, labels, 2)
+	require.Equal(t, "Main", labels[common.Address{1}].Name)
+	require.Equal(t, LabelSourceOwnAccount, labels[common.Address{1}].Source)
+	require.Equal(t, "Alice", labels[common.Address{2}].Name)
+	resolver.lookups.Wait()
+	require.Equal(t, 2, ens.calls)
+
+	labels = resolver.ResolveLabels(context.Background(), []common.Address{{1}, {2}, {3}, {4}})
+	require.Len(t, labels, 3)
+	require.Equal(t, "bob.eth", labels[common.Address{3}].Name)
+	require.Equal(t, LabelSourceENS, labels[common.Address{3}].Source)
+	require.Equal(t, 2, ens.calls)
+
+	// ENS names, including missing ones, are cached
+	labels = resolver.ResolveLabels(context.Background(), []common.Address{{3}, {4}})
+	require.Len(t, labels, 1)
+	require.Equal(t, 2, ens.calls)
+}
+
+func TestFindAddresses(t *testing.T) {
+	source := &staticSource{labels: []*Label{
+		{Address: common.Address{1}, Name: "Alice - Savings", Source: LabelSourceContact, ContactID: "0x04aa"},
+		{Address: common.Address{2}, Name: "Alice - Daily", Source: LabelSourceContact, ContactID: "0x04aa"},
+		{Address: common.Address{3}, Name: "Treasury", ENSName: "dao.eth", Source: LabelSourceSavedAddress},
+	}}
+	ens := &mockENSResolver{names: map[common.Address]string{{4}: "bob.eth"}}
+	resolver := NewResolver([]Source{source}, ens)
+
+	require.ElementsMatch(t, []common.Address{{1}, {2}}, resolver.FindAddresses(context.Background(), nil, []string{"0x04aa"}))
+	require.ElementsMatch(t, []common.Address{{1}}, resolver.FindAddresses(context.Background(), []string{"savings"}, nil))
+	require.ElementsMatch(t, []common.Address{{3}}, resolver.FindAddresses(context.Background(), []string{"DAO"}, nil))
+	require.Empty(t, resolver.FindAddresses(context.Background(), []string{"bob"}, nil))
+
+	// Only cached ENS names can be searched
+	resolver.ResolveLabels(context.Background(), []common.Address{{4}})
+	resolver.lookups.Wait()
+	require.ElementsMatch(t, []common.Address{{4}}, resolver.FindAddresses(context.Background(), []string{"bob"}, nil))
+}
+
+type testNetworksSettings struct {
+	enabled bool
+}
+
+func (s *testNetworksSettings) GetTestNetworksEnabled() (bool, error) {
+	return s.enabled, nil
+}
+
+func TestSavedAddressesSource(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	insert := "INSERT INTO saved_addresses (address, name, removed, update_clock, chain_short_names, ens_name, is_test, created_at, color) VALUES (?, ?, ?, 0, '', ?, ?, 0, ?)"
+	_, err = db.Exec(insert, common.Address{1}, "Alice", false, "alice.eth", false, "blue")
+	require.NoError(t, err)
+	_, err = db.Exec(insert, common.Address{2}, "Removed", true, "", false, "")
+	require.NoError(t, err)
+	_, err = db.Exec(insert, common.Address{3}, "Bob", false, "", true, "")
+	require.NoError(t, err)
+
+	settings := &testNetworksSettings{}
+	source := NewSavedAddressesSource(db, settings)
+	labels, err := source.Labels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, labels, 1)
+	require.Equal(t, common.Address{1}, labels[0].Address)
+	require.Equal(t, "Alice", labels[0].Name)
+	require.Equal(t, "alice.eth", labels[0].ENSName)
+	require.Equal(t, "blue", labels[0].ColorID)
+	require.Equal(t, LabelSourceSavedAddress, labels[0].Source)
+
+	// Only the saved addresses of the current networks mode are labeled
+	settings.enabled = true
+	labels, err = source.Labels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, labels, 1)
+	require.Equal(t, common.Address{3}, labels[0].Address)
+	require.Equal(t, "Bob", labels[0].Name)
+}
+
+func TestContactLabelName(t *testing.T) {
+	require.Equal(t, "Ally - Savings", contactLabelName("Ally", "Alice", "alice.eth", "Savings"))
+	require.Equal(t, "Alice", contactLabelName("", "Alice", "alice.eth", ""))
+	require.Equal(t, "alice.eth - Savings", contactLabelName("", "", "alice.eth", "Savings"))
+	require.Equal(t, "Savings", contactLabelName("", "", "", "Savings"))
+}
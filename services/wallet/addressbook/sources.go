@@ -0,0 +1,192 @@
+package addressbook
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/multiaccounts/accounts"
+)
+
+type LabelSource string
+
+const (
+	LabelSourceOwnAccount   LabelSource = "own-account"
+	LabelSourceSavedAddress LabelSource = "saved-address"
+	LabelSourceContact      LabelSource = "contact"
+	LabelSourceENS          LabelSource = "ens"
+)
+
+// Label is a human readable name resolved for an address
+type Label struct {
+	Address   common.Address `json:"address"`
+	Name      string         `json:"name"`
+	Source    LabelSource    `json:"source"`
+	ColorID   string         `json:"colorId,omitempty"`
+	Emoji     string         `json:"emoji,omitempty"`
+	ENSName   string         `json:"ens,omitempty"`
+	ContactID string         `json:"contactId,omitempty"`
+}
+
+// Source provides labels for all the addresses it knows about
+type Source interface {
+	Labels(ctx context.Context) ([]*Label, error)
+}
+
+// OwnAccountsSource labels the wallet accounts of the user
+type OwnAccountsSource struct {
+	accountsDB *accounts.Database
+}
+
+func NewOwnAccountsSource(accountsDB *accounts.Database) *OwnAccountsSource {
+	return &OwnAccountsSource{accountsDB: accountsDB}
+}
+
+func (s *OwnAccountsSource) Labels(ctx context.Context) ([]*Label, error) {
+	accs, err := s.accountsDB.GetActiveAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*Label, 0, len(accs))
+	for _, acc := range accs {
+		if acc.Chat {
+			continue
+		}
+		labels = append(labels, &Label{
+			Address: common.Address(acc.Address),
+			Name:    acc.Name,
+			Source:  LabelSourceOwnAccount,
+			ColorID: string(acc.ColorID),
+			Emoji:   acc.Emoji,
+		})
+	}
+	return labels, nil
+}
+
+// TestNetworksSettings is implemented by accounts.Database
+type TestNetworksSettings interface {
+	GetTestNetworksEnabled() (bool, error)
+}
+
+// SavedAddressesSource labels the saved addresses stored in the wallet DB for the current networks mode
+type SavedAddressesSource struct {
+	db       *sql.DB
+	settings TestNetworksSettings
+}
+
+func NewSavedAddressesSource(walletDB *sql.DB, settings TestNetworksSettings) *SavedAddressesSource {
+	return &SavedAddressesSource{db: walletDB, settings: settings}
+}
+
+func (s *SavedAddressesSource) Labels(ctx context.Context) ([]*Label, error) {
+	testNetworksEnabled, err := s.settings.GetTestNetworksEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT address, name, ens_name, color FROM saved_addresses WHERE removed != 1 AND is_test = ?", testNetworksEnabled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []*Label
+	for rows.Next() {
+		label := &Label{Source: LabelSourceSavedAddress}
+		var ensName, colorID sql.NullString
+		err := rows.Scan(&label.Address, &label.Name, &ensName, &colorID)
+		if err != nil {
+			return nil, err
+		}
+		label.ENSName = ensName.String
+		label.ColorID = colorID.String
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+// ContactsSource labels the accounts the added and not blocked contacts showcase in their profile
+type ContactsSource struct {
+	db *sql.DB
+}
+
+func NewContactsSource(appDB *sql.DB) *ContactsSource {
+	return &ContactsSource{db: appDB}
+}
+
+const contactShowcaseAccountsQuery = `
+SELECT
+	psa.address,
+	psa.name,
+	psa.color_id,
+	psa.emoji,
+	psa.contact_id,
+	COALESCE(c.local_nickname, ''),
+	c.display_name,
+	c.name
+FROM
+	profile_showcase_accounts_contacts psa
+JOIN
+	contacts c
+ON
+	c.id = psa.contact_id
+WHERE
+	c.contact_request_state = ? AND
+	NOT COALESCE(c.blocked, FALSE) AND
+	NOT COALESCE(c.removed, FALSE)
+`
+
+// contactRequestStateSent is protocol.ContactRequestStateSent, the local state of the contacts the user added
+const contactRequestStateSent = 2
+
+func (s *ContactsSource) Labels(ctx context.Context) ([]*Label, error) {
+	rows, err := s.db.QueryContext(ctx, contactShowcaseAccountsQuery, contactRequestStateSent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []*Label
+	for rows.Next() {
+		var address, accountName, colorID, emoji, contactID, nickname, displayName, ensName sql.NullString
+		err := rows.Scan(&address, &accountName, &colorID, &emoji, &contactID, &nickname, &displayName, &ensName)
+		if err != nil {
+			return nil, err
+		}
+		if !common.IsHexAddress(address.String) {
+			continue
+		}
+
+		labels = append(labels, &Label{
+			Address:   common.HexToAddress(address.String),
+			Name:      contactLabelName(nickname.String, displayName.String, ensName.String, accountName.String),
+			Source:    LabelSourceContact,
+			ColorID:   colorID.String,
+			Emoji:     emoji.String,
+			ENSName:   ensName.String,
+			ContactID: contactID.String,
+		})
+	}
+	return labels, rows.Err()
+}
+
+// contactLabelName prefers the name the user gave to the contact, then the contact's own names.
+// The showcased account name is appended to tell apart multiple accounts of the same contact
+func contactLabelName(nickname, displayName, ensName, accountName string) string {
+	name := nickname
+	if len(name) == 0 {
+		name = displayName
+	}
+	if len(name) == 0 {
+		name = ensName
+	}
+	if len(accountName) == 0 {
+		return name
+	}
+	if len(name) == 0 {
+		return accountName
+	}
+	return name + " - " + accountName
+}
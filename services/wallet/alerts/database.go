@@ -0,0 +1,148 @@
+package alerts
+
+import (
+	"database/sql"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type RulesDB struct {
+	db *sql.DB
+}
+
+func NewRulesDB(sqlDb *sql.DB) *RulesDB {
+	return &RulesDB{
+		db: sqlDb,
+	}
+}
+
+const selectRulesColumns = `SELECT id, type, symbol, currency, threshold, account, chain_id, enabled, last_value, created_at, last_triggered_at
+	FROM wallet_alert_rules`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*Rule, error) {
+	rule := &Rule{}
+	var account string
+	var lastValue sql.NullFloat64
+	err := row.Scan(&rule.ID, &rule.Type, &rule.Symbol, &rule.Currency, &rule.Threshold, &account, &rule.ChainID,
+		&rule.Enabled, &lastValue, &rule.CreatedAt, &rule.LastTriggeredAt)
+	if err != nil {
+		return nil, err
+	}
+	if account != "" {
+		rule.Account = common.HexToAddress(account)
+	}
+	if lastValue.Valid {
+		rule.lastValue = &lastValue.Float64
+	}
+	return rule, nil
+}
+
+func (r *RulesDB) queryRules(query string, args ...interface{}) ([]*Rule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*Rule, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// Add stores a new rule and sets its ID
+func (r *RulesDB) Add(rule *Rule) error {
+	var account string
+	if rule.Type == RuleTypeBalanceChange {
+		account = rule.Account.Hex()
+	}
+	res, err := r.db.Exec(`INSERT INTO wallet_alert_rules
+		(type, symbol, currency, threshold, account, chain_id, enabled, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.Type, rule.Symbol, rule.Currency, rule.Threshold, account, rule.ChainID, rule.Enabled, rule.CreatedAt)
+	if err != nil {
+		return err
+	}
+	rule.ID, err = res.LastInsertId()
+	return err
+}
+
+func (r *RulesDB) Get(id int64) (*Rule, error) {
+	rule, err := scanRule(r.db.QueryRow(selectRulesColumns+` WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrAlertRuleNotFound
+	}
+	return rule, err
+}
+
+func (r *RulesDB) GetAll() ([]*Rule, error) {
+	return r.queryRules(selectRulesColumns + ` ORDER BY id`)
+}
+
+// GetEnabled returns the enabled rules of the given types
+func (r *RulesDB) GetEnabled(types ...RuleType) ([]*Rule, error) {
+	rules, err := r.queryRules(selectRulesColumns + ` WHERE enabled ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		for _, t := range types {
+			if rule.Type == t {
+				res = append(res, rule)
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+func (r *RulesDB) Delete(id int64) error {
+	res, err := r.db.Exec(`DELETE FROM wallet_alert_rules WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+// SetEnabled enables or disables a rule. The last observed value is reset so that a re-enabled
+// rule doesn't trigger on changes that happened while it was disabled
+func (r *RulesDB) SetEnabled(id int64, enabled bool) error {
+	res, err := r.db.Exec(`UPDATE wallet_alert_rules SET enabled = ?, last_value = NULL WHERE id = ?`, enabled, id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+// UpdateLastValue records the value a rule was last evaluated against
+func (r *RulesDB) UpdateLastValue(id int64, value float64) error {
+	_, err := r.db.Exec(`UPDATE wallet_alert_rules SET last_value = ? WHERE id = ?`, value, id)
+	return err
+}
+
+// SetTriggered records the value and time a rule was triggered at
+func (r *RulesDB) SetTriggered(id int64, value float64, at int64) error {
+	_, err := r.db.Exec(`UPDATE wallet_alert_rules SET last_value = ?, last_triggered_at = ? WHERE id = ?`, value, at, id)
+	return err
+}
+
+func checkRowsAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAlertRuleNotFound
+	}
+	return nil
+}
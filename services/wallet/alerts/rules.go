@@ -0,0 +1,147 @@
+package alerts
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+type RuleType string
+
+const (
+	// RuleTypePriceAbove triggers when the price of Symbol in Currency rises to Threshold or above
+	RuleTypePriceAbove RuleType = "price-above"
+	// RuleTypePriceBelow triggers when the price of Symbol in Currency falls to Threshold or below
+	RuleTypePriceBelow RuleType = "price-below"
+	// RuleTypeBalanceChange triggers when the Symbol balance of Account changed by at least Threshold
+	// since the rule last triggered. ChainID 0 sums the balances of all chains
+	RuleTypeBalanceChange RuleType = "balance-change"
+)
+
+var (
+	ErrUnknownRuleType   = errors.New("unknown alert rule type")
+	ErrMissingSymbol     = errors.New("alert rule symbol is required")
+	ErrMissingCurrency   = errors.New("price alert rule currency is required")
+	ErrMissingAccount    = errors.New("balance alert rule account is required")
+	ErrInvalidThreshold  = errors.New("alert rule threshold must be a positive number")
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+)
+
+type Rule struct {
+	ID              int64          `json:"id"`
+	Type            RuleType       `json:"type"`
+	Symbol          string         `json:"symbol"`
+	Currency        string         `json:"currency,omitempty"`
+	Threshold       float64        `json:"threshold"`
+	Account         common.Address `json:"account"`
+	ChainID         uint64         `json:"chainId,omitempty"`
+	Enabled         bool           `json:"enabled"`
+	CreatedAt       int64          `json:"createdAt"`
+	LastTriggeredAt int64          `json:"lastTriggeredAt"`
+	// lastValue is the previously observed price or the balance the next change is measured from
+	lastValue *float64
+}
+
+func (r *Rule) isPriceRule() bool {
+	return r.Type == RuleTypePriceAbove || r.Type == RuleTypePriceBelow
+}
+
+// validate checks the rule and normalizes the currency
+func (r *Rule) validate() error {
+	if r.Symbol == "" {
+		return ErrMissingSymbol
+	}
+	if r.Threshold <= 0 || math.IsNaN(r.Threshold) || math.IsInf(r.Threshold, 0) {
+		return ErrInvalidThreshold
+	}
+
+	switch r.Type {
+	case RuleTypePriceAbove, RuleTypePriceBelow:
+		if r.Currency == "" {
+			return ErrMissingCurrency
+		}
+		r.Currency = strings.ToUpper(r.Currency)
+	case RuleTypeBalanceChange:
+		if r.Account == (common.Address{}) {
+			return ErrMissingAccount
+		}
+		r.Currency = ""
+	default:
+		return ErrUnknownRuleType
+	}
+	return nil
+}
+
+// evaluatePrice reports whether the price crossed the threshold since the previous observation.
+// The first observation only records the price, so creating a rule never triggers it right away
+func evaluatePrice(rule *Rule, price float64) bool {
+	if rule.lastValue == nil {
+		return false
+	}
+	previous := *rule.lastValue
+
+	switch rule.Type {
+	case RuleTypePriceAbove:
+		return previous < rule.Threshold && price >= rule.Threshold
+	case RuleTypePriceBelow:
+		return previous > rule.Threshold && price <= rule.Threshold
+	}
+	return false
+}
+
+// evaluateBalance reports whether the balance moved by at least the threshold from the baseline.
+// The first observation only records the baseline
+func evaluateBalance(rule *Rule, balance float64) bool {
+	if rule.lastValue == nil {
+		return false
+	}
+	return math.Abs(balance-*rule.lastValue) >= rule.Threshold
+}
+
+// priceOf looks up the rule's price in a map[symbol]map[currency]price
+func priceOf(rule *Rule, prices map[string]map[string]float64) (float64, bool) {
+	perCurrency, ok := prices[rule.Symbol]
+	if !ok {
+		return 0, false
+	}
+	price, ok := perCurrency[rule.Currency]
+	return price, ok
+}
+
+// balanceOf sums the rule's balance over the matching chains. Balances with an error are not
+// reliable, so the rule is not evaluated when one of them is involved
+func balanceOf(rule *Rule, balances map[common.Address][]token.StorageToken) (float64, bool) {
+	tokens, ok := balances[rule.Account]
+	if !ok {
+		return 0, false
+	}
+
+	total := new(big.Float)
+	found := false
+	for _, t := range tokens {
+		if t.Symbol != rule.Symbol {
+			continue
+		}
+		for chainID, chainBalance := range t.BalancesPerChain {
+			if rule.ChainID != 0 && chainID != rule.ChainID {
+				continue
+			}
+			if chainBalance.HasError || chainBalance.Balance == nil {
+				return 0, false
+			}
+			total.Add(total, chainBalance.Balance)
+			found = true
+		}
+	}
+	if !found {
+		return 0, false
+	}
+
+	res, _ := total.Float64()
+	return res, true
+}
@@ -0,0 +1,133 @@
+package alerts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestValidate(t *testing.T) {
+	account := common.HexToAddress("0x1")
+
+	rule := Rule{Type: RuleTypePriceAbove, Symbol: "ETH", Currency: "usd", Threshold: 4000}
+	require.NoError(t, rule.validate())
+	require.Equal(t, "USD", rule.Currency)
+
+	rule = Rule{Type: RuleTypeBalanceChange, Symbol: "ETH", Currency: "USD", Threshold: 1, Account: account}
+	require.NoError(t, rule.validate())
+	require.Empty(t, rule.Currency)
+
+	invalid := []struct {
+		rule Rule
+		err  error
+	}{
+		{Rule{Type: "unknown", Symbol: "ETH", Threshold: 1}, ErrUnknownRuleType},
+		{Rule{Type: RuleTypePriceBelow, Currency: "USD", Threshold: 1}, ErrMissingSymbol},
+		{Rule{Type: RuleTypePriceBelow, Symbol: "ETH", Threshold: 1}, ErrMissingCurrency},
+		{Rule{Type: RuleTypePriceBelow, Symbol: "ETH", Currency: "USD"}, ErrInvalidThreshold},
+		{Rule{Type: RuleTypeBalanceChange, Symbol: "ETH", Threshold: -1, Account: account}, ErrInvalidThreshold},
+		{Rule{Type: RuleTypeBalanceChange, Symbol: "ETH", Threshold: 1}, ErrMissingAccount},
+	}
+	for _, tc := range invalid {
+		require.ErrorIs(t, tc.rule.validate(), tc.err)
+	}
+}
+
+func TestEvaluatePrice(t *testing.T) {
+	above := &Rule{Type: RuleTypePriceAbove, Threshold: 100}
+	below := &Rule{Type: RuleTypePriceBelow, Threshold: 100}
+
+	// The first observation never triggers
+	require.False(t, evaluatePrice(above, 150))
+	require.False(t, evaluatePrice(below, 50))
+
+	above.lastValue = floatPtr(99)
+	require.True(t, evaluatePrice(above, 100))
+	require.True(t, evaluatePrice(above, 150))
+	require.False(t, evaluatePrice(above, 98))
+
+	// Staying above the threshold doesn't trigger again
+	above.lastValue = floatPtr(120)
+	require.False(t, evaluatePrice(above, 150))
+
+	below.lastValue = floatPtr(101)
+	require.True(t, evaluatePrice(below, 100))
+	require.False(t, evaluatePrice(below, 100.5))
+	below.lastValue = floatPtr(90)
+	require.False(t, evaluatePrice(below, 80))
+}
+
+func TestEvaluateBalance(t *testing.T) {
+	rule := &Rule{Type: RuleTypeBalanceChange, Threshold: 1}
+	require.False(t, evaluateBalance(rule, 10))
+
+	rule.lastValue = floatPtr(10)
+	require.False(t, evaluateBalance(rule, 10.5))
+	require.True(t, evaluateBalance(rule, 11))
+	require.True(t, evaluateBalance(rule, 8))
+}
+
+func TestPriceOf(t *testing.T) {
+	prices := map[string]map[string]float64{"ETH": {"USD": 3000, "EUR": 2800}}
+
+	price, ok := priceOf(&Rule{Symbol: "ETH", Currency: "EUR"}, prices)
+	require.True(t, ok)
+	require.Equal(t, 2800.0, price)
+
+	_, ok = priceOf(&Rule{Symbol: "ETH", Currency: "CHF"}, prices)
+	require.False(t, ok)
+	_, ok = priceOf(&Rule{Symbol: "SNT", Currency: "USD"}, prices)
+	require.False(t, ok)
+}
+
+func TestBalanceOf(t *testing.T) {
+	account := common.HexToAddress("0x1")
+	balances := map[common.Address][]token.StorageToken{
+		account: {
+			{
+				Token: token.Token{Symbol: "ETH"},
+				BalancesPerChain: map[uint64]token.ChainBalance{
+					1:  {Balance: big.NewFloat(1.5), ChainID: 1},
+					10: {Balance: big.NewFloat(2), ChainID: 10},
+				},
+			},
+			{
+				Token: token.Token{Symbol: "SNT"},
+				BalancesPerChain: map[uint64]token.ChainBalance{
+					1:  {Balance: big.NewFloat(100), ChainID: 1},
+					10: {Balance: big.NewFloat(0), ChainID: 10, HasError: true},
+				},
+			},
+		},
+	}
+
+	balance, ok := balanceOf(&Rule{Account: account, Symbol: "ETH"}, balances)
+	require.True(t, ok)
+	require.Equal(t, 3.5, balance)
+
+	balance, ok = balanceOf(&Rule{Account: account, Symbol: "ETH", ChainID: 10}, balances)
+	require.True(t, ok)
+	require.Equal(t, 2.0, balance)
+
+	balance, ok = balanceOf(&Rule{Account: account, Symbol: "SNT", ChainID: 1}, balances)
+	require.True(t, ok)
+	require.Equal(t, 100.0, balance)
+
+	// Balances with an error are not reliable
+	_, ok = balanceOf(&Rule{Account: account, Symbol: "SNT"}, balances)
+	require.False(t, ok)
+
+	_, ok = balanceOf(&Rule{Account: account, Symbol: "DAI"}, balances)
+	require.False(t, ok)
+	_, ok = balanceOf(&Rule{Account: common.HexToAddress("0x2"), Symbol: "ETH"}, balances)
+	require.False(t, ok)
+}
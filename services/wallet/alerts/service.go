@@ -0,0 +1,251 @@
+package alerts
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	localnotifications "github.com/status-im/status-go/services/local-notifications"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// EventWalletAlertTriggered is sent with a TriggeredAlert as message whenever a rule triggers
+	EventWalletAlertTriggered walletevent.EventType = "wallet-alert-triggered"
+
+	walletDeeplinkPrefix = "status-app://wallet/"
+)
+
+// TriggeredAlert is the payload of EventWalletAlertTriggered and of the local notification
+type TriggeredAlert struct {
+	Rule Rule `json:"rule"`
+	// Value is the price or the balance that triggered the rule
+	Value float64 `json:"value"`
+	// PreviousValue is the previous price or the balance the change was measured from
+	PreviousValue float64 `json:"previousValue"`
+}
+
+func (t TriggeredAlert) MarshalJSON() ([]byte, error) {
+	type Alias TriggeredAlert
+	item := struct{ *Alias }{Alias: (*Alias)(&t)}
+	return json.Marshal(item)
+}
+
+// Service evaluates the alert rules against the price and balance updates sent on the wallet feed
+type Service struct {
+	db                  *RulesDB
+	walletFeed          *event.Feed
+	walletEventsWatcher *walletevent.Watcher
+	// evaluation of the rules is serialized so that consecutive updates see each other's last values
+	mu sync.Mutex
+	// pushNotifications is replaceable in tests
+	pushNotifications func(ns []*localnotifications.Notification)
+}
+
+func NewService(db *sql.DB, walletFeed *event.Feed) *Service {
+	return &Service{
+		db:                NewRulesDB(db),
+		walletFeed:        walletFeed,
+		pushNotifications: localnotifications.PushMessages,
+	}
+}
+
+func (s *Service) Start() {
+	if s.walletEventsWatcher != nil {
+		return
+	}
+
+	s.walletEventsWatcher = walletevent.NewWatcher(s.walletFeed, func(event walletevent.Event) {
+		switch event.Type {
+		case market.EventInternalMarketPricesUpdated:
+			prices, ok := event.EventParams.(map[string]map[string]float64)
+			if !ok {
+				return
+			}
+			// Evaluating sends events on the same feed, so it can't block the watcher
+			go func() {
+				defer gocommon.LogOnPanic()
+				s.onPricesUpdated(prices)
+			}()
+		case token.EventInternalBalancesUpdated:
+			balances, ok := event.EventParams.(map[common.Address][]token.StorageToken)
+			if !ok {
+				return
+			}
+			go func() {
+				defer gocommon.LogOnPanic()
+				s.onBalancesUpdated(balances)
+			}()
+		}
+	})
+	s.walletEventsWatcher.Start()
+}
+
+func (s *Service) Stop() {
+	if s.walletEventsWatcher != nil {
+		s.walletEventsWatcher.Stop()
+		s.walletEventsWatcher = nil
+	}
+}
+
+// AddRule validates and stores a new enabled rule
+func (s *Service) AddRule(rule Rule) (*Rule, error) {
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = 0
+	rule.Enabled = true
+	rule.CreatedAt = time.Now().Unix()
+	rule.LastTriggeredAt = 0
+	rule.lastValue = nil
+
+	if err := s.db.Add(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *Service) GetRules() ([]*Rule, error) {
+	return s.db.GetAll()
+}
+
+func (s *Service) DeleteRule(id int64) error {
+	return s.db.Delete(id)
+}
+
+func (s *Service) SetRuleEnabled(id int64, enabled bool) error {
+	return s.db.SetEnabled(id, enabled)
+}
+
+func (s *Service) onPricesUpdated(prices map[string]map[string]float64) {
+	s.evaluate([]RuleType{RuleTypePriceAbove, RuleTypePriceBelow}, func(rule *Rule) (float64, bool, bool) {
+		price, ok := priceOf(rule, prices)
+		if !ok {
+			return 0, false, false
+		}
+		return price, true, evaluatePrice(rule, price)
+	})
+}
+
+func (s *Service) onBalancesUpdated(balances map[common.Address][]token.StorageToken) {
+	s.evaluate([]RuleType{RuleTypeBalanceChange}, func(rule *Rule) (float64, bool, bool) {
+		balance, ok := balanceOf(rule, balances)
+		if !ok {
+			return 0, false, false
+		}
+		return balance, true, evaluateBalance(rule, balance)
+	})
+}
+
+// evaluate runs check on the enabled rules of the given types. check returns the current value of the
+// rule, whether that value is known and whether the rule triggers
+func (s *Service) evaluate(types []RuleType, check func(rule *Rule) (value float64, known bool, triggered bool)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules, err := s.db.GetEnabled(types...)
+	if err != nil {
+		logutils.ZapLogger().Error("alerts: failed to load rules", zap.Error(err))
+		return
+	}
+
+	now := time.Now().Unix()
+	notifications := make([]*localnotifications.Notification, 0)
+	for _, rule := range rules {
+		value, known, triggered := check(rule)
+		if !known {
+			continue
+		}
+
+		if !triggered {
+			// A balance rule keeps measuring the change from its baseline until it triggers
+			if rule.isPriceRule() || rule.lastValue == nil {
+				if err := s.db.UpdateLastValue(rule.ID, value); err != nil {
+					logutils.ZapLogger().Error("alerts: failed to update rule", zap.Int64("id", rule.ID), zap.Error(err))
+				}
+			}
+			continue
+		}
+
+		if err := s.db.SetTriggered(rule.ID, value, now); err != nil {
+			logutils.ZapLogger().Error("alerts: failed to update rule", zap.Int64("id", rule.ID), zap.Error(err))
+			continue
+		}
+
+		alert := TriggeredAlert{
+			Rule:          *rule,
+			Value:         value,
+			PreviousValue: *rule.lastValue,
+		}
+		alert.Rule.LastTriggeredAt = now
+		s.sendTriggeredEvent(alert)
+		notifications = append(notifications, buildNotification(alert, now))
+	}
+
+	if len(notifications) > 0 {
+		s.pushNotifications(notifications)
+	}
+}
+
+func (s *Service) sendTriggeredEvent(alert TriggeredAlert) {
+	message, err := json.Marshal(alert)
+	if err != nil {
+		logutils.ZapLogger().Error("alerts: failed to marshal alert", zap.Error(err))
+		return
+	}
+
+	var accounts []common.Address
+	if alert.Rule.Type == RuleTypeBalanceChange {
+		accounts = []common.Address{alert.Rule.Account}
+	}
+
+	s.walletFeed.Send(walletevent.Event{
+		Type:     EventWalletAlertTriggered,
+		Accounts: accounts,
+		ChainID:  alert.Rule.ChainID,
+		Message:  string(message),
+		At:       time.Now().Unix(),
+	})
+}
+
+func buildNotification(alert TriggeredAlert, at int64) *localnotifications.Notification {
+	rule := alert.Rule
+	n := &localnotifications.Notification{
+		ID:       crypto.Keccak256Hash([]byte(fmt.Sprintf("wallet-alert-%d-%d", rule.ID, at))),
+		Body:     alert,
+		BodyType: localnotifications.TypeWalletAlert,
+		Category: localnotifications.CategoryWalletAlert,
+		Deeplink: walletDeeplinkPrefix,
+	}
+
+	switch rule.Type {
+	case RuleTypePriceAbove:
+		n.Title = rule.Symbol + " price alert"
+		n.Message = fmt.Sprintf("%s is above %s %s", rule.Symbol, formatFloat(rule.Threshold), rule.Currency)
+	case RuleTypePriceBelow:
+		n.Title = rule.Symbol + " price alert"
+		n.Message = fmt.Sprintf("%s is below %s %s", rule.Symbol, formatFloat(rule.Threshold), rule.Currency)
+	case RuleTypeBalanceChange:
+		n.Title = rule.Symbol + " balance alert"
+		n.Message = fmt.Sprintf("%s balance changed from %s to %s", rule.Symbol, formatFloat(alert.PreviousValue), formatFloat(alert.Value))
+		n.Deeplink = walletDeeplinkPrefix + rule.Account.String()
+	}
+	return n
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,151 @@
+package alerts
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+
+	localnotifications "github.com/status-im/status-go/services/local-notifications"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupServiceTest(t *testing.T) (*Service, *[]*localnotifications.Notification, chan walletevent.Event, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	feed := &event.Feed{}
+	ch := make(chan walletevent.Event, 10)
+	sub := feed.Subscribe(ch)
+
+	s := NewService(db, feed)
+	pushed := make([]*localnotifications.Notification, 0)
+	s.pushNotifications = func(ns []*localnotifications.Notification) {
+		pushed = append(pushed, ns...)
+	}
+
+	return s, &pushed, ch, func() {
+		sub.Unsubscribe()
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestRulesDB(t *testing.T) {
+	s, _, _, cleanup := setupServiceTest(t)
+	defer cleanup()
+
+	price, err := s.AddRule(Rule{Type: RuleTypePriceAbove, Symbol: "ETH", Currency: "usd", Threshold: 4000})
+	require.NoError(t, err)
+	require.NotZero(t, price.ID)
+	require.True(t, price.Enabled)
+
+	account := common.HexToAddress("0x1")
+	balance, err := s.AddRule(Rule{Type: RuleTypeBalanceChange, Symbol: "SNT", Threshold: 10, Account: account, ChainID: 10})
+	require.NoError(t, err)
+
+	_, err = s.AddRule(Rule{Type: RuleTypePriceBelow, Symbol: "ETH", Threshold: 1})
+	require.ErrorIs(t, err, ErrMissingCurrency)
+
+	rules, err := s.GetRules()
+	require.NoError(t, err)
+	require.Equal(t, []*Rule{price, balance}, rules)
+
+	require.NoError(t, s.db.UpdateLastValue(price.ID, 3000))
+	stored, err := s.db.Get(price.ID)
+	require.NoError(t, err)
+	require.Equal(t, floatPtr(3000), stored.lastValue)
+
+	// Disabling resets the last value
+	require.NoError(t, s.SetRuleEnabled(price.ID, false))
+	enabled, err := s.db.GetEnabled(RuleTypePriceAbove, RuleTypePriceBelow)
+	require.NoError(t, err)
+	require.Empty(t, enabled)
+	require.NoError(t, s.SetRuleEnabled(price.ID, true))
+	stored, err = s.db.Get(price.ID)
+	require.NoError(t, err)
+	require.Nil(t, stored.lastValue)
+
+	require.NoError(t, s.DeleteRule(balance.ID))
+	require.ErrorIs(t, s.DeleteRule(balance.ID), ErrAlertRuleNotFound)
+	require.ErrorIs(t, s.SetRuleEnabled(balance.ID, true), ErrAlertRuleNotFound)
+	_, err = s.db.Get(balance.ID)
+	require.ErrorIs(t, err, ErrAlertRuleNotFound)
+}
+
+func TestPriceAlert(t *testing.T) {
+	s, pushed, ch, cleanup := setupServiceTest(t)
+	defer cleanup()
+
+	rule, err := s.AddRule(Rule{Type: RuleTypePriceAbove, Symbol: "ETH", Currency: "USD", Threshold: 4000})
+	require.NoError(t, err)
+
+	s.onPricesUpdated(map[string]map[string]float64{"ETH": {"USD": 3900}})
+	s.onPricesUpdated(map[string]map[string]float64{"SNT": {"USD": 0.1}})
+	require.Empty(t, *pushed)
+
+	s.onPricesUpdated(map[string]map[string]float64{"ETH": {"USD": 4100}})
+	require.Len(t, *pushed, 1)
+	n := (*pushed)[0]
+	require.Equal(t, localnotifications.TypeWalletAlert, n.BodyType)
+	require.Equal(t, "ETH is above 4000 USD", n.Message)
+
+	ev := <-ch
+	require.Equal(t, EventWalletAlertTriggered, ev.Type)
+	var alert TriggeredAlert
+	require.NoError(t, json.Unmarshal([]byte(ev.Message), &alert))
+	require.Equal(t, rule.ID, alert.Rule.ID)
+	require.Equal(t, 4100.0, alert.Value)
+	require.Equal(t, 3900.0, alert.PreviousValue)
+
+	// Staying above the threshold doesn't trigger again
+	s.onPricesUpdated(map[string]map[string]float64{"ETH": {"USD": 4200}})
+	require.Len(t, *pushed, 1)
+
+	stored, err := s.db.Get(rule.ID)
+	require.NoError(t, err)
+	require.NotZero(t, stored.LastTriggeredAt)
+}
+
+func TestBalanceAlert(t *testing.T) {
+	s, pushed, ch, cleanup := setupServiceTest(t)
+	defer cleanup()
+
+	account := common.HexToAddress("0x1")
+	_, err := s.AddRule(Rule{Type: RuleTypeBalanceChange, Symbol: "ETH", Threshold: 1, Account: account})
+	require.NoError(t, err)
+
+	balances := func(value float64) map[common.Address][]token.StorageToken {
+		return map[common.Address][]token.StorageToken{
+			account: {{
+				Token:            token.Token{Symbol: "ETH"},
+				BalancesPerChain: map[uint64]token.ChainBalance{1: {Balance: big.NewFloat(value), ChainID: 1}},
+			}},
+		}
+	}
+
+	s.onBalancesUpdated(balances(10))
+	// Small changes accumulate against the baseline
+	s.onBalancesUpdated(balances(10.6))
+	require.Empty(t, *pushed)
+	s.onBalancesUpdated(balances(11.2))
+	require.Len(t, *pushed, 1)
+	require.Equal(t, "ETH balance changed from 10 to 11.2", (*pushed)[0].Message)
+	require.Equal(t, walletDeeplinkPrefix+account.String(), (*pushed)[0].Deeplink)
+
+	ev := <-ch
+	require.Equal(t, EventWalletAlertTriggered, ev.Type)
+	require.Equal(t, []common.Address{account}, ev.Accounts)
+
+	// The baseline moved to the triggering balance
+	s.onBalancesUpdated(balances(11.9))
+	require.Len(t, *pushed, 1)
+	s.onBalancesUpdated(balances(10.1))
+	require.Len(t, *pushed, 2)
+}
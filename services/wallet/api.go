@@ -19,25 +19,34 @@ import (
 	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
 	abi_spec "github.com/status-im/status-go/abi-spec"
 	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/circuitbreaker"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/rpc/network"
 	"github.com/status-im/status-go/services/typeddata"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/addressbook"
+	"github.com/status-im/status-go/services/wallet/alerts"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	wcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/eip712"
 	"github.com/status-im/status-go/services/wallet/history"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/portfolio"
 	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/routeexecution"
 	"github.com/status-im/status-go/services/wallet/router"
 	"github.com/status-im/status-go/services/wallet/router/fees"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/safe"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/walletconnect"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/transactions"
@@ -133,7 +142,8 @@ func (api *API) GetTransfersByAddress(ctx context.Context, address common.Addres
 	if limit != nil {
 		intLimit = limit.ToInt().Int64()
 	}
-	return api.s.transferController.GetTransfersByAddress(ctx, api.s.rpcClient.UpstreamChainID, address, hexBigToBN(toBlock), intLimit, fetchMore)
+	views, err := api.s.transferController.GetTransfersByAddress(ctx, api.s.rpcClient.UpstreamChainID, address, hexBigToBN(toBlock), intLimit, fetchMore)
+	return api.s.fillInTransferLabels(ctx, views), err
 }
 
 // @deprecated
@@ -147,14 +157,24 @@ func (api *API) LoadTransferByHash(ctx context.Context, address common.Address,
 // @deprecated
 func (api *API) GetTransfersByAddressAndChainID(ctx context.Context, chainID uint64, address common.Address, toBlock, limit *hexutil.Big, fetchMore bool) ([]transfer.View, error) {
 	logutils.ZapLogger().Debug("[WalletAPI:: GetTransfersByAddressAndChainIDs] get transfers for an address", zap.Stringer("address", address))
-	return api.s.transferController.GetTransfersByAddress(ctx, chainID, address, hexBigToBN(toBlock), limit.ToInt().Int64(), fetchMore)
+	views, err := api.s.transferController.GetTransfersByAddress(ctx, chainID, address, hexBigToBN(toBlock), limit.ToInt().Int64(), fetchMore)
+	return api.s.fillInTransferLabels(ctx, views), err
 }
 
 // @deprecated
 func (api *API) GetTransfersForIdentities(ctx context.Context, identities []transfer.TransactionIdentity) ([]transfer.View, error) {
 	logutils.ZapLogger().Debug("wallet.api.GetTransfersForIdentities", zap.Int("identities.len", len(identities)))
 
-	return api.s.transferController.GetTransfersForIdentities(ctx, identities)
+	views, err := api.s.transferController.GetTransfersForIdentities(ctx, identities)
+	return api.s.fillInTransferLabels(ctx, views), err
+}
+
+// ResolveAddressLabels returns the address book labels (own accounts, saved addresses, contacts and ENS names)
+// of the given addresses. Addresses without a label are not part of the result
+func (api *API) ResolveAddressLabels(ctx context.Context, addresses []common.Address) (map[common.Address]*addressbook.Label, error) {
+	logutils.ZapLogger().Debug("wallet.api.ResolveAddressLabels", zap.Int("addresses.len", len(addresses)))
+
+	return api.s.labelResolver.ResolveLabels(ctx, addresses), nil
 }
 
 func (api *API) FetchDecodedTxData(ctx context.Context, data string) (*thirdparty.DataParsed, error) {
@@ -163,6 +183,33 @@ func (api *API) FetchDecodedTxData(ctx context.Context, data string) (*thirdpart
 	return api.s.decoder.Decode(data)
 }
 
+// DecodeTransaction decodes the calldata of a transaction with the imported, verified or well-known contract ABIs
+// and summarizes transfers, approvals and swaps. Plain value transfers have an empty data
+func (api *API) DecodeTransaction(ctx context.Context, chainID uint64, to *common.Address, data types.HexBytes, value *hexutil.Big) (*txdecoder.DecodedCall, error) {
+	logutils.ZapLogger().Debug("wallet.api.DecodeTransaction", zap.Uint64("chainID", chainID), zap.Int("data.len", len(data)))
+
+	return api.s.txDecoder.Decode(ctx, chainID, to, data, value.ToInt())
+}
+
+// ImportContractABI stores the JSON ABI of a contract used to decode the transactions sent to it
+func (api *API) ImportContractABI(ctx context.Context, chainID uint64, address common.Address, name string, abiJSON string) error {
+	logutils.ZapLogger().Debug("wallet.api.ImportContractABI", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.txDecoder.ImportContractABI(chainID, address, name, abiJSON)
+}
+
+func (api *API) GetImportedContractABIs(ctx context.Context) ([]*txdecoder.ContractABI, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetImportedContractABIs")
+
+	return api.s.txDecoder.GetContractABIs()
+}
+
+func (api *API) RemoveContractABI(ctx context.Context, chainID uint64, address common.Address) error {
+	logutils.ZapLogger().Debug("wallet.api.RemoveContractABI", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.txDecoder.RemoveContractABI(chainID, address)
+}
+
 // GetBalanceHistory retrieves token balance history for token identity on multiple chains
 func (api *API) GetBalanceHistory(ctx context.Context, chainIDs []uint64, addresses []common.Address, tokenSymbol string, currencySymbol string, timeInterval history.TimeInterval) ([]*history.ValuePoint, error) {
 	logutils.ZapLogger().Debug("wallet.api.GetBalanceHistory",
@@ -193,6 +240,133 @@ func (api *API) GetBalanceHistory(ctx context.Context, chainIDs []uint64, addres
 	return api.GetBalanceHistoryRange(ctx, chainIDs, addresses, tokenSymbol, currencySymbol, fromTimestamp, now)
 }
 
+// GetPortfolioPnL returns the realized and unrealized profit and loss of the given accounts, aggregated and per account
+func (api *API) GetPortfolioPnL(ctx context.Context, addresses []common.Address, method portfolio.CostBasisMethod, currency string) (*portfolio.PnL, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetPortfolioPnL",
+		zap.Stringers("addresses", addresses),
+		zap.Int("method", int(method)),
+		zap.String("currency", currency),
+	)
+
+	return api.s.portfolio.GetPnL(ctx, addresses, method, currency)
+}
+
+// AddAlertRule stores a new price or balance alert rule and returns it with its ID
+func (api *API) AddAlertRule(ctx context.Context, rule alerts.Rule) (*alerts.Rule, error) {
+	logutils.ZapLogger().Debug("wallet.api.AddAlertRule",
+		zap.String("type", string(rule.Type)),
+		zap.String("symbol", rule.Symbol),
+	)
+
+	return api.s.alerts.AddRule(rule)
+}
+
+// GetAlertRules returns all the alert rules
+func (api *API) GetAlertRules(ctx context.Context) ([]*alerts.Rule, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetAlertRules")
+
+	return api.s.alerts.GetRules()
+}
+
+// DeleteAlertRule removes an alert rule
+func (api *API) DeleteAlertRule(ctx context.Context, id int64) error {
+	logutils.ZapLogger().Debug("wallet.api.DeleteAlertRule", zap.Int64("id", id))
+
+	return api.s.alerts.DeleteRule(id)
+}
+
+// SetAlertRuleEnabled enables or disables an alert rule
+func (api *API) SetAlertRuleEnabled(ctx context.Context, id int64, enabled bool) error {
+	logutils.ZapLogger().Debug("wallet.api.SetAlertRuleEnabled", zap.Int64("id", id), zap.Bool("enabled", enabled))
+
+	return api.s.alerts.SetRuleEnabled(id, enabled)
+}
+
+// AddSafeAccount starts following the Safe multisig deployed at address on the given chain
+func (api *API) AddSafeAccount(ctx context.Context, chainID uint64, address common.Address, name string) (*safe.Account, error) {
+	logutils.ZapLogger().Debug("wallet.api.AddSafeAccount", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.safeManager.AddAccount(ctx, chainID, address, name)
+}
+
+// GetSafeAccounts returns the followed Safes with the owners available in the keystore
+func (api *API) GetSafeAccounts(ctx context.Context) ([]*safe.Account, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetSafeAccounts")
+
+	return api.s.safeManager.GetAccounts()
+}
+
+// RefreshSafeAccount reads the owners, threshold and nonce of the Safe again
+func (api *API) RefreshSafeAccount(ctx context.Context, chainID uint64, address common.Address) (*safe.Account, error) {
+	logutils.ZapLogger().Debug("wallet.api.RefreshSafeAccount", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.safeManager.RefreshAccount(ctx, chainID, address)
+}
+
+// RemoveSafeAccount stops following the Safe and drops its proposals
+func (api *API) RemoveSafeAccount(ctx context.Context, chainID uint64, address common.Address) error {
+	logutils.ZapLogger().Debug("wallet.api.RemoveSafeAccount", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.safeManager.RemoveAccount(chainID, address)
+}
+
+// ProposeSafeTransactions turns the best route computed for a Safe (addrFrom) into Safe transactions to be signed by the owners
+func (api *API) ProposeSafeTransactions(ctx context.Context, uuid string) ([]*safe.Proposal, error) {
+	logutils.ZapLogger().Debug("wallet.api.ProposeSafeTransactions", zap.String("uuid", uuid))
+
+	route, routeInputParams := api.s.router.GetBestRouteAndAssociatedInputParams()
+	if routeInputParams.Uuid != uuid {
+		return nil, routeexecution.ErrCannotResolveRouteId
+	}
+
+	return api.s.safeManager.ProposeRoute(ctx, route, api.s.router.GetPathProcessors(), routeInputParams)
+}
+
+// GetSafeProposals returns the transactions proposed to the Safe, most recent first
+func (api *API) GetSafeProposals(ctx context.Context, chainID uint64, address common.Address) ([]*safe.Proposal, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetSafeProposals", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+
+	return api.s.safeManager.GetProposals(chainID, address)
+}
+
+// SignSafeProposal signs the proposal with a Safe owner from the keystore
+func (api *API) SignSafeProposal(ctx context.Context, safeTxHash common.Hash, owner common.Address, password string) (*safe.Proposal, error) {
+	logutils.ZapLogger().Debug("wallet.api.SignSafeProposal", zap.Stringer("safeTxHash", safeTxHash), zap.Stringer("owner", owner))
+
+	key, err := api.s.gethManager.VerifyAccountPassword(api.s.Config().KeyStoreDir, owner.Hex(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.s.safeManager.SignProposal(safeTxHash, key)
+}
+
+// AddSafeProposalSignature adds a signature of the Safe transaction hash made outside of the keystore, e.g. on a keycard
+func (api *API) AddSafeProposalSignature(ctx context.Context, safeTxHash common.Hash, signature types.HexBytes) (*safe.Proposal, error) {
+	logutils.ZapLogger().Debug("wallet.api.AddSafeProposalSignature", zap.Stringer("safeTxHash", safeTxHash))
+
+	return api.s.safeManager.AddSignature(safeTxHash, signature)
+}
+
+// RejectSafeProposal drops a pending proposal
+func (api *API) RejectSafeProposal(ctx context.Context, safeTxHash common.Hash) error {
+	logutils.ZapLogger().Debug("wallet.api.RejectSafeProposal", zap.Stringer("safeTxHash", safeTxHash))
+
+	return api.s.safeManager.RejectProposal(safeTxHash)
+}
+
+// ExecuteSafeProposal sends the signed proposal to the Safe from the executor account, which pays for the gas
+func (api *API) ExecuteSafeProposal(ctx context.Context, safeTxHash common.Hash, executor common.Address, password string) (common.Hash, error) {
+	logutils.ZapLogger().Debug("wallet.api.ExecuteSafeProposal", zap.Stringer("safeTxHash", safeTxHash), zap.Stringer("executor", executor))
+
+	key, err := api.s.gethManager.VerifyAccountPassword(api.s.Config().KeyStoreDir, executor.Hex(), password)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return api.s.safeManager.ExecuteProposal(ctx, safeTxHash, key)
+}
+
 // GetBalanceHistoryRange retrieves token balance history for token identity on multiple chains for a time range
 // 'toTimestamp' is ignored for now, but will be used in the future to limit the range of the history
 func (api *API) GetBalanceHistoryRange(ctx context.Context, chainIDs []uint64, addresses []common.Address, tokenSymbol string, currencySymbol string, fromTimestamp uint64, _ uint64) ([]*history.ValuePoint, error) {
@@ -395,14 +569,51 @@ func (api *API) SearchCollections(ctx context.Context, chainID wcommon.ChainID,
    Collectibles API End
 */
 
+// ProbeEthereumChain detects the chain ID and the capabilities of the chain served by the RPC url
+func (api *API) ProbeEthereumChain(ctx context.Context, rpcURL string) (*network.ProbeResult, error) {
+	logutils.ZapLogger().Debug("call to ProbeEthereumChain")
+	result, err := network.ProbeRPC(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	result.Capabilities.TokenLists = api.s.tokenManager.HasListedTokens(result.ChainID)
+	return result, nil
+}
+
+// AddEthereumChain probes the capabilities of the networks which are not configured when they are not given, the
+// network is added without capabilities when the RPC can't be reached
 func (api *API) AddEthereumChain(ctx context.Context, network params.Network) error {
 	logutils.ZapLogger().Debug("call to AddEthereumChain")
+	if network.Capabilities == nil && network.RPCURL != "" && !api.isConfiguredNetwork(network.ChainID) {
+		result, err := api.ProbeEthereumChain(ctx, network.RPCURL)
+		if err != nil {
+			logutils.ZapLogger().Warn("can't probe the network", zap.Uint64("chainID", network.ChainID), zap.Error(err))
+		} else if result.ChainID != network.ChainID {
+			return fmt.Errorf("the rpc url serves the chain %d instead of %d", result.ChainID, network.ChainID)
+		} else {
+			network.Capabilities = &result.Capabilities
+		}
+	}
 	return api.s.rpcClient.NetworkManager.Upsert(&network)
 }
 
+func (api *API) isConfiguredNetwork(chainID uint64) bool {
+	for _, network := range api.s.rpcClient.NetworkManager.GetConfiguredNetworks() {
+		if network.ChainID == chainID {
+			return true
+		}
+	}
+	return false
+}
+
 func (api *API) DeleteEthereumChain(ctx context.Context, chainID uint64) error {
 	logutils.ZapLogger().Debug("call to DeleteEthereumChain")
-	return api.s.rpcClient.NetworkManager.Delete(chainID)
+	err := api.s.rpcClient.NetworkManager.Delete(chainID)
+	if err != nil {
+		return err
+	}
+	// Another chain can be added with the same ID
+	return api.s.rpcClient.ClearCache(chainID)
 }
 
 func (api *API) GetEthereumChains(ctx context.Context) ([]*network.CombinedNetwork, error) {
@@ -410,6 +621,72 @@ func (api *API) GetEthereumChains(ctx context.Context) ([]*network.CombinedNetwo
 	return api.s.rpcClient.NetworkManager.GetCombinedNetworks()
 }
 
+func (api *API) GetRpcProviders(ctx context.Context, chainID uint64) ([]params.RpcProvider, error) {
+	logutils.ZapLogger().Debug("call to GetRpcProviders", zap.Uint64("chainID", chainID))
+	return api.s.rpcClient.NetworkManager.GetRpcProviders(chainID)
+}
+
+// SaveRpcProvider adds the provider when its ID is 0 or updates it, the chain client uses it right away
+func (api *API) SaveRpcProvider(ctx context.Context, provider params.RpcProvider) (*params.RpcProvider, error) {
+	logutils.ZapLogger().Debug("call to SaveRpcProvider", zap.Uint64("chainID", provider.ChainID), zap.Int64("id", provider.ID))
+	previousChainID := provider.ChainID
+	if provider.ID != 0 {
+		previous, err := api.s.rpcClient.NetworkManager.GetRpcProvider(provider.ID)
+		if err != nil {
+			return nil, err
+		}
+		previousChainID = previous.ChainID
+	}
+
+	err := api.s.rpcClient.NetworkManager.UpsertRpcProvider(&provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider can be moved to another chain, the previous one is reloaded too
+	if previousChainID != provider.ChainID {
+		if err := api.s.rpcClient.ReloadProviders(previousChainID); err != nil {
+			return nil, err
+		}
+	}
+	err = api.s.rpcClient.ReloadProviders(provider.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+func (api *API) DeleteRpcProvider(ctx context.Context, id int64) error {
+	logutils.ZapLogger().Debug("call to DeleteRpcProvider", zap.Int64("id", id))
+	provider, err := api.s.rpcClient.NetworkManager.GetRpcProvider(id)
+	if err != nil {
+		return err
+	}
+	err = api.s.rpcClient.NetworkManager.DeleteRpcProvider(id)
+	if err != nil {
+		return err
+	}
+	return api.s.rpcClient.ReloadProviders(provider.ChainID)
+}
+
+// GetRpcProvidersStatus returns the providers of the chain in the order they are used, with their health status
+func (api *API) GetRpcProvidersStatus(ctx context.Context, chainID uint64) ([]rpc.RpcProviderStatus, error) {
+	logutils.ZapLogger().Debug("call to GetRpcProvidersStatus", zap.Uint64("chainID", chainID))
+	return api.s.rpcClient.GetProvidersStatus(chainID)
+}
+
+// GetCircuitStatuses returns the states and the recent calls of the circuits of the providers
+func (api *API) GetCircuitStatuses(ctx context.Context) ([]circuitbreaker.CircuitStatus, error) {
+	logutils.ZapLogger().Debug("call to GetCircuitStatuses")
+	return circuitbreaker.DefaultMonitor().Statuses(), nil
+}
+
+// ForceCircuitState forces the circuit "open" or "closed", an empty state releases it. It is meant for debugging.
+func (api *API) ForceCircuitState(ctx context.Context, name string, state circuitbreaker.CircuitState) error {
+	logutils.ZapLogger().Debug("call to ForceCircuitState", zap.String("name", name), zap.String("state", string(state)))
+	return circuitbreaker.DefaultMonitor().ForceState(name, state)
+}
+
 // @deprecated
 func (api *API) FetchPrices(ctx context.Context, symbols []string, currencies []string) (map[string]map[string]float64, error) {
 	logutils.ZapLogger().Debug("call to FetchPrices")
@@ -843,6 +1120,20 @@ func (api *API) StopActivityFilterSession(id activity.SessionID) {
 	api.s.activity.StopFilterSession(id)
 }
 
+// ExportActivityAsync writes all activity entries matching the filter to params.Path.
+// Progress and result are delivered via wallet-activity-export-progress and wallet-activity-export-done events
+func (api *API) ExportActivityAsync(requestID int32, params activity.ExportParams) error {
+	logutils.ZapLogger().Debug("wallet.api.ExportActivityAsync",
+		zap.Int32("requestID", requestID),
+		zap.Int("addr.count", len(params.Addresses)),
+		zap.Int("chainIDs.count", len(params.ChainIDs)),
+		zap.String("format", string(params.Format)),
+	)
+
+	api.s.activity.ExportActivityAsync(requestID, params)
+	return nil
+}
+
 func (api *API) GetMultiTxDetails(ctx context.Context, multiTxID int) (*activity.EntryDetails, error) {
 	logutils.ZapLogger().Debug("wallet.api.GetMultiTxDetails", zap.Int("multiTxID", multiTxID))
 
@@ -959,6 +1250,24 @@ func (api *API) HashMessageEIP191(ctx context.Context, message types.HexBytes) t
 	return crypto.Keccak256Hash([]byte(safeMsg))
 }
 
+// HashTypedDataLegacy is used for hashing dApps requests for "eth_signTypedData" (v1)
+// for signing on the client side.
+func (api *API) HashTypedDataLegacy(ctx context.Context, typedJson string) (types.Hash, error) {
+	logutils.ZapLogger().Debug("wallet.api.HashTypedDataLegacy", zap.Int("len(typedJson)", len(typedJson)))
+
+	var fields []typeddata.LegacyField
+	err := json.Unmarshal([]byte(typedJson), &fields)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	hash, err := typeddata.HashLegacy(fields)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.Hash(hash), nil
+}
+
 // SignTypedDataV4 dApps use it to execute "eth_signTypedData_v4" requests
 // the formatted typed data will be prefixed with \x19\x01 based on the EIP-712
 // @deprecated
@@ -1010,6 +1319,46 @@ func (api *API) SafeSignTypedDataForDApps(typedJson string, address string, pass
 	return walletconnect.SafeSignTypedDataForDApps(typedJson, account.AccountKey.PrivateKey, chainID, legacy)
 }
 
+// GetEncryptionPublicKeyForDApps is used to execute requests for "eth_getEncryptionPublicKey",
+// the key of the wallet accounts derived from a keypair is derived again with the password
+func (api *API) GetEncryptionPublicKeyForDApps(address string, password string) (string, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetEncryptionPublicKeyForDApps", zap.String("address", address))
+
+	account, err := api.s.gethManager.GetVerifiedWalletAccount(api.s.accountsDB, address, password)
+	if err != nil {
+		return "", err
+	}
+
+	return walletconnect.EncryptionPublicKeyForDApps(account.AccountKey.PrivateKey)
+}
+
+// DecryptForDApps is used to execute requests for "eth_decrypt", encryptedJson is the JSON encoded
+// message, as the dApp hex encoded it
+func (api *API) DecryptForDApps(encryptedJson string, address string, password string) (string, error) {
+	logutils.ZapLogger().Debug("wallet.api.DecryptForDApps",
+		zap.Int("len(encryptedJson)", len(encryptedJson)),
+		zap.String("address", address),
+	)
+
+	account, err := api.s.gethManager.GetVerifiedWalletAccount(api.s.accountsDB, address, password)
+	if err != nil {
+		return "", err
+	}
+
+	return walletconnect.DecryptForDApps(encryptedJson, account.AccountKey.PrivateKey)
+}
+
+// AnalyzeTypedData breaks down the "eth_signTypedData_v4" request of a dApp connected to chainID,
+// it is meant to be shown to the user before calling SafeSignTypedDataForDApps
+func (api *API) AnalyzeTypedData(ctx context.Context, typedJson string, chainID uint64) (*eip712.Analysis, error) {
+	logutils.ZapLogger().Debug("wallet.api.AnalyzeTypedData",
+		zap.Int("len(typedJson)", len(typedJson)),
+		zap.Uint64("chainID", chainID),
+	)
+
+	return api.s.typedDataAnalyzer.Analyze(ctx, typedJson, chainID)
+}
+
 func (api *API) RestartWalletReloadTimer(ctx context.Context) error {
 	return api.s.reader.Restart()
 }
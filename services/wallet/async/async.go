@@ -90,6 +90,31 @@ func (c InfiniteCommand) Run(ctx context.Context) error {
 	}
 }
 
+// TriggeredCommand runs until context is closed, at the interval and when triggered.
+// A nil trigger makes it an InfiniteCommand.
+type TriggeredCommand struct {
+	Interval time.Duration
+	Trigger  <-chan struct{}
+	Runable  func(context.Context) error
+}
+
+func (c TriggeredCommand) Run(ctx context.Context) error {
+	_ = c.Runable(ctx)
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_ = c.Runable(ctx)
+		case <-c.Trigger:
+			ticker.Reset(c.Interval)
+			_ = c.Runable(ctx)
+		}
+	}
+}
+
 func NewGroup(parent context.Context) *Group {
 	ctx, cancel := context.WithCancel(parent)
 	return &Group{
@@ -50,3 +50,37 @@ func TestAtomicGroupWaitsAllToFinish(t *testing.T) {
 	group.Wait()
 	require.True(t, finished)
 }
+
+func TestTriggeredCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 10)
+	trigger := make(chan struct{}, 1)
+	cmd := TriggeredCommand{
+		Interval: time.Hour,
+		Trigger:  trigger,
+		Runable: func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		},
+	}
+	done := make(chan error)
+	go func() {
+		done <- cmd.Run(ctx)
+	}()
+
+	// Runs right away, then when triggered
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			require.Fail(t, "command did not run")
+		}
+		trigger <- struct{}{}
+	}
+	<-runs
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
@@ -296,6 +296,14 @@ func (c *loadOwnedCollectiblesCommand) Run(parent context.Context) (err error) {
 					zap.Int("page", pageNr),
 					zap.Error(err),
 				)
+
+				// Providers are down or don't support the chain, derive the ownership from the transfer logs instead.
+				// Indexing the history takes long, the ownership is updated in the background once it's done
+				if pageNr == 0 && c.manager.ownershipIndexer != nil {
+					c.manager.ownershipIndexer.StartIndexing(c.chainID, c.account)
+					break
+				}
+
 				c.err = err
 				break
 			}
@@ -108,6 +108,10 @@ func (c *Controller) Stop() {
 	c.stopAccountsWatcher()
 
 	c.stopPeriodicalOwnershipFetch()
+
+	if c.manager.ownershipIndexer != nil {
+		c.manager.ownershipIndexer.Stop()
+	}
 }
 
 func (c *Controller) RefetchOwnedCollectibles() {
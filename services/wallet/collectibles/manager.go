@@ -65,6 +65,7 @@ type Manager struct {
 	collectionsDataDB  CollectionDataStorage
 	communityManager   *community.Manager
 	ownershipDB        *OwnershipDB
+	ownershipIndexer   *OwnershipIndexer
 
 	mediaServer *server.MediaServer
 
@@ -83,10 +84,12 @@ func NewManager(
 	feed *event.Feed) *Manager {
 
 	var ownershipDB *OwnershipDB
+	var ownershipIndexer *OwnershipIndexer
 	var statuses *sync.Map
 	var statusNotifier *connection.StatusNotifier
 	if db != nil {
 		ownershipDB = NewOwnershipDB(db)
+		ownershipIndexer = NewOwnershipIndexer(rpcClient, db, ownershipDB, feed)
 		statuses = initStatuses(ownershipDB)
 		statusNotifier = createStatusNotifier(statuses, feed)
 	}
@@ -109,6 +112,7 @@ func NewManager(
 		collectionsDataDB:  NewCollectionDataDB(db),
 		communityManager:   communityManager,
 		ownershipDB:        ownershipDB,
+		ownershipIndexer:   ownershipIndexer,
 		mediaServer:        mediaServer,
 		statuses:           statuses,
 		statusNotifier:     statusNotifier,
@@ -0,0 +1,132 @@
+package collectibles
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+// OwnershipDelta is a change of the balance of a collectible held by an account
+type OwnershipDelta struct {
+	ContractAddress common.Address
+	TokenID         *big.Int
+	Delta           *big.Int
+}
+
+// OwnershipIndexDB stores the collectibles balances derived from transfer logs by the OwnershipIndexer
+type OwnershipIndexDB struct {
+	db *sql.DB
+}
+
+func NewOwnershipIndexDB(sqlDb *sql.DB) *OwnershipIndexDB {
+	return &OwnershipIndexDB{
+		db: sqlDb,
+	}
+}
+
+// GetLastIndexedBlock returns nil if the account was never indexed on the chain
+func (o *OwnershipIndexDB) GetLastIndexedBlock(chainID w_common.ChainID, ownerAddress common.Address) (*big.Int, error) {
+	var lastIndexedBlock int64
+	err := o.db.QueryRow(`SELECT last_indexed_block FROM collectibles_ownership_index_progress
+		WHERE chain_id = ? AND owner_address = ?`, chainID, ownerAddress).Scan(&lastIndexedBlock)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(lastIndexedBlock), nil
+}
+
+// ApplyDeltas updates the indexed balances with the transfers found up to lastIndexedBlock
+func (o *OwnershipIndexDB) ApplyDeltas(chainID w_common.ChainID, ownerAddress common.Address, deltas []OwnershipDelta, lastIndexedBlock *big.Int) (err error) {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	selectBalance, err := tx.Prepare(`SELECT balance FROM collectibles_ownership_index
+		WHERE chain_id = ? AND owner_address = ? AND contract_address = ? AND token_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer selectBalance.Close()
+
+	upsertBalance, err := tx.Prepare(`INSERT OR REPLACE INTO collectibles_ownership_index
+		(chain_id, owner_address, contract_address, token_id, balance) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer upsertBalance.Close()
+
+	deleteBalance, err := tx.Prepare(`DELETE FROM collectibles_ownership_index
+		WHERE chain_id = ? AND owner_address = ? AND contract_address = ? AND token_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer deleteBalance.Close()
+
+	for _, delta := range deltas {
+		balance := new(big.Int)
+		err = selectBalance.QueryRow(chainID, ownerAddress, delta.ContractAddress, (*bigint.SQLBigIntBytes)(delta.TokenID)).
+			Scan((*bigint.SQLBigIntBytes)(balance))
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		balance.Add(balance, delta.Delta)
+		if balance.Sign() <= 0 {
+			_, err = deleteBalance.Exec(chainID, ownerAddress, delta.ContractAddress, (*bigint.SQLBigIntBytes)(delta.TokenID))
+		} else {
+			_, err = upsertBalance.Exec(chainID, ownerAddress, delta.ContractAddress, (*bigint.SQLBigIntBytes)(delta.TokenID), (*bigint.SQLBigIntBytes)(balance))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO collectibles_ownership_index_progress (chain_id, owner_address, last_indexed_block)
+		VALUES (?, ?, ?)`, chainID, ownerAddress, lastIndexedBlock.Int64())
+	return err
+}
+
+func (o *OwnershipIndexDB) GetBalances(chainID w_common.ChainID, ownerAddress common.Address) (thirdparty.TokenBalancesPerContractAddress, error) {
+	rows, err := o.db.Query(`SELECT contract_address, token_id, balance FROM collectibles_ownership_index
+		WHERE chain_id = ? AND owner_address = ?`, chainID, ownerAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(thirdparty.TokenBalancesPerContractAddress)
+	for rows.Next() {
+		var contractAddress common.Address
+		balance := thirdparty.TokenBalance{
+			TokenID: &bigint.BigInt{Int: new(big.Int)},
+			Balance: &bigint.BigInt{Int: new(big.Int)},
+		}
+		err = rows.Scan(
+			&contractAddress,
+			(*bigint.SQLBigIntBytes)(balance.TokenID.Int),
+			(*bigint.SQLBigIntBytes)(balance.Balance.Int),
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret[contractAddress] = append(ret[contractAddress], balance)
+	}
+
+	return ret, rows.Err()
+}
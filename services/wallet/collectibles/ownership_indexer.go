@@ -0,0 +1,273 @@
+package collectibles
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/async"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// Blocks behind the head left out of the index, their logs could still be reorged out
+	ownershipIndexConfirmations = 12
+	ownershipIndexMaxRange      = 100000
+	ownershipIndexMinRange      = 1000
+	// Blocks indexed per pass, passes are repeated until the index reaches the latest confirmed block
+	ownershipIndexMaxBlocksPerPass = 1000000
+	ownershipIndexPassInterval     = 5 * time.Second
+)
+
+var errOwnershipIndexIncomplete = errors.New("ownership index incomplete")
+
+// OwnershipIndexer derives the collectibles owned by an account from its ERC721/ERC1155 transfer logs,
+// so that ownership is known without third party providers and on chains they don't support.
+// Block ranges are indexed incrementally in the background, starting from the last indexed block.
+type OwnershipIndexer struct {
+	rpcClient   rpc.ClientInterface
+	indexDB     *OwnershipIndexDB
+	ownershipDB *OwnershipDB
+	feed        *event.Feed
+
+	group        *async.Group
+	running      map[string]bool
+	runningMutex sync.Mutex
+}
+
+func NewOwnershipIndexer(rpcClient rpc.ClientInterface, db *sql.DB, ownershipDB *OwnershipDB, feed *event.Feed) *OwnershipIndexer {
+	return &OwnershipIndexer{
+		rpcClient:   rpcClient,
+		indexDB:     NewOwnershipIndexDB(db),
+		ownershipDB: ownershipDB,
+		feed:        feed,
+		group:       async.NewGroup(context.Background()),
+		running:     make(map[string]bool),
+	}
+}
+
+// StartIndexing indexes the transfer logs of the account in the background, one bounded block range per pass,
+// and stores the resulting ownership in the OwnershipDB once the latest confirmed block is reached.
+// Does nothing if the account is already being indexed
+func (i *OwnershipIndexer) StartIndexing(chainID walletCommon.ChainID, account common.Address) {
+	key := fmt.Sprintf("%d-%s", chainID, account.Hex())
+
+	i.runningMutex.Lock()
+	defer i.runningMutex.Unlock()
+	if i.running[key] {
+		return
+	}
+	i.running[key] = true
+
+	i.group.Add(func(ctx context.Context) error {
+		defer func() {
+			i.runningMutex.Lock()
+			delete(i.running, key)
+			i.runningMutex.Unlock()
+		}()
+
+		return async.FiniteCommand{
+			Interval: ownershipIndexPassInterval,
+			Runable: func(ctx context.Context) error {
+				return i.indexPass(ctx, chainID, account)
+			},
+		}.Run(ctx)
+	})
+}
+
+// Stop cancels the running indexing, which resumes from the last indexed block when started again
+func (i *OwnershipIndexer) Stop() {
+	i.runningMutex.Lock()
+	group := i.group
+	i.group = async.NewGroup(context.Background())
+	i.runningMutex.Unlock()
+
+	group.Stop()
+	group.Wait()
+}
+
+// indexPass returns errOwnershipIndexIncomplete to be called again until the index is complete.
+// Other errors end the indexing, which is started again by the next ownership refresh
+func (i *OwnershipIndexer) indexPass(ctx context.Context, chainID walletCommon.ChainID, account common.Address) error {
+	start := time.Now()
+
+	complete, err := i.indexTransfers(ctx, chainID, account)
+	if err == nil && !complete {
+		return errOwnershipIndexIncomplete
+	}
+
+	var updateMessage OwnershipUpdateMessage
+	if err == nil {
+		updateMessage.Removed, updateMessage.Updated, updateMessage.Added, err = i.updateOwnership(chainID, account, start)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			logutils.ZapLogger().Error("failed indexing collectibles ownership",
+				zap.Stringer("chainID", chainID),
+				zap.Stringer("account", account),
+				zap.Error(err),
+			)
+			i.triggerEvent(EventCollectiblesOwnershipUpdateFinishedWithError, chainID, account, err.Error())
+		}
+		return nil
+	}
+
+	encodedMessage, err := json.Marshal(updateMessage)
+	if err != nil {
+		return nil
+	}
+	i.triggerEvent(EventCollectiblesOwnershipUpdateFinished, chainID, account, string(encodedMessage))
+	return nil
+}
+
+func (i *OwnershipIndexer) updateOwnership(chainID walletCommon.ChainID, account common.Address, start time.Time) (removedIDs, updatedIDs, insertedIDs []thirdparty.CollectibleUniqueID, err error) {
+	balances, err := i.indexDB.GetBalances(chainID, account)
+	if err != nil {
+		return
+	}
+
+	return i.ownershipDB.Update(chainID, account, balances, start.Unix())
+}
+
+func (i *OwnershipIndexer) triggerEvent(eventType walletevent.EventType, chainID walletCommon.ChainID, account common.Address, message string) {
+	if i.feed == nil {
+		return
+	}
+	i.feed.Send(walletevent.Event{
+		Type:     eventType,
+		ChainID:  uint64(chainID),
+		Accounts: []common.Address{account},
+		Message:  message,
+	})
+}
+
+// indexTransfers indexes at most ownershipIndexMaxBlocksPerPass blocks after the last indexed block,
+// complete is true when the latest confirmed block is indexed
+func (i *OwnershipIndexer) indexTransfers(ctx context.Context, chainID walletCommon.ChainID, account common.Address) (complete bool, err error) {
+	chainClient, err := i.rpcClient.EthClient(uint64(chainID))
+	if err != nil {
+		return false, err
+	}
+
+	head, err := chainClient.BlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	if head < ownershipIndexConfirmations {
+		return true, nil
+	}
+	to := new(big.Int).SetUint64(head - ownershipIndexConfirmations)
+
+	from := big.NewInt(0)
+	lastIndexedBlock, err := i.indexDB.GetLastIndexedBlock(chainID, account)
+	if err != nil {
+		return false, err
+	}
+	if lastIndexedBlock != nil {
+		from.Add(lastIndexedBlock, big.NewInt(1))
+	}
+
+	complete = true
+	passEnd := new(big.Int).Add(from, big.NewInt(ownershipIndexMaxBlocksPerPass-1))
+	if passEnd.Cmp(to) < 0 {
+		to = passEnd
+		complete = false
+	}
+
+	downloader := transfer.NewERC20TransfersDownloader(chainClient, []common.Address{account}, types.LatestSignerForChainID(chainClient.ToBigInt()), false)
+
+	rangeSize := big.NewInt(ownershipIndexMaxRange)
+	for from.Cmp(to) <= 0 {
+		if walletCommon.ShouldCancel(ctx) {
+			return false, ctx.Err()
+		}
+
+		rangeEnd := new(big.Int).Add(from, rangeSize)
+		rangeEnd.Sub(rangeEnd, big.NewInt(1))
+		if rangeEnd.Cmp(to) > 0 {
+			rangeEnd.Set(to)
+		}
+
+		headers, err := downloader.GetHeadersInRange(ctx, from, rangeEnd)
+		if err != nil {
+			// Providers limit the size of log queries, retry with smaller ranges
+			if rangeSize.Int64() > ownershipIndexMinRange {
+				rangeSize.Div(rangeSize, big.NewInt(2))
+				continue
+			}
+			return false, err
+		}
+
+		err = i.indexDB.ApplyDeltas(chainID, account, ownershipDeltasFromHeaders(account, headers), rangeEnd)
+		if err != nil {
+			return false, err
+		}
+
+		logutils.ZapLogger().Debug("indexed collectibles ownership",
+			zap.Stringer("chainID", chainID),
+			zap.Stringer("account", account),
+			zap.Stringer("from", from),
+			zap.Stringer("to", rangeEnd),
+			zap.Int("headers", len(headers)),
+		)
+
+		from = rangeEnd.Add(rangeEnd, big.NewInt(1))
+	}
+
+	return complete, nil
+}
+
+// ownershipDeltasFromHeaders returns the collectible balance changes of the account found in the downloaded logs
+func ownershipDeltasFromHeaders(account common.Address, headers []*transfer.DBHeader) []OwnershipDelta {
+	deltas := make([]OwnershipDelta, 0, len(headers))
+	// Self transfers match both inbound and outbound queries
+	seen := make(map[common.Hash]bool)
+
+	for _, header := range headers {
+		for _, preloaded := range header.PreloadedTransactions {
+			if preloaded.Type != walletCommon.Erc721Transfer && preloaded.Type != walletCommon.Erc1155Transfer {
+				continue
+			}
+			if preloaded.Log == nil || preloaded.TokenID == nil || preloaded.Value == nil || seen[preloaded.ID] {
+				continue
+			}
+			seen[preloaded.ID] = true
+
+			from, to, _, _, _, err := walletCommon.ParseTransferLog(*preloaded.Log)
+			if err != nil || from == to {
+				continue
+			}
+
+			delta := new(big.Int).Set(preloaded.Value)
+			if from == account {
+				delta.Neg(delta)
+			} else if to != account {
+				continue
+			}
+
+			deltas = append(deltas, OwnershipDelta{
+				ContractAddress: preloaded.Log.Address,
+				TokenID:         preloaded.TokenID,
+				Delta:           delta,
+			})
+		}
+	}
+
+	return deltas
+}
@@ -0,0 +1,158 @@
+package collectibles
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupOwnershipIndexDBTest(t *testing.T) (*OwnershipIndexDB, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewOwnershipIndexDB(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func erc721TransferLog(contractAddress, from, to common.Address, tokenID int64, logIndex uint) *types.Log {
+	return &types.Log{
+		Address: contractAddress,
+		Topics: []common.Hash{
+			w_common.GetEventSignatureHash(w_common.Erc20_721TransferEventSignature),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BigToHash(big.NewInt(tokenID)),
+		},
+		TxHash: common.BigToHash(big.NewInt(int64(logIndex))),
+		Index:  logIndex,
+	}
+}
+
+func erc721Preloaded(log *types.Log, tokenID int64) *transfer.PreloadedTransaction {
+	return &transfer.PreloadedTransaction{
+		Type:    w_common.Erc721Transfer,
+		ID:      w_common.GetLogSubTxID(*log),
+		Log:     log,
+		TokenID: big.NewInt(tokenID),
+		Value:   big.NewInt(1),
+	}
+}
+
+func TestOwnershipDeltasFromHeaders(t *testing.T) {
+	account := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	contractAddress := common.HexToAddress("0x3")
+
+	inbound := erc721Preloaded(erc721TransferLog(contractAddress, other, account, 1, 0), 1)
+	outbound := erc721Preloaded(erc721TransferLog(contractAddress, account, other, 2, 1), 2)
+	self := erc721Preloaded(erc721TransferLog(contractAddress, account, account, 3, 2), 3)
+	erc20 := &transfer.PreloadedTransaction{
+		Type:  w_common.Erc20Transfer,
+		Log:   erc721TransferLog(contractAddress, other, account, 4, 3),
+		Value: big.NewInt(100),
+	}
+
+	headers := []*transfer.DBHeader{
+		{PreloadedTransactions: []*transfer.PreloadedTransaction{inbound}},
+		// the same log is returned by both inbound and outbound queries
+		{PreloadedTransactions: []*transfer.PreloadedTransaction{inbound}},
+		{PreloadedTransactions: []*transfer.PreloadedTransaction{outbound, self, erc20}},
+	}
+
+	deltas := ownershipDeltasFromHeaders(account, headers)
+	require.Equal(t, []OwnershipDelta{
+		{ContractAddress: contractAddress, TokenID: big.NewInt(1), Delta: big.NewInt(1)},
+		{ContractAddress: contractAddress, TokenID: big.NewInt(2), Delta: big.NewInt(-1)},
+	}, deltas)
+}
+
+func TestOwnershipIndexDB(t *testing.T) {
+	indexDB, cleanDB := setupOwnershipIndexDBTest(t)
+	defer cleanDB()
+
+	chainID := w_common.ChainID(1)
+	account := common.HexToAddress("0x1")
+	contractAddress := common.HexToAddress("0x3")
+
+	lastIndexedBlock, err := indexDB.GetLastIndexedBlock(chainID, account)
+	require.NoError(t, err)
+	require.Nil(t, lastIndexedBlock)
+
+	err = indexDB.ApplyDeltas(chainID, account, []OwnershipDelta{
+		{ContractAddress: contractAddress, TokenID: big.NewInt(1), Delta: big.NewInt(1)},
+		{ContractAddress: contractAddress, TokenID: big.NewInt(2), Delta: big.NewInt(5)},
+	}, big.NewInt(100))
+	require.NoError(t, err)
+
+	err = indexDB.ApplyDeltas(chainID, account, []OwnershipDelta{
+		{ContractAddress: contractAddress, TokenID: big.NewInt(1), Delta: big.NewInt(-1)},
+		{ContractAddress: contractAddress, TokenID: big.NewInt(2), Delta: big.NewInt(-2)},
+	}, big.NewInt(200))
+	require.NoError(t, err)
+
+	lastIndexedBlock, err = indexDB.GetLastIndexedBlock(chainID, account)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(200), lastIndexedBlock)
+
+	balances, err := indexDB.GetBalances(chainID, account)
+	require.NoError(t, err)
+	require.Len(t, balances[contractAddress], 1)
+	require.Equal(t, int64(2), balances[contractAddress][0].TokenID.Int64())
+	require.Equal(t, int64(3), balances[contractAddress][0].Balance.Int64())
+}
+
+func TestOwnershipIndexerBoundedPasses(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chainID := w_common.ChainID(1)
+	account := common.HexToAddress("0x1")
+	head := uint64(2*ownershipIndexMaxBlocksPerPass + 500)
+
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	chainClient.EXPECT().BlockNumber(gomock.Any()).Return(head, nil).AnyTimes()
+	chainClient.EXPECT().ToBigInt().Return(big.NewInt(1)).AnyTimes()
+	chainClient.EXPECT().NetworkID().Return(uint64(1)).AnyTimes()
+	chainClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	rpcClient.EXPECT().EthClient(uint64(chainID)).Return(chainClient, nil).AnyTimes()
+
+	indexer := NewOwnershipIndexer(rpcClient, db, NewOwnershipDB(db), nil)
+
+	// The history is indexed one pass at a time, resuming from the last indexed block
+	for pass := int64(1); pass <= 2; pass++ {
+		complete, err := indexer.indexTransfers(context.Background(), chainID, account)
+		require.NoError(t, err)
+		require.False(t, complete)
+
+		lastIndexedBlock, err := indexer.indexDB.GetLastIndexedBlock(chainID, account)
+		require.NoError(t, err)
+		require.Equal(t, pass*ownershipIndexMaxBlocksPerPass-1, lastIndexedBlock.Int64())
+	}
+
+	complete, err := indexer.indexTransfers(context.Background(), chainID, account)
+	require.NoError(t, err)
+	require.True(t, complete)
+
+	lastIndexedBlock, err := indexer.indexDB.GetLastIndexedBlock(chainID, account)
+	require.NoError(t, err)
+	require.Equal(t, int64(head-ownershipIndexConfirmations), lastIndexedBlock.Int64())
+}
@@ -15,11 +15,15 @@ import (
 )
 
 func IsProcessorBridge(name string) bool {
-	return name == pathProcessorCommon.ProcessorBridgeHopName || name == pathProcessorCommon.ProcessorBridgeCelerName
+	return name == pathProcessorCommon.ProcessorBridgeHopName ||
+		name == pathProcessorCommon.ProcessorBridgeCelerName ||
+		name == pathProcessorCommon.ProcessorBridgeAcrossName
 }
 
 func IsProcessorSwap(name string) bool {
-	return name == pathProcessorCommon.ProcessorSwapParaswapName
+	return name == pathProcessorCommon.ProcessorSwapParaswapName ||
+		name == pathProcessorCommon.ProcessorSwapKyberName ||
+		name == pathProcessorCommon.ProcessorSwapUniswapName
 }
 
 func PackApprovalInputData(amountIn *big.Int, approvalContractAddress *common.Address) ([]byte, error) {
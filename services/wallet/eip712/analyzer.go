@@ -0,0 +1,277 @@
+package eip712
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/typeddata"
+	"github.com/status-im/status-go/services/wallet/addressbook"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+const (
+	maxDisplayedDecimals = 6
+	dateLayout           = "2006-01-02 15:04 UTC"
+)
+
+// unlimitedThreshold is the amount from which an allowance is considered unlimited, dApps usually request
+// the maximum of the amount type which is way above any real token supply
+var unlimitedThreshold = new(big.Int).Lsh(big.NewInt(1), 128)
+
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// TokenResolver is implemented by token.Manager
+type TokenResolver interface {
+	FindTokenByAddress(chainID uint64, address common.Address) *token.Token
+}
+
+// LabelResolver is implemented by addressbook.Resolver
+type LabelResolver interface {
+	ResolveLabels(ctx context.Context, addresses []common.Address) map[common.Address]*addressbook.Label
+}
+
+// Analyzer breaks down EIP-712 typed data messages so the user knows what a signature grants before signing it
+type Analyzer struct {
+	tokens TokenResolver
+	labels LabelResolver
+}
+
+// NewAnalyzer creates an analyzer, both resolvers are optional
+func NewAnalyzer(tokens TokenResolver, labels LabelResolver) *Analyzer {
+	return &Analyzer{
+		tokens: tokens,
+		labels: labels,
+	}
+}
+
+// Analyze parses typedJson, the message to be signed with eth_signTypedData_v4, for a dApp connected to chainID.
+// A message that is valid typed data but can't be broken down is returned with a WarningUnableToAnalyze warning
+func (a *Analyzer) Analyze(ctx context.Context, typedJson string, chainID uint64) (*Analysis, error) {
+	var typed typeddata.TypedData
+	if err := json.Unmarshal([]byte(typedJson), &typed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+	if err := typed.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+
+	domain, err := parseDomain(typed.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: domain: %v", ErrInvalidTypedData, err)
+	}
+
+	analysis := &Analysis{
+		PrimaryType: typed.PrimaryType,
+		Domain:      domain,
+		Kind:        KindGeneric,
+		Fields:      make([]Field, 0),
+		Approvals:   make([]Approval, 0),
+		Warnings:    make([]Warning, 0),
+	}
+	err = flattenStruct(typed.Types, typed.Types[typed.PrimaryType], typed.Message, "", &analysis.Fields)
+	if err == nil {
+		msg := message(typed.Message)
+		switch {
+		case domain.Name == "Permit2":
+			err = analyzePermit2(analysis, msg)
+		case domain.Name == "Seaport" && typed.PrimaryType == "OrderComponents":
+			err = analyzeSeaportOrder(analysis, msg)
+		case typed.PrimaryType == "Permit":
+			err = analyzePermit(analysis, msg)
+		default:
+			err = analyzeApproval(analysis, msg)
+		}
+	}
+	// The user is still shown what could be parsed, with a warning instead of a possibly wrong breakdown
+	if err != nil {
+		analysis.Kind = KindGeneric
+		analysis.Approvals = make([]Approval, 0)
+		analysis.Order = nil
+		analysis.Warnings = append(analysis.Warnings, Warning{
+			Code:    WarningUnableToAnalyze,
+			Message: fmt.Sprintf("Unable to analyze the %s message: %v", typed.PrimaryType, err),
+		})
+	}
+
+	a.checkChain(analysis, chainID)
+	a.checkSpenders(ctx, analysis, chainID)
+	analysis.Summary = a.summary(analysis, chainID)
+
+	return analysis, nil
+}
+
+func parseDomain(raw map[string]json.RawMessage) (Domain, error) {
+	var domain Domain
+	if value, ok := raw["name"]; ok {
+		domain.Name = parseString(value)
+	}
+	if value, ok := raw["version"]; ok {
+		domain.Version = parseString(value)
+	}
+	if value, ok := raw[typeddata.ChainIDKey]; ok {
+		chainID, err := parseBig(value)
+		if err != nil || !chainID.IsUint64() {
+			return domain, errors.New("invalid chainId")
+		}
+		id := chainID.Uint64()
+		domain.ChainID = &id
+	}
+	if value, ok := raw["verifyingContract"]; ok {
+		address, err := parseAddress(value)
+		if err != nil {
+			return domain, fmt.Errorf("verifyingContract: %v", err)
+		}
+		domain.VerifyingContract = &address
+	}
+	return domain, nil
+}
+
+func (a *Analyzer) checkChain(analysis *Analysis, chainID uint64) {
+	if analysis.Domain.ChainID == nil || *analysis.Domain.ChainID == chainID {
+		return
+	}
+	analysis.Warnings = append(analysis.Warnings, Warning{
+		Code:    WarningChainMismatch,
+		Message: fmt.Sprintf("The message is for chain %d while the dApp is connected to chain %d", *analysis.Domain.ChainID, chainID),
+	})
+}
+
+// checkSpenders names the spenders and warns about the unlimited allowances and the spenders the user doesn't know.
+// Spenders only known by their ENS name are still reported as unknown, anyone can register one.
+func (a *Analyzer) checkSpenders(ctx context.Context, analysis *Analysis, chainID uint64) {
+	spenders := make([]common.Address, 0, len(analysis.Approvals)+1)
+	for _, approval := range analysis.Approvals {
+		spenders = append(spenders, approval.Spender)
+	}
+	// Anyone fulfilling an order gets the offered items through the marketplace contract
+	if analysis.Kind == KindSeaportOrder && analysis.Domain.VerifyingContract != nil {
+		spenders = append(spenders, *analysis.Domain.VerifyingContract)
+	}
+
+	var labels map[common.Address]*addressbook.Label
+	if a.labels != nil && len(spenders) > 0 {
+		labels = a.labels.ResolveLabels(ctx, spenders)
+	}
+	name := func(address common.Address) (string, bool) {
+		if name, ok := wellKnownSpenders[chainID][address]; ok {
+			return name, true
+		}
+		if label, ok := labels[address]; ok && label != nil {
+			return label.Name, label.Source != addressbook.LabelSourceENS
+		}
+		return "", false
+	}
+
+	warned := make(map[common.Address]bool)
+	warnUnknown := func(address common.Address) {
+		if warned[address] {
+			return
+		}
+		warned[address] = true
+		analysis.Warnings = append(analysis.Warnings, Warning{
+			Code:    WarningUnknownSpender,
+			Message: fmt.Sprintf("%s is not a known contract nor one of your contacts or saved addresses", address.Hex()),
+		})
+	}
+
+	for i := range analysis.Approvals {
+		approval := &analysis.Approvals[i]
+		spenderName, known := name(approval.Spender)
+		approval.SpenderName = spenderName
+		if approval.Unlimited {
+			analysis.Warnings = append(analysis.Warnings, Warning{
+				Code:    WarningUnlimitedAllowance,
+				Message: fmt.Sprintf("%s can spend an unlimited amount of %s", displayAddress(approval.Spender, spenderName), a.tokenSymbol(chainID, approval.Token)),
+			})
+		}
+		if !known {
+			warnUnknown(approval.Spender)
+		}
+	}
+
+	if analysis.Kind == KindSeaportOrder && analysis.Domain.VerifyingContract != nil {
+		if _, known := name(*analysis.Domain.VerifyingContract); !known {
+			warnUnknown(*analysis.Domain.VerifyingContract)
+		}
+	}
+}
+
+func (a *Analyzer) summary(analysis *Analysis, chainID uint64) string {
+	switch analysis.Kind {
+	case KindPermit, KindPermit2, KindApproval:
+		parts := make([]string, 0, len(analysis.Approvals))
+		for _, approval := range analysis.Approvals {
+			parts = append(parts, a.approvalText(approval, chainID))
+		}
+		return strings.Join(parts, "; ")
+	case KindSeaportOrder:
+		return a.orderText(analysis.Order, chainID)
+	}
+
+	name := analysis.Domain.Name
+	if name == "" && analysis.Domain.VerifyingContract != nil {
+		name = analysis.Domain.VerifyingContract.Hex()
+	}
+	if name == "" {
+		return fmt.Sprintf("Sign a %s message", analysis.PrimaryType)
+	}
+	return fmt.Sprintf("Sign a %s message for %s", analysis.PrimaryType, name)
+}
+
+func (a *Analyzer) approvalText(approval Approval, chainID uint64) string {
+	spender := displayAddress(approval.Spender, approval.SpenderName)
+	var text string
+	switch {
+	case approval.Unlimited:
+		text = fmt.Sprintf("Allow %s to spend an unlimited amount of %s", spender, a.tokenSymbol(chainID, approval.Token))
+	case approval.Amount == nil || approval.Amount.ToInt().Sign() == 0:
+		text = fmt.Sprintf("Revoke the allowance of %s on %s", spender, a.tokenSymbol(chainID, approval.Token))
+	default:
+		text = fmt.Sprintf("Allow %s to spend %s", spender, a.tokenAmount(chainID, approval.Token, approval.Amount.ToInt()))
+	}
+	if approval.Expiration > 0 {
+		text += " until " + time.Unix(approval.Expiration, 0).UTC().Format(dateLayout)
+	}
+	return text
+}
+
+func (a *Analyzer) tokenSymbol(chainID uint64, address common.Address) string {
+	if a.tokens != nil {
+		if token := a.tokens.FindTokenByAddress(chainID, address); token != nil {
+			return token.Symbol
+		}
+	}
+	return address.Hex()
+}
+
+func (a *Analyzer) tokenAmount(chainID uint64, address common.Address, amount *big.Int) string {
+	if a.tokens != nil {
+		if token := a.tokens.FindTokenByAddress(chainID, address); token != nil {
+			return formatAmount(amount, token.Decimals) + " " + token.Symbol
+		}
+	}
+	return amount.String() + " of " + address.Hex()
+}
+
+func displayAddress(address common.Address, name string) string {
+	if name == "" {
+		return address.Hex()
+	}
+	return name
+}
+
+func formatAmount(amount *big.Int, decimals uint) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	res := new(big.Rat).SetFrac(amount, unit).FloatString(maxDisplayedDecimals)
+	if strings.Contains(res, ".") {
+		res = strings.TrimRight(strings.TrimRight(res, "0"), ".")
+	}
+	return res
+}
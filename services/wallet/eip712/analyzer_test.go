@@ -0,0 +1,295 @@
+package eip712
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/addressbook"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+const eip712DomainType = `"EIP712Domain": [
+	{"name": "name", "type": "string"},
+	{"name": "version", "type": "string"},
+	{"name": "chainId", "type": "uint256"},
+	{"name": "verifyingContract", "type": "address"}
+]`
+
+const maxUint256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+var (
+	usdcAddress    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	permit2Address = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	routerAddress  = common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD")
+	seaportAddress = common.HexToAddress("0x0000000000000068F116a894984e2DB1123eB395")
+	owner          = common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	stranger       = common.HexToAddress("0x000000000000000000000000000000000000bad0")
+	friend         = common.HexToAddress("0x000000000000000000000000000000000000f00d")
+)
+
+type fakeTokens struct{}
+
+func (f *fakeTokens) FindTokenByAddress(chainID uint64, address common.Address) *token.Token {
+	switch address {
+	case common.Address{}:
+		return &token.Token{Symbol: "ETH", Decimals: 18, ChainID: chainID}
+	case usdcAddress:
+		return &token.Token{Address: usdcAddress, Symbol: "USDC", Decimals: 6, ChainID: chainID}
+	}
+	return nil
+}
+
+type fakeLabels struct {
+	labels map[common.Address]*addressbook.Label
+}
+
+func (f *fakeLabels) ResolveLabels(ctx context.Context, addresses []common.Address) map[common.Address]*addressbook.Label {
+	res := make(map[common.Address]*addressbook.Label)
+	for _, address := range addresses {
+		if label, ok := f.labels[address]; ok {
+			res[address] = label
+		}
+	}
+	return res
+}
+
+func setupAnalyzerTest() *Analyzer {
+	return NewAnalyzer(&fakeTokens{}, &fakeLabels{labels: map[common.Address]*addressbook.Label{
+		friend:   {Address: friend, Name: "Alice", Source: addressbook.LabelSourceSavedAddress},
+		stranger: {Address: stranger, Name: "notascam.eth", Source: addressbook.LabelSourceENS},
+	}})
+}
+
+func permitJSON(name string, chainID uint64, spender common.Address, value string) string {
+	return fmt.Sprintf(`{
+		"types": {
+			%s,
+			"Permit": [
+				{"name": "owner", "type": "address"},
+				{"name": "spender", "type": "address"},
+				{"name": "value", "type": "uint256"},
+				{"name": "nonce", "type": "uint256"},
+				{"name": "deadline", "type": "uint256"}
+			]
+		},
+		"primaryType": "Permit",
+		"domain": {"name": %q, "version": "2", "chainId": %d, "verifyingContract": %q},
+		"message": {"owner": %q, "spender": %q, "value": %q, "nonce": 0, "deadline": 1767225600}
+	}`, eip712DomainType, name, chainID, usdcAddress.Hex(), owner.Hex(), spender.Hex(), value)
+}
+
+func warningCodes(analysis *Analysis) []WarningCode {
+	codes := make([]WarningCode, 0, len(analysis.Warnings))
+	for _, warning := range analysis.Warnings {
+		codes = append(codes, warning.Code)
+	}
+	return codes
+}
+
+func TestAnalyzePermit(t *testing.T) {
+	analyzer := setupAnalyzerTest()
+
+	analysis, err := analyzer.Analyze(context.Background(), permitJSON("USD Coin", 1, routerAddress, "2500000"), 1)
+	require.NoError(t, err)
+	require.Equal(t, KindPermit, analysis.Kind)
+	require.Equal(t, "USD Coin", analysis.Domain.Name)
+	require.Equal(t, usdcAddress, *analysis.Domain.VerifyingContract)
+	require.Len(t, analysis.Approvals, 1)
+	require.Equal(t, usdcAddress, analysis.Approvals[0].Token)
+	require.Equal(t, "Uniswap Universal Router", analysis.Approvals[0].SpenderName)
+	require.Equal(t, int64(1767225600), analysis.Approvals[0].Expiration)
+	require.False(t, analysis.Approvals[0].Unlimited)
+	require.Equal(t, "Allow Uniswap Universal Router to spend 2.5 USDC until 2026-01-01 00:00 UTC", analysis.Summary)
+	require.Empty(t, analysis.Warnings)
+	require.Equal(t, Field{Path: "spender", Type: "address", Value: routerAddress.Hex()}, analysis.Fields[1])
+
+	analysis, err = analyzer.Analyze(context.Background(), permitJSON("USD Coin", 1, friend, maxUint256), 1)
+	require.NoError(t, err)
+	require.True(t, analysis.Approvals[0].Unlimited)
+	require.Equal(t, "Alice", analysis.Approvals[0].SpenderName)
+	require.Equal(t, []WarningCode{WarningUnlimitedAllowance}, warningCodes(analysis))
+	require.Equal(t, "Allow Alice to spend an unlimited amount of USDC until 2026-01-01 00:00 UTC", analysis.Summary)
+}
+
+func TestAnalyzeUnknownSpenderAndChainMismatch(t *testing.T) {
+	analyzer := setupAnalyzerTest()
+
+	// An ENS name doesn't make the spender known
+	analysis, err := analyzer.Analyze(context.Background(), permitJSON("USD Coin", 10, stranger, "0x10"), 1)
+	require.NoError(t, err)
+	require.Equal(t, "notascam.eth", analysis.Approvals[0].SpenderName)
+	require.Equal(t, []WarningCode{WarningChainMismatch, WarningUnknownSpender}, warningCodes(analysis))
+	require.Equal(t, "16", analysis.Approvals[0].Amount.ToInt().String())
+
+	// Known spenders are only known on the chains they are deployed on
+	analysis, err = analyzer.Analyze(context.Background(), permitJSON("USD Coin", 137, routerAddress, "0x10"), 137)
+	require.NoError(t, err)
+	require.Empty(t, analysis.Approvals[0].SpenderName)
+	require.Equal(t, []WarningCode{WarningUnknownSpender}, warningCodes(analysis))
+}
+
+func TestAnalyzePermit2(t *testing.T) {
+	analyzer := setupAnalyzerTest()
+
+	typedJSON := fmt.Sprintf(`{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"PermitSingle": [
+				{"name": "details", "type": "PermitDetails"},
+				{"name": "spender", "type": "address"},
+				{"name": "sigDeadline", "type": "uint256"}
+			],
+			"PermitDetails": [
+				{"name": "token", "type": "address"},
+				{"name": "amount", "type": "uint160"},
+				{"name": "expiration", "type": "uint48"},
+				{"name": "nonce", "type": "uint48"}
+			]
+		},
+		"primaryType": "PermitSingle",
+		"domain": {"name": "Permit2", "chainId": "0x1", "verifyingContract": %q},
+		"message": {
+			"details": {"token": %q, "amount": "1461501637330902918203684832716283019655932542975", "expiration": "1767225600", "nonce": "0"},
+			"spender": %q,
+			"sigDeadline": "1767225600"
+		}
+	}`, permit2Address.Hex(), usdcAddress.Hex(), stranger.Hex())
+
+	analysis, err := analyzer.Analyze(context.Background(), typedJSON, 1)
+	require.NoError(t, err)
+	require.Equal(t, KindPermit2, analysis.Kind)
+	require.Equal(t, uint64(1), *analysis.Domain.ChainID)
+	require.Len(t, analysis.Approvals, 1)
+	require.Equal(t, usdcAddress, analysis.Approvals[0].Token)
+	require.Equal(t, stranger, analysis.Approvals[0].Spender)
+	require.True(t, analysis.Approvals[0].Unlimited)
+	require.Equal(t, []WarningCode{WarningUnlimitedAllowance, WarningUnknownSpender}, warningCodes(analysis))
+	require.Equal(t, "Allow notascam.eth to spend an unlimited amount of USDC until 2026-01-01 00:00 UTC", analysis.Summary)
+	require.Equal(t, []Field{
+		{Path: "details.token", Type: "address", Value: usdcAddress.Hex()},
+		{Path: "details.amount", Type: "uint160", Value: "1461501637330902918203684832716283019655932542975"},
+		{Path: "details.expiration", Type: "uint48", Value: "1767225600"},
+		{Path: "details.nonce", Type: "uint48", Value: "0"},
+		{Path: "spender", Type: "address", Value: stranger.Hex()},
+		{Path: "sigDeadline", Type: "uint256", Value: "1767225600"},
+	}, analysis.Fields)
+}
+
+func seaportJSON(verifyingContract common.Address, consideration string) string {
+	return fmt.Sprintf(`{
+		"types": {
+			%s,
+			"OrderComponents": [
+				{"name": "offerer", "type": "address"},
+				{"name": "offer", "type": "OfferItem[]"},
+				{"name": "consideration", "type": "ConsiderationItem[]"},
+				{"name": "startTime", "type": "uint256"},
+				{"name": "endTime", "type": "uint256"}
+			],
+			"OfferItem": [
+				{"name": "itemType", "type": "uint8"},
+				{"name": "token", "type": "address"},
+				{"name": "identifierOrCriteria", "type": "uint256"},
+				{"name": "startAmount", "type": "uint256"},
+				{"name": "endAmount", "type": "uint256"}
+			],
+			"ConsiderationItem": [
+				{"name": "itemType", "type": "uint8"},
+				{"name": "token", "type": "address"},
+				{"name": "identifierOrCriteria", "type": "uint256"},
+				{"name": "startAmount", "type": "uint256"},
+				{"name": "endAmount", "type": "uint256"},
+				{"name": "recipient", "type": "address"}
+			]
+		},
+		"primaryType": "OrderComponents",
+		"domain": {"name": "Seaport", "version": "1.6", "chainId": 1, "verifyingContract": %q},
+		"message": {
+			"offerer": %q,
+			"offer": [{"itemType": 2, "token": "0x000000000000000000000000000000000000c011", "identifierOrCriteria": "42", "startAmount": "1", "endAmount": "1"}],
+			"consideration": [%s],
+			"startTime": "1735689600",
+			"endTime": "1767225600"
+		}
+	}`, eip712DomainType, verifyingContract.Hex(), owner.Hex(), consideration)
+}
+
+func TestAnalyzeSeaportOrder(t *testing.T) {
+	analyzer := setupAnalyzerTest()
+	collection := common.HexToAddress("0xc011")
+
+	payment := fmt.Sprintf(`{"itemType": 0, "token": %q, "identifierOrCriteria": "0", "startAmount": "1500000000000000000", "endAmount": "1500000000000000000", "recipient": %q}`, common.Address{}.Hex(), owner.Hex())
+	fee := fmt.Sprintf(`{"itemType": 0, "token": %q, "identifierOrCriteria": "0", "startAmount": "37500000000000000", "endAmount": "37500000000000000", "recipient": %q}`, common.Address{}.Hex(), stranger.Hex())
+
+	analysis, err := analyzer.Analyze(context.Background(), seaportJSON(seaportAddress, payment+","+fee), 1)
+	require.NoError(t, err)
+	require.Equal(t, KindSeaportOrder, analysis.Kind)
+	require.Equal(t, owner, analysis.Order.Offerer)
+	require.Len(t, analysis.Order.Offer, 1)
+	require.Equal(t, SeaportItemERC721, analysis.Order.Offer[0].ItemType)
+	require.Len(t, analysis.Order.Consideration, 2)
+	require.Equal(t, stranger, *analysis.Order.Consideration[1].Recipient)
+	require.Equal(t, int64(1767225600), analysis.Order.EndTime)
+	require.Equal(t, "List collectible #42 of "+collection.Hex()+" for 1.5 ETH", analysis.Summary)
+	require.Empty(t, analysis.Warnings)
+	require.Equal(t, Field{Path: "consideration[1].recipient", Type: "address", Value: stranger.Hex()}, analysis.Fields[17])
+
+	// Everything is paid to someone else and the order can be fulfilled through an unknown contract
+	analysis, err = analyzer.Analyze(context.Background(), seaportJSON(stranger, fee), 1)
+	require.NoError(t, err)
+	require.Equal(t, "List collectible #42 of "+collection.Hex()+" for nothing", analysis.Summary)
+	require.Equal(t, []WarningCode{WarningNoPayment, WarningUnknownSpender}, warningCodes(analysis))
+}
+
+func TestAnalyzeGenericAndInvalid(t *testing.T) {
+	analyzer := NewAnalyzer(nil, nil)
+
+	typedJSON := fmt.Sprintf(`{
+		"types": {
+			%s,
+			"Mail": [
+				{"name": "to", "type": "address"},
+				{"name": "contents", "type": "string"},
+				{"name": "tags", "type": "string[]"}
+			]
+		},
+		"primaryType": "Mail",
+		"domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": %q},
+		"message": {"to": %q, "contents": "Hello, Bob!", "tags": ["a", "b"]}
+	}`, eip712DomainType, friend.Hex(), friend.Hex())
+
+	analysis, err := analyzer.Analyze(context.Background(), typedJSON, 1)
+	require.NoError(t, err)
+	require.Equal(t, KindGeneric, analysis.Kind)
+	require.Equal(t, "Sign a Mail message for Ether Mail", analysis.Summary)
+	require.Empty(t, analysis.Approvals)
+	require.Empty(t, analysis.Warnings)
+	require.Equal(t, []Field{
+		{Path: "to", Type: "address", Value: friend.Hex()},
+		{Path: "contents", Type: "string", Value: "Hello, Bob!"},
+		{Path: "tags[0]", Type: "string", Value: "a"},
+		{Path: "tags[1]", Type: "string", Value: "b"},
+	}, analysis.Fields)
+
+	_, err = analyzer.Analyze(context.Background(), `{"primaryType": "Mail"}`, 1)
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+
+	// A permit with an invalid value can't be analyzed, its fields are still returned
+	analysis, err = analyzer.Analyze(context.Background(), permitJSON("USD Coin", 1, routerAddress, "not a number"), 1)
+	require.NoError(t, err)
+	require.Equal(t, KindGeneric, analysis.Kind)
+	require.Empty(t, analysis.Approvals)
+	require.NotEmpty(t, analysis.Fields)
+	require.Len(t, analysis.Warnings, 1)
+	require.Equal(t, WarningUnableToAnalyze, analysis.Warnings[0].Code)
+	require.Equal(t, "Sign a Permit message for USD Coin", analysis.Summary)
+}
@@ -0,0 +1,245 @@
+package eip712
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxDeadline is the last second of year 9999, later deadlines are considered as never expiring
+const maxDeadline = 253402300799
+
+var errMissingVerifyingContract = errors.New("missing verifyingContract")
+
+// analyzePermit handles EIP-2612 permits, `Permit(owner,spender,value,nonce,deadline)`, and the DAI permits
+// predating it, `Permit(holder,spender,nonce,expiry,allowed)`. The permitted token is the verifying contract.
+func analyzePermit(analysis *Analysis, msg message) error {
+	if analysis.Domain.VerifyingContract == nil {
+		return errMissingVerifyingContract
+	}
+	spender, err := parseAddress(msg["spender"])
+	if err != nil {
+		return fmt.Errorf("spender: %v", err)
+	}
+
+	approval := Approval{
+		Token:   *analysis.Domain.VerifyingContract,
+		Spender: spender,
+	}
+	if raw, ok := msg["allowed"]; ok {
+		if err := setAllowed(&approval, raw); err != nil {
+			return fmt.Errorf("allowed: %v", err)
+		}
+		if approval.Expiration, err = parseDeadline(msg["expiry"]); err != nil {
+			return fmt.Errorf("expiry: %v", err)
+		}
+	} else {
+		if err := setAmount(&approval, msg["value"]); err != nil {
+			return fmt.Errorf("value: %v", err)
+		}
+		if approval.Expiration, err = parseDeadline(msg["deadline"]); err != nil {
+			return fmt.Errorf("deadline: %v", err)
+		}
+	}
+
+	analysis.Kind = KindPermit
+	analysis.Approvals = append(analysis.Approvals, approval)
+	return nil
+}
+
+// analyzePermit2 handles the allowances (`PermitSingle`, `PermitBatch`) and the signature transfers
+// (`PermitTransferFrom`, `PermitBatchTransferFrom` and their witness variants) of the Permit2 contract
+func analyzePermit2(analysis *Analysis, msg message) error {
+	spender, err := parseAddress(msg["spender"])
+	if err != nil {
+		return fmt.Errorf("spender: %v", err)
+	}
+
+	switch analysis.PrimaryType {
+	case "PermitSingle":
+		return addPermit2Approvals(analysis, spender, msg["details"], false)
+	case "PermitBatch":
+		return addPermit2Approvals(analysis, spender, msg["details"], true)
+	case "PermitTransferFrom", "PermitWitnessTransferFrom":
+		return addPermit2Transfers(analysis, spender, msg["permitted"], false, msg["deadline"])
+	case "PermitBatchTransferFrom", "PermitBatchWitnessTransferFrom":
+		return addPermit2Transfers(analysis, spender, msg["permitted"], true, msg["deadline"])
+	}
+	// Other messages of the Permit2 domain are shown as is
+	return nil
+}
+
+func addPermit2Approvals(analysis *Analysis, spender common.Address, raw json.RawMessage, batch bool) error {
+	details, err := structs(raw, batch)
+	if err != nil {
+		return fmt.Errorf("details: %v", err)
+	}
+	for i, detail := range details {
+		approval, err := tokenApproval(spender, detail)
+		if err != nil {
+			return fmt.Errorf("details[%d]: %v", i, err)
+		}
+		if approval.Expiration, err = parseDeadline(detail["expiration"]); err != nil {
+			return fmt.Errorf("details[%d].expiration: %v", i, err)
+		}
+		analysis.Approvals = append(analysis.Approvals, approval)
+	}
+	analysis.Kind = KindPermit2
+	return nil
+}
+
+// addPermit2Transfers adds the signature transfers as approvals, the spender can transfer them once until the deadline
+func addPermit2Transfers(analysis *Analysis, spender common.Address, raw json.RawMessage, batch bool, rawDeadline json.RawMessage) error {
+	deadline, err := parseDeadline(rawDeadline)
+	if err != nil {
+		return fmt.Errorf("deadline: %v", err)
+	}
+	permitted, err := structs(raw, batch)
+	if err != nil {
+		return fmt.Errorf("permitted: %v", err)
+	}
+	for i, item := range permitted {
+		approval, err := tokenApproval(spender, item)
+		if err != nil {
+			return fmt.Errorf("permitted[%d]: %v", i, err)
+		}
+		approval.Expiration = deadline
+		analysis.Approvals = append(analysis.Approvals, approval)
+	}
+	analysis.Kind = KindPermit2
+	return nil
+}
+
+// analyzeApproval recognizes the messages of other protocols granting a spender or an operator access to the
+// signer's tokens, e.g. gasless approvals of meta transaction relayers. Anything else stays generic.
+func analyzeApproval(analysis *Analysis, msg message) error {
+	var spender common.Address
+	var err error
+	if raw, ok := msg["spender"]; ok {
+		spender, err = parseAddress(raw)
+	} else if raw, ok := msg["operator"]; ok {
+		spender, err = parseAddress(raw)
+	} else {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("spender: %v", err)
+	}
+
+	approval := Approval{Spender: spender}
+	if raw, ok := msg["token"]; ok {
+		if approval.Token, err = parseAddress(raw); err != nil {
+			return fmt.Errorf("token: %v", err)
+		}
+	} else if analysis.Domain.VerifyingContract != nil {
+		approval.Token = *analysis.Domain.VerifyingContract
+	} else {
+		return errMissingVerifyingContract
+	}
+
+	switch {
+	case msg["value"] != nil:
+		err = setAmount(&approval, msg["value"])
+	case msg["amount"] != nil:
+		err = setAmount(&approval, msg["amount"])
+	case msg["approved"] != nil:
+		err = setAllowed(&approval, msg["approved"])
+	case msg["allowed"] != nil:
+		err = setAllowed(&approval, msg["allowed"])
+	default:
+		// Access to all the tokens without an amount
+		approval.Unlimited = true
+	}
+	if err != nil {
+		return fmt.Errorf("amount: %v", err)
+	}
+
+	for _, key := range []string{"deadline", "expiry", "expiration"} {
+		if raw, ok := msg[key]; ok {
+			if approval.Expiration, err = parseDeadline(raw); err != nil {
+				return fmt.Errorf("%s: %v", key, err)
+			}
+			break
+		}
+	}
+
+	analysis.Kind = KindApproval
+	analysis.Approvals = append(analysis.Approvals, approval)
+	return nil
+}
+
+func tokenApproval(spender common.Address, msg message) (Approval, error) {
+	approval := Approval{Spender: spender}
+	var err error
+	if approval.Token, err = parseAddress(msg["token"]); err != nil {
+		return approval, fmt.Errorf("token: %v", err)
+	}
+	if err := setAmount(&approval, msg["amount"]); err != nil {
+		return approval, fmt.Errorf("amount: %v", err)
+	}
+	return approval, nil
+}
+
+func setAmount(approval *Approval, raw json.RawMessage) error {
+	amount, err := parseBig(raw)
+	if err != nil {
+		return err
+	}
+	approval.Amount = (*hexutil.Big)(amount)
+	approval.Unlimited = amount.Cmp(unlimitedThreshold) >= 0
+	return nil
+}
+
+func setAllowed(approval *Approval, raw json.RawMessage) error {
+	allowed, err := parseBool(raw)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		approval.Unlimited = true
+	} else {
+		approval.Amount = (*hexutil.Big)(new(big.Int))
+	}
+	return nil
+}
+
+// parseDeadline returns 0 for deadlines too far in the future to be shown, usually the max uint256
+func parseDeadline(raw json.RawMessage) (int64, error) {
+	deadline, err := parseBig(raw)
+	if err != nil {
+		return 0, err
+	}
+	if !deadline.IsInt64() || deadline.Int64() > maxDeadline {
+		return 0, nil
+	}
+	return deadline.Int64(), nil
+}
+
+// structs parses a struct or, for batches, an array of structs
+func structs(raw json.RawMessage, batch bool) ([]message, error) {
+	if !batch {
+		msg, err := parseStruct(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []message{msg}, nil
+	}
+
+	items, err := parseArray(raw)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]message, 0, len(items))
+	for _, item := range items {
+		msg, err := parseStruct(item)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
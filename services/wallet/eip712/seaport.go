@@ -0,0 +1,136 @@
+package eip712
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// analyzeSeaportOrder handles Seaport `OrderComponents`. Signing an order lists the offered items, anyone can
+// fulfill it by paying the consideration items; the ones paid to the offerer are the price of the order.
+func analyzeSeaportOrder(analysis *Analysis, msg message) error {
+	offerer, err := parseAddress(msg["offerer"])
+	if err != nil {
+		return fmt.Errorf("offerer: %v", err)
+	}
+	order := &Order{Offerer: offerer}
+	if order.Offer, err = parseOrderItems(msg["offer"], false); err != nil {
+		return fmt.Errorf("offer: %v", err)
+	}
+	if order.Consideration, err = parseOrderItems(msg["consideration"], true); err != nil {
+		return fmt.Errorf("consideration: %v", err)
+	}
+	if order.StartTime, err = parseDeadline(msg["startTime"]); err != nil {
+		return fmt.Errorf("startTime: %v", err)
+	}
+	if order.EndTime, err = parseDeadline(msg["endTime"]); err != nil {
+		return fmt.Errorf("endTime: %v", err)
+	}
+
+	if len(order.payment()) == 0 && len(order.Offer) > 0 {
+		analysis.Warnings = append(analysis.Warnings, Warning{
+			Code:    WarningNoPayment,
+			Message: "You receive nothing in exchange of the offered items, anyone can take them for free",
+		})
+	}
+
+	analysis.Kind = KindSeaportOrder
+	analysis.Order = order
+	return nil
+}
+
+func parseOrderItems(raw json.RawMessage, consideration bool) ([]OrderItem, error) {
+	msgs, err := structs(raw, true)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]OrderItem, 0, len(msgs))
+	for i, msg := range msgs {
+		item, err := parseOrderItem(msg, consideration)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %v", i, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func parseOrderItem(msg message, consideration bool) (OrderItem, error) {
+	var item OrderItem
+	itemType, err := parseBig(msg["itemType"])
+	if err != nil || !itemType.IsInt64() || itemType.Int64() > int64(SeaportItemERC1155WithCriteria) {
+		return item, fmt.Errorf("itemType: invalid item type")
+	}
+	item.ItemType = SeaportItemType(itemType.Int64())
+	if item.Token, err = parseAddress(msg["token"]); err != nil {
+		return item, fmt.Errorf("token: %v", err)
+	}
+	for key, value := range map[string]**hexutil.Big{
+		"identifierOrCriteria": &item.IdentifierOrCriteria,
+		"startAmount":          &item.StartAmount,
+		"endAmount":            &item.EndAmount,
+	} {
+		amount, err := parseBig(msg[key])
+		if err != nil {
+			return item, fmt.Errorf("%s: %v", key, err)
+		}
+		*value = (*hexutil.Big)(amount)
+	}
+	if consideration {
+		recipient, err := parseAddress(msg["recipient"])
+		if err != nil {
+			return item, fmt.Errorf("recipient: %v", err)
+		}
+		item.Recipient = &recipient
+	}
+	return item, nil
+}
+
+// payment returns the consideration items paid to the offerer, the others are fees and royalties
+func (o *Order) payment() []OrderItem {
+	var items []OrderItem
+	for _, item := range o.Consideration {
+		if item.Recipient != nil && *item.Recipient == o.Offerer {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (a *Analyzer) orderText(order *Order, chainID uint64) string {
+	text := "List " + a.orderItemsText(order.Offer, chainID)
+	if payment := order.payment(); len(payment) > 0 {
+		text += " for " + a.orderItemsText(payment, chainID)
+	} else {
+		text += " for nothing"
+	}
+	return text
+}
+
+func (a *Analyzer) orderItemsText(items []OrderItem, chainID uint64) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, a.orderItemText(item, chainID))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (a *Analyzer) orderItemText(item OrderItem, chainID uint64) string {
+	// Dutch auctions start at startAmount, the starting price is shown
+	amount := item.StartAmount.ToInt()
+	switch item.ItemType {
+	case SeaportItemNative, SeaportItemERC20:
+		return a.tokenAmount(chainID, item.Token, amount)
+	case SeaportItemERC721:
+		return fmt.Sprintf("collectible #%s of %s", item.IdentifierOrCriteria.ToInt(), item.Token.Hex())
+	case SeaportItemERC1155:
+		return fmt.Sprintf("%s of collectible #%s of %s", amount, item.IdentifierOrCriteria.ToInt(), item.Token.Hex())
+	}
+	if amount.Cmp(big.NewInt(1)) == 0 {
+		return "any collectible of " + item.Token.Hex()
+	}
+	return fmt.Sprintf("%s collectibles of %s", amount, item.Token.Hex())
+}
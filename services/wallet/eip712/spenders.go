@@ -0,0 +1,49 @@
+package eip712
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+var allMainnets = []uint64{walletCommon.EthereumMainnet, walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet}
+
+// knownSpender is a contract and the chains it is deployed on at that address
+type knownSpender struct {
+	address common.Address
+	name    string
+	chains  []uint64
+}
+
+var knownSpenders = []knownSpender{
+	{common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3"), "Uniswap Permit2", allMainnets},
+	{common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD"), "Uniswap Universal Router", allMainnets},
+	{common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"), "Uniswap V2 Router", []uint64{walletCommon.EthereumMainnet}},
+	{common.HexToAddress("0xE592427A0AEce92De3Edee1F18E0157C05861564"), "Uniswap V3 Router", allMainnets},
+	{common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"), "Uniswap V3 Router 2", allMainnets},
+	{common.HexToAddress("0x00000000000000ADc04C56Bf30aC9d3c0aAF14dC"), "Seaport 1.5", allMainnets},
+	{common.HexToAddress("0x0000000000000068F116a894984e2DB1123eB395"), "Seaport 1.6", allMainnets},
+	{common.HexToAddress("0x1E0049783F008A0085193E00003D00cd54003c71"), "OpenSea Conduit", allMainnets},
+	{common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582"), "1inch Router v5", allMainnets},
+	{common.HexToAddress("0x111111125421cA6dc452d289314280a0f8842A65"), "1inch Router v6", allMainnets},
+	{common.HexToAddress("0xDef1C0ded9bec7F1a1670819833240f027b25EfF"), "0x Exchange Proxy", []uint64{walletCommon.EthereumMainnet, walletCommon.ArbitrumMainnet}},
+	{common.HexToAddress("0xDEF1ABE32c034e558Cdd535791643C58a13aCC10"), "0x Exchange Proxy", []uint64{walletCommon.OptimismMainnet}},
+	{common.HexToAddress("0x9008D19f58AAbD9eD0D60971565AA8510560ab41"), "CoW Protocol Settlement", []uint64{walletCommon.EthereumMainnet, walletCommon.ArbitrumMainnet}},
+	{common.HexToAddress("0xC92E8bdf79f0507f65a392b0ab4667716BFE0110"), "CoW Protocol Vault Relayer", []uint64{walletCommon.EthereumMainnet, walletCommon.ArbitrumMainnet}},
+	{common.HexToAddress("0x6131B5fae19EA4f9D964eAc0408E4408b66337b5"), "KyberSwap Router", allMainnets},
+	{common.HexToAddress("0x6A000F20005980200259B80c5102003040001068"), "ParaSwap Augustus v6", allMainnets},
+}
+
+// wellKnownSpenders are the known spenders by chain ID, the same address can be another contract on other chains
+var wellKnownSpenders = func() map[uint64]map[common.Address]string {
+	res := make(map[uint64]map[common.Address]string)
+	for _, spender := range knownSpenders {
+		for _, chainID := range spender.chains {
+			if res[chainID] == nil {
+				res[chainID] = make(map[common.Address]string)
+			}
+			res[chainID][spender.address] = spender.name
+		}
+	}
+	return res
+}()
@@ -0,0 +1,104 @@
+package eip712
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Kind is the recognized meaning of the signed message
+type Kind string
+
+const (
+	KindGeneric Kind = "generic"
+	// KindPermit is an EIP-2612 (or DAI style) token permit
+	KindPermit Kind = "permit"
+	// KindPermit2 is an allowance or a signature transfer of the Uniswap Permit2 contract
+	KindPermit2 Kind = "permit2"
+	// KindSeaportOrder is a Seaport marketplace order, the offered items can be taken by anyone fulfilling it
+	KindSeaportOrder Kind = "seaportOrder"
+	// KindApproval is any other message granting a spender or an operator access to the signer's assets
+	KindApproval Kind = "approval"
+)
+
+type WarningCode string
+
+const (
+	WarningUnlimitedAllowance WarningCode = "unlimitedAllowance"
+	WarningUnknownSpender     WarningCode = "unknownSpender"
+	WarningChainMismatch      WarningCode = "chainMismatch"
+	WarningNoPayment          WarningCode = "noPayment"
+	WarningUnableToAnalyze    WarningCode = "unableToAnalyze"
+)
+
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
+}
+
+type Domain struct {
+	Name              string          `json:"name,omitempty"`
+	Version           string          `json:"version,omitempty"`
+	ChainID           *uint64         `json:"chainId,omitempty"`
+	VerifyingContract *common.Address `json:"verifyingContract,omitempty"`
+}
+
+// Field is a leaf value of the message, nested structs and arrays are flattened in the path, e.g. `details[0].token`
+type Field struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Approval is an access to the signer's tokens granted by the message
+type Approval struct {
+	Token       common.Address `json:"token"`
+	Spender     common.Address `json:"spender"`
+	SpenderName string         `json:"spenderName,omitempty"`
+	// Amount is nil when the approval isn't limited to an amount, e.g. DAI permits
+	Amount    *hexutil.Big `json:"amount,omitempty"`
+	Unlimited bool         `json:"unlimited"`
+	// Expiration is the unix timestamp of the end of the approval, 0 if it doesn't expire
+	Expiration int64 `json:"expiration"`
+}
+
+// SeaportItemType follows the Seaport ItemType enum
+type SeaportItemType int
+
+const (
+	SeaportItemNative SeaportItemType = iota
+	SeaportItemERC20
+	SeaportItemERC721
+	SeaportItemERC1155
+	SeaportItemERC721WithCriteria
+	SeaportItemERC1155WithCriteria
+)
+
+type OrderItem struct {
+	ItemType             SeaportItemType `json:"itemType"`
+	Token                common.Address  `json:"token"`
+	IdentifierOrCriteria *hexutil.Big    `json:"identifierOrCriteria"`
+	StartAmount          *hexutil.Big    `json:"startAmount"`
+	EndAmount            *hexutil.Big    `json:"endAmount"`
+	// Recipient is only set for consideration items
+	Recipient *common.Address `json:"recipient,omitempty"`
+}
+
+type Order struct {
+	Offerer       common.Address `json:"offerer"`
+	Offer         []OrderItem    `json:"offer"`
+	Consideration []OrderItem    `json:"consideration"`
+	StartTime     int64          `json:"startTime"`
+	EndTime       int64          `json:"endTime"`
+}
+
+// Analysis is the human-readable breakdown of a typed data message, shown to the user before signing it
+type Analysis struct {
+	PrimaryType string     `json:"primaryType"`
+	Domain      Domain     `json:"domain"`
+	Kind        Kind       `json:"kind"`
+	Summary     string     `json:"summary"`
+	Fields      []Field    `json:"fields"`
+	Approvals   []Approval `json:"approvals"`
+	Order       *Order     `json:"order,omitempty"`
+	Warnings    []Warning  `json:"warnings"`
+}
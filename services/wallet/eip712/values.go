@@ -0,0 +1,155 @@
+package eip712
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/typeddata"
+)
+
+var (
+	errMissingValue   = errors.New("missing value")
+	errInvalidNumber  = errors.New("invalid number")
+	errInvalidAddress = errors.New("invalid address")
+)
+
+// message is a struct of the typed data with its fields still encoded
+type message map[string]json.RawMessage
+
+func parseBig(raw json.RawMessage) (*big.Int, error) {
+	if len(raw) == 0 {
+		return nil, errMissingValue
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		// Numbers are kept as written to not lose the precision of big values
+		str = string(raw)
+	}
+
+	var value *big.Int
+	var ok bool
+	if strings.HasPrefix(str, "0x") || strings.HasPrefix(str, "0X") {
+		value, ok = new(big.Int).SetString(str[2:], 16)
+	} else {
+		value, ok = new(big.Int).SetString(str, 10)
+	}
+	if !ok {
+		return nil, errInvalidNumber
+	}
+	return value, nil
+}
+
+func parseAddress(raw json.RawMessage) (common.Address, error) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return common.Address{}, errInvalidAddress
+	}
+	if !common.IsHexAddress(str) {
+		return common.Address{}, errInvalidAddress
+	}
+	return common.HexToAddress(str), nil
+}
+
+func parseBool(raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return false, err
+	}
+	return str == "true", nil
+}
+
+func parseString(raw json.RawMessage) string {
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return string(raw)
+	}
+	return str
+}
+
+func parseStruct(raw json.RawMessage) (message, error) {
+	var msg message
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, errMissingValue
+	}
+	return msg, nil
+}
+
+func parseArray(raw json.RawMessage) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func formatLeaf(typ string, raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "int"):
+		if value, err := parseBig(raw); err == nil {
+			return value.String()
+		}
+	case typ == "address":
+		if address, err := parseAddress(raw); err == nil {
+			return address.Hex()
+		}
+	}
+	return parseString(raw)
+}
+
+// flatten appends the leaf values of raw, of type typ, to fields
+func flatten(types typeddata.Types, typ string, raw json.RawMessage, path string, fields *[]Field) error {
+	if strings.HasSuffix(typ, "]") {
+		items, err := parseArray(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		elemType := typ[:strings.LastIndex(typ, "[")]
+		for i, item := range items {
+			if err := flatten(types, elemType, item, fmt.Sprintf("%s[%d]", path, i), fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	structFields, isStruct := types[typ]
+	if !isStruct {
+		*fields = append(*fields, Field{Path: path, Type: typ, Value: formatLeaf(typ, raw)})
+		return nil
+	}
+
+	msg, err := parseStruct(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return flattenStruct(types, structFields, msg, path, fields)
+}
+
+func flattenStruct(types typeddata.Types, structFields []typeddata.Field, msg message, path string, fields *[]Field) error {
+	for _, field := range structFields {
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if err := flatten(types, field.Type, msg[field.Name], fieldPath, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
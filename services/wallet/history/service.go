@@ -425,6 +425,11 @@ func (s *Service) updateBalanceHistory(ctx context.Context) error {
 				continue
 			}
 
+			// The balances at the past blocks are not served without an archive node
+			if network.Capabilities != nil && !network.Capabilities.Archive {
+				continue
+			}
+
 			entries, err := s.balance.db.getEntriesWithoutBalances(network.ChainID, common.Address(address))
 			if err != nil {
 				logutils.ZapLogger().Error("Error getting blocks without balances",
@@ -18,6 +18,8 @@ import (
 
 const (
 	EventMarketStatusChanged walletevent.EventType = "wallet-market-status-changed"
+	// EventInternalMarketPricesUpdated carries the freshly fetched prices (map[symbol]map[currency]price) as EventParams
+	EventInternalMarketPricesUpdated walletevent.EventType = walletevent.InternalEventTypePrefix + "market-prices-updated"
 )
 
 const (
@@ -299,6 +301,14 @@ func (pm *Manager) updatePriceCache(prices map[string]map[string]float64) {
 
 		return tokenPriceCache
 	})
+
+	if pm.feed != nil && len(prices) > 0 {
+		pm.feed.Send(walletevent.Event{
+			Type:        EventInternalMarketPricesUpdated,
+			At:          time.Now().Unix(),
+			EventParams: prices,
+		})
+	}
 }
 
 // Return cached price if present in cache and age is less than maxAgeInSeconds. Fetch otherwise.
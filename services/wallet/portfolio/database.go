@@ -0,0 +1,73 @@
+package portfolio
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type CacheDB struct {
+	db *sql.DB
+}
+
+func NewCacheDB(sqlDb *sql.DB) *CacheDB {
+	return &CacheDB{
+		db: sqlDb,
+	}
+}
+
+type cacheEntry struct {
+	signature  eventsSignature
+	computedAt int64
+	report     *Report
+}
+
+// accountsKey is independent of the order of the addresses
+func accountsKey(addresses []common.Address) string {
+	hexes := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		hexes = append(hexes, strings.ToLower(address.Hex()))
+	}
+	sort.Strings(hexes)
+	return strings.Join(hexes, ",")
+}
+
+func (c *CacheDB) get(addresses []common.Address, method CostBasisMethod, currency string) (*cacheEntry, error) {
+	var reportJSON []byte
+	entry := &cacheEntry{}
+	err := c.db.QueryRow(`SELECT events_count, last_event_timestamp, computed_at, report FROM portfolio_pnl_cache
+		WHERE accounts_key = ? AND method = ? AND currency = ?`, accountsKey(addresses), method, currency).
+		Scan(&entry.signature.count, &entry.signature.lastTimestamp, &entry.computedAt, &reportJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry.report = &Report{}
+	if err = json.Unmarshal(reportJSON, entry.report); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (c *CacheDB) set(addresses []common.Address, method CostBasisMethod, currency string, entry *cacheEntry) error {
+	reportJSON, err := json.Marshal(entry.report)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.Exec(`INSERT OR REPLACE INTO portfolio_pnl_cache
+		(accounts_key, method, currency, events_count, last_event_timestamp, computed_at, report) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		accountsKey(addresses), method, currency, entry.signature.count, entry.signature.lastTimestamp, entry.computedAt, reportJSON)
+	return err
+}
+
+// Clear removes all cached reports, e.g. when accounts are removed
+func (c *CacheDB) Clear() error {
+	_, err := c.db.Exec(`DELETE FROM portfolio_pnl_cache`)
+	return err
+}
@@ -0,0 +1,60 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupCacheDBTest(t *testing.T) (*CacheDB, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewCacheDB(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestCacheDB(t *testing.T) {
+	db, cleanup := setupCacheDBTest(t)
+	defer cleanup()
+
+	addresses := []common.Address{common.HexToAddress("0x2"), common.HexToAddress("0x1")}
+	reversed := []common.Address{addresses[1], addresses[0]}
+
+	entry, err := db.get(addresses, CostBasisFIFO, "USD")
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	expected := &cacheEntry{
+		signature:  eventsSignature{count: 3, lastTimestamp: 100},
+		computedAt: 200,
+		report: &Report{
+			Currency:         "USD",
+			Method:           CostBasisFIFO,
+			Tokens:           []TokenPnL{{Symbol: "ETH", Amount: 1, CostBasis: 1000, RealizedPnL: 10, UnrealizedPnL: 20, Price: 1020}},
+			TotalCostBasis:   1000,
+			TotalRealizedPnL: 10,
+			History:          []PnLPoint{{Timestamp: 100, RealizedPnL: 10, UnrealizedPnL: 20}},
+		},
+	}
+	require.NoError(t, db.set(addresses, CostBasisFIFO, "USD", expected))
+
+	// The order of the addresses doesn't matter
+	entry, err = db.get(reversed, CostBasisFIFO, "USD")
+	require.NoError(t, err)
+	require.Equal(t, expected, entry)
+
+	entry, err = db.get(addresses, CostBasisAverage, "USD")
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	require.NoError(t, db.Clear())
+	entry, err = db.get(addresses, CostBasisFIFO, "USD")
+	require.NoError(t, err)
+	require.Nil(t, entry)
+}
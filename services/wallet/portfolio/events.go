@@ -0,0 +1,403 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/transfer"
+)
+
+// amountPrecision keeps the token amounts exact across the decimals of the chains
+const amountPrecision = 256
+
+// rawEvent is an event with the on-chain amount, before pricing
+type rawEvent struct {
+	timestamp int64
+	symbol    string
+	kind      EventKind
+	amount    *big.Float
+}
+
+// eventsSignature changes whenever new events are stored, it is used to invalidate the cache
+type eventsSignature struct {
+	count         int
+	lastTimestamp int64
+}
+
+// Transfers that are part of swaps and bridges are accounted for by their multi-transaction
+const transfersEventsQuery = `
+SELECT
+	transfers.network_id,
+	transfers.timestamp,
+	transfers.type,
+	transfers.token_address,
+	transfers.amount_padded128hex,
+	transfers.tx_from_address,
+	transfers.tx_to_address,
+	transfers.address
+FROM
+	transfers
+LEFT JOIN
+	multi_transactions mt
+ON
+	mt.id = transfers.multi_transaction_id
+WHERE
+	transfers.address IN (%s)
+	AND transfers.type IN ('eth', 'erc20')
+	AND (transfers.status IS NULL OR transfers.status != 0)
+	AND (mt.type IS NULL OR mt.type NOT IN (?, ?))
+`
+
+const swapsEventsQuery = `
+SELECT
+	timestamp,
+	from_network_id,
+	from_asset,
+	from_amount,
+	COALESCE(to_network_id, from_network_id),
+	to_asset,
+	to_amount
+FROM
+	multi_transactions
+WHERE
+	type = ?
+	AND from_address IN (%s)
+`
+
+const bridgesEventsQuery = `
+SELECT
+	timestamp,
+	from_address,
+	from_network_id,
+	from_asset,
+	from_amount,
+	to_address,
+	to_network_id,
+	to_asset,
+	to_amount
+FROM
+	multi_transactions
+WHERE
+	type = ?
+	AND (from_address IN (%s) OR to_address IN (%s))
+`
+
+// A transaction has a transfer per log, the gas of each transaction is only counted once
+const bridgesGasFeesQuery = `
+SELECT
+	transfers.network_id,
+	transfers.timestamp,
+	transfers.tx_type,
+	transfers.gas_used,
+	transfers.gas_price_clamped64,
+	transfers.gas_tip_cap_clamped64,
+	transfers.gas_fee_cap_clamped64,
+	transfers.base_gas_fee
+FROM
+	transfers
+JOIN
+	multi_transactions mt
+ON
+	mt.id = transfers.multi_transaction_id
+WHERE
+	mt.type = ?
+	AND transfers.tx_from_address IN (%s)
+	AND transfers.gas_used IS NOT NULL
+GROUP BY
+	transfers.network_id,
+	transfers.tx_hash
+`
+
+func placeholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", count), ",")
+}
+
+// loadEvents reads the acquisitions and disposals of the given accounts from transfers, swaps and bridges.
+// Transfers between the given accounts are ignored, as are bridges between them, except for the bridge fee and gas
+// paid to move the tokens. Gas fees of the other transactions are not accounted for
+func loadEvents(ctx context.Context, db *sql.DB, tokens token.ManagerInterface, addresses []common.Address) ([]rawEvent, eventsSignature, error) {
+	var sig eventsSignature
+	if len(addresses) == 0 {
+		return nil, sig, nil
+	}
+
+	owned := make(map[common.Address]bool, len(addresses))
+	args := make([]interface{}, 0, len(addresses)+2)
+	for _, address := range addresses {
+		owned[address] = true
+		args = append(args, address)
+	}
+
+	events, err := loadTransferEvents(ctx, db, tokens, owned, args, &sig)
+	if err != nil {
+		return nil, sig, err
+	}
+
+	swaps, err := loadSwapEvents(ctx, db, tokens, args, &sig)
+	if err != nil {
+		return nil, sig, err
+	}
+	events = append(events, swaps...)
+
+	bridges, err := loadBridgeEvents(ctx, db, tokens, owned, args, &sig)
+	if err != nil {
+		return nil, sig, err
+	}
+	events = append(events, bridges...)
+
+	gasFees, err := loadBridgeGasFeeEvents(ctx, db, tokens, args, &sig)
+	if err != nil {
+		return nil, sig, err
+	}
+	events = append(events, gasFees...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].timestamp < events[j].timestamp
+	})
+
+	return events, sig, nil
+}
+
+func loadTransferEvents(ctx context.Context, db *sql.DB, tokens token.ManagerInterface, owned map[common.Address]bool, addressArgs []interface{}, sig *eventsSignature) ([]rawEvent, error) {
+	query := fmt.Sprintf(transfersEventsQuery, placeholders(len(addressArgs)))
+	args := append(append([]interface{}{}, addressArgs...), transfer.MultiTransactionSwap, transfer.MultiTransactionBridge)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []rawEvent
+	for rows.Next() {
+		var chainID uint64
+		var timestamp int64
+		var trType string
+		var tokenAddressDB, fromDB, toDB []byte
+		var amountDB sql.NullString
+		var owner common.Address
+		err := rows.Scan(&chainID, &timestamp, &trType, &tokenAddressDB, &amountDB, &fromDB, &toDB, &owner)
+		if err != nil {
+			return nil, err
+		}
+		sig.add(timestamp)
+
+		from := common.BytesToAddress(fromDB)
+		to := common.BytesToAddress(toDB)
+		if owned[from] && owned[to] {
+			// Internal transfer
+			continue
+		}
+
+		var kind EventKind
+		if owner == to {
+			kind = Acquire
+		} else if owner == from {
+			kind = Dispose
+		} else {
+			continue
+		}
+
+		native := trType == "eth"
+		t := tokens.LookupTokenIdentity(chainID, common.BytesToAddress(tokenAddressDB), native)
+		if t == nil || !amountDB.Valid {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(amountDB.String, 16)
+		if !ok {
+			continue
+		}
+
+		events = append(events, rawEvent{
+			timestamp: timestamp,
+			symbol:    t.Symbol,
+			kind:      kind,
+			amount:    tokenAmount(amount, t.Decimals),
+		})
+	}
+	return events, rows.Err()
+}
+
+func loadSwapEvents(ctx context.Context, db *sql.DB, tokens token.ManagerInterface, addressArgs []interface{}, sig *eventsSignature) ([]rawEvent, error) {
+	query := fmt.Sprintf(swapsEventsQuery, placeholders(len(addressArgs)))
+	args := append([]interface{}{transfer.MultiTransactionSwap}, addressArgs...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []rawEvent
+	for rows.Next() {
+		var timestamp int64
+		var fromChainID, toChainID sql.NullInt64
+		var fromAsset, toAsset string
+		var fromAmountDB, toAmountDB sql.NullString
+		err := rows.Scan(&timestamp, &fromChainID, &fromAsset, &fromAmountDB, &toChainID, &toAsset, &toAmountDB)
+		if err != nil {
+			return nil, err
+		}
+		sig.add(timestamp)
+
+		if amount, ok := chainAmount(tokens, fromChainID, fromAsset, fromAmountDB); ok {
+			events = append(events, rawEvent{timestamp: timestamp, symbol: fromAsset, kind: Dispose, amount: amount})
+		}
+		if amount, ok := chainAmount(tokens, toChainID, toAsset, toAmountDB); ok {
+			events = append(events, rawEvent{timestamp: timestamp, symbol: toAsset, kind: Acquire, amount: amount})
+		}
+	}
+	return events, rows.Err()
+}
+
+// loadBridgeEvents accounts for both legs of the bridges. A bridge between the given accounts only moves the holdings
+// to another chain, the difference between the sent and received amounts is the fee kept by the bridge
+func loadBridgeEvents(ctx context.Context, db *sql.DB, tokens token.ManagerInterface, owned map[common.Address]bool, addressArgs []interface{}, sig *eventsSignature) ([]rawEvent, error) {
+	query := fmt.Sprintf(bridgesEventsQuery, placeholders(len(addressArgs)), placeholders(len(addressArgs)))
+	args := append(append([]interface{}{transfer.MultiTransactionBridge}, addressArgs...), addressArgs...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []rawEvent
+	for rows.Next() {
+		var timestamp int64
+		var from, to common.Address
+		var fromChainID, toChainID sql.NullInt64
+		var fromAsset, toAsset string
+		var fromAmountDB, toAmountDB sql.NullString
+		err := rows.Scan(&timestamp, &from, &fromChainID, &fromAsset, &fromAmountDB, &to, &toChainID, &toAsset, &toAmountDB)
+		if err != nil {
+			return nil, err
+		}
+		sig.add(timestamp)
+
+		sent, sentOk := chainAmount(tokens, fromChainID, fromAsset, fromAmountDB)
+		// The received amount is unknown until the bridge completes
+		received, receivedOk := chainAmount(tokens, toChainID, toAsset, toAmountDB)
+
+		if owned[from] && owned[to] && fromAsset == toAsset {
+			if sentOk && receivedOk && sent.Cmp(received) > 0 {
+				fee := new(big.Float).SetPrec(amountPrecision).Sub(sent, received)
+				events = append(events, rawEvent{timestamp: timestamp, symbol: fromAsset, kind: Fee, amount: fee})
+			}
+			continue
+		}
+
+		if owned[from] && sentOk {
+			events = append(events, rawEvent{timestamp: timestamp, symbol: fromAsset, kind: Dispose, amount: sent})
+		}
+		if owned[to] && receivedOk {
+			events = append(events, rawEvent{timestamp: timestamp, symbol: toAsset, kind: Acquire, amount: received})
+		}
+	}
+	return events, rows.Err()
+}
+
+// loadBridgeGasFeeEvents reads the gas paid in the native token of the chain by the transactions of the bridges
+func loadBridgeGasFeeEvents(ctx context.Context, db *sql.DB, tokens token.ManagerInterface, addressArgs []interface{}, sig *eventsSignature) ([]rawEvent, error) {
+	query := fmt.Sprintf(bridgesGasFeesQuery, placeholders(len(addressArgs)))
+	args := append([]interface{}{transfer.MultiTransactionBridge}, addressArgs...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []rawEvent
+	for rows.Next() {
+		var chainID uint64
+		var timestamp int64
+		var txType, gasUsed, gasPrice, gasTipCap, gasFeeCap sql.NullInt64
+		var baseGasFee sql.NullString
+		err := rows.Scan(&chainID, &timestamp, &txType, &gasUsed, &gasPrice, &gasTipCap, &gasFeeCap, &baseGasFee)
+		if err != nil {
+			return nil, err
+		}
+		sig.add(timestamp)
+
+		fee := gasFee(txType, gasUsed, gasPrice, gasTipCap, gasFeeCap, baseGasFee)
+		if fee == nil {
+			continue
+		}
+		native := tokens.LookupTokenIdentity(chainID, common.Address{}, true)
+		if native == nil {
+			continue
+		}
+		events = append(events, rawEvent{
+			timestamp: timestamp,
+			symbol:    native.Symbol,
+			kind:      Fee,
+			amount:    tokenAmount(fee, native.Decimals),
+		})
+	}
+	return events, rows.Err()
+}
+
+func (s *eventsSignature) add(timestamp int64) {
+	s.count++
+	if timestamp > s.lastTimestamp {
+		s.lastTimestamp = timestamp
+	}
+}
+
+// gasFee returns the fee paid by a transaction, nil if unknown. The dynamic fee transactions pay the base fee of the
+// block plus the tip, capped to the max fee
+func gasFee(txType, gasUsed, gasPrice, gasTipCap, gasFeeCap sql.NullInt64, baseGasFee sql.NullString) *big.Int {
+	if !gasUsed.Valid {
+		return nil
+	}
+
+	var price *big.Int
+	if txType.Valid && txType.Int64 >= types.DynamicFeeTxType {
+		baseFee, ok := new(big.Int).SetString(baseGasFee.String, 0)
+		if !baseGasFee.Valid || !ok || !gasTipCap.Valid || !gasFeeCap.Valid {
+			return nil
+		}
+		price = new(big.Int).Add(baseFee, big.NewInt(gasTipCap.Int64))
+		if feeCap := big.NewInt(gasFeeCap.Int64); price.Cmp(feeCap) > 0 {
+			price = feeCap
+		}
+	} else {
+		if !gasPrice.Valid {
+			return nil
+		}
+		price = big.NewInt(gasPrice.Int64)
+	}
+
+	return new(big.Int).Mul(price, big.NewInt(gasUsed.Int64))
+}
+
+// chainAmount parses a multi-transaction amount (0x prefixed hex) of the token with the given symbol on the chain
+func chainAmount(tokens token.ManagerInterface, chainID sql.NullInt64, symbol string, amountDB sql.NullString) (*big.Float, bool) {
+	if !chainID.Valid || !amountDB.Valid || len(amountDB.String) <= 2 {
+		return nil, false
+	}
+	amount, ok := new(big.Int).SetString(amountDB.String[2:], 16)
+	if !ok {
+		return nil, false
+	}
+	id := uint64(chainID.Int64)
+	t, _ := tokens.LookupToken(&id, symbol)
+	if t == nil {
+		return nil, false
+	}
+	return tokenAmount(amount, t.Decimals), true
+}
+
+// tokenAmount converts the amount in the smallest unit of a token to whole tokens
+func tokenAmount(amount *big.Int, decimals uint) *big.Float {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Float).SetPrec(amountPrecision).Quo(
+		new(big.Float).SetPrec(amountPrecision).SetInt(amount),
+		new(big.Float).SetPrec(amountPrecision).SetInt(unit),
+	)
+}
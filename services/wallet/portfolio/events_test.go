@@ -0,0 +1,48 @@
+package portfolio
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func validInt(v int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: v, Valid: true}
+}
+
+func TestGasFee(t *testing.T) {
+	legacy := gasFee(validInt(types.LegacyTxType), validInt(21000), validInt(10), sql.NullInt64{}, sql.NullInt64{}, sql.NullString{})
+	require.Equal(t, big.NewInt(210000), legacy)
+
+	// Base fee plus tip
+	dynamic := gasFee(validInt(types.DynamicFeeTxType), validInt(21000), sql.NullInt64{}, validInt(2), validInt(100),
+		sql.NullString{String: "0x8", Valid: true})
+	require.Equal(t, big.NewInt(210000), dynamic)
+
+	// Capped to the max fee
+	capped := gasFee(validInt(types.DynamicFeeTxType), validInt(21000), sql.NullInt64{}, validInt(2), validInt(5),
+		sql.NullString{String: "8", Valid: true})
+	require.Equal(t, big.NewInt(105000), capped)
+
+	// Unknown base fee
+	require.Nil(t, gasFee(validInt(types.DynamicFeeTxType), validInt(21000), sql.NullInt64{}, validInt(2), validInt(5),
+		sql.NullString{Valid: true}))
+	require.Nil(t, gasFee(validInt(types.LegacyTxType), sql.NullInt64{}, validInt(10), sql.NullInt64{}, sql.NullInt64{}, sql.NullString{}))
+}
+
+func TestTokenAmountKeepsPrecision(t *testing.T) {
+	// 1 wei less than 1000 tokens doesn't fit a float64
+	amount, ok := new(big.Int).SetString("999999999999999999999", 10)
+	require.True(t, ok)
+	sent := tokenAmount(amount, 18)
+
+	// Bridged to a chain where the token has 6 decimals
+	received := tokenAmount(big.NewInt(999000000), 6)
+
+	fee := new(big.Float).SetPrec(amountPrecision).Sub(sent, received)
+	require.Equal(t, "0.999999999999999999", fee.Text('f', 18))
+}
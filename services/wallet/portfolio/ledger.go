@@ -0,0 +1,173 @@
+package portfolio
+
+import (
+	"sort"
+)
+
+type CostBasisMethod int
+
+const (
+	// CostBasisFIFO disposes the oldest acquired lots first
+	CostBasisFIFO CostBasisMethod = iota + 1
+	// CostBasisAverage uses the weighted average cost of the current holdings
+	CostBasisAverage
+)
+
+type EventKind int
+
+const (
+	Acquire EventKind = iota + 1
+	Dispose
+	// Fee disposes tokens without proceeds, e.g. gas and bridge fees
+	Fee
+)
+
+// Event is an acquisition or disposal of a token at a given unit price (in the report currency)
+type Event struct {
+	Timestamp int64
+	Symbol    string
+	Kind      EventKind
+	Amount    float64
+	UnitPrice float64
+}
+
+type lot struct {
+	amount   float64
+	unitCost float64
+}
+
+// position tracks the holdings of a single token
+type position struct {
+	lots      []lot // FIFO only
+	amount    float64
+	costBasis float64
+	realized  float64
+}
+
+// Ledger applies events in chronological order and keeps track of the cost basis and realized P&L per token
+type Ledger struct {
+	method    CostBasisMethod
+	positions map[string]*position
+}
+
+func NewLedger(method CostBasisMethod) *Ledger {
+	return &Ledger{
+		method:    method,
+		positions: make(map[string]*position),
+	}
+}
+
+func (l *Ledger) position(symbol string) *position {
+	p, ok := l.positions[symbol]
+	if !ok {
+		p = &position{}
+		l.positions[symbol] = p
+	}
+	return p
+}
+
+// Apply processes a single event. Events must be applied in chronological order
+func (l *Ledger) Apply(e Event) {
+	if e.Amount <= 0 {
+		return
+	}
+
+	p := l.position(e.Symbol)
+	switch e.Kind {
+	case Acquire:
+		p.amount += e.Amount
+		p.costBasis += e.Amount * e.UnitPrice
+		if l.method == CostBasisFIFO {
+			p.lots = append(p.lots, lot{amount: e.Amount, unitCost: e.UnitPrice})
+		}
+	case Dispose:
+		l.dispose(p, e)
+	case Fee:
+		e.UnitPrice = 0
+		l.dispose(p, e)
+	}
+}
+
+func (l *Ledger) dispose(p *position, e Event) {
+	// Tokens acquired before the known history have an unknown cost, they are disposed at cost (no P&L)
+	known := e.Amount
+	if known > p.amount {
+		known = p.amount
+	}
+
+	if known > 0 {
+		var cost float64
+		if l.method == CostBasisFIFO {
+			remaining := known
+			for remaining > 0 && len(p.lots) > 0 {
+				consumed := p.lots[0].amount
+				if consumed > remaining {
+					consumed = remaining
+				}
+				cost += consumed * p.lots[0].unitCost
+				p.lots[0].amount -= consumed
+				remaining -= consumed
+				if p.lots[0].amount <= 0 {
+					p.lots = p.lots[1:]
+				}
+			}
+		} else {
+			cost = known * p.costBasis / p.amount
+		}
+
+		p.realized += known*e.UnitPrice - cost
+		p.costBasis -= cost
+		p.amount -= known
+	}
+
+	if p.amount <= 0 {
+		p.amount = 0
+		p.costBasis = 0
+		p.lots = nil
+	}
+}
+
+// TokenPnL is the P&L state of a single token
+type TokenPnL struct {
+	Symbol        string  `json:"symbol"`
+	Amount        float64 `json:"amount"`
+	CostBasis     float64 `json:"costBasis"`
+	RealizedPnL   float64 `json:"realizedPnL"`
+	UnrealizedPnL float64 `json:"unrealizedPnL"`
+	Price         float64 `json:"price"`
+}
+
+// Snapshot returns the P&L per token, valuing the holdings with the given prices
+func (l *Ledger) Snapshot(prices map[string]float64) []TokenPnL {
+	res := make([]TokenPnL, 0, len(l.positions))
+	for symbol, p := range l.positions {
+		price, hasPrice := prices[symbol]
+		t := TokenPnL{
+			Symbol:      symbol,
+			Amount:      p.amount,
+			CostBasis:   p.costBasis,
+			RealizedPnL: p.realized,
+			Price:       price,
+		}
+		if hasPrice {
+			t.UnrealizedPnL = p.amount*price - p.costBasis
+		}
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Symbol < res[j].Symbol
+	})
+	return res
+}
+
+// Symbols returns the tokens that are currently held
+func (l *Ledger) Symbols() []string {
+	res := make([]string, 0, len(l.positions))
+	for symbol, p := range l.positions {
+		if p.amount > 0 {
+			res = append(res, symbol)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,88 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func applyAll(l *Ledger, events []Event) {
+	for _, e := range events {
+		l.Apply(e)
+	}
+}
+
+var ledgerTestEvents = []Event{
+	{Timestamp: 1, Symbol: "ETH", Kind: Acquire, Amount: 1, UnitPrice: 1000},
+	{Timestamp: 2, Symbol: "ETH", Kind: Acquire, Amount: 1, UnitPrice: 2000},
+	{Timestamp: 3, Symbol: "ETH", Kind: Dispose, Amount: 1, UnitPrice: 3000},
+}
+
+func TestLedgerFIFO(t *testing.T) {
+	l := NewLedger(CostBasisFIFO)
+	applyAll(l, ledgerTestEvents)
+
+	res := l.Snapshot(map[string]float64{"ETH": 4000})
+	require.Len(t, res, 1)
+	require.Equal(t, "ETH", res[0].Symbol)
+	require.InDelta(t, 1, res[0].Amount, 1e-9)
+	require.InDelta(t, 2000, res[0].CostBasis, 1e-9)
+	require.InDelta(t, 2000, res[0].RealizedPnL, 1e-9)
+	require.InDelta(t, 2000, res[0].UnrealizedPnL, 1e-9)
+}
+
+func TestLedgerAverage(t *testing.T) {
+	l := NewLedger(CostBasisAverage)
+	applyAll(l, ledgerTestEvents)
+
+	res := l.Snapshot(map[string]float64{"ETH": 4000})
+	require.Len(t, res, 1)
+	require.InDelta(t, 1, res[0].Amount, 1e-9)
+	require.InDelta(t, 1500, res[0].CostBasis, 1e-9)
+	require.InDelta(t, 1500, res[0].RealizedPnL, 1e-9)
+	require.InDelta(t, 2500, res[0].UnrealizedPnL, 1e-9)
+}
+
+func TestLedgerDisposeMoreThanKnown(t *testing.T) {
+	for _, method := range []CostBasisMethod{CostBasisFIFO, CostBasisAverage} {
+		l := NewLedger(method)
+		applyAll(l, []Event{
+			{Timestamp: 1, Symbol: "DAI", Kind: Acquire, Amount: 10, UnitPrice: 1},
+			{Timestamp: 2, Symbol: "DAI", Kind: Dispose, Amount: 15, UnitPrice: 2},
+		})
+
+		res := l.Snapshot(map[string]float64{"DAI": 2})
+		require.Len(t, res, 1)
+		require.Zero(t, res[0].Amount)
+		require.Zero(t, res[0].CostBasis)
+		// Only the 10 known tokens are realized
+		require.InDelta(t, 10, res[0].RealizedPnL, 1e-9)
+		require.Zero(t, res[0].UnrealizedPnL)
+		require.Empty(t, l.Symbols())
+	}
+}
+
+func TestLedgerFee(t *testing.T) {
+	l := NewLedger(CostBasisFIFO)
+	applyAll(l, []Event{
+		{Timestamp: 1, Symbol: "ETH", Kind: Acquire, Amount: 1, UnitPrice: 1000},
+		{Timestamp: 2, Symbol: "ETH", Kind: Fee, Amount: 0.1, UnitPrice: 2000},
+	})
+
+	res := l.Snapshot(map[string]float64{"ETH": 2000})
+	require.Len(t, res, 1)
+	require.InDelta(t, 0.9, res[0].Amount, 1e-9)
+	require.InDelta(t, 900, res[0].CostBasis, 1e-9)
+	// The fee is a loss of its cost, without proceeds
+	require.InDelta(t, -100, res[0].RealizedPnL, 1e-9)
+}
+
+func TestLedgerUnknownPrice(t *testing.T) {
+	l := NewLedger(CostBasisFIFO)
+	l.Apply(Event{Timestamp: 1, Symbol: "SNT", Kind: Acquire, Amount: 100, UnitPrice: 0.02})
+
+	res := l.Snapshot(nil)
+	require.Len(t, res, 1)
+	require.Zero(t, res[0].UnrealizedPnL)
+	require.Equal(t, []string{"SNT"}, l.Symbols())
+}
@@ -0,0 +1,66 @@
+package portfolio
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+// PricesProvider is implemented by market.Manager
+type PricesProvider interface {
+	FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error)
+	FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error)
+}
+
+// dailyPrices fetches the full daily price history of each token once and looks up prices by timestamp
+type dailyPrices struct {
+	provider PricesProvider
+	currency string
+	prices   map[string][]thirdparty.HistoricalPrice
+}
+
+func newDailyPrices(provider PricesProvider, currency string) *dailyPrices {
+	return &dailyPrices{
+		provider: provider,
+		currency: currency,
+		prices:   make(map[string][]thirdparty.HistoricalPrice),
+	}
+}
+
+// priceAt returns the price of the day of timestamp, ok is false when the token has no known price at that time
+func (d *dailyPrices) priceAt(symbol string, timestamp int64) (price float64, ok bool) {
+	prices, fetched := d.prices[symbol]
+	if !fetched {
+		var err error
+		prices, err = d.provider.FetchHistoricalDailyPrices(symbol, d.currency, 0, true, 1)
+		if err != nil {
+			logutils.ZapLogger().Warn("portfolio: failed to fetch historical prices", zap.String("symbol", symbol), zap.Error(err))
+		}
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].Timestamp < prices[j].Timestamp
+		})
+		d.prices[symbol] = prices
+	}
+
+	idx := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Timestamp > timestamp
+	})
+	if idx == 0 {
+		return 0, false
+	}
+	return prices[idx-1].Value, true
+}
+
+// pricesAt returns the known prices of the symbols at the given timestamp
+func (d *dailyPrices) pricesAt(symbols []string, timestamp int64) map[string]float64 {
+	res := make(map[string]float64, len(symbols))
+	for _, symbol := range symbols {
+		if price, ok := d.priceAt(symbol, timestamp); ok {
+			res[symbol] = price
+		}
+	}
+	return res
+}
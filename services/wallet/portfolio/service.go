@@ -0,0 +1,216 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+// cacheMaxAge bounds how stale the unrealized P&L of a cached report can be
+const cacheMaxAge = time.Hour
+
+// PnLPoint is the cumulated P&L right after an event
+type PnLPoint struct {
+	Timestamp     int64   `json:"time"`
+	RealizedPnL   float64 `json:"realizedPnL"`
+	UnrealizedPnL float64 `json:"unrealizedPnL"`
+}
+
+// UnpricedEvent is an event left out of the P&L since its token had no known price at the time
+type UnpricedEvent struct {
+	Timestamp int64     `json:"time"`
+	Symbol    string    `json:"symbol"`
+	Kind      EventKind `json:"kind"`
+	Amount    float64   `json:"amount"`
+}
+
+// Report is the P&L of a set of accounts, per token and over time, in the report currency
+type Report struct {
+	Currency           string          `json:"currency"`
+	Method             CostBasisMethod `json:"method"`
+	Tokens             []TokenPnL      `json:"tokens"`
+	TotalCostBasis     float64         `json:"totalCostBasis"`
+	TotalRealizedPnL   float64         `json:"totalRealizedPnL"`
+	TotalUnrealizedPnL float64         `json:"totalUnrealizedPnL"`
+	History            []PnLPoint      `json:"history"`
+	Unpriced           []UnpricedEvent `json:"unpriced,omitempty"`
+}
+
+// PnL contains the aggregated report of all the requested accounts and one report per account
+type PnL struct {
+	Aggregated *Report                    `json:"aggregated"`
+	PerAccount map[common.Address]*Report `json:"perAccount"`
+}
+
+type Service struct {
+	db             *sql.DB
+	cacheDB        *CacheDB
+	tokenManager   token.ManagerInterface
+	pricesProvider PricesProvider
+}
+
+func NewService(db *sql.DB, tokenManager token.ManagerInterface, pricesProvider PricesProvider) *Service {
+	return &Service{
+		db:             db,
+		cacheDB:        NewCacheDB(db),
+		tokenManager:   tokenManager,
+		pricesProvider: pricesProvider,
+	}
+}
+
+// GetPnL returns the P&L derived from the transfers and swaps of the accounts.
+// Transfers between the accounts are ignored in the aggregated report but not in the per account ones
+func (s *Service) GetPnL(ctx context.Context, addresses []common.Address, method CostBasisMethod, currency string) (*PnL, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no addresses provided")
+	}
+	if method != CostBasisFIFO && method != CostBasisAverage {
+		return nil, errors.New("unsupported cost basis method")
+	}
+	currency = strings.ToUpper(currency)
+
+	res := &PnL{
+		PerAccount: make(map[common.Address]*Report, len(addresses)),
+	}
+
+	var err error
+	res.Aggregated, err = s.getReport(ctx, addresses, method, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 1 {
+		res.PerAccount[addresses[0]] = res.Aggregated
+		return res, nil
+	}
+
+	for _, address := range addresses {
+		res.PerAccount[address], err = s.getReport(ctx, []common.Address{address}, method, currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *Service) getReport(ctx context.Context, addresses []common.Address, method CostBasisMethod, currency string) (*Report, error) {
+	events, sig, err := loadEvents(ctx, s.db, s.tokenManager, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := s.cacheDB.get(addresses, method, currency)
+	if err != nil {
+		logutils.ZapLogger().Warn("portfolio: failed to read cached report", zap.Error(err))
+	}
+	now := time.Now()
+	if cached != nil && cached.signature == sig && now.Sub(time.Unix(cached.computedAt, 0)) < cacheMaxAge {
+		return cached.report, nil
+	}
+
+	report := s.computeReport(events, method, currency, now.Unix())
+
+	err = s.cacheDB.set(addresses, method, currency, &cacheEntry{
+		signature:  sig,
+		computedAt: now.Unix(),
+		report:     report,
+	})
+	if err != nil {
+		logutils.ZapLogger().Warn("portfolio: failed to cache report", zap.Error(err))
+	}
+
+	return report, nil
+}
+
+func (s *Service) computeReport(events []rawEvent, method CostBasisMethod, currency string, now int64) *Report {
+	prices := newDailyPrices(s.pricesProvider, currency)
+	ledger := NewLedger(method)
+
+	report := &Report{
+		Currency: currency,
+		Method:   method,
+		History:  make([]PnLPoint, 0, len(events)+1),
+	}
+
+	addPoint := func(timestamp int64, valuation map[string]float64) {
+		point := PnLPoint{Timestamp: timestamp}
+		for _, t := range ledger.Snapshot(valuation) {
+			point.RealizedPnL += t.RealizedPnL
+			point.UnrealizedPnL += t.UnrealizedPnL
+		}
+		// Merge events happening at the same time
+		if n := len(report.History); n > 0 && report.History[n-1].Timestamp == timestamp {
+			report.History[n-1] = point
+			return
+		}
+		report.History = append(report.History, point)
+	}
+
+	for _, e := range events {
+		amount, _ := e.amount.Float64()
+		// Fees have no proceeds, they don't need a price
+		var price float64
+		if e.kind != Fee {
+			var ok bool
+			price, ok = prices.priceAt(e.symbol, e.timestamp)
+			if !ok {
+				// Booking the event at a zero price would make up the cost basis or the proceeds
+				report.Unpriced = append(report.Unpriced, UnpricedEvent{
+					Timestamp: e.timestamp,
+					Symbol:    e.symbol,
+					Kind:      e.kind,
+					Amount:    amount,
+				})
+				continue
+			}
+		}
+		ledger.Apply(Event{
+			Timestamp: e.timestamp,
+			Symbol:    e.symbol,
+			Kind:      e.kind,
+			Amount:    amount,
+			UnitPrice: price,
+		})
+		addPoint(e.timestamp, prices.pricesAt(ledger.Symbols(), e.timestamp))
+	}
+
+	currentPrices := s.currentPrices(ledger.Symbols(), currency)
+	addPoint(now, currentPrices)
+
+	report.Tokens = ledger.Snapshot(currentPrices)
+	for _, t := range report.Tokens {
+		report.TotalCostBasis += t.CostBasis
+		report.TotalRealizedPnL += t.RealizedPnL
+		report.TotalUnrealizedPnL += t.UnrealizedPnL
+	}
+
+	return report
+}
+
+func (s *Service) currentPrices(symbols []string, currency string) map[string]float64 {
+	res := make(map[string]float64, len(symbols))
+	if len(symbols) == 0 {
+		return res
+	}
+
+	prices, err := s.pricesProvider.FetchPrices(symbols, []string{currency})
+	if err != nil {
+		logutils.ZapLogger().Warn("portfolio: failed to fetch current prices", zap.Error(err))
+		return res
+	}
+	for symbol, perCurrency := range prices {
+		if price, ok := perCurrency[currency]; ok {
+			res[symbol] = price
+		}
+	}
+	return res
+}
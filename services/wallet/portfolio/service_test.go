@@ -0,0 +1,77 @@
+package portfolio
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+type testPricesProvider struct {
+	daily   map[string][]thirdparty.HistoricalPrice
+	current map[string]float64
+}
+
+func (p *testPricesProvider) FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return p.daily[symbol], nil
+}
+
+func (p *testPricesProvider) FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error) {
+	res := make(map[string]map[string]float64)
+	for _, symbol := range symbols {
+		if price, ok := p.current[symbol]; ok {
+			res[symbol] = map[string]float64{currencies[0]: price}
+		}
+	}
+	return res, nil
+}
+
+func TestDailyPricesPriceAt(t *testing.T) {
+	prices := newDailyPrices(&testPricesProvider{daily: map[string][]thirdparty.HistoricalPrice{
+		"ETH": {{Timestamp: 200, Value: 2}, {Timestamp: 100, Value: 1}},
+	}}, "USD")
+
+	_, ok := prices.priceAt("ETH", 50)
+	require.False(t, ok)
+	_, ok = prices.priceAt("SNT", 150)
+	require.False(t, ok)
+
+	price, ok := prices.priceAt("ETH", 150)
+	require.True(t, ok)
+	require.Equal(t, float64(1), price)
+	price, ok = prices.priceAt("ETH", 250)
+	require.True(t, ok)
+	require.Equal(t, float64(2), price)
+
+	require.Equal(t, map[string]float64{"ETH": 1}, prices.pricesAt([]string{"ETH", "SNT"}, 100))
+}
+
+func TestComputeReportUnpricedEvents(t *testing.T) {
+	s := &Service{pricesProvider: &testPricesProvider{
+		daily: map[string][]thirdparty.HistoricalPrice{
+			"ETH": {{Timestamp: 100, Value: 1000}},
+		},
+		current: map[string]float64{"ETH": 2000, "SNT": 0.05},
+	}}
+
+	report := s.computeReport([]rawEvent{
+		// Before the first known ETH price
+		{timestamp: 50, symbol: "ETH", kind: Acquire, amount: big.NewFloat(1)},
+		{timestamp: 100, symbol: "ETH", kind: Acquire, amount: big.NewFloat(1)},
+		// SNT has no known price at all
+		{timestamp: 150, symbol: "SNT", kind: Acquire, amount: big.NewFloat(100)},
+	}, CostBasisFIFO, "USD", 300)
+
+	require.Len(t, report.Tokens, 1)
+	require.Equal(t, "ETH", report.Tokens[0].Symbol)
+	require.InDelta(t, 1, report.Tokens[0].Amount, 1e-9)
+	require.InDelta(t, 1000, report.TotalCostBasis, 1e-9)
+	require.InDelta(t, 1000, report.TotalUnrealizedPnL, 1e-9)
+
+	require.Equal(t, []UnpricedEvent{
+		{Timestamp: 50, Symbol: "ETH", Kind: Acquire, Amount: 1},
+		{Timestamp: 150, Symbol: "SNT", Kind: Acquire, Amount: 100},
+	}, report.Unpriced)
+}
@@ -184,8 +184,15 @@ func (r *Reader) startWalletEventsWatcher() {
 		return
 	}
 
-	// Respond to ETH/Token transfers
+	// Respond to ETH/Token transfers and to the reorganizations of the chains
 	walletEventCb := func(event walletevent.Event) {
+		if event.Type == transfer.EventChainReorganized {
+			// The balances may include the removed transfers
+			r.triggerDelayedWalletReload()
+			r.invalidateBalanceCache()
+			return
+		}
+
 		if event.Type != transfer.EventInternalETHTransferDetected &&
 			event.Type != transfer.EventInternalERC20TransferDetected {
 			return
@@ -546,6 +553,15 @@ func (r *Reader) FetchBalances(ctx context.Context, clients map[uint64]chain.Cli
 	r.updateTokenUpdateTimestamp(addresses)
 	r.balanceRefreshed()
 
+	if r.walletFeed != nil {
+		r.walletFeed.Send(walletevent.Event{
+			Type:        token.EventInternalBalancesUpdated,
+			Accounts:    addresses,
+			At:          time.Now().Unix(),
+			EventParams: tokens,
+		})
+	}
+
 	return tokens, err
 }
 
@@ -12,14 +12,15 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/logutils/tracing"
 
 	status_common "github.com/status-im/status-go/common"
 	statusErrors "github.com/status-im/status-go/errors"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
 	"github.com/status-im/status-go/services/wallet/routeexecution/storage"
 	"github.com/status-im/status-go/services/wallet/router"
-	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
 	"github.com/status-im/status-go/services/wallet/router/routes"
 	"github.com/status-im/status-go/services/wallet/router/sendtype"
 	"github.com/status-im/status-go/services/wallet/transfer"
@@ -129,14 +130,23 @@ func (m *Manager) SendRouterTransactionsWithSignatures(ctx context.Context, send
 			},
 		}
 
+		ctx, span := tracing.Start(tracing.WithTraceKey(ctx, sendInputParams.Uuid), "routeexecution.SendRouterTransactionsWithSignatures",
+			tracing.String("uuid", sendInputParams.Uuid))
 		defer func() {
+			span.End(err)
+
 			clearLocalData := true
 			if routeInputParams.SendType == sendtype.Swap {
 				// in case of swap don't clear local data if an approval is placed, but swap tx is not sent yet
-				if m.transactionManager.ApprovalRequiredForPath(pathProcessorCommon.ProcessorSwapParaswapName) &&
-					m.transactionManager.ApprovalPlacedForPath(pathProcessorCommon.ProcessorSwapParaswapName) &&
-					!m.transactionManager.TxPlacedForPath(pathProcessorCommon.ProcessorSwapParaswapName) {
-					clearLocalData = false
+				for _, path := range route {
+					if !walletCommon.IsProcessorSwap(path.ProcessorName) {
+						continue
+					}
+					if m.transactionManager.ApprovalRequiredForPath(path.ProcessorName) &&
+						m.transactionManager.ApprovalPlacedForPath(path.ProcessorName) &&
+						!m.transactionManager.TxPlacedForPath(path.ProcessorName) {
+						clearLocalData = false
+					}
 				}
 			}
 
@@ -230,9 +240,13 @@ func (m *Manager) SendRouterTransactionsWithSignatures(ctx context.Context, send
 			addresses = append(addresses, common.Address(tx.FromAddress))
 			go func(chainId uint64, txHash common.Hash) {
 				defer status_common.LogOnPanic()
-				tmpErr = m.transactionManager.WatchTransaction(context.Background(), chainId, txHash)
-				if tmpErr != nil {
-					logutils.ZapLogger().Error("Error watching transaction", zap.Error(tmpErr))
+				// The watching outlives the request, only its trace is kept
+				watchCtx, watchSpan := tracing.Start(tracing.WithTraceKey(context.Background(), sendInputParams.Uuid), "routeexecution.WatchTransaction",
+					tracing.Uint64("chainID", chainId), tracing.String("txHash", txHash.Hex()))
+				err := m.transactionManager.WatchTransaction(watchCtx, chainId, txHash)
+				watchSpan.End(err)
+				if err != nil {
+					logutils.ZapLogger().Error("Error watching transaction", zap.Error(err))
 					return
 				}
 			}(tx.FromChain, common.Hash(tx.Hash))
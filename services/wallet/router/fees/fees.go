@@ -10,8 +10,9 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc"
-	"github.com/ethereum/go-ethereum/params"
+	gethParams "github.com/ethereum/go-ethereum/params"
 	gaspriceoracle "github.com/status-im/status-go/contracts/gas-price-oracle"
+	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/rpc/chain"
 	"github.com/status-im/status-go/services/wallet/common"
@@ -90,6 +91,19 @@ type FeeManager struct {
 	RPCClient rpc.ClientInterface
 }
 
+// capabilities returns the probed capabilities of the chain, nil when they are unknown
+func (f *FeeManager) capabilities(chainID uint64) *params.NetworkCapabilities {
+	networkManager := f.RPCClient.GetNetworkManager()
+	if networkManager == nil {
+		return nil
+	}
+	network := networkManager.Find(chainID)
+	if network == nil {
+		return nil
+	}
+	return network.Capabilities
+}
+
 func (f *FeeManager) SuggestedFees(ctx context.Context, chainID uint64) (*SuggestedFees, error) {
 	backend, err := f.RPCClient.EthClient(chainID)
 	if err != nil {
@@ -99,8 +113,7 @@ func (f *FeeManager) SuggestedFees(ctx context.Context, chainID uint64) (*Sugges
 	if err != nil {
 		return nil, err
 	}
-	maxPriorityFeePerGas, err := backend.SuggestGasTipCap(ctx)
-	if err != nil {
+	legacyFees := func() *SuggestedFees {
 		return &SuggestedFees{
 			GasPrice:             gasPrice,
 			BaseFee:              big.NewInt(0),
@@ -111,7 +124,14 @@ func (f *FeeManager) SuggestedFees(ctx context.Context, chainID uint64) (*Sugges
 				High:   (*hexutil.Big)(gasPrice),
 			},
 			EIP1559Enabled: false,
-		}, nil
+		}
+	}
+	if capabilities := f.capabilities(chainID); capabilities != nil && !capabilities.EIP1559 {
+		return legacyFees(), nil
+	}
+	maxPriorityFeePerGas, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return legacyFees(), nil
 	}
 	baseFee, err := f.getBaseFee(ctx, backend)
 	if err != nil {
@@ -154,12 +174,12 @@ func (f *FeeManager) getBaseFee(ctx context.Context, client chain.ClientInterfac
 	}
 
 	chainID := client.NetworkID()
-	config := params.MainnetChainConfig
+	config := gethParams.MainnetChainConfig
 	switch chainID {
 	case common.EthereumSepolia,
 		common.OptimismSepolia,
 		common.ArbitrumSepolia:
-		config = params.SepoliaChainConfig
+		config = gethParams.SepoliaChainConfig
 	}
 	baseFee := misc.CalcBaseFee(config, header)
 	return baseFee, nil
@@ -270,7 +290,15 @@ func (f *FeeManager) GetL1Fee(ctx context.Context, chainID uint64, input []byte)
 
 	contractAddress, err := gaspriceoracle.ContractAddress(chainID)
 	if err != nil {
-		return 0, err
+		// The probed chains use the OP Stack predeploy or have no L1 fee to add
+		capabilities := f.capabilities(chainID)
+		if capabilities == nil {
+			return 0, err
+		}
+		if capabilities.L2FeeModel != params.OptimismL2FeeModel {
+			return 0, nil
+		}
+		contractAddress = gaspriceoracle.PredeployAddress
 	}
 
 	contract, err := gaspriceoracle.NewGaspriceoracleCaller(contractAddress, ethClient)
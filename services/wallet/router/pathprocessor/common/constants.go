@@ -7,7 +7,10 @@ const (
 	ProcessorTransferName     = "Transfer"
 	ProcessorBridgeHopName    = "Hop"
 	ProcessorBridgeCelerName  = "CBridge"
+	ProcessorBridgeAcrossName = "Across"
 	ProcessorSwapParaswapName = "Paraswap"
+	ProcessorSwapKyberName    = "KyberSwap"
+	ProcessorSwapUniswapName  = "UniswapV3"
 	ProcessorERC721Name       = "ERC721Transfer"
 	ProcessorERC1155Name      = "ERC1155Transfer"
 	ProcessorENSRegisterName  = "ENSRegister"
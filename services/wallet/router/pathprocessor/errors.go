@@ -49,6 +49,11 @@ var (
 	ErrPriceTimeout                   = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-037"), Details: "price timeout"}
 	ErrNotEnoughLiquidity             = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-038"), Details: "not enough liquidity"}
 	ErrPriceImpactTooHigh             = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-039"), Details: "price impact too high"}
+	ErrSwapKyberCustomError           = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-040"), Details: "KyberSwap custom error"}
+	ErrSwapUniswapCustomError         = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-041"), Details: "UniswapV3 custom error"}
+	ErrSwapQuoteNotFound              = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-042"), Details: "swap quote not found"}
+	ErrBridgeAcrossCustomError        = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-043"), Details: "Across custom error"}
+	ErrBridgeQuoteNotFound            = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-044"), Details: "bridge quote not found"}
 )
 
 func createErrorResponse(processorName string, err error) error {
@@ -81,8 +86,14 @@ func createErrorResponse(processorName string, err error) error {
 		customErrResp = ErrBridgeHopCustomError
 	case pathProcessorCommon.ProcessorBridgeCelerName:
 		customErrResp = ErrBridgeCellerCustomError
+	case pathProcessorCommon.ProcessorBridgeAcrossName:
+		customErrResp = ErrBridgeAcrossCustomError
 	case pathProcessorCommon.ProcessorSwapParaswapName:
 		customErrResp = ErrSwapParaswapCustomError
+	case pathProcessorCommon.ProcessorSwapKyberName:
+		customErrResp = ErrSwapKyberCustomError
+	case pathProcessorCommon.ProcessorSwapUniswapName:
+		customErrResp = ErrSwapUniswapCustomError
 	case pathProcessorCommon.ProcessorENSRegisterName:
 		customErrResp = ErrENSRegisterCustomError
 	case pathProcessorCommon.ProcessorENSReleaseName:
@@ -115,7 +126,10 @@ func IsCustomError(err error) bool {
 		ErrERC1155TransferCustomError,
 		ErrBridgeHopCustomError,
 		ErrBridgeCellerCustomError,
+		ErrBridgeAcrossCustomError,
 		ErrSwapParaswapCustomError,
+		ErrSwapKyberCustomError,
+		ErrSwapUniswapCustomError,
 		ErrENSRegisterCustomError,
 		ErrENSReleaseCustomError,
 		ErrENSPublicKeyCustomError,
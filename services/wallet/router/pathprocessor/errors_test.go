@@ -21,7 +21,10 @@ func TestPlainError(t *testing.T) {
 		common.ProcessorTransferName,
 		common.ProcessorBridgeHopName,
 		common.ProcessorBridgeCelerName,
+		common.ProcessorBridgeAcrossName,
 		common.ProcessorSwapParaswapName,
+		common.ProcessorSwapKyberName,
+		common.ProcessorSwapUniswapName,
 		common.ProcessorERC721Name,
 		common.ProcessorERC1155Name,
 		common.ProcessorENSRegisterName,
@@ -0,0 +1,17 @@
+package pathprocessor
+
+import (
+	"github.com/status-im/status-go/transactions"
+)
+
+// BridgeProcessor is a path processor moving funds to another chain, able to tell when the funds arrive there.
+// Sent bridge txs are followed by the pending tx tracker until the destination chain side completes
+type BridgeProcessor interface {
+	PathProcessor
+	transactions.BridgeStatusChecker
+}
+
+var (
+	_ BridgeProcessor = (*HopBridgeProcessor)(nil)
+	_ BridgeProcessor = (*AcrossBridgeProcessor)(nil)
+)
@@ -0,0 +1,391 @@
+package pathprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/contracts"
+	"github.com/status-im/status-go/contracts/across"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	acrossClient "github.com/status-im/status-go/services/wallet/thirdparty/across"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// the deposit cannot be simulated for ERC20 tokens before the approval is placed
+	acrossDepositGasLimit = 150000
+
+	// subset of the SpokePool ABI
+	acrossSpokePoolABI = `[
+	{"name":"depositV3","type":"function","stateMutability":"payable","inputs":[
+		{"name":"depositor","type":"address"},{"name":"recipient","type":"address"},{"name":"inputToken","type":"address"},
+		{"name":"outputToken","type":"address"},{"name":"inputAmount","type":"uint256"},{"name":"outputAmount","type":"uint256"},
+		{"name":"destinationChainId","type":"uint256"},{"name":"exclusiveRelayer","type":"address"},{"name":"quoteTimestamp","type":"uint32"},
+		{"name":"fillDeadline","type":"uint32"},{"name":"exclusivityDeadline","type":"uint32"},{"name":"message","type":"bytes"}],
+		"outputs":[]}
+	]`
+)
+
+// acrossSupportedSymbols are the tokens with liquidity on all the supported chains
+var acrossSupportedSymbols = map[string]bool{
+	walletCommon.EthSymbol: true,
+	"USDC":                 true,
+	"USDT":                 true,
+	"DAI":                  true,
+	"WBTC":                 true,
+}
+
+type acrossQuote struct {
+	inputToken  common.Address
+	outputToken common.Address
+	inputAmount *big.Int
+	// outputAmount is the amount the relayer delivers on the destination chain, relay fees excluded
+	outputAmount *big.Int
+	fees         acrossClient.SuggestedFees
+}
+
+// AcrossBridgeProcessor bridges through the Across protocol, relayers front the funds on the destination chain
+// and are repaid later, so the transfer usually completes within seconds
+type AcrossBridgeProcessor struct {
+	client        acrossClient.ClientInterface
+	transactor    transactions.TransactorIface
+	tokenManager  *token.Manager
+	contractMaker *contracts.ContractMaker
+	quotes        *sync.Map // [fromChainID-toChainID-fromTokenSymbol--amountIn, *acrossQuote]
+}
+
+func NewAcrossBridgeProcessor(rpcClient rpc.ClientInterface, transactor transactions.TransactorIface, tokenManager *token.Manager) *AcrossBridgeProcessor {
+	return &AcrossBridgeProcessor{
+		client:        acrossClient.NewClient(),
+		transactor:    transactor,
+		tokenManager:  tokenManager,
+		contractMaker: &contracts.ContractMaker{RPCClient: rpcClient},
+		quotes:        &sync.Map{},
+	}
+}
+
+func createBridgeAcrossErrorResponse(err error) error {
+	return createErrorResponse(pathProcessorCommon.ProcessorBridgeAcrossName, err)
+}
+
+func (a *AcrossBridgeProcessor) Name() string {
+	return pathProcessorCommon.ProcessorBridgeAcrossName
+}
+
+func (a *AcrossBridgeProcessor) Clear() {
+	a.quotes = &sync.Map{}
+}
+
+func (a *AcrossBridgeProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil {
+		return false, ErrNoTokenSet
+	}
+	if params.ToToken != nil {
+		return false, ErrToTokenShouldNotBeSet
+	}
+	if params.FromChain.ChainID == params.ToChain.ChainID {
+		return false, ErrFromAndToChainsMustBeDifferent
+	}
+
+	if _, err := across.ContractDeployment(params.FromChain.ChainID); err != nil {
+		return false, nil
+	}
+	if _, err := across.ContractDeployment(params.ToChain.ChainID); err != nil {
+		return false, nil
+	}
+
+	return acrossSupportedSymbols[params.FromToken.Symbol], nil
+}
+
+// resolveTokens returns the input token on the source chain and the output token on the destination chain,
+// the native token is bridged as its wrapped version and unwrapped by the relayer
+func (a *AcrossBridgeProcessor) resolveTokens(params ProcessorInputParams) (common.Address, common.Address, error) {
+	fromDeployment, err := across.ContractDeployment(params.FromChain.ChainID)
+	if err != nil {
+		return common.Address{}, common.Address{}, ErrFromChainNotSupported
+	}
+	toDeployment, err := across.ContractDeployment(params.ToChain.ChainID)
+	if err != nil {
+		return common.Address{}, common.Address{}, ErrToChainNotSupported
+	}
+
+	if params.FromToken.IsNative() {
+		return fromDeployment.WrappedNative, toDeployment.WrappedNative, nil
+	}
+
+	toToken := a.tokenManager.FindToken(params.ToChain, params.FromToken.Symbol)
+	if toToken == nil {
+		return common.Address{}, common.Address{}, ErrTokenNotFound
+	}
+	return params.FromToken.Address, toToken.Address, nil
+}
+
+func (a *AcrossBridgeProcessor) quoteKey(fromChainID uint64, toChainID uint64, symbol string, amountIn *big.Int) string {
+	return pathProcessorCommon.MakeKey(fromChainID, toChainID, symbol, "", amountIn)
+}
+
+func (a *AcrossBridgeProcessor) loadQuote(fromChainID uint64, toChainID uint64, symbol string, amountIn *big.Int) (*acrossQuote, error) {
+	quote, ok := a.quotes.Load(a.quoteKey(fromChainID, toChainID, symbol, amountIn))
+	if !ok {
+		return nil, ErrBridgeQuoteNotFound
+	}
+	return quote.(*acrossQuote), nil
+}
+
+func (a *AcrossBridgeProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	key := a.quoteKey(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.AmountIn)
+	if params.TestsMode {
+		if val, ok := params.TestBonderFeeMap[params.FromToken.Symbol]; ok {
+			now := time.Now()
+			a.quotes.Store(key, &acrossQuote{
+				inputAmount:  params.AmountIn,
+				outputAmount: new(big.Int).Sub(params.AmountIn, val),
+				fees: acrossClient.SuggestedFees{
+					TotalRelayFee: acrossClient.Fee{Total: &bigint.BigInt{Int: val}},
+					Timestamp:     json.Number(strconv.FormatInt(now.Unix(), 10)),
+					FillDeadline:  json.Number(strconv.FormatInt(now.Add(time.Hour).Unix(), 10)),
+				},
+			})
+			return val, walletCommon.ZeroBigIntValue(), nil
+		}
+		return nil, nil, ErrNoBonderFeeFound
+	}
+
+	inputToken, outputToken, err := a.resolveTokens(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fees, err := a.client.FetchSuggestedFees(context.Background(), params.FromChain.ChainID, params.ToChain.ChainID, inputToken, outputToken, params.AmountIn)
+	if err != nil {
+		return nil, nil, createBridgeAcrossErrorResponse(err)
+	}
+
+	relayFee := fees.TotalRelayFee.Total.Int
+	if relayFee.Cmp(params.AmountIn) >= 0 {
+		return nil, nil, ErrNotEnoughLiquidity
+	}
+
+	a.quotes.Store(key, &acrossQuote{
+		inputToken:   inputToken,
+		outputToken:  outputToken,
+		inputAmount:  new(big.Int).Set(params.AmountIn),
+		outputAmount: new(big.Int).Sub(params.AmountIn, relayFee),
+		fees:         fees,
+	})
+
+	return new(big.Int).Set(relayFee), walletCommon.ZeroBigIntValue(), nil
+}
+
+func (a *AcrossBridgeProcessor) packDeposit(quote *acrossQuote, toChainID uint64, depositor common.Address, recipient common.Address) ([]byte, error) {
+	spokePoolABI, err := abi.JSON(strings.NewReader(acrossSpokePoolABI))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	quoteTimestamp, err := quote.fees.QuoteTimestamp()
+	if err != nil {
+		return []byte{}, err
+	}
+	fillDeadline, err := quote.fees.FillDeadlineTimestamp()
+	if err != nil {
+		return []byte{}, err
+	}
+	exclusivityDeadline, err := quote.fees.ExclusivityDeadlineTimestamp()
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return spokePoolABI.Pack("depositV3",
+		depositor,
+		recipient,
+		quote.inputToken,
+		quote.outputToken,
+		quote.inputAmount,
+		quote.outputAmount,
+		new(big.Int).SetUint64(toChainID),
+		quote.fees.ExclusiveRelayer,
+		quoteTimestamp,
+		fillDeadline,
+		exclusivityDeadline,
+		[]byte{})
+}
+
+func (a *AcrossBridgeProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	quote, err := a.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.AmountIn)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	data, err := a.packDeposit(quote, params.ToChain.ChainID, params.FromAddr, params.ToAddr)
+	if err != nil {
+		return []byte{}, createBridgeAcrossErrorResponse(err)
+	}
+	return data, nil
+}
+
+func (a *AcrossBridgeProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[a.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	if !params.FromToken.IsNative() {
+		return acrossDepositGasLimit, nil
+	}
+
+	input, err := a.PackTxInputData(params)
+	if err != nil {
+		return 0, err
+	}
+
+	contractAddress, err := a.GetContractAddress(params)
+	if err != nil {
+		return 0, err
+	}
+
+	ethClient, err := a.contractMaker.RPCClient.EthClient(params.FromChain.ChainID)
+	if err != nil {
+		return 0, createBridgeAcrossErrorResponse(err)
+	}
+
+	estimation, err := ethClient.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:  params.FromAddr,
+		To:    &contractAddress,
+		Value: params.AmountIn,
+		Data:  input,
+	})
+	if err != nil {
+		return 0, createBridgeAcrossErrorResponse(err)
+	}
+
+	increasedEstimation := float64(estimation) * pathProcessorCommon.IncreaseEstimatedGasFactor
+	return uint64(increasedEstimation), nil
+}
+
+func (a *AcrossBridgeProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	deployment, err := across.ContractDeployment(params.FromChain.ChainID)
+	if err != nil {
+		return common.Address{}, ErrFromChainNotSupported
+	}
+	return deployment.SpokePool, nil
+}
+
+func (a *AcrossBridgeProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	quote, err := a.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.AmountIn)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(quote.outputAmount), nil
+}
+
+func (a *AcrossBridgeProcessor) prepareTransactionV2(sendArgs *wallettypes.SendTxArgs) error {
+	quote, err := a.loadQuote(sendArgs.FromChainID, sendArgs.ToChainID, sendArgs.FromTokenID, sendArgs.ValueIn.ToInt())
+	if err != nil {
+		return err
+	}
+
+	deployment, err := across.ContractDeployment(sendArgs.FromChainID)
+	if err != nil {
+		return ErrFromChainNotSupported
+	}
+
+	depositor := common.Address(sendArgs.From)
+	recipient := depositor
+	if sendArgs.To != nil {
+		recipient = common.Address(*sendArgs.To)
+	}
+
+	data, err := a.packDeposit(quote, sendArgs.ToChainID, depositor, recipient)
+	if err != nil {
+		return err
+	}
+
+	value := walletCommon.ZeroBigIntValue()
+	if sendArgs.FromTokenID == walletCommon.EthSymbol {
+		// the spoke pool wraps the sent value
+		value = new(big.Int).Set(quote.inputAmount)
+	}
+
+	spokePool := types.Address(deployment.SpokePool)
+	sendArgs.To = &spokePool
+	sendArgs.Value = (*hexutil.Big)(value)
+	sendArgs.Data = data
+	return nil
+}
+
+// TODO: remove once mobile switches to the new approach
+func (a *AcrossBridgeProcessor) prepareTransaction(sendArgs *MultipathProcessorTxArgs) error {
+	if sendArgs.TransferTx == nil {
+		return ErrBridgeQuoteNotFound
+	}
+	return a.prepareTransactionV2(sendArgs.TransferTx)
+}
+
+func (a *AcrossBridgeProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	err := a.prepareTransaction(sendArgs)
+	if err != nil {
+		return types.Hash{}, 0, createBridgeAcrossErrorResponse(err)
+	}
+	return a.transactor.SendTransactionWithChainID(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce, verifiedAccount)
+}
+
+func (a *AcrossBridgeProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := a.prepareTransaction(sendArgs)
+	if err != nil {
+		return nil, 0, createBridgeAcrossErrorResponse(err)
+	}
+	return a.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce)
+}
+
+func (a *AcrossBridgeProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := a.prepareTransactionV2(sendArgs)
+	if err != nil {
+		return nil, 0, createBridgeAcrossErrorResponse(err)
+	}
+	return a.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (a *AcrossBridgeProcessor) CheckBridgeStatus(ctx context.Context, source transactions.TxIdentity, destinationChainID walletCommon.ChainID) (transactions.BridgeStatus, error) {
+	status, err := a.client.FetchDepositStatus(ctx, source.ChainID.ToUint(), source.Hash)
+	if err != nil {
+		return transactions.BridgeStatus{}, createBridgeAcrossErrorResponse(err)
+	}
+
+	switch status.Status {
+	case acrossClient.DepositStatusFilled:
+		res := transactions.BridgeStatus{Status: transactions.Success}
+		if status.FillTx != nil {
+			res.DestinationHash = *status.FillTx
+		}
+		return res, nil
+	case acrossClient.DepositStatusExpired, acrossClient.DepositStatusRefunded:
+		// nobody filled the deposit in time, the funds are refunded on the source chain
+		return transactions.BridgeStatus{Status: transactions.Failed}, nil
+	}
+	return transactions.BridgeStatus{Status: transactions.Pending}, nil
+}
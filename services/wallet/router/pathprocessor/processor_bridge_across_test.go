@@ -0,0 +1,205 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/contracts/across"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	acrossClient "github.com/status-im/status-go/services/wallet/thirdparty/across"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAcrossClient struct {
+	fees   acrossClient.SuggestedFees
+	status acrossClient.DepositStatus
+
+	inputToken  common.Address
+	outputToken common.Address
+}
+
+func (c *testAcrossClient) FetchSuggestedFees(ctx context.Context, fromChainID uint64, toChainID uint64, inputToken common.Address, outputToken common.Address,
+	amount *big.Int) (acrossClient.SuggestedFees, error) {
+	c.inputToken = inputToken
+	c.outputToken = outputToken
+	return c.fees, nil
+}
+
+func (c *testAcrossClient) FetchDepositStatus(ctx context.Context, originChainID uint64, depositTxHash common.Hash) (acrossClient.DepositStatus, error) {
+	return c.status, nil
+}
+
+func newTestAcrossProcessor(client *testAcrossClient) *AcrossBridgeProcessor {
+	processor := NewAcrossBridgeProcessor(nil, nil, nil)
+	processor.client = client
+	return processor
+}
+
+func TestAcrossBridgeProcessor(t *testing.T) {
+	client := &testAcrossClient{
+		fees: acrossClient.SuggestedFees{
+			TotalRelayFee:       acrossClient.Fee{Total: &bigint.BigInt{Int: big.NewInt(1000)}},
+			Timestamp:           "1727361023",
+			FillDeadline:        "1727375423",
+			ExclusivityDeadline: "0",
+			ExclusiveRelayer:    common.HexToAddress("0x1"),
+		},
+	}
+	processor := newTestAcrossProcessor(client)
+
+	eth := &token.Token{Symbol: walletCommon.EthSymbol, Decimals: 18}
+	inputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.OptimismMainnet},
+		FromToken: eth,
+		FromAddr:  common.HexToAddress("0xaaa"),
+		ToAddr:    common.HexToAddress("0xbbb"),
+		AmountIn:  big.NewInt(1000000),
+	}
+
+	can, err := processor.AvailableFor(inputParams)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	bonderFees, tokenFees, err := processor.CalculateFees(inputParams)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), bonderFees)
+	require.Equal(t, walletCommon.ZeroBigIntValue(), tokenFees)
+
+	fromDeployment, err := across.ContractDeployment(walletCommon.EthereumMainnet)
+	require.NoError(t, err)
+	toDeployment, err := across.ContractDeployment(walletCommon.OptimismMainnet)
+	require.NoError(t, err)
+	require.Equal(t, fromDeployment.WrappedNative, client.inputToken)
+	require.Equal(t, toDeployment.WrappedNative, client.outputToken)
+
+	amountOut, err := processor.CalculateAmountOut(inputParams)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(999000), amountOut)
+
+	contractAddress, err := processor.GetContractAddress(inputParams)
+	require.NoError(t, err)
+	require.Equal(t, fromDeployment.SpokePool, contractAddress)
+
+	data, err := processor.PackTxInputData(inputParams)
+	require.NoError(t, err)
+
+	spokePoolABI, err := abi.JSON(strings.NewReader(acrossSpokePoolABI))
+	require.NoError(t, err)
+	method, err := spokePoolABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "depositV3", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, inputParams.FromAddr, args[0])
+	require.Equal(t, inputParams.ToAddr, args[1])
+	require.Equal(t, big.NewInt(1000000), args[4])
+	require.Equal(t, big.NewInt(999000), args[5])
+	require.Equal(t, big.NewInt(int64(walletCommon.OptimismMainnet)), args[6])
+	require.Equal(t, common.HexToAddress("0x1"), args[7])
+	require.Equal(t, uint32(1727361023), args[8])
+	require.Equal(t, uint32(1727375423), args[9])
+
+	recipient := types.Address(inputParams.ToAddr)
+	sendArgs := &wallettypes.SendTxArgs{
+		From:        types.Address(inputParams.FromAddr),
+		To:          &recipient,
+		ValueIn:     (*hexutil.Big)(inputParams.AmountIn),
+		FromChainID: walletCommon.EthereumMainnet,
+		ToChainID:   walletCommon.OptimismMainnet,
+		FromTokenID: walletCommon.EthSymbol,
+	}
+	err = processor.prepareTransactionV2(sendArgs)
+	require.NoError(t, err)
+	require.Equal(t, types.Address(fromDeployment.SpokePool), *sendArgs.To)
+	require.Equal(t, big.NewInt(1000000), sendArgs.Value.ToInt())
+	require.Equal(t, data, []byte(sendArgs.Data))
+
+	processor.Clear()
+	_, err = processor.CalculateAmountOut(inputParams)
+	require.ErrorIs(t, err, ErrBridgeQuoteNotFound)
+}
+
+func TestAcrossBridgeProcessorAvailability(t *testing.T) {
+	processor := newTestAcrossProcessor(&testAcrossClient{})
+
+	inputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.EthereumSepolia},
+		FromToken: &token.Token{Symbol: walletCommon.EthSymbol},
+	}
+	can, err := processor.AvailableFor(inputParams)
+	require.NoError(t, err)
+	require.False(t, can)
+
+	inputParams.ToChain = &params.Network{ChainID: walletCommon.ArbitrumMainnet}
+	inputParams.FromToken = &token.Token{Symbol: "SNT"}
+	can, err = processor.AvailableFor(inputParams)
+	require.NoError(t, err)
+	require.False(t, can)
+
+	inputParams.ToChain = inputParams.FromChain
+	_, err = processor.AvailableFor(inputParams)
+	require.ErrorIs(t, err, ErrFromAndToChainsMustBeDifferent)
+}
+
+func TestAcrossCheckBridgeStatus(t *testing.T) {
+	fillTx := common.HexToHash("0x1234")
+	source := transactions.TxIdentity{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Hash: common.HexToHash("0x1")}
+
+	tests := []struct {
+		name     string
+		status   acrossClient.DepositStatus
+		expected transactions.BridgeStatus
+	}{
+		{
+			name:     "pending",
+			status:   acrossClient.DepositStatus{Status: acrossClient.DepositStatusPending},
+			expected: transactions.BridgeStatus{Status: transactions.Pending},
+		},
+		{
+			name:     "filled",
+			status:   acrossClient.DepositStatus{Status: acrossClient.DepositStatusFilled, FillTx: &fillTx},
+			expected: transactions.BridgeStatus{Status: transactions.Success, DestinationHash: fillTx},
+		},
+		{
+			name:     "expired",
+			status:   acrossClient.DepositStatus{Status: acrossClient.DepositStatusExpired},
+			expected: transactions.BridgeStatus{Status: transactions.Failed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := newTestAcrossProcessor(&testAcrossClient{status: tt.status})
+			status, err := processor.CheckBridgeStatus(context.Background(), source, walletCommon.ChainID(walletCommon.OptimismMainnet))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+func TestParseHopTransferStatus(t *testing.T) {
+	status, err := parseHopTransferStatus([]byte(`{"transferId": "0x01", "bonded": false, "bondTransactionHash": null}`))
+	require.NoError(t, err)
+	require.Equal(t, transactions.Pending, status.Status)
+
+	status, err = parseHopTransferStatus([]byte(`{"transferId": "0x01", "bonded": true,
+		"bondTransactionHash": "0x0b7b2a1d0c2a9a3c8c0b0b9c3d6f1e6e2f7a9b3c4d5e6f708192a3b4c5d6e7f8"}`))
+	require.NoError(t, err)
+	require.Equal(t, transactions.Success, status.Status)
+	require.Equal(t, common.HexToHash("0x0b7b2a1d0c2a9a3c8c0b0b9c3d6f1e6e2f7a9b3c4d5e6f708192a3b4c5d6e7f8"), status.DestinationHash)
+}
@@ -733,3 +733,39 @@ func (h *HopBridgeProcessor) sendL2BridgeTx(contractAddress common.Address, ethC
 
 	return tx, ErrTxForChainNotSupported
 }
+
+type hopTransferStatus struct {
+	Bonded              bool         `json:"bonded"`
+	BondTransactionHash *common.Hash `json:"bondTransactionHash"`
+}
+
+func (h *HopBridgeProcessor) CheckBridgeStatus(ctx context.Context, source transactions.TxIdentity, destinationChainID walletCommon.ChainID) (transactions.BridgeStatus, error) {
+	reqParams := netUrl.Values{}
+	reqParams.Add("transactionHash", source.Hash.Hex())
+
+	url := "https://api.hop.exchange/v1/transfer-status"
+	response, err := h.httpClient.DoGetRequest(ctx, url, reqParams, nil)
+	if err != nil {
+		return transactions.BridgeStatus{}, createBridgeHopErrorResponse(err)
+	}
+
+	return parseHopTransferStatus(response)
+}
+
+// parseHopTransferStatus maps the transfer status, a transfer is complete once a bonder delivered the funds
+// on the destination chain, unbonded transfers are settled later by the bridge so they never fail
+func parseHopTransferStatus(response []byte) (transactions.BridgeStatus, error) {
+	status := &hopTransferStatus{}
+	err := json.Unmarshal(response, status)
+	if err != nil {
+		return transactions.BridgeStatus{}, createBridgeHopErrorResponse(err)
+	}
+
+	if !status.Bonded || status.BondTransactionHash == nil {
+		return transactions.BridgeStatus{Status: transactions.Pending}, nil
+	}
+	return transactions.BridgeStatus{
+		Status:          transactions.Success,
+		DestinationHash: *status.BondTransactionHash,
+	}, nil
+}
@@ -0,0 +1,245 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+// SwapQuote is the result of quoting an exact input swap with a provider
+type SwapQuote struct {
+	ChainID   uint64
+	AmountIn  *big.Int
+	AmountOut *big.Int // the amount the recipient receives, provider fees excluded
+	GasLimit  uint64
+	// Spender is the contract that needs an allowance for the from token, it's also the tx destination
+	Spender common.Address
+	// ProviderData holds whatever the provider needs to build the tx for this quote
+	ProviderData interface{}
+}
+
+// SwapTx is the swap transaction built by a provider for a quote
+type SwapTx struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+	Gas   uint64 // 0 to keep the router estimation
+}
+
+// SwapProvider is a source of swap quotes (aggregator, DEX...) that can be plugged into the router through a SwapProcessor.
+// Providers only support exact input swaps on a single chain
+type SwapProvider interface {
+	// Name returns the name of the provider, used as processor name
+	Name() string
+	// IsChainSupported checks if the provider can swap on the given chain
+	IsChainSupported(chainID uint64) bool
+	// FetchQuote quotes swapping params.AmountIn of params.FromToken to params.ToToken
+	FetchQuote(ctx context.Context, params ProcessorInputParams) (*SwapQuote, error)
+	// BuildSwapTx builds the tx executing the quote, reverting if less than the slippage tolerated amount is received
+	BuildSwapTx(ctx context.Context, quote *SwapQuote, from common.Address, recipient common.Address, slippageBasisPoints uint) (*SwapTx, error)
+}
+
+// SwapProcessor exposes a SwapProvider as a path processor, so that the router can compare quotes across providers
+type SwapProcessor struct {
+	provider   SwapProvider
+	transactor transactions.TransactorIface
+	quotes     sync.Map // [fromChainID-toChainID-fromTokenSymbol-toTokenSymbol-amountIn, *SwapQuote]
+}
+
+func NewSwapProcessor(provider SwapProvider, transactor transactions.TransactorIface) *SwapProcessor {
+	return &SwapProcessor{
+		provider:   provider,
+		transactor: transactor,
+		quotes:     sync.Map{},
+	}
+}
+
+func (s *SwapProcessor) Name() string {
+	return s.provider.Name()
+}
+
+func (s *SwapProcessor) Clear() {
+	s.quotes = sync.Map{}
+}
+
+func (s *SwapProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil || params.ToToken == nil {
+		return false, ErrToAndFromTokensMustBeSet
+	}
+
+	if params.FromChain.ChainID != params.ToChain.ChainID {
+		return false, ErrFromAndToChainsMustBeSame
+	}
+
+	if params.FromToken.Symbol == params.ToToken.Symbol {
+		return false, ErrFromAndToTokensMustBeDifferent
+	}
+
+	// exact output swaps are left to the processors supporting them
+	if params.AmountIn == nil || params.AmountIn.Cmp(walletCommon.ZeroBigIntValue()) <= 0 {
+		return false, nil
+	}
+
+	return s.provider.IsChainSupported(params.FromChain.ChainID), nil
+}
+
+func (s *SwapProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+func (s *SwapProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	// the tx data is built by the provider
+	return []byte{}, nil
+}
+
+func (s *SwapProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	quote, err := s.provider.FetchQuote(context.Background(), params)
+	if err != nil {
+		return 0, createErrorResponse(s.Name(), err)
+	}
+
+	key := pathProcessorCommon.MakeKey(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol, params.AmountIn)
+	s.quotes.Store(key, quote)
+
+	return quote.GasLimit, nil
+}
+
+func (s *SwapProcessor) loadQuote(fromChainID uint64, toChainID uint64, fromTokenSymbol string, toTokenSymbol string, amountIn *big.Int) (*SwapQuote, error) {
+	key := pathProcessorCommon.MakeKey(fromChainID, toChainID, fromTokenSymbol, toTokenSymbol, amountIn)
+	quote, ok := s.quotes.Load(key)
+	if !ok {
+		return nil, ErrSwapQuoteNotFound
+	}
+	return quote.(*SwapQuote), nil
+}
+
+func (s *SwapProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	quote, err := s.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol, params.AmountIn)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return quote.Spender, nil
+}
+
+func (s *SwapProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	quote, err := s.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol, params.AmountIn)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(quote.AmountOut), nil
+}
+
+func (s *SwapProcessor) buildSwapTx(quote *SwapQuote, from common.Address, recipient common.Address, slippagePercentage float32) (*SwapTx, error) {
+	slippageBP := uint(slippagePercentage * 100) // convert to basis points
+	tx, err := s.provider.BuildSwapTx(context.Background(), quote, from, recipient, slippageBP)
+	if err != nil {
+		return nil, createErrorResponse(s.Name(), err)
+	}
+	return tx, nil
+}
+
+func applySwapTx(sendArgs *wallettypes.SendTxArgs, tx *SwapTx) {
+	toAddr := types.Address(tx.To)
+	sendArgs.To = &toAddr
+	sendArgs.Value = (*hexutil.Big)(tx.Value)
+	sendArgs.Data = tx.Data
+	if tx.Gas > 0 {
+		gas := tx.Gas
+		sendArgs.Gas = (*hexutil.Uint64)(&gas)
+	}
+}
+
+// TODO: remove once mobile switches to the new approach
+func (s *SwapProcessor) prepareTransaction(sendArgs *MultipathProcessorTxArgs) error {
+	if sendArgs.SwapTx == nil {
+		return ErrSwapQuoteNotFound
+	}
+
+	quote, err := s.loadQuote(sendArgs.SwapTx.ChainID, sendArgs.SwapTx.ChainIDTo, sendArgs.SwapTx.TokenIDFrom, sendArgs.SwapTx.TokenIDTo, sendArgs.SwapTx.ValueIn.ToInt())
+	if err != nil {
+		return err
+	}
+
+	from := common.Address(sendArgs.SwapTx.From)
+	recipient := from
+	if sendArgs.SwapTx.To != nil {
+		recipient = common.Address(*sendArgs.SwapTx.To)
+	}
+
+	tx, err := s.buildSwapTx(quote, from, recipient, sendArgs.SwapTx.SlippagePercentage)
+	if err != nil {
+		return err
+	}
+
+	sendArgs.ChainID = quote.ChainID
+	applySwapTx(&sendArgs.SwapTx.SendTxArgs, tx)
+	return nil
+}
+
+func (s *SwapProcessor) prepareTransactionV2(sendArgs *wallettypes.SendTxArgs) error {
+	quote, err := s.loadQuote(sendArgs.FromChainID, sendArgs.ToChainID, sendArgs.FromTokenID, sendArgs.ToTokenID, sendArgs.ValueIn.ToInt())
+	if err != nil {
+		return err
+	}
+
+	from := common.Address(sendArgs.From)
+	recipient := from
+	if sendArgs.To != nil {
+		recipient = common.Address(*sendArgs.To)
+	}
+
+	tx, err := s.buildSwapTx(quote, from, recipient, sendArgs.SlippagePercentage)
+	if err != nil {
+		return err
+	}
+
+	applySwapTx(sendArgs, tx)
+	return nil
+}
+
+func (s *SwapProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := s.prepareTransaction(sendArgs)
+	if err != nil {
+		return nil, 0, createErrorResponse(s.Name(), err)
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, sendArgs.SwapTx.SendTxArgs, lastUsedNonce)
+}
+
+func (s *SwapProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := s.prepareTransactionV2(sendArgs)
+	if err != nil {
+		return nil, 0, createErrorResponse(s.Name(), err)
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (s *SwapProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	err := s.prepareTransaction(sendArgs)
+	if err != nil {
+		return types.Hash{}, 0, createErrorResponse(s.Name(), err)
+	}
+
+	return s.transactor.SendTransactionWithChainID(sendArgs.ChainID, sendArgs.SwapTx.SendTxArgs, lastUsedNonce, verifiedAccount)
+}
@@ -0,0 +1,100 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty/kyberswap"
+	walletToken "github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/transactions"
+)
+
+// KyberSwapProvider quotes swaps through the KyberSwap aggregator
+type KyberSwapProvider struct {
+	client kyberswap.ClientInterface
+}
+
+func NewKyberSwapProvider() *KyberSwapProvider {
+	return &KyberSwapProvider{
+		client: kyberswap.NewClient(partnerID),
+	}
+}
+
+func NewSwapKyberProcessor(transactor transactions.TransactorIface) *SwapProcessor {
+	return NewSwapProcessor(NewKyberSwapProvider(), transactor)
+}
+
+func (k *KyberSwapProvider) Name() string {
+	return pathProcessorCommon.ProcessorSwapKyberName
+}
+
+func (k *KyberSwapProvider) IsChainSupported(chainID uint64) bool {
+	return k.client.IsChainSupported(chainID)
+}
+
+func kyberTokenAddress(token *walletToken.Token) common.Address {
+	if token.IsNative() {
+		return kyberswap.NativeTokenAddress
+	}
+	return token.Address
+}
+
+func createSwapKyberErrorResponse(err error) error {
+	switch err.Error() {
+	case "route not found":
+		return ErrNotEnoughLiquidity
+	}
+	return createErrorResponse(pathProcessorCommon.ProcessorSwapKyberName, err)
+}
+
+func (k *KyberSwapProvider) FetchQuote(ctx context.Context, params ProcessorInputParams) (*SwapQuote, error) {
+	fromToken := kyberTokenAddress(params.FromToken)
+	toToken := kyberTokenAddress(params.ToToken)
+	if fromToken == walletCommon.ZeroAddress() || toToken == walletCommon.ZeroAddress() {
+		return nil, ErrCannotResolveTokens
+	}
+
+	route, err := k.client.FetchRoute(ctx, params.FromChain.ChainID, fromToken, toToken, params.AmountIn)
+	if err != nil {
+		return nil, createSwapKyberErrorResponse(err)
+	}
+
+	return &SwapQuote{
+		ChainID:      params.FromChain.ChainID,
+		AmountIn:     new(big.Int).Set(params.AmountIn),
+		AmountOut:    route.Summary.AmountOut.Int,
+		GasLimit:     route.Summary.Gas.Uint64(),
+		Spender:      route.RouterAddress,
+		ProviderData: route,
+	}, nil
+}
+
+func (k *KyberSwapProvider) BuildSwapTx(ctx context.Context, quote *SwapQuote, from common.Address, recipient common.Address, slippageBasisPoints uint) (*SwapTx, error) {
+	route, ok := quote.ProviderData.(kyberswap.Route)
+	if !ok {
+		return nil, ErrSwapQuoteNotFound
+	}
+
+	tx, err := k.client.BuildTransaction(ctx, quote.ChainID, route, from, recipient, slippageBasisPoints)
+	if err != nil {
+		return nil, createSwapKyberErrorResponse(err)
+	}
+
+	value := walletCommon.ZeroBigIntValue()
+	if route.Summary.TokenIn == kyberswap.NativeTokenAddress {
+		value = new(big.Int).Set(quote.AmountIn)
+	}
+
+	swapTx := &SwapTx{
+		To:    tx.RouterAddress,
+		Value: value,
+		Data:  tx.Data,
+	}
+	if tx.Gas != nil {
+		swapTx.Gas = tx.Gas.Uint64()
+	}
+	return swapTx, nil
+}
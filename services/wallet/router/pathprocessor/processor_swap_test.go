@@ -0,0 +1,198 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	uniswapv3 "github.com/status-im/status-go/contracts/uniswapV3"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSwapProvider struct {
+	quote       *SwapQuote
+	tx          *SwapTx
+	slippageBP  uint
+	recipient   common.Address
+	quoteCalled int
+}
+
+func (p *testSwapProvider) Name() string {
+	return "TestSwap"
+}
+
+func (p *testSwapProvider) IsChainSupported(chainID uint64) bool {
+	return chainID == walletCommon.EthereumMainnet
+}
+
+func (p *testSwapProvider) FetchQuote(ctx context.Context, params ProcessorInputParams) (*SwapQuote, error) {
+	p.quoteCalled++
+	return p.quote, nil
+}
+
+func (p *testSwapProvider) BuildSwapTx(ctx context.Context, quote *SwapQuote, from common.Address, recipient common.Address, slippageBasisPoints uint) (*SwapTx, error) {
+	p.slippageBP = slippageBasisPoints
+	p.recipient = recipient
+	return p.tx, nil
+}
+
+func TestSwapProcessor(t *testing.T) {
+	provider := &testSwapProvider{
+		quote: &SwapQuote{
+			ChainID:   walletCommon.EthereumMainnet,
+			AmountIn:  big.NewInt(1000),
+			AmountOut: big.NewInt(2000),
+			GasLimit:  150000,
+			Spender:   common.HexToAddress("0xabc"),
+		},
+		tx: &SwapTx{
+			To:    common.HexToAddress("0xabc"),
+			Value: big.NewInt(0),
+			Data:  []byte{0x01, 0x02},
+			Gas:   160000,
+		},
+	}
+	processor := NewSwapProcessor(provider, nil)
+	require.Equal(t, "TestSwap", processor.Name())
+
+	input := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken: &token.Token{Symbol: walletCommon.UsdcSymbol},
+		ToToken:   &token.Token{Symbol: walletCommon.EthSymbol},
+		AmountIn:  big.NewInt(1000),
+	}
+
+	can, err := processor.AvailableFor(input)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	// exact output swaps are not supported
+	buyInput := input
+	buyInput.AmountIn = big.NewInt(0)
+	buyInput.AmountOut = big.NewInt(2000)
+	can, err = processor.AvailableFor(buyInput)
+	require.NoError(t, err)
+	require.False(t, can)
+
+	otherChainInput := input
+	otherChainInput.FromChain = &params.Network{ChainID: walletCommon.OptimismMainnet}
+	otherChainInput.ToChain = otherChainInput.FromChain
+	can, err = processor.AvailableFor(otherChainInput)
+	require.NoError(t, err)
+	require.False(t, can)
+
+	_, err = processor.CalculateAmountOut(input)
+	require.Equal(t, ErrSwapQuoteNotFound, err)
+
+	gas, err := processor.EstimateGas(input)
+	require.NoError(t, err)
+	require.Equal(t, uint64(150000), gas)
+	require.Equal(t, 1, provider.quoteCalled)
+
+	amountOut, err := processor.CalculateAmountOut(input)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2000), amountOut)
+
+	spender, err := processor.GetContractAddress(input)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xabc"), spender)
+
+	recipient := types.HexToAddress("0x123")
+	sendArgs := &wallettypes.SendTxArgs{
+		From:               types.HexToAddress("0x456"),
+		To:                 &recipient,
+		ValueIn:            (*hexutil.Big)(big.NewInt(1000)),
+		FromChainID:        walletCommon.EthereumMainnet,
+		ToChainID:          walletCommon.EthereumMainnet,
+		FromTokenID:        walletCommon.UsdcSymbol,
+		ToTokenID:          walletCommon.EthSymbol,
+		SlippagePercentage: 0.5,
+	}
+	err = processor.prepareTransactionV2(sendArgs)
+	require.NoError(t, err)
+	require.Equal(t, uint(50), provider.slippageBP)
+	require.Equal(t, common.Address(recipient), provider.recipient)
+	require.Equal(t, types.Address(provider.tx.To), *sendArgs.To)
+	require.Equal(t, uint64(160000), uint64(*sendArgs.Gas))
+	require.Equal(t, types.HexBytes{0x01, 0x02}, sendArgs.Data)
+
+	processor.Clear()
+	_, err = processor.CalculateAmountOut(input)
+	require.Equal(t, ErrSwapQuoteNotFound, err)
+}
+
+func TestUniswapQuote(t *testing.T) {
+	quoterABI, err := uniswapv3.IQuoterV2MetaData.GetAbi()
+	require.NoError(t, err)
+
+	tokenIn := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	tokenOut := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	input, err := packUniswapQuote(tokenIn, tokenOut, big.NewInt(1000000), 500)
+	require.NoError(t, err)
+
+	method, err := quoterABI.MethodById(input[:4])
+	require.NoError(t, err)
+	require.Equal(t, "quoteExactInputSingle", method.Name)
+	args, err := method.Inputs.Unpack(input[4:])
+	require.NoError(t, err)
+	quoteParams := args[0].(struct {
+		TokenIn           common.Address `json:"tokenIn"`
+		TokenOut          common.Address `json:"tokenOut"`
+		AmountIn          *big.Int       `json:"amountIn"`
+		Fee               *big.Int       `json:"fee"`
+		SqrtPriceLimitX96 *big.Int       `json:"sqrtPriceLimitX96"`
+	})
+	require.Equal(t, tokenIn, quoteParams.TokenIn)
+	require.Equal(t, tokenOut, quoteParams.TokenOut)
+	require.Equal(t, big.NewInt(1000000), quoteParams.AmountIn)
+	require.Equal(t, big.NewInt(500), quoteParams.Fee)
+
+	output, err := quoterABI.Methods["quoteExactInputSingle"].Outputs.Pack(big.NewInt(997000), big.NewInt(1), uint32(2), big.NewInt(95000))
+	require.NoError(t, err)
+	amountOut, gasEstimate, err := unpackUniswapQuote(output)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(997000), amountOut)
+	require.Equal(t, uint64(95000), gasEstimate)
+
+	_, _, err = unpackUniswapQuote([]byte{0x01})
+	require.Error(t, err)
+}
+
+func TestPackUniswapSwap(t *testing.T) {
+	routerABI, err := uniswapv3.ISwapRouter02MetaData.GetAbi()
+	require.NoError(t, err)
+
+	recipient := common.HexToAddress("0x123")
+	data := uniswapQuoteData{
+		tokenIn:  common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		tokenOut: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		fee:      500,
+	}
+
+	input, err := packUniswapSwap(data, big.NewInt(1000), big.NewInt(2000), recipient, 50)
+	require.NoError(t, err)
+	require.Equal(t, routerABI.Methods["exactInputSingle"].ID, input[:4])
+
+	args, err := routerABI.Methods["exactInputSingle"].Inputs.Unpack(input[4:])
+	require.NoError(t, err)
+	swapParams := abi.ConvertType(args[0], new(uniswapv3.IV3SwapRouterExactInputSingleParams)).(*uniswapv3.IV3SwapRouterExactInputSingleParams)
+	require.Equal(t, recipient, swapParams.Recipient)
+	require.Equal(t, big.NewInt(1990), swapParams.AmountOutMinimum)
+
+	// the output is unwrapped when swapping to the native token
+	data.nativeOut = true
+	input, err = packUniswapSwap(data, big.NewInt(1000), big.NewInt(2000), recipient, 50)
+	require.NoError(t, err)
+	require.Equal(t, routerABI.Methods["multicall"].ID, input[:4])
+}
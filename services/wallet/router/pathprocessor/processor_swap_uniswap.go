@@ -0,0 +1,279 @@
+package pathprocessor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	uniswapv3 "github.com/status-im/status-go/contracts/uniswapV3"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	walletToken "github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// uniswapRouterGasOverhead is added to the pool swap gas measured by the quoter when the swap can't be simulated,
+	// which happens before the approval of the from token is placed. It covers the intrinsic tx gas, the router and the token transfers
+	uniswapRouterGasOverhead = 80000
+)
+
+var (
+	uniswapFeeTiers = []uint32{100, 500, 3000, 10000}
+	// uniswapRouterAddressThis makes the router keep the output, used to unwrap it before sending it to the recipient
+	uniswapRouterAddressThis = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+type uniswapQuoteData struct {
+	tokenIn   common.Address
+	tokenOut  common.Address
+	fee       uint32
+	nativeIn  bool
+	nativeOut bool
+}
+
+// UniswapV3Provider quotes swaps with the Uniswap V3 QuoterV2 and executes them through SwapRouter02.
+// Only single pool swaps are supported
+type UniswapV3Provider struct {
+	rpcClient *rpc.Client
+}
+
+func NewUniswapV3Provider(rpcClient *rpc.Client) *UniswapV3Provider {
+	return &UniswapV3Provider{
+		rpcClient: rpcClient,
+	}
+}
+
+func NewSwapUniswapProcessor(rpcClient *rpc.Client, transactor transactions.TransactorIface) *SwapProcessor {
+	return NewSwapProcessor(NewUniswapV3Provider(rpcClient), transactor)
+}
+
+func (u *UniswapV3Provider) Name() string {
+	return pathProcessorCommon.ProcessorSwapUniswapName
+}
+
+func (u *UniswapV3Provider) IsChainSupported(chainID uint64) bool {
+	_, err := uniswapv3.ContractDeployment(chainID)
+	return err == nil
+}
+
+func uniswapTokenAddress(token *walletToken.Token, deployment uniswapv3.Deployment) common.Address {
+	if token.IsNative() {
+		return deployment.WrappedNative
+	}
+	return token.Address
+}
+
+func (u *UniswapV3Provider) FetchQuote(ctx context.Context, params ProcessorInputParams) (*SwapQuote, error) {
+	chainID := params.FromChain.ChainID
+	deployment, err := uniswapv3.ContractDeployment(chainID)
+	if err != nil {
+		return nil, ErrFromChainNotSupported
+	}
+
+	tokenIn := uniswapTokenAddress(params.FromToken, deployment)
+	tokenOut := uniswapTokenAddress(params.ToToken, deployment)
+	if tokenIn == walletCommon.ZeroAddress() || tokenOut == walletCommon.ZeroAddress() {
+		return nil, ErrCannotResolveTokens
+	}
+	if tokenIn == tokenOut {
+		// wrapping is not a swap
+		return nil, ErrFromAndToTokensMustBeDifferent
+	}
+
+	ethClient, err := u.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, createErrorResponse(u.Name(), err)
+	}
+
+	var (
+		best            *SwapQuote
+		bestGasEstimate uint64
+	)
+	for _, fee := range uniswapFeeTiers {
+		input, err := packUniswapQuote(tokenIn, tokenOut, params.AmountIn, fee)
+		if err != nil {
+			return nil, createErrorResponse(u.Name(), err)
+		}
+
+		output, err := ethClient.CallContract(ctx, ethereum.CallMsg{To: &deployment.QuoterV2, Data: input}, nil)
+		if err != nil {
+			// the quoter reverts when the pool is not deployed for this fee tier or lacks liquidity
+			continue
+		}
+		amountOut, gasEstimate, err := unpackUniswapQuote(output)
+		if err != nil {
+			return nil, createErrorResponse(u.Name(), err)
+		}
+		if amountOut.Sign() <= 0 || (best != nil && amountOut.Cmp(best.AmountOut) <= 0) {
+			continue
+		}
+
+		best = &SwapQuote{
+			ChainID:   chainID,
+			AmountIn:  new(big.Int).Set(params.AmountIn),
+			AmountOut: amountOut,
+			Spender:   deployment.SwapRouter02,
+			ProviderData: uniswapQuoteData{
+				tokenIn:   tokenIn,
+				tokenOut:  tokenOut,
+				fee:       fee,
+				nativeIn:  params.FromToken.IsNative(),
+				nativeOut: params.ToToken.IsNative(),
+			},
+		}
+		bestGasEstimate = gasEstimate
+	}
+
+	if best == nil {
+		return nil, ErrNotEnoughLiquidity
+	}
+
+	best.GasLimit, err = u.estimateSwapGas(ctx, ethClient, best, params, bestGasEstimate)
+	if err != nil {
+		return nil, createErrorResponse(u.Name(), err)
+	}
+	return best, nil
+}
+
+// estimateSwapGas simulates the swap of the quote, the pool gas measured by the quoter is used
+// when the simulation fails because the approval of the from token is not placed yet
+func (u *UniswapV3Provider) estimateSwapGas(ctx context.Context, ethClient uniswapGasEstimator, quote *SwapQuote, params ProcessorInputParams, poolGasEstimate uint64) (uint64, error) {
+	data := quote.ProviderData.(uniswapQuoteData)
+
+	recipient := params.ToAddr
+	if recipient == walletCommon.ZeroAddress() {
+		recipient = params.FromAddr
+	}
+	// the price can move until the estimation, so no minimum output is required
+	input, err := packUniswapSwap(data, quote.AmountIn, quote.AmountOut, recipient, 10000)
+	if err != nil {
+		return 0, err
+	}
+
+	value := walletCommon.ZeroBigIntValue()
+	if data.nativeIn {
+		value = quote.AmountIn
+	}
+
+	estimation, err := ethClient.EstimateGas(ctx, ethereum.CallMsg{
+		From:  params.FromAddr,
+		To:    &quote.Spender,
+		Value: value,
+		Data:  input,
+	})
+	if err != nil {
+		if data.nativeIn || poolGasEstimate == 0 {
+			return 0, err
+		}
+		estimation = poolGasEstimate + uniswapRouterGasOverhead
+	}
+
+	increasedEstimation := float64(estimation) * pathProcessorCommon.IncreaseEstimatedGasFactor
+	return uint64(increasedEstimation), nil
+}
+
+type uniswapGasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+func packUniswapQuote(tokenIn common.Address, tokenOut common.Address, amountIn *big.Int, fee uint32) ([]byte, error) {
+	quoterABI, err := uniswapv3.IQuoterV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return quoterABI.Pack("quoteExactInputSingle", uniswapv3.IQuoterV2QuoteExactInputSingleParams{
+		TokenIn:           tokenIn,
+		TokenOut:          tokenOut,
+		AmountIn:          amountIn,
+		Fee:               new(big.Int).SetUint64(uint64(fee)),
+		SqrtPriceLimitX96: walletCommon.ZeroBigIntValue(),
+	})
+}
+
+// unpackUniswapQuote returns the amount out and the gas used by the pool swap from the output of quoteExactInputSingle
+func unpackUniswapQuote(output []byte) (*big.Int, uint64, error) {
+	quoterABI, err := uniswapv3.IQuoterV2MetaData.GetAbi()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values, err := quoterABI.Unpack("quoteExactInputSingle", output)
+	if err != nil {
+		return nil, 0, err
+	}
+	amountOut, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, 0, errors.New("unexpected quote amount type")
+	}
+	gasEstimate, ok := values[3].(*big.Int)
+	if !ok || !gasEstimate.IsUint64() {
+		return nil, 0, errors.New("unexpected quote gas estimate")
+	}
+	return amountOut, gasEstimate.Uint64(), nil
+}
+
+func (u *UniswapV3Provider) BuildSwapTx(ctx context.Context, quote *SwapQuote, from common.Address, recipient common.Address, slippageBasisPoints uint) (*SwapTx, error) {
+	data, ok := quote.ProviderData.(uniswapQuoteData)
+	if !ok {
+		return nil, ErrSwapQuoteNotFound
+	}
+
+	input, err := packUniswapSwap(data, quote.AmountIn, quote.AmountOut, recipient, slippageBasisPoints)
+	if err != nil {
+		return nil, createErrorResponse(u.Name(), err)
+	}
+
+	value := walletCommon.ZeroBigIntValue()
+	if data.nativeIn {
+		// the router wraps the sent value
+		value = new(big.Int).Set(quote.AmountIn)
+	}
+
+	return &SwapTx{
+		To:    quote.Spender,
+		Value: value,
+		Data:  input,
+		Gas:   quote.GasLimit,
+	}, nil
+}
+
+func packUniswapSwap(data uniswapQuoteData, amountIn *big.Int, amountOut *big.Int, recipient common.Address, slippageBasisPoints uint) ([]byte, error) {
+	routerABI, err := uniswapv3.ISwapRouter02MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	amountOutMinimum := new(big.Int).Mul(amountOut, big.NewInt(int64(10000-min(slippageBasisPoints, 10000))))
+	amountOutMinimum.Div(amountOutMinimum, big.NewInt(10000))
+
+	swapParams := uniswapv3.IV3SwapRouterExactInputSingleParams{
+		TokenIn:           data.tokenIn,
+		TokenOut:          data.tokenOut,
+		Fee:               new(big.Int).SetUint64(uint64(data.fee)),
+		Recipient:         recipient,
+		AmountIn:          amountIn,
+		AmountOutMinimum:  amountOutMinimum,
+		SqrtPriceLimitX96: walletCommon.ZeroBigIntValue(),
+	}
+	if !data.nativeOut {
+		return routerABI.Pack("exactInputSingle", swapParams)
+	}
+
+	// keep the wrapped output in the router and unwrap it to the recipient
+	swapParams.Recipient = uniswapRouterAddressThis
+	swapInput, err := routerABI.Pack("exactInputSingle", swapParams)
+	if err != nil {
+		return nil, err
+	}
+	unwrapInput, err := routerABI.Pack("unwrapWETH9", amountOutMinimum, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return routerABI.Pack("multicall", [][]byte{swapInput, unwrapInput})
+}
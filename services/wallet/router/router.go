@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/logutils/tracing"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/services/wallet/async"
@@ -226,6 +227,11 @@ func (r *Router) StopSuggestedRoutesCalculation() {
 }
 
 func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInputParams) (suggestedRoutes *SuggestedRoutes, err error) {
+	// The calls of the route share its trace
+	ctx, span := tracing.Start(tracing.WithTraceKey(ctx, input.Uuid), "router.SuggestedRoutes",
+		tracing.String("uuid", input.Uuid), tracing.Int64("sendType", int64(input.SendType)))
+	defer func() { span.End(err) }()
+
 	r.clearActiveRoute()
 	r.abortUpdates()
 	r.markRouteCanceled(false)
@@ -644,7 +650,9 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 		if testsMode {
 			fetchedFees = input.TestParams.SuggestedFees
 		} else {
-			fetchedFees, err = r.feesManager.SuggestedFees(ctx, network.ChainID)
+			feesCtx, feesSpan := tracing.Start(ctx, "router.SuggestedFees", tracing.Uint64("chainID", network.ChainID))
+			fetchedFees, err = r.feesManager.SuggestedFees(feesCtx, network.ChainID)
+			feesSpan.End(err)
 			if err != nil {
 				continue
 			}
@@ -893,6 +901,7 @@ func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputPa
 
 	tokenPrice := prices[input.TokenID]
 	nativeTokenPrice := prices[walletCommon.EthSymbol]
+	toTokenPrice := prices[input.ToTokenID]
 
 	var allRoutes []routes.Route
 	suggestedRoutes, allRoutes = newSuggestedRoutes(input, candidates, prices)
@@ -914,7 +923,7 @@ func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputPa
 	)
 
 	for len(allRoutes) > 0 {
-		bestRoute = routes.FindBestRoute(allRoutes, tokenPrice, nativeTokenPrice)
+		bestRoute = routes.FindBestRoute(allRoutes, tokenPrice, nativeTokenPrice, toTokenPrice)
 		var hasPositiveBalance bool
 		hasPositiveBalance, err = r.checkBalancesForTheBestRoute(ctx, bestRoute)
 
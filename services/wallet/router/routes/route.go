@@ -30,7 +30,9 @@ func (r Route) GetFirstPathChains() (uint64, uint64) {
 	return r[0].FromChain.ChainID, r[0].ToChain.ChainID
 }
 
-func FindBestRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64) Route {
+// FindBestRoute returns the cheapest route, for swaps the value of the received amount is deducted from the cost
+// so that the best output net of fees wins
+func FindBestRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64, toTokenPrice float64) Route {
 	var best Route
 	bestCost := big.NewFloat(math.Inf(1))
 	for _, route := range routes {
@@ -74,6 +76,13 @@ func FindBestRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64)
 				}
 			}
 
+			if common.IsProcessorSwap(path.ProcessorName) && path.ToToken != nil && path.AmountOut != nil {
+				toTokenDenominator := big.NewFloat(math.Pow(10, float64(path.ToToken.Decimals)))
+				pathCost.Sub(pathCost, new(big.Float).Mul(
+					new(big.Float).Quo(new(big.Float).SetInt(path.AmountOut.ToInt()), toTokenDenominator),
+					new(big.Float).SetFloat64(toTokenPrice)))
+			}
+
 			currentCost = new(big.Float).Add(currentCost, pathCost)
 		}
 
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/params"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+func newSwapPath(processorName string, txFeeWei int64, amountOut int64) *Path {
+	return &Path{
+		ProcessorName: processorName,
+		FromChain:     &params.Network{ChainID: 1},
+		ToChain:       &params.Network{ChainID: 1},
+		FromToken:     &token.Token{Symbol: "ETH", Decimals: 18},
+		ToToken:       &token.Token{Symbol: "USDC", Decimals: 6},
+		AmountIn:      (*hexutil.Big)(big.NewInt(1000000000000000000)),
+		AmountOut:     (*hexutil.Big)(big.NewInt(amountOut)),
+		TxFee:         (*hexutil.Big)(big.NewInt(txFeeWei)),
+		TxL1Fee:       (*hexutil.Big)(big.NewInt(0)),
+	}
+}
+
+func TestFindBestSwapRouteNetOfFees(t *testing.T) {
+	const (
+		ethPrice  = 3000.0
+		usdcPrice = 1.0
+	)
+
+	// 1 USD more output, but 2 USD more fees
+	paraswap := Route{newSwapPath(pathProcessorCommon.ProcessorSwapParaswapName, 1000000000000000, 3000000000)}
+	kyber := Route{newSwapPath(pathProcessorCommon.ProcessorSwapKyberName, 1666666666666667, 3001000000)}
+	// 5 USD more output for the same fees
+	uniswap := Route{newSwapPath(pathProcessorCommon.ProcessorSwapUniswapName, 1000000000000000, 3005000000)}
+
+	best := FindBestRoute([]Route{paraswap, kyber}, ethPrice, ethPrice, usdcPrice)
+	assert.Equal(t, paraswap, best)
+
+	best = FindBestRoute([]Route{paraswap, kyber, uniswap}, ethPrice, ethPrice, usdcPrice)
+	assert.Equal(t, uniswap, best)
+}
@@ -0,0 +1,106 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// safeABI is the subset of the GnosisSafe interface used by the wallet, stable since Safe 1.0.0
+const safeABI = `[
+	{"name":"VERSION","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"name":"getOwners","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"name":"getThreshold","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"nonce","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"execTransaction","type":"function","stateMutability":"payable","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},
+		{"name":"safeTxGas","type":"uint256"},
+		{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},
+		{"name":"gasToken","type":"address"},
+		{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}
+	],"outputs":[{"name":"success","type":"bool"}]}
+	]`
+
+var parsedSafeABI abi.ABI
+
+func init() {
+	var err error
+	parsedSafeABI, err = abi.JSON(strings.NewReader(safeABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+type info struct {
+	version   string
+	owners    []common.Address
+	threshold uint64
+	nonce     uint64
+}
+
+// fetchInfo reads the Safe configuration from the contract
+func fetchInfo(ctx context.Context, backend bind.ContractCaller, address common.Address) (*info, error) {
+	code, err := backend.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, ErrNotAContract
+	}
+
+	contract := bind.NewBoundContract(address, parsedSafeABI, backend, nil, nil)
+	opts := &bind.CallOpts{Context: ctx}
+
+	var out []interface{}
+	if err := contract.Call(opts, &out, "VERSION"); err != nil {
+		return nil, ErrNotAContract
+	}
+	res := &info{version: out[0].(string)}
+	if _, _, err := parseVersion(res.version); err != nil {
+		return nil, err
+	}
+
+	out = nil
+	if err := contract.Call(opts, &out, "getOwners"); err != nil {
+		return nil, err
+	}
+	res.owners = out[0].([]common.Address)
+
+	out = nil
+	if err := contract.Call(opts, &out, "getThreshold"); err != nil {
+		return nil, err
+	}
+	res.threshold = out[0].(*big.Int).Uint64()
+
+	out = nil
+	if err := contract.Call(opts, &out, "nonce"); err != nil {
+		return nil, err
+	}
+	res.nonce = out[0].(*big.Int).Uint64()
+
+	return res, nil
+}
+
+func packExecTransaction(tx *SafeTx, signatures []byte) ([]byte, error) {
+	return parsedSafeABI.Pack("execTransaction",
+		tx.To,
+		tx.Value.ToInt(),
+		[]byte(tx.Data),
+		uint8(tx.Operation),
+		tx.SafeTxGas.ToInt(),
+		tx.BaseGas.ToInt(),
+		tx.GasPrice.ToInt(),
+		tx.GasToken,
+		tx.RefundReceiver,
+		signatures,
+	)
+}
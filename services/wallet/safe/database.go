@@ -0,0 +1,237 @@
+package safe
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDatabase(sqlDb *sql.DB) *Database {
+	return &Database{
+		db: sqlDb,
+	}
+}
+
+// SaveAccount inserts or updates a Safe
+func (d *Database) SaveAccount(account *Account) error {
+	owners, err := json.Marshal(account.Owners)
+	if err != nil {
+		return err
+	}
+	_, err = d.db.Exec(`INSERT OR REPLACE INTO safe_accounts
+		(chain_id, address, name, version, owners, threshold, nonce, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		account.ChainID, account.Address, account.Name, account.Version, string(owners), account.Threshold, account.Nonce, account.UpdatedAt)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAccount(row rowScanner) (*Account, error) {
+	account := &Account{}
+	var owners string
+	err := row.Scan(&account.ChainID, &account.Address, &account.Name, &account.Version, &owners, &account.Threshold, &account.Nonce, &account.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(owners), &account.Owners); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (d *Database) GetAccount(chainID uint64, address common.Address) (*Account, error) {
+	account, err := scanAccount(d.db.QueryRow(`SELECT chain_id, address, name, version, owners, threshold, nonce, updated_at
+		FROM safe_accounts WHERE chain_id = ? AND address = ?`, chainID, address))
+	if err == sql.ErrNoRows {
+		return nil, ErrSafeNotFound
+	}
+	return account, err
+}
+
+func (d *Database) GetAccounts() ([]*Account, error) {
+	rows, err := d.db.Query(`SELECT chain_id, address, name, version, owners, threshold, nonce, updated_at
+		FROM safe_accounts ORDER BY chain_id, address`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make([]*Account, 0)
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+// DeleteAccount removes the Safe and its proposals
+func (d *Database) DeleteAccount(chainID uint64, address common.Address) (err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM safe_proposal_signatures WHERE safe_tx_hash IN
+		(SELECT safe_tx_hash FROM safe_proposals WHERE chain_id = ? AND safe_address = ?)`, chainID, address)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM safe_proposals WHERE chain_id = ? AND safe_address = ?`, chainID, address)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM safe_accounts WHERE chain_id = ? AND address = ?`, chainID, address)
+	return err
+}
+
+func (d *Database) InsertProposal(proposal *Proposal) error {
+	safeTx, err := json.Marshal(proposal.Tx)
+	if err != nil {
+		return err
+	}
+	_, err = d.db.Exec(`INSERT INTO safe_proposals
+		(safe_tx_hash, chain_id, safe_address, nonce, safe_tx, status, multi_transaction_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		proposal.SafeTxHash, proposal.ChainID, proposal.Safe, proposal.Tx.Nonce, safeTx, proposal.Status, proposal.MultiTransactionID, proposal.CreatedAt)
+	return err
+}
+
+const selectProposalsColumns = `SELECT safe_tx_hash, chain_id, safe_address, safe_tx, status, multi_transaction_id, execution_tx_hash, created_at
+	FROM safe_proposals`
+
+func (d *Database) queryProposals(query string, args ...interface{}) ([]*Proposal, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	proposals := make([]*Proposal, 0)
+	for rows.Next() {
+		proposal := &Proposal{}
+		var safeTx []byte
+		var executionTxHash []byte
+		var multiTransactionID int64
+		err := rows.Scan(&proposal.SafeTxHash, &proposal.ChainID, &proposal.Safe, &safeTx, &proposal.Status,
+			&multiTransactionID, &executionTxHash, &proposal.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(safeTx, &proposal.Tx); err != nil {
+			return nil, err
+		}
+		proposal.MultiTransactionID = wallet_common.MultiTransactionIDType(multiTransactionID)
+		if len(executionTxHash) > 0 {
+			hash := common.BytesToHash(executionTxHash)
+			proposal.ExecutionTxHash = &hash
+		}
+		proposals = append(proposals, proposal)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, proposal := range proposals {
+		proposal.Signatures, err = d.getSignatures(proposal.SafeTxHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
+
+func (d *Database) GetProposal(safeTxHash common.Hash) (*Proposal, error) {
+	proposals, err := d.queryProposals(selectProposalsColumns+` WHERE safe_tx_hash = ?`, safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(proposals) == 0 {
+		return nil, ErrProposalNotFound
+	}
+	return proposals[0], nil
+}
+
+// GetProposals returns the proposals of a Safe by nonce, most recent first
+func (d *Database) GetProposals(chainID uint64, safe common.Address) ([]*Proposal, error) {
+	return d.queryProposals(selectProposalsColumns+` WHERE chain_id = ? AND safe_address = ? ORDER BY nonce DESC, created_at DESC`,
+		chainID, safe)
+}
+
+func (d *Database) GetPendingProposals(chainID uint64, safe common.Address) ([]*Proposal, error) {
+	return d.queryProposals(selectProposalsColumns+` WHERE chain_id = ? AND safe_address = ? AND status = ? ORDER BY nonce`,
+		chainID, safe, ProposalStatusPending)
+}
+
+func (d *Database) UpdateProposalStatus(safeTxHash common.Hash, status ProposalStatus) error {
+	res, err := d.db.Exec(`UPDATE safe_proposals SET status = ? WHERE safe_tx_hash = ?`, status, safeTxHash)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProposalNotFound
+	}
+	return nil
+}
+
+func (d *Database) SetProposalExecuted(safeTxHash common.Hash, executionTxHash common.Hash) error {
+	_, err := d.db.Exec(`UPDATE safe_proposals SET status = ?, execution_tx_hash = ? WHERE safe_tx_hash = ?`,
+		ProposalStatusExecuted, executionTxHash, safeTxHash)
+	return err
+}
+
+// CountActiveProposals returns how many pending or executed proposals share the multi transaction
+func (d *Database) CountActiveProposals(multiTransactionID wallet_common.MultiTransactionIDType) (int, error) {
+	var count int
+	err := d.db.QueryRow(`SELECT COUNT(*) FROM safe_proposals WHERE multi_transaction_id = ? AND status IN (?, ?)`,
+		multiTransactionID, ProposalStatusPending, ProposalStatusExecuted).Scan(&count)
+	return count, err
+}
+
+// AddSignature stores an owner's signature, replacing a previous one of the same owner
+func (d *Database) AddSignature(safeTxHash common.Hash, signature Signature) error {
+	_, err := d.db.Exec(`INSERT OR REPLACE INTO safe_proposal_signatures (safe_tx_hash, owner, signature) VALUES (?, ?, ?)`,
+		safeTxHash, signature.Owner, []byte(signature.Signature))
+	return err
+}
+
+func (d *Database) getSignatures(safeTxHash common.Hash) ([]Signature, error) {
+	rows, err := d.db.Query(`SELECT owner, signature FROM safe_proposal_signatures WHERE safe_tx_hash = ? ORDER BY owner`, safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	signatures := make([]Signature, 0)
+	for rows.Next() {
+		var s Signature
+		var sig []byte
+		if err := rows.Scan(&s.Owner, &sig); err != nil {
+			return nil, err
+		}
+		s.Signature = sig
+		signatures = append(signatures, s)
+	}
+	return signatures, rows.Err()
+}
@@ -0,0 +1,123 @@
+package safe
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupDatabaseTest(t *testing.T) (*Database, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewDatabase(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestAccounts(t *testing.T) {
+	db, cleanup := setupDatabaseTest(t)
+	defer cleanup()
+
+	safe := common.HexToAddress("0x5afe")
+	_, err := db.GetAccount(10, safe)
+	require.ErrorIs(t, err, ErrSafeNotFound)
+
+	account := &Account{
+		ChainID:   10,
+		Address:   safe,
+		Name:      "Treasury",
+		Version:   "1.3.0",
+		Owners:    []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")},
+		Threshold: 2,
+		Nonce:     5,
+		UpdatedAt: 100,
+	}
+	require.NoError(t, db.SaveAccount(account))
+
+	stored, err := db.GetAccount(10, safe)
+	require.NoError(t, err)
+	require.Equal(t, account, stored)
+
+	account.Nonce = 6
+	require.NoError(t, db.SaveAccount(account))
+	accounts, err := db.GetAccounts()
+	require.NoError(t, err)
+	require.Equal(t, []*Account{account}, accounts)
+
+	require.NoError(t, db.DeleteAccount(10, safe))
+	accounts, err = db.GetAccounts()
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
+func TestProposals(t *testing.T) {
+	db, cleanup := setupDatabaseTest(t)
+	defer cleanup()
+
+	safe := common.HexToAddress("0x5afe")
+	owner := common.HexToAddress("0x1")
+	mtID := wallet_common.MultiTransactionIDType(7)
+
+	first := &Proposal{
+		SafeTxHash:         common.HexToHash("0xaa"),
+		ChainID:            10,
+		Safe:               safe,
+		Tx:                 *testSafeTx(),
+		Status:             ProposalStatusPending,
+		MultiTransactionID: mtID,
+		Signatures:         []Signature{},
+		CreatedAt:          100,
+	}
+	second := *first
+	second.SafeTxHash = common.HexToHash("0xbb")
+	second.Tx.Nonce = first.Tx.Nonce + 1
+	second.Signatures = []Signature{}
+	require.NoError(t, db.InsertProposal(first))
+	require.NoError(t, db.InsertProposal(&second))
+
+	_, err := db.GetProposal(common.HexToHash("0xcc"))
+	require.ErrorIs(t, err, ErrProposalNotFound)
+
+	sig := Signature{Owner: owner, Signature: make([]byte, signatureLength)}
+	require.NoError(t, db.AddSignature(first.SafeTxHash, sig))
+	// A new signature of the same owner replaces the previous one
+	sig.Signature[64] = 27
+	require.NoError(t, db.AddSignature(first.SafeTxHash, sig))
+
+	stored, err := db.GetProposal(first.SafeTxHash)
+	require.NoError(t, err)
+	first.Signatures = []Signature{sig}
+	require.Equal(t, first, stored)
+
+	proposals, err := db.GetProposals(10, safe)
+	require.NoError(t, err)
+	require.Equal(t, []*Proposal{&second, first}, proposals)
+
+	count, err := db.CountActiveProposals(mtID)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	executionTxHash := common.HexToHash("0xee")
+	require.NoError(t, db.SetProposalExecuted(first.SafeTxHash, executionTxHash))
+	require.NoError(t, db.UpdateProposalStatus(second.SafeTxHash, ProposalStatusRejected))
+	require.ErrorIs(t, db.UpdateProposalStatus(common.HexToHash("0xcc"), ProposalStatusRejected), ErrProposalNotFound)
+
+	pending, err := db.GetPendingProposals(10, safe)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	stored, err = db.GetProposal(first.SafeTxHash)
+	require.NoError(t, err)
+	require.Equal(t, ProposalStatusExecuted, stored.Status)
+	require.Equal(t, &executionTxHash, stored.ExecutionTxHash)
+
+	count, err = db.CountActiveProposals(mtID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
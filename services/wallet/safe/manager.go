@@ -0,0 +1,479 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/rpc"
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+const (
+	// EventSafeProposalsUpdated is sent with the Safe address and chain whenever its proposals change
+	EventSafeProposalsUpdated walletevent.EventType = "wallet-safe-proposals-updated"
+)
+
+// Transactor builds and sends the execTransaction calls, see transactions.Transactor
+type Transactor interface {
+	ValidateAndBuildTransaction(chainID uint64, sendArgs wallettypes.SendTxArgs, lastUsedNonce int64) (*gethtypes.Transaction, uint64, error)
+	SendTransactionWithSignature(from common.Address, symbol string, multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) (types.Hash, error)
+}
+
+// Manager follows Safe multisig accounts: it turns router paths into Safe transactions, collects the owners'
+// signatures and executes the transactions once the threshold is reached
+type Manager struct {
+	db         *Database
+	mtDB       *transfer.MultiTransactionDB
+	accountsDB *accounts.Database
+	rpcClient  rpc.ClientInterface
+	transactor Transactor
+	feed       *event.Feed
+}
+
+func NewManager(db *Database, mtDB *transfer.MultiTransactionDB, accountsDB *accounts.Database, rpcClient rpc.ClientInterface,
+	transactor Transactor, feed *event.Feed) *Manager {
+	return &Manager{
+		db:         db,
+		mtDB:       mtDB,
+		accountsDB: accountsDB,
+		rpcClient:  rpcClient,
+		transactor: transactor,
+		feed:       feed,
+	}
+}
+
+func (m *Manager) fetchAccount(ctx context.Context, chainID uint64, address common.Address) (*Account, error) {
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := fetchInfo(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		ChainID:   chainID,
+		Address:   address,
+		Version:   info.version,
+		Owners:    info.owners,
+		Threshold: info.threshold,
+		Nonce:     info.nonce,
+		UpdatedAt: time.Now().Unix(),
+	}, nil
+}
+
+// fillLocalOwners sets the owners whose keys are in the wallet, watched addresses can't sign
+func (m *Manager) fillLocalOwners(account *Account) error {
+	account.LocalOwners = make([]common.Address, 0)
+	if m.accountsDB == nil {
+		return nil
+	}
+
+	walletAccounts, err := m.accountsDB.GetActiveAccounts()
+	if err != nil {
+		return err
+	}
+	for _, walletAccount := range walletAccounts {
+		if walletAccount.Type == accounts.AccountTypeWatch {
+			continue
+		}
+		if address := common.Address(walletAccount.Address); account.IsOwner(address) {
+			account.LocalOwners = append(account.LocalOwners, address)
+		}
+	}
+	return nil
+}
+
+// AddAccount starts following the Safe deployed at address
+func (m *Manager) AddAccount(ctx context.Context, chainID uint64, address common.Address, name string) (*Account, error) {
+	account, err := m.fetchAccount(ctx, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	account.Name = name
+
+	if err = m.db.SaveAccount(account); err != nil {
+		return nil, err
+	}
+	return account, m.fillLocalOwners(account)
+}
+
+func (m *Manager) GetAccounts() ([]*Account, error) {
+	res, err := m.db.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range res {
+		if err = m.fillLocalOwners(account); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (m *Manager) RemoveAccount(chainID uint64, address common.Address) error {
+	proposals, err := m.db.GetProposals(chainID, address)
+	if err != nil {
+		return err
+	}
+	if err = m.db.DeleteAccount(chainID, address); err != nil {
+		return err
+	}
+
+	// Pending proposals would otherwise stay pending in activity forever
+	for _, proposal := range proposals {
+		if proposal.Status == ProposalStatusPending && proposal.MultiTransactionID != wallet_common.NoMultiTransactionID {
+			m.deleteMultiTransaction(proposal.MultiTransactionID)
+		}
+	}
+	return nil
+}
+
+// RefreshAccount updates the owners, threshold and nonce of the Safe. Pending proposals whose nonce
+// was used by a transaction made outside the wallet become outdated
+func (m *Manager) RefreshAccount(ctx context.Context, chainID uint64, address common.Address) (*Account, error) {
+	stored, err := m.db.GetAccount(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := m.fetchAccount(ctx, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	account.Name = stored.Name
+
+	if err = m.db.SaveAccount(account); err != nil {
+		return nil, err
+	}
+
+	pending, err := m.db.GetPendingProposals(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, proposal := range pending {
+		if proposal.Tx.Nonce >= account.Nonce {
+			continue
+		}
+		if err = m.setProposalInactive(proposal, ProposalStatusOutdated); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if changed {
+		m.sendProposalsUpdated(chainID, address)
+	}
+
+	return account, m.fillLocalOwners(account)
+}
+
+// nextNonce returns the nonce following the on-chain nonce and the pending proposals
+func (m *Manager) nextNonce(account *Account) (uint64, error) {
+	pending, err := m.db.GetPendingProposals(account.ChainID, account.Address)
+	if err != nil {
+		return 0, err
+	}
+
+	nonce := account.Nonce
+	for _, proposal := range pending {
+		if proposal.Tx.Nonce >= nonce {
+			nonce = proposal.Tx.Nonce + 1
+		}
+	}
+	return nonce, nil
+}
+
+// ProposeRoute turns the paths of a route computed for the Safe (AddrFrom) into Safe transactions with consecutive nonces.
+// All the proposals of the route share a multi transaction, so that they show as a pending entry in activity
+func (m *Manager) ProposeRoute(ctx context.Context, route routes.Route, pathProcessors map[string]pathprocessor.PathProcessor,
+	params requests.RouteInputParams) ([]*Proposal, error) {
+	if len(route) == 0 {
+		return nil, ErrEmptyRoute
+	}
+
+	chainID := route[0].FromChain.ChainID
+	for _, path := range route {
+		if path.FromChain.ChainID != chainID {
+			return nil, ErrMultiChainRoute
+		}
+	}
+
+	account, err := m.RefreshAccount(ctx, chainID, params.AddrFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := m.nextNonce(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var mtType transfer.MultiTransactionType = transfer.MultiTransactionSend
+	if params.SendType == sendtype.Bridge {
+		mtType = transfer.MultiTransactionBridge
+	} else if params.SendType == sendtype.Swap {
+		mtType = transfer.MultiTransactionSwap
+	}
+	fromChainID, toChainID := route.GetFirstPathChains()
+	multiTx := transfer.NewMultiTransaction(
+		/* Timestamp:     */ uint64(time.Now().Unix()),
+		/* FromNetworkID: */ fromChainID,
+		/* ToNetworkID:	  */ toChainID,
+		/* FromTxHash:    */ common.Hash{},
+		/* ToTxHash:      */ common.Hash{},
+		/* FromAddress:   */ params.AddrFrom,
+		/* ToAddress:     */ params.AddrTo,
+		/* FromAsset:     */ params.TokenID,
+		/* ToAsset:       */ params.ToTokenID,
+		/* FromAmount:    */ params.AmountIn,
+		/* ToAmount:      */ params.AmountOut,
+		/* Type:		  */ mtType,
+		/* CrossTxID:	  */ "",
+	)
+
+	proposals := make([]*Proposal, 0, len(route))
+	for _, path := range route {
+		processor, ok := pathProcessors[path.ProcessorName]
+		if !ok {
+			return nil, ErrUnknownPathProcessor
+		}
+
+		inputParams := pathprocessor.ProcessorInputParams{
+			Username:  params.Username,
+			PublicKey: params.PublicKey,
+			PackID:    params.PackID.ToInt(),
+		}
+		tx, err := safeTxFromPath(account.Address, params.AddrTo, path, processor, inputParams, nonce)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := tx.Hash(account.ChainID, account.Address, account.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		proposals = append(proposals, &Proposal{
+			SafeTxHash:         hash,
+			ChainID:            account.ChainID,
+			Safe:               account.Address,
+			Tx:                 *tx,
+			Status:             ProposalStatusPending,
+			MultiTransactionID: multiTx.ID,
+			Signatures:         make([]Signature, 0),
+			CreatedAt:          time.Now().Unix(),
+		})
+		nonce++
+	}
+
+	if err = m.mtDB.CreateMultiTransaction(multiTx); err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		if err = m.db.InsertProposal(proposal); err != nil {
+			return nil, err
+		}
+	}
+
+	m.sendProposalsUpdated(account.ChainID, account.Address)
+	return proposals, nil
+}
+
+func (m *Manager) GetProposals(chainID uint64, safe common.Address) ([]*Proposal, error) {
+	return m.db.GetProposals(chainID, safe)
+}
+
+func (m *Manager) getPendingProposal(safeTxHash common.Hash) (*Proposal, *Account, error) {
+	proposal, err := m.db.GetProposal(safeTxHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if proposal.Status != ProposalStatusPending {
+		return nil, nil, ErrProposalNotPending
+	}
+	account, err := m.db.GetAccount(proposal.ChainID, proposal.Safe)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proposal, account, nil
+}
+
+// SignProposal signs the proposal with the key of one of the Safe owners
+func (m *Manager) SignProposal(safeTxHash common.Hash, ownerKey *types.Key) (*Proposal, error) {
+	proposal, account, err := m.getPendingProposal(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := common.Address(ownerKey.Address)
+	if !account.IsOwner(owner) {
+		return nil, ErrNotAnOwner
+	}
+
+	sig, err := sign(safeTxHash, ownerKey.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return m.addSignature(proposal, Signature{Owner: owner, Signature: sig})
+}
+
+// AddSignature adds a signature made outside of the wallet's keystore, e.g. on a keycard or by another owner
+func (m *Manager) AddSignature(safeTxHash common.Hash, signature []byte) (*Proposal, error) {
+	proposal, account, err := m.getPendingProposal(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, sig, err := recoverOwner(safeTxHash, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsOwner(owner) {
+		return nil, ErrNotAnOwner
+	}
+	return m.addSignature(proposal, Signature{Owner: owner, Signature: sig})
+}
+
+func (m *Manager) addSignature(proposal *Proposal, signature Signature) (*Proposal, error) {
+	if err := m.db.AddSignature(proposal.SafeTxHash, signature); err != nil {
+		return nil, err
+	}
+	m.sendProposalsUpdated(proposal.ChainID, proposal.Safe)
+	return m.db.GetProposal(proposal.SafeTxHash)
+}
+
+// RejectProposal drops a pending proposal. Its nonce is not reused, a replacement has to be proposed
+// for the following proposals to be executable
+func (m *Manager) RejectProposal(safeTxHash common.Hash) error {
+	proposal, _, err := m.getPendingProposal(safeTxHash)
+	if err != nil {
+		return err
+	}
+	if err = m.setProposalInactive(proposal, ProposalStatusRejected); err != nil {
+		return err
+	}
+	m.sendProposalsUpdated(proposal.ChainID, proposal.Safe)
+	return nil
+}
+
+func (m *Manager) setProposalInactive(proposal *Proposal, status ProposalStatus) error {
+	if err := m.db.UpdateProposalStatus(proposal.SafeTxHash, status); err != nil {
+		return err
+	}
+	if proposal.MultiTransactionID == wallet_common.NoMultiTransactionID {
+		return nil
+	}
+
+	active, err := m.db.CountActiveProposals(proposal.MultiTransactionID)
+	if err != nil {
+		return err
+	}
+	if active == 0 {
+		m.deleteMultiTransaction(proposal.MultiTransactionID)
+	}
+	return nil
+}
+
+func (m *Manager) deleteMultiTransaction(id wallet_common.MultiTransactionIDType) {
+	if err := m.mtDB.DeleteMultiTransaction(id); err != nil {
+		logutils.ZapLogger().Error("safe: failed to delete multi transaction", zap.Int64("id", int64(id)), zap.Error(err))
+	}
+}
+
+// ExecuteProposal sends execTransaction from the executor account, which pays for the gas.
+// An executor that owns the Safe counts as a signer without signing the proposal
+func (m *Manager) ExecuteProposal(ctx context.Context, safeTxHash common.Hash, executorKey *types.Key) (common.Hash, error) {
+	proposal, account, err := m.getPendingProposal(safeTxHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	account, err = m.RefreshAccount(ctx, account.ChainID, account.Address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if proposal.Tx.Nonce != account.Nonce {
+		return common.Hash{}, ErrNonceMismatch
+	}
+
+	signatures := make([]Signature, 0, len(proposal.Signatures)+1)
+	for _, s := range proposal.Signatures {
+		// owners may have been removed since they signed
+		if account.IsOwner(s.Owner) {
+			signatures = append(signatures, s)
+		}
+	}
+	executor := common.Address(executorKey.Address)
+	if account.IsOwner(executor) && !proposal.hasSigned(executor) {
+		signatures = append(signatures, Signature{Owner: executor, Signature: approvedHashSignature(executor)})
+	}
+	if uint64(len(signatures)) < account.Threshold {
+		return common.Hash{}, ErrThresholdNotReached
+	}
+
+	data, err := packExecTransaction(&proposal.Tx, packSignatures(signatures))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	to := types.Address(account.Address)
+	sendArgs := wallettypes.SendTxArgs{
+		From:  types.Address(executor),
+		To:    &to,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Data:  data,
+	}
+	tx, _, err := m.transactor.ValidateAndBuildTransaction(account.ChainID, sendArgs, -1)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	signedTx, err := gethtypes.SignTx(tx, gethtypes.NewLondonSigner(new(big.Int).SetUint64(account.ChainID)), executorKey.PrivateKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hash, err := m.transactor.SendTransactionWithSignature(executor, "", proposal.MultiTransactionID, signedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	txHash := common.Hash(hash)
+	if err = m.db.SetProposalExecuted(safeTxHash, txHash); err != nil {
+		return txHash, err
+	}
+	m.sendProposalsUpdated(account.ChainID, account.Address)
+	return txHash, nil
+}
+
+func (m *Manager) sendProposalsUpdated(chainID uint64, safe common.Address) {
+	if m.feed == nil {
+		return
+	}
+	m.feed.Send(walletevent.Event{
+		Type:     EventSafeProposalsUpdated,
+		Accounts: []common.Address{safe},
+		ChainID:  chainID,
+		At:       time.Now().Unix(),
+	})
+}
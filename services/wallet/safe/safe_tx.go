@@ -0,0 +1,122 @@
+package safe
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+)
+
+var (
+	domainSeparatorTypeHash       = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	legacyDomainSeparatorTypeHash = crypto.Keccak256([]byte("EIP712Domain(address verifyingContract)"))
+	safeTxTypeHash                = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+func word(b []byte) []byte {
+	return common.LeftPadBytes(b, 32)
+}
+
+func bigWord(v *hexutil.Big) []byte {
+	if v == nil {
+		return word(nil)
+	}
+	return word(v.ToInt().Bytes())
+}
+
+// domainSeparator of the Safe, the chain ID is part of it since Safe 1.3.0
+func domainSeparator(chainID uint64, safe common.Address, version string) ([]byte, error) {
+	major, minor, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if major < 1 {
+		// 0.x Safes sign a different SafeTx type
+		return nil, ErrUnsupportedSafeVersion
+	}
+	if major == 1 && minor < 3 {
+		return crypto.Keccak256(legacyDomainSeparatorTypeHash, word(safe.Bytes())), nil
+	}
+	return crypto.Keccak256(domainSeparatorTypeHash, word(new(big.Int).SetUint64(chainID).Bytes()), word(safe.Bytes())), nil
+}
+
+// Hash returns the EIP-712 hash the owners sign, the same as GnosisSafe.getTransactionHash
+func (tx *SafeTx) Hash(chainID uint64, safe common.Address, version string) (common.Hash, error) {
+	separator, err := domainSeparator(chainID, safe, version)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		word(tx.To.Bytes()),
+		bigWord(tx.Value),
+		crypto.Keccak256(tx.Data),
+		word([]byte{byte(tx.Operation)}),
+		bigWord(tx.SafeTxGas),
+		bigWord(tx.BaseGas),
+		bigWord(tx.GasPrice),
+		word(tx.GasToken.Bytes()),
+		word(tx.RefundReceiver.Bytes()),
+		word(new(big.Int).SetUint64(tx.Nonce).Bytes()),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, separator, structHash), nil
+}
+
+// safeTxFromPath builds the Safe transaction doing what the router path would do if it was sent by the Safe.
+// Gas is paid by the executor, so no refund is set
+func safeTxFromPath(safe common.Address, addressTo common.Address, path *routes.Path, processor pathprocessor.PathProcessor,
+	inputParams pathprocessor.ProcessorInputParams, nonce uint64) (*SafeTx, error) {
+	if path.ApprovalRequired {
+		return nil, ErrApprovalRequired
+	}
+
+	inputParams.FromAddr = safe
+	inputParams.ToAddr = addressTo
+	inputParams.FromChain = path.FromChain
+	inputParams.ToChain = path.ToChain
+	inputParams.FromToken = path.FromToken
+	inputParams.ToToken = path.ToToken
+	inputParams.AmountIn = path.AmountIn.ToInt()
+	inputParams.AmountOut = path.AmountOut.ToInt()
+
+	data, err := processor.PackTxInputData(inputParams)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress, err := processor.GetContractAddress(inputParams)
+	if err != nil {
+		return nil, err
+	}
+
+	isNative := path.FromToken == nil || path.FromToken.IsNative()
+	tx := &SafeTx{
+		To:        addressTo,
+		Value:     (*hexutil.Big)(big.NewInt(0)),
+		Data:      data,
+		Operation: OperationCall,
+		SafeTxGas: (*hexutil.Big)(big.NewInt(0)),
+		BaseGas:   (*hexutil.Big)(big.NewInt(0)),
+		GasPrice:  (*hexutil.Big)(big.NewInt(0)),
+		Nonce:     nonce,
+	}
+	if isNative {
+		tx.Value = (*hexutil.Big)(new(big.Int).Set(path.AmountIn.ToInt()))
+	}
+
+	switch {
+	case contractAddress != (common.Address{}):
+		tx.To = contractAddress
+	case !isNative:
+		// plain token transfer, the recipient is part of the call data
+		tx.To = path.FromToken.Address
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,160 @@
+package safe
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/status-im/status-go/contracts/ierc20"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSafeTx() *SafeTx {
+	return &SafeTx{
+		To:             common.HexToAddress("0x7"),
+		Value:          (*hexutil.Big)(big.NewInt(1000)),
+		Data:           hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb},
+		Operation:      OperationCall,
+		SafeTxGas:      (*hexutil.Big)(big.NewInt(1)),
+		BaseGas:        (*hexutil.Big)(big.NewInt(2)),
+		GasPrice:       (*hexutil.Big)(big.NewInt(3)),
+		GasToken:       common.HexToAddress("0x8"),
+		RefundReceiver: common.HexToAddress("0x9"),
+		Nonce:          42,
+	}
+}
+
+// typedDataHash computes the hash with the generic EIP-712 implementation
+func typedDataHash(t *testing.T, tx *SafeTx, chainID uint64, safe common.Address, withChainID bool) common.Hash {
+	domainTypes := []apitypes.Type{{Name: "verifyingContract", Type: "address"}}
+	domain := apitypes.TypedDataDomain{VerifyingContract: safe.Hex()}
+	if withChainID {
+		domainTypes = append([]apitypes.Type{{Name: "chainId", Type: "uint256"}}, domainTypes...)
+		domain.ChainId = math.NewHexOrDecimal256(int64(chainID))
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainTypes,
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.ToInt().String(),
+			"data":           tx.Data.String(),
+			"operation":      "0",
+			"safeTxGas":      tx.SafeTxGas.ToInt().String(),
+			"baseGas":        tx.BaseGas.ToInt().String(),
+			"gasPrice":       tx.GasPrice.ToInt().String(),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          new(big.Int).SetUint64(tx.Nonce).String(),
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+	return common.BytesToHash(hash)
+}
+
+func TestSafeTxHash(t *testing.T) {
+	tx := testSafeTx()
+	safe := common.HexToAddress("0x5afe")
+
+	hash, err := tx.Hash(10, safe, "1.3.0+L2")
+	require.NoError(t, err)
+	require.Equal(t, typedDataHash(t, tx, 10, safe, true), hash)
+
+	// The chain ID is not part of the domain before 1.3.0
+	hash, err = tx.Hash(10, safe, "1.2.0")
+	require.NoError(t, err)
+	require.Equal(t, typedDataHash(t, tx, 10, safe, false), hash)
+
+	_, err = tx.Hash(10, safe, "0.1.0")
+	require.ErrorIs(t, err, ErrUnsupportedSafeVersion)
+	_, err = tx.Hash(10, safe, "unknown")
+	require.ErrorIs(t, err, ErrUnsupportedSafeVersion)
+}
+
+func TestSafeTxFromPath(t *testing.T) {
+	safe := common.HexToAddress("0x5afe")
+	recipient := common.HexToAddress("0xbeef")
+	network := &params.Network{ChainID: 10}
+	processor := pathprocessor.NewTransferProcessor(nil, nil)
+
+	path := &routes.Path{
+		ProcessorName: processor.Name(),
+		FromChain:     network,
+		ToChain:       network,
+		FromToken:     &token.Token{Symbol: "ETH", Address: common.Address{}},
+		AmountIn:      (*hexutil.Big)(big.NewInt(100)),
+		AmountOut:     (*hexutil.Big)(big.NewInt(100)),
+	}
+	tx, err := safeTxFromPath(safe, recipient, path, processor, pathprocessor.ProcessorInputParams{}, 3)
+	require.NoError(t, err)
+	require.Equal(t, recipient, tx.To)
+	require.Equal(t, big.NewInt(100), tx.Value.ToInt())
+	require.Empty(t, tx.Data)
+	require.Equal(t, uint64(3), tx.Nonce)
+
+	tokenAddress := common.HexToAddress("0xda1")
+	path.FromToken = &token.Token{Symbol: "DAI", Address: tokenAddress}
+	tx, err = safeTxFromPath(safe, recipient, path, processor, pathprocessor.ProcessorInputParams{}, 4)
+	require.NoError(t, err)
+	require.Equal(t, tokenAddress, tx.To)
+	require.Equal(t, big.NewInt(0), tx.Value.ToInt())
+
+	erc20ABI, err := abi.JSON(strings.NewReader(ierc20.IERC20ABI))
+	require.NoError(t, err)
+	expectedData, err := erc20ABI.Pack("transfer", recipient, big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes(expectedData), tx.Data)
+
+	path.ApprovalRequired = true
+	_, err = safeTxFromPath(safe, recipient, path, processor, pathprocessor.ProcessorInputParams{}, 5)
+	require.ErrorIs(t, err, ErrApprovalRequired)
+}
+
+func TestPackExecTransaction(t *testing.T) {
+	tx := testSafeTx()
+	signatures := []byte{1, 2, 3}
+
+	data, err := packExecTransaction(tx, signatures)
+	require.NoError(t, err)
+
+	method, err := parsedSafeABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "execTransaction", method.Name)
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, tx.To, args[0])
+	require.Equal(t, big.NewInt(1000), args[1])
+	require.Equal(t, []byte(tx.Data), args[2])
+	require.Equal(t, uint8(0), args[3])
+	require.Equal(t, signatures, args[9])
+}
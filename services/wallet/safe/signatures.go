@@ -0,0 +1,82 @@
+package safe
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const signatureLength = 65
+
+// sign signs the Safe transaction hash with an owner key, without the eth_sign prefix
+func sign(safeTxHash common.Hash, key *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := crypto.Sign(safeTxHash.Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// recoverOwner returns the signer of the Safe transaction hash and the signature in the format the Safe
+// contract expects. Both plain signatures (v 0/1 or 27/28) and eth_sign ones (v 31/32) are accepted
+func recoverOwner(safeTxHash common.Hash, signature []byte) (common.Address, []byte, error) {
+	if len(signature) != signatureLength {
+		return common.Address{}, nil, ErrInvalidSignature
+	}
+
+	sig := make([]byte, signatureLength)
+	copy(sig, signature)
+
+	hash := safeTxHash.Bytes()
+	switch sig[64] {
+	case 0, 1:
+		sig[64] += 27
+	case 27, 28:
+	case 31, 32:
+		hash = accounts.TextHash(hash)
+	default:
+		return common.Address{}, nil, ErrInvalidSignature
+	}
+
+	rsv := make([]byte, signatureLength)
+	copy(rsv, sig)
+	if sig[64] > 28 {
+		rsv[64] -= 31
+	} else {
+		rsv[64] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(hash, rsv)
+	if err != nil {
+		return common.Address{}, nil, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pubKey), sig, nil
+}
+
+// approvedHashSignature is the pre-validated signature of an owner executing the transaction itself
+func approvedHashSignature(owner common.Address) []byte {
+	sig := make([]byte, signatureLength)
+	copy(sig, common.LeftPadBytes(owner.Bytes(), 32))
+	sig[64] = 1
+	return sig
+}
+
+// packSignatures concatenates the signatures sorted by owner, as required by checkSignatures
+func packSignatures(signatures []Signature) []byte {
+	sorted := make([]Signature, len(signatures))
+	copy(sorted, signatures)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Owner.Bytes(), sorted[j].Owner.Bytes()) < 0
+	})
+
+	packed := make([]byte, 0, len(sorted)*signatureLength)
+	for _, s := range sorted {
+		packed = append(packed, s.Signature...)
+	}
+	return packed
+}
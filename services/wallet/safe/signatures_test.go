@@ -0,0 +1,70 @@
+package safe
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndRecover(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	hash := crypto.Keccak256Hash([]byte("safe tx"))
+
+	sig, err := sign(hash, key)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, sig[64])
+
+	recovered, normalized, err := recoverOwner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+	require.Equal(t, sig, normalized)
+
+	// Raw signatures with v 0/1, e.g. from a keycard, are normalized
+	raw, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+	recovered, normalized, err = recoverOwner(hash, raw)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+	require.Equal(t, sig, normalized)
+
+	// eth_sign signatures have v shifted by 4
+	ethSign, err := crypto.Sign(accounts.TextHash(hash.Bytes()), key)
+	require.NoError(t, err)
+	ethSign[64] += 31
+	recovered, normalized, err = recoverOwner(hash, ethSign)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+	require.Equal(t, ethSign, normalized)
+
+	invalid := append([]byte{}, sig...)
+	invalid[64] = 2
+	_, _, err = recoverOwner(hash, invalid)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	_, _, err = recoverOwner(hash, sig[:64])
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestPackSignatures(t *testing.T) {
+	low := common.HexToAddress("0x1")
+	high := common.HexToAddress("0x2")
+
+	approved := approvedHashSignature(high)
+	require.Len(t, approved, signatureLength)
+	require.Equal(t, common.LeftPadBytes(high.Bytes(), 32), approved[:32])
+	require.Equal(t, make([]byte, 32), approved[32:64])
+	require.Equal(t, byte(1), approved[64])
+
+	lowSig := make([]byte, signatureLength)
+	lowSig[64] = 27
+	packed := packSignatures([]Signature{
+		{Owner: high, Signature: approved},
+		{Owner: low, Signature: lowSig},
+	})
+	require.Equal(t, append(append([]byte{}, lowSig...), approved...), packed)
+}
@@ -0,0 +1,130 @@
+package safe
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+)
+
+var (
+	ErrSafeNotFound           = errors.New("safe not found")
+	ErrProposalNotFound       = errors.New("safe proposal not found")
+	ErrNotAContract           = errors.New("address is not a safe contract")
+	ErrUnsupportedSafeVersion = errors.New("unsupported safe version")
+	ErrNotAnOwner             = errors.New("address is not an owner of the safe")
+	ErrInvalidSignature       = errors.New("invalid safe signature")
+	ErrThresholdNotReached    = errors.New("safe threshold not reached")
+	ErrNonceMismatch          = errors.New("safe nonce doesn't match the proposal, execute the previous proposals first")
+	ErrProposalNotPending     = errors.New("safe proposal is not pending")
+	ErrApprovalRequired       = errors.New("paths requiring an approval can't be proposed to a safe")
+	ErrEmptyRoute             = errors.New("no route to propose")
+	ErrMultiChainRoute        = errors.New("a safe is deployed on a single chain, the route must start from it")
+	ErrUnknownPathProcessor   = errors.New("unknown path processor")
+)
+
+// Operation is the kind of call the Safe makes
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+// SafeTx is the transaction the Safe owners sign, see GnosisSafe.execTransaction
+type SafeTx struct {
+	To             common.Address `json:"to"`
+	Value          *hexutil.Big   `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      Operation      `json:"operation"`
+	SafeTxGas      *hexutil.Big   `json:"safeTxGas"`
+	BaseGas        *hexutil.Big   `json:"baseGas"`
+	GasPrice       *hexutil.Big   `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          uint64         `json:"nonce"`
+}
+
+// Account is a Safe followed by the wallet. The wallet can't sign for it, it proposes transactions
+// and collects the owners' signatures until the threshold is reached
+type Account struct {
+	ChainID   uint64           `json:"chainId"`
+	Address   common.Address   `json:"address"`
+	Name      string           `json:"name"`
+	Version   string           `json:"version"`
+	Owners    []common.Address `json:"owners"`
+	Threshold uint64           `json:"threshold"`
+	Nonce     uint64           `json:"nonce"`
+	UpdatedAt int64            `json:"updatedAt"`
+	// LocalOwners are the owners available in the wallet's keystore
+	LocalOwners []common.Address `json:"localOwners"`
+}
+
+func (a *Account) IsOwner(address common.Address) bool {
+	for _, owner := range a.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+type ProposalStatus string
+
+const (
+	// ProposalStatusPending is collecting signatures
+	ProposalStatusPending ProposalStatus = "pending"
+	// ProposalStatusExecuted had its execTransaction sent, its outcome is tracked as a pending transaction
+	ProposalStatusExecuted ProposalStatus = "executed"
+	ProposalStatusRejected ProposalStatus = "rejected"
+	// ProposalStatusOutdated had its nonce used by another Safe transaction
+	ProposalStatusOutdated ProposalStatus = "outdated"
+)
+
+// Signature is an owner's approval of a Safe transaction
+type Signature struct {
+	Owner     common.Address `json:"owner"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+type Proposal struct {
+	SafeTxHash         common.Hash                          `json:"safeTxHash"`
+	ChainID            uint64                               `json:"chainId"`
+	Safe               common.Address                       `json:"safe"`
+	Tx                 SafeTx                               `json:"tx"`
+	Status             ProposalStatus                       `json:"status"`
+	MultiTransactionID wallet_common.MultiTransactionIDType `json:"multiTransactionId"`
+	Signatures         []Signature                          `json:"signatures"`
+	ExecutionTxHash    *common.Hash                         `json:"executionTxHash,omitempty"`
+	CreatedAt          int64                                `json:"createdAt"`
+}
+
+func (p *Proposal) hasSigned(owner common.Address) bool {
+	for _, s := range p.Signatures {
+		if s.Owner == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVersion returns the major and minor components of a Safe VERSION, e.g. "1.3.0+L2"
+func parseVersion(version string) (major int, minor int, err error) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, ErrUnsupportedSafeVersion
+	}
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, ErrUnsupportedSafeVersion
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, ErrUnsupportedSafeVersion
+	}
+	return major, minor, nil
+}
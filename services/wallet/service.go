@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/ipfs"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/params"
@@ -21,25 +23,33 @@ import (
 	"github.com/status-im/status-go/server"
 	"github.com/status-im/status-go/services/ens/ensresolver"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/addressbook"
+	"github.com/status-im/status-go/services/wallet/alerts"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	"github.com/status-im/status-go/services/wallet/community"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/eip712"
 	"github.com/status-im/status-go/services/wallet/history"
 	"github.com/status-im/status-go/services/wallet/market"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/portfolio"
 	"github.com/status-im/status-go/services/wallet/routeexecution"
 	"github.com/status-im/status-go/services/wallet/router"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/safe"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/thirdparty/alchemy"
 	"github.com/status-im/status-go/services/wallet/thirdparty/coingecko"
 	"github.com/status-im/status-go/services/wallet/thirdparty/cryptocompare"
+	"github.com/status-im/status-go/services/wallet/thirdparty/onchain"
 	"github.com/status-im/status-go/services/wallet/thirdparty/opensea"
 	"github.com/status-im/status-go/services/wallet/thirdparty/rarible"
+	"github.com/status-im/status-go/services/wallet/thirdparty/sourcify"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/txdecoder"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/transactions"
 )
@@ -63,6 +73,7 @@ func NewService(
 	pendingTxManager *transactions.PendingTxTracker,
 	feed *event.Feed,
 	mediaServer *server.MediaServer,
+	downloader *ipfs.Downloader,
 	statusProxyStageName string,
 ) *Service {
 	signals := &walletevent.SignalsTransmitter{
@@ -148,6 +159,11 @@ func NewService(
 	openseaV2Client := opensea.NewClientV2(config.WalletConfig.OpenseaAPIKey, openseaHTTPClient)
 	raribleClient := rarible.NewClient(config.WalletConfig.RaribleMainnetAPIKey, config.WalletConfig.RaribleTestnetAPIKey)
 	alchemyClient := alchemy.NewClient(config.WalletConfig.AlchemyAPIKeys)
+	var ipfsDownloader onchain.IPFSDownloader
+	if downloader != nil {
+		ipfsDownloader = downloader
+	}
+	onchainClient := onchain.NewClient(rpcClient, ipfsDownloader, !config.WalletConfig.DisableTokenURIFetching)
 
 	// Collectible providers in priority order (i.e. provider N+1 will be tried only if provider N fails)
 	contractOwnershipProviders := []thirdparty.CollectibleContractOwnershipProvider{
@@ -165,6 +181,7 @@ func NewService(
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectionDataProviders := []thirdparty.CollectionDataProvider{
@@ -195,16 +212,38 @@ func NewService(
 	)
 	collectibles := collectibles.NewService(db, feed, accountsDB, accountFeed, settingsFeed, communityManager, rpcClient.NetworkManager, collectiblesManager)
 
-	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed, pendingTxManager)
+	// Label sources in priority order (i.e. a label from source N+1 is used only if source N doesn't know the address)
+	labelSources := []addressbook.Source{
+		addressbook.NewOwnAccountsSource(accountsDB),
+		addressbook.NewSavedAddressesSource(db, accountsDB),
+		addressbook.NewContactsSource(appDB),
+	}
+	var ensNameResolver addressbook.ENSNameResolver
+	if ensResolver != nil {
+		ensNameResolver = ensResolver
+	}
+	labelResolver := addressbook.NewResolver(labelSources, ensNameResolver)
+
+	decoder := NewDecoder()
+	txDecoder := txdecoder.NewDecoder(txdecoder.NewDatabase(db), sourcify.NewClient(), tokenManager, decoder.Main, decoder.Fallback)
+	typedDataAnalyzer := eip712.NewAnalyzer(tokenManager, labelResolver)
+
+	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed, pendingTxManager, marketManager, labelResolver, txDecoder)
+	portfolio := portfolio.NewService(db, tokenManager, marketManager)
+	alerts := alerts.NewService(db, feed)
 
 	router := router.NewRouter(rpcClient, transactor, tokenManager, marketManager, collectibles,
 		collectiblesManager)
 	pathProcessors := buildPathProcessors(rpcClient, transactor, tokenManager, ensResolver, featureFlags)
 	for _, processor := range pathProcessors {
 		router.AddPathProcessor(processor)
+		if bridge, ok := processor.(pathprocessor.BridgeProcessor); ok && pendingTxManager != nil {
+			pendingTxManager.RegisterBridgeStatusChecker(bridge)
+		}
 	}
 
 	routeExecutionManager := routeexecution.NewManager(db, feed, router, transactionManager, transferController)
+	safeManager := safe.NewManager(safe.NewDatabase(db), transfer.NewMultiTransactionDB(db), accountsDB, rpcClient, transactor, feed)
 
 	return &Service{
 		db:                    db,
@@ -228,13 +267,19 @@ func NewService(
 		history:               history,
 		currency:              currency,
 		activity:              activity,
-		decoder:               NewDecoder(),
+		labelResolver:         labelResolver,
+		portfolio:             portfolio,
+		alerts:                alerts,
+		decoder:               decoder,
+		txDecoder:             txDecoder,
+		typedDataAnalyzer:     typedDataAnalyzer,
 		blockChainState:       blockChainState,
 		keycardPairings:       NewKeycardPairings(),
 		config:                config,
 		featureFlags:          featureFlags,
 		router:                router,
 		routeExecutionManager: routeExecutionManager,
+		safeManager:           safeManager,
 	}
 }
 
@@ -259,6 +304,9 @@ func buildPathProcessors(
 	hop := pathprocessor.NewHopBridgeProcessor(rpcClient, transactor, tokenManager, rpcClient.NetworkManager)
 	ret = append(ret, hop)
 
+	across := pathprocessor.NewAcrossBridgeProcessor(rpcClient, transactor, tokenManager)
+	ret = append(ret, across)
+
 	if featureFlags.EnableCelerBridge {
 		// TODO: Celar Bridge is out of scope for 2.30, check it thoroughly once we decide to include it again
 		cbridge := pathprocessor.NewCelerBridgeProcessor(rpcClient, transactor, tokenManager)
@@ -268,6 +316,12 @@ func buildPathProcessors(
 	paraswap := pathprocessor.NewSwapParaswapProcessor(rpcClient, transactor, tokenManager)
 	ret = append(ret, paraswap)
 
+	kyberSwap := pathprocessor.NewSwapKyberProcessor(transactor)
+	ret = append(ret, kyberSwap)
+
+	uniswap := pathprocessor.NewSwapUniswapProcessor(rpcClient, transactor)
+	ret = append(ret, uniswap)
+
 	ensRegister := pathprocessor.NewENSRegisterProcessor(rpcClient, transactor, ensResolver)
 	ret = append(ret, ensRegister)
 
@@ -307,13 +361,19 @@ type Service struct {
 	history               *history.Service
 	currency              *currency.Service
 	activity              *activity.Service
+	labelResolver         *addressbook.Resolver
+	portfolio             *portfolio.Service
+	alerts                *alerts.Service
 	decoder               *Decoder
+	txDecoder             *txdecoder.Decoder
+	typedDataAnalyzer     *eip712.Analyzer
 	blockChainState       *blockchainstate.BlockChainState
 	keycardPairings       *KeycardPairings
 	config                *params.NodeConfig
 	featureFlags          *protocolCommon.FeatureFlags
 	router                *router.Router
 	routeExecutionManager *routeexecution.Manager
+	safeManager           *safe.Manager
 }
 
 // Start signals transmitter.
@@ -323,6 +383,7 @@ func (s *Service) Start() error {
 	err := s.signals.Start()
 	s.history.Start()
 	s.collectibles.Start()
+	s.alerts.Start()
 	s.started = true
 	return err
 }
@@ -343,6 +404,7 @@ func (s *Service) Stop() error {
 	s.history.Stop()
 	s.activity.Stop()
 	s.collectibles.Stop()
+	s.alerts.Stop()
 	s.tokenManager.Stop()
 	s.started = false
 	logutils.ZapLogger().Info("wallet stopped")
@@ -405,3 +467,22 @@ func (s *Service) GetCollectiblesService() *collectibles.Service {
 func (s *Service) GetCollectiblesManager() *collectibles.Manager {
 	return s.collectiblesManager
 }
+
+// fillInTransferLabels sets the address book labels of the senders and recipients of the transfers
+func (s *Service) fillInTransferLabels(ctx context.Context, views []transfer.View) []transfer.View {
+	if len(views) == 0 {
+		return views
+	}
+
+	addresses := make([]common.Address, 0, len(views)*2)
+	for _, view := range views {
+		addresses = append(addresses, view.From, view.To)
+	}
+
+	labels := s.labelResolver.ResolveLabels(ctx, addresses)
+	for i := range views {
+		views[i].FromLabel = labels[views[i].From]
+		views[i].ToLabel = labels[views[i].To]
+	}
+	return views
+}
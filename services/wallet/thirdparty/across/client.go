@@ -0,0 +1,44 @@
+package across
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const baseURL = "https://app.across.to/api"
+
+type Client struct {
+	httpClient *thirdparty.HTTPClient
+}
+
+func NewClient() *Client {
+	return &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+	}
+}
+
+// errorResponse is returned by the API instead of the expected data when a request fails
+type errorResponse struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func handleResponse(body []byte, data interface{}) error {
+	var errResp errorResponse
+	err := json.Unmarshal(body, &errResp)
+	if err != nil {
+		return err
+	}
+
+	if errResp.Type == "AcrossApiError" || errResp.Code != "" {
+		if errResp.Message == "" {
+			return errors.New("unknown error")
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return json.Unmarshal(body, data)
+}
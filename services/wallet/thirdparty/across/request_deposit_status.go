@@ -0,0 +1,45 @@
+package across
+
+import (
+	"context"
+	netUrl "net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const depositStatusURL = baseURL + "/deposit/status"
+
+type DepositStatusType string
+
+const (
+	DepositStatusPending  DepositStatusType = "pending"
+	DepositStatusFilled   DepositStatusType = "filled"
+	DepositStatusExpired  DepositStatusType = "expired"
+	DepositStatusRefunded DepositStatusType = "refunded"
+)
+
+type DepositStatus struct {
+	Status             DepositStatusType `json:"status"`
+	DestinationChainID uint64            `json:"destinationChainId"`
+	FillTx             *common.Hash      `json:"fillTx"`
+}
+
+func (c *Client) FetchDepositStatus(ctx context.Context, originChainID uint64, depositTxHash common.Hash) (DepositStatus, error) {
+	params := netUrl.Values{}
+	params.Add("originChainId", strconv.FormatUint(originChainID, 10))
+	params.Add("depositTxHash", depositTxHash.Hex())
+
+	response, err := c.httpClient.DoGetRequest(ctx, depositStatusURL, params, nil)
+	if err != nil {
+		return DepositStatus{}, err
+	}
+
+	return handleDepositStatusResponse(response)
+}
+
+func handleDepositStatusResponse(response []byte) (DepositStatus, error) {
+	var status DepositStatus
+	err := handleResponse(response, &status)
+	return status, err
+}
@@ -0,0 +1,41 @@
+package across
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshallDepositStatus(t *testing.T) {
+	data := []byte(`{
+		"status": "filled",
+		"fillTx": "0x0b7b2a1d0c2a9a3c8c0b0b9c3d6f1e6e2f7a9b3c4d5e6f708192a3b4c5d6e7f8",
+		"destinationChainId": 42161,
+		"depositId": 1234
+	}`)
+
+	status, err := handleDepositStatusResponse(data)
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusFilled, status.Status)
+	require.Equal(t, uint64(42161), status.DestinationChainID)
+	require.NotNil(t, status.FillTx)
+	require.Equal(t, common.HexToHash("0x0b7b2a1d0c2a9a3c8c0b0b9c3d6f1e6e2f7a9b3c4d5e6f708192a3b4c5d6e7f8"), *status.FillTx)
+}
+
+func TestUnmarshallPendingDepositStatus(t *testing.T) {
+	data := []byte(`{"status": "pending", "fillTx": null, "destinationChainId": 10}`)
+
+	status, err := handleDepositStatusResponse(data)
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusPending, status.Status)
+	require.Nil(t, status.FillTx)
+}
+
+func TestDepositStatusError(t *testing.T) {
+	data := []byte(`{"type": "AcrossApiError", "code": "DEPOSIT_NOT_FOUND", "status": 404, "message": "Deposit not found"}`)
+
+	_, err := handleDepositStatusResponse(data)
+	require.EqualError(t, err, "Deposit not found")
+}
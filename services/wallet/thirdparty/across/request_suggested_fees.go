@@ -0,0 +1,92 @@
+package across
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	netUrl "net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+const suggestedFeesURL = baseURL + "/suggested-fees"
+
+var errAmountTooLow = errors.New("amount too low")
+
+type Fee struct {
+	Pct   *bigint.BigInt `json:"pct"`
+	Total *bigint.BigInt `json:"total"`
+}
+
+// SuggestedFees is the quote for a deposit, its values have to be used as is in the deposit tx
+type SuggestedFees struct {
+	TotalRelayFee       Fee            `json:"totalRelayFee"`
+	Timestamp           json.Number    `json:"timestamp"`
+	FillDeadline        json.Number    `json:"fillDeadline"`
+	ExclusiveRelayer    common.Address `json:"exclusiveRelayer"`
+	ExclusivityDeadline json.Number    `json:"exclusivityDeadline"`
+	SpokePoolAddress    common.Address `json:"spokePoolAddress"`
+	EstimatedFillTime   json.Number    `json:"estimatedFillTimeSec"`
+	IsAmountTooLow      bool           `json:"isAmountTooLow"`
+}
+
+// QuoteTimestamp returns the quote timestamp as expected by the SpokePool contract
+func (f SuggestedFees) QuoteTimestamp() (uint32, error) {
+	return parseUint32(f.Timestamp)
+}
+
+// FillDeadlineTimestamp returns the time until which relayers can fill the deposit
+func (f SuggestedFees) FillDeadlineTimestamp() (uint32, error) {
+	return parseUint32(f.FillDeadline)
+}
+
+// ExclusivityDeadlineTimestamp returns the time until which only the exclusive relayer can fill the deposit
+func (f SuggestedFees) ExclusivityDeadlineTimestamp() (uint32, error) {
+	if f.ExclusivityDeadline == "" {
+		return 0, nil
+	}
+	return parseUint32(f.ExclusivityDeadline)
+}
+
+func parseUint32(n json.Number) (uint32, error) {
+	v, err := strconv.ParseUint(n.String(), 10, 32)
+	return uint32(v), err
+}
+
+func (c *Client) FetchSuggestedFees(ctx context.Context, fromChainID uint64, toChainID uint64, inputToken common.Address, outputToken common.Address,
+	amount *big.Int) (SuggestedFees, error) {
+	params := netUrl.Values{}
+	params.Add("inputToken", inputToken.Hex())
+	params.Add("outputToken", outputToken.Hex())
+	params.Add("originChainId", strconv.FormatUint(fromChainID, 10))
+	params.Add("destinationChainId", strconv.FormatUint(toChainID, 10))
+	params.Add("amount", amount.String())
+
+	response, err := c.httpClient.DoGetRequest(ctx, suggestedFeesURL, params, nil)
+	if err != nil {
+		return SuggestedFees{}, err
+	}
+
+	return handleSuggestedFeesResponse(response)
+}
+
+func handleSuggestedFeesResponse(response []byte) (SuggestedFees, error) {
+	var fees SuggestedFees
+	err := handleResponse(response, &fees)
+	if err != nil {
+		return SuggestedFees{}, err
+	}
+
+	if fees.IsAmountTooLow {
+		return SuggestedFees{}, errAmountTooLow
+	}
+	if fees.TotalRelayFee.Total == nil {
+		return SuggestedFees{}, errors.New("missing relay fee")
+	}
+
+	return fees, nil
+}
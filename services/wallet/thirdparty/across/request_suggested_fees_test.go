@@ -0,0 +1,65 @@
+package across
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshallSuggestedFees(t *testing.T) {
+	data := []byte(`{
+		"totalRelayFee": {"pct": "78930919924823", "total": "78930919924823"},
+		"relayerCapitalFee": {"pct": "100000000000000", "total": "100000000000000"},
+		"relayerGasFee": {"pct": "7864845935", "total": "7864845935"},
+		"lpFee": {"pct": "0", "total": "0"},
+		"timestamp": "1727361023",
+		"isAmountTooLow": false,
+		"quoteBlock": "20835100",
+		"spokePoolAddress": "0x5c7BCd6E7De5423a257D81B442095A1a6ced35C5",
+		"exclusiveRelayer": "0x0000000000000000000000000000000000000000",
+		"exclusivityDeadline": 0,
+		"expectedFillTimeSec": "12",
+		"fillDeadline": "1727375423",
+		"estimatedFillTimeSec": 12
+	}`)
+
+	fees, err := handleSuggestedFeesResponse(data)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(78930919924823), fees.TotalRelayFee.Total.Int)
+	require.Equal(t, common.HexToAddress("0x5c7BCd6E7De5423a257D81B442095A1a6ced35C5"), fees.SpokePoolAddress)
+	require.Equal(t, common.Address{}, fees.ExclusiveRelayer)
+
+	quoteTimestamp, err := fees.QuoteTimestamp()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1727361023), quoteTimestamp)
+
+	fillDeadline, err := fees.FillDeadlineTimestamp()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1727375423), fillDeadline)
+
+	exclusivityDeadline, err := fees.ExclusivityDeadlineTimestamp()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), exclusivityDeadline)
+}
+
+func TestSuggestedFeesAmountTooLow(t *testing.T) {
+	data := []byte(`{
+		"totalRelayFee": {"pct": "78930919924823", "total": "78930919924823"},
+		"timestamp": "1727361023",
+		"isAmountTooLow": true,
+		"fillDeadline": "1727375423"
+	}`)
+
+	_, err := handleSuggestedFeesResponse(data)
+	require.ErrorIs(t, err, errAmountTooLow)
+}
+
+func TestSuggestedFeesError(t *testing.T) {
+	data := []byte(`{"type": "AcrossApiError", "code": "INVALID_PARAM", "status": 400, "message": "Route not enabled"}`)
+
+	_, err := handleSuggestedFeesResponse(data)
+	require.EqualError(t, err, "Route not enabled")
+}
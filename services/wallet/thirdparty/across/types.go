@@ -0,0 +1,16 @@
+package across
+
+//go:generate mockgen -package=mock_across -source=types.go -destination=mock/types.go
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ClientInterface interface {
+	FetchSuggestedFees(ctx context.Context, fromChainID uint64, toChainID uint64, inputToken common.Address, outputToken common.Address,
+		amount *big.Int) (SuggestedFees, error)
+	FetchDepositStatus(ctx context.Context, originChainID uint64, depositTxHash common.Hash) (DepositStatus, error)
+}
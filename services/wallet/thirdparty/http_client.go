@@ -8,6 +8,8 @@ import (
 	"net/http"
 	netUrl "net/url"
 	"time"
+
+	"github.com/status-im/status-go/rpc/chain/rpclimiter"
 )
 
 const requestTimeout = 5 * time.Second
@@ -26,6 +28,8 @@ func NewHTTPClient() *HTTPClient {
 	return &HTTPClient{
 		client: &http.Client{
 			Timeout: requestTimeout,
+			// Shares the budget of the providers with the RPC clients
+			Transport: rpclimiter.NewLimitedTransport(rpclimiter.SharedAdaptiveLimiters()),
 		},
 	}
 }
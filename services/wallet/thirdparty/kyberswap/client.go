@@ -0,0 +1,76 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const baseURL = "https://aggregator-api.kyberswap.com"
+
+// NativeTokenAddress is used by the aggregator to refer to the native token of a chain
+var NativeTokenAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+var chainNames = map[uint64]string{
+	walletCommon.EthereumMainnet: "ethereum",
+	walletCommon.OptimismMainnet: "optimism",
+	walletCommon.ArbitrumMainnet: "arbitrum",
+}
+
+var (
+	errChainNotSupported = errors.New("chain not supported")
+	errNoRouteFound      = errors.New("no route found")
+)
+
+type Client struct {
+	httpClient *thirdparty.HTTPClient
+	source     string
+}
+
+func NewClient(source string) *Client {
+	return &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		source:     source,
+	}
+}
+
+func (c *Client) IsChainSupported(chainID uint64) bool {
+	_, ok := chainNames[chainID]
+	return ok
+}
+
+func chainName(chainID uint64) (string, error) {
+	name, ok := chainNames[chainID]
+	if !ok {
+		return "", errChainNotSupported
+	}
+	return name, nil
+}
+
+// response is the envelope of all the aggregator responses, a non zero code means an error
+type response struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func handleResponse(body []byte, data interface{}) error {
+	var resp response
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != 0 {
+		if resp.Message == "" {
+			return errors.New("unknown error")
+		}
+		return errors.New(resp.Message)
+	}
+
+	return json.Unmarshal(resp.Data, data)
+}
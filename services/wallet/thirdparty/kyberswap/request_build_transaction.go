@@ -0,0 +1,56 @@
+package kyberswap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+const buildTransactionURL = baseURL + "/%s/api/v1/route/build"
+
+type Transaction struct {
+	AmountIn      *bigint.BigInt `json:"amountIn"`
+	AmountOut     *bigint.BigInt `json:"amountOut"`
+	Gas           *bigint.BigInt `json:"gas"`
+	Data          hexutil.Bytes  `json:"data"`
+	RouterAddress common.Address `json:"routerAddress"`
+}
+
+func (c *Client) BuildTransaction(ctx context.Context, chainID uint64, route Route, sender common.Address, recipient common.Address,
+	slippageBasisPoints uint) (Transaction, error) {
+	chain, err := chainName(chainID)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	params := map[string]interface{}{}
+	params["routeSummary"] = route.RawSummary
+	params["sender"] = sender.Hex()
+	params["recipient"] = recipient.Hex()
+	params["slippageTolerance"] = slippageBasisPoints
+	params["source"] = c.source
+
+	url := fmt.Sprintf(buildTransactionURL, chain)
+	response, err := c.httpClient.DoPostRequest(ctx, url, params, nil)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return handleBuildTransactionResponse(response)
+}
+
+func handleBuildTransactionResponse(response []byte) (Transaction, error) {
+	var tx Transaction
+	err := handleResponse(response, &tx)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if len(tx.Data) == 0 {
+		return Transaction{}, errNoRouteFound
+	}
+	return tx, nil
+}
@@ -0,0 +1,37 @@
+package kyberswap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshallBuildTransaction(t *testing.T) {
+	data := []byte(`{
+		"code": 0,
+		"message": "successfully",
+		"data": {
+			"amountIn": "1000000000000000000",
+			"amountInUsd": "3000.1",
+			"amountOut": "2999123456",
+			"amountOutUsd": "2999.12",
+			"gas": "190000",
+			"gasUsd": "5.6",
+			"data": "0xe21fd0e9",
+			"routerAddress": "0x6131B5fae19EA4f9D964eAc0408E4408b66337b5"
+		}
+	}`)
+
+	tx, err := handleBuildTransactionResponse(data)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2999123456), tx.AmountOut.Int)
+	require.Equal(t, big.NewInt(190000), tx.Gas.Int)
+	require.Equal(t, []byte{0xe2, 0x1f, 0xd0, 0xe9}, []byte(tx.Data))
+	require.Equal(t, common.HexToAddress("0x6131B5fae19EA4f9D964eAc0408E4408b66337b5"), tx.RouterAddress)
+
+	_, err = handleBuildTransactionResponse([]byte(`{"code": 4227, "message": "estimate gas failed"}`))
+	require.EqualError(t, err, "estimate gas failed")
+}
@@ -0,0 +1,78 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	netUrl "net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+const routesURL = baseURL + "/%s/api/v1/routes"
+
+type RouteSummary struct {
+	TokenIn   common.Address `json:"tokenIn"`
+	AmountIn  *bigint.BigInt `json:"amountIn"`
+	TokenOut  common.Address `json:"tokenOut"`
+	AmountOut *bigint.BigInt `json:"amountOut"`
+	Gas       *bigint.BigInt `json:"gas"`
+}
+
+type Route struct {
+	Summary       RouteSummary
+	RouterAddress common.Address
+	// RawSummary has to be sent back as is when building the transaction
+	RawSummary json.RawMessage
+}
+
+type routeData struct {
+	RouteSummary  json.RawMessage `json:"routeSummary"`
+	RouterAddress common.Address  `json:"routerAddress"`
+}
+
+func (c *Client) FetchRoute(ctx context.Context, chainID uint64, tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) (Route, error) {
+	chain, err := chainName(chainID)
+	if err != nil {
+		return Route{}, err
+	}
+
+	params := netUrl.Values{}
+	params.Add("tokenIn", tokenIn.Hex())
+	params.Add("tokenOut", tokenOut.Hex())
+	params.Add("amountIn", amountIn.String())
+	params.Add("source", c.source)
+
+	url := fmt.Sprintf(routesURL, chain)
+	response, err := c.httpClient.DoGetRequest(ctx, url, params, nil)
+	if err != nil {
+		return Route{}, err
+	}
+
+	return handleRouteResponse(response)
+}
+
+func handleRouteResponse(response []byte) (Route, error) {
+	var data routeData
+	err := handleResponse(response, &data)
+	if err != nil {
+		return Route{}, err
+	}
+
+	var route Route
+	err = json.Unmarshal(data.RouteSummary, &route.Summary)
+	if err != nil {
+		return Route{}, err
+	}
+	if route.Summary.AmountOut == nil || route.Summary.Gas == nil {
+		return Route{}, errNoRouteFound
+	}
+
+	route.RouterAddress = data.RouterAddress
+	route.RawSummary = data.RouteSummary
+
+	return route, nil
+}
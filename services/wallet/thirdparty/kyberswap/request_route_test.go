@@ -0,0 +1,49 @@
+package kyberswap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshallRoute(t *testing.T) {
+	data := []byte(`{
+		"code": 0,
+		"message": "successfully",
+		"data": {
+			"routeSummary": {
+				"tokenIn": "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE",
+				"amountIn": "1000000000000000000",
+				"amountInUsd": "3000.1",
+				"tokenOut": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+				"amountOut": "2999123456",
+				"amountOutUsd": "2999.12",
+				"gas": "184000",
+				"gasPrice": "10000000000",
+				"gasUsd": "5.5",
+				"route": [[{"pool": "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640", "exchange": "uniswapv3"}]]
+			},
+			"routerAddress": "0x6131B5fae19EA4f9D964eAc0408E4408b66337b5"
+		}
+	}`)
+
+	route, err := handleRouteResponse(data)
+	require.NoError(t, err)
+	require.Equal(t, NativeTokenAddress, route.Summary.TokenIn)
+	require.Equal(t, common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), route.Summary.TokenOut)
+	require.Equal(t, big.NewInt(1000000000000000000), route.Summary.AmountIn.Int)
+	require.Equal(t, big.NewInt(2999123456), route.Summary.AmountOut.Int)
+	require.Equal(t, big.NewInt(184000), route.Summary.Gas.Int)
+	require.Equal(t, common.HexToAddress("0x6131B5fae19EA4f9D964eAc0408E4408b66337b5"), route.RouterAddress)
+	require.Contains(t, string(route.RawSummary), "uniswapv3")
+}
+
+func TestRouteError(t *testing.T) {
+	data := []byte(`{"code": 4008, "message": "route not found"}`)
+
+	_, err := handleRouteResponse(data)
+	require.EqualError(t, err, "route not found")
+}
@@ -0,0 +1,17 @@
+package kyberswap
+
+//go:generate mockgen -package=mock_kyberswap -source=types.go -destination=mock/types.go
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ClientInterface interface {
+	IsChainSupported(chainID uint64) bool
+	FetchRoute(ctx context.Context, chainID uint64, tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) (Route, error)
+	BuildTransaction(ctx context.Context, chainID uint64, route Route, sender common.Address, recipient common.Address,
+		slippageBasisPoints uint) (Transaction, error)
+}
@@ -0,0 +1,303 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/connection"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const OnchainID = "onchain"
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxMetadataSize protects against token URIs pointing to huge files
+	maxMetadataSize = 2 << 20
+
+	tokenABI = `[
+	{"name":"supportsInterface","type":"function","stateMutability":"view","inputs":[{"name":"interfaceID","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"tokenURI","type":"function","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+	{"name":"uri","type":"function","stateMutability":"view","inputs":[{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+	{"name":"name","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]}
+	]`
+)
+
+var (
+	erc721InterfaceID  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	erc1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
+	errNoTokenURI          = errors.New("contract doesn't expose a token uri")
+	errURIFetchingDisabled = errors.New("fetching token uris from third party hosts is disabled")
+)
+
+// IPFSDownloader fetches IPFS content, see ipfs.Downloader
+type IPFSDownloader interface {
+	GetByCID(cid string, download bool) ([]byte, error)
+}
+
+// Client is a collectible data provider reading metadata straight from the token contracts,
+// used as a fallback when the indexing providers are down or don't support a chain.
+// The token URIs are set by the contract owners, so they are only fetched over https from public hosts,
+// and not at all when the user disabled third party fetching
+type Client struct {
+	rpcClient        rpc.ClientInterface
+	downloader       IPFSDownloader
+	httpClient       *http.Client
+	fetchURIs        bool
+	tokenABI         abi.ABI
+	connectionStatus *connection.Status
+}
+
+var _ thirdparty.CollectibleDataProvider = (*Client)(nil)
+
+func NewClient(rpcClient rpc.ClientInterface, downloader IPFSDownloader, fetchURIs bool) *Client {
+	parsedABI, err := abi.JSON(strings.NewReader(tokenABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return &Client{
+		rpcClient:        rpcClient,
+		downloader:       downloader,
+		httpClient:       newPublicHTTPClient(),
+		fetchURIs:        fetchURIs,
+		tokenABI:         parsedABI,
+		connectionStatus: connection.NewStatus(),
+	}
+}
+
+func (o *Client) ID() string {
+	return OnchainID
+}
+
+func (o *Client) IsChainSupported(chainID walletCommon.ChainID) bool {
+	return o.rpcClient.GetNetworkManager().Find(uint64(chainID)) != nil
+}
+
+func (o *Client) IsConnected() bool {
+	return o.connectionStatus.IsConnected()
+}
+
+func (o *Client) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID) ([]thirdparty.FullCollectibleData, error) {
+	ret := make([]thirdparty.FullCollectibleData, 0, len(uniqueIDs))
+	collections := make(map[string]*thirdparty.CollectionData)
+
+	var lastErr error
+	for _, id := range uniqueIDs {
+		collection, ok := collections[id.ContractID.HashKey()]
+		if !ok {
+			var err error
+			collection, err = o.fetchCollectionData(ctx, id.ContractID)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			collections[id.ContractID.HashKey()] = collection
+		}
+
+		asset, err := o.fetchAsset(ctx, id, collection)
+		if err != nil {
+			logutils.ZapLogger().Debug("onchain metadata not available",
+				zap.Stringer("chainID", id.ContractID.ChainID),
+				zap.Stringer("contract", id.ContractID.Address),
+				zap.Stringer("tokenID", id.TokenID),
+				zap.Error(err),
+			)
+			lastErr = err
+			continue
+		}
+		ret = append(ret, asset)
+	}
+
+	// let the next provider be tried only when nothing could be fetched
+	if len(ret) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return ret, nil
+}
+
+func (o *Client) FetchCollectionSocials(ctx context.Context, contractID thirdparty.ContractID) (*thirdparty.CollectionSocials, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (o *Client) contract(chainID walletCommon.ChainID, address common.Address) (*bind.BoundContract, error) {
+	backend, err := o.rpcClient.EthClient(uint64(chainID))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, o.tokenABI, backend, nil, nil), nil
+}
+
+func (o *Client) fetchCollectionData(ctx context.Context, id thirdparty.ContractID) (*thirdparty.CollectionData, error) {
+	contract, err := o.contract(id.ChainID, id.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	contractType := walletCommon.ContractTypeUnknown
+	if supportsInterface(contract, callOpts, erc721InterfaceID) {
+		contractType = walletCommon.ContractTypeERC721
+	} else if supportsInterface(contract, callOpts, erc1155InterfaceID) {
+		contractType = walletCommon.ContractTypeERC1155
+	}
+
+	// the name is optional for both standards
+	var out []interface{}
+	name := ""
+	if err := contract.Call(callOpts, &out, "name"); err == nil && len(out) == 1 {
+		name, _ = out[0].(string)
+	}
+
+	return &thirdparty.CollectionData{
+		ID:           id,
+		ContractType: contractType,
+		Provider:     OnchainID,
+		Name:         name,
+	}, nil
+}
+
+func supportsInterface(contract *bind.BoundContract, callOpts *bind.CallOpts, interfaceID [4]byte) bool {
+	var out []interface{}
+	err := contract.Call(callOpts, &out, "supportsInterface", interfaceID)
+	if err != nil || len(out) != 1 {
+		return false
+	}
+	supported, _ := out[0].(bool)
+	return supported
+}
+
+func (o *Client) fetchTokenURI(ctx context.Context, id thirdparty.CollectibleUniqueID, contractType walletCommon.ContractType) (string, error) {
+	contract, err := o.contract(id.ContractID.ChainID, id.ContractID.Address)
+	if err != nil {
+		return "", err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	methods := []string{"tokenURI", "uri"}
+	if contractType == walletCommon.ContractTypeERC1155 {
+		methods = []string{"uri", "tokenURI"}
+	}
+
+	for _, method := range methods {
+		var out []interface{}
+		err := contract.Call(callOpts, &out, method, id.TokenID.Int)
+		if err != nil || len(out) != 1 {
+			continue
+		}
+		uri, _ := out[0].(string)
+		if uri == "" {
+			continue
+		}
+		if method == "uri" {
+			uri = erc1155TokenURI(uri, id.TokenID)
+		}
+		return uri, nil
+	}
+
+	return "", errNoTokenURI
+}
+
+func (o *Client) fetchAsset(ctx context.Context, id thirdparty.CollectibleUniqueID, collection *thirdparty.CollectionData) (thirdparty.FullCollectibleData, error) {
+	if id.TokenID == nil {
+		return thirdparty.FullCollectibleData{}, errors.New("empty token ID")
+	}
+
+	tokenURI, err := o.fetchTokenURI(ctx, id, collection.ContractType)
+	if err != nil {
+		return thirdparty.FullCollectibleData{}, err
+	}
+
+	metadata, err := o.fetchMetadata(ctx, tokenURI)
+	if err != nil {
+		return thirdparty.FullCollectibleData{}, err
+	}
+
+	collectible := metadata.toCollectibleData(id, tokenURI)
+	collectible.ContractType = collection.ContractType
+
+	return thirdparty.FullCollectibleData{
+		CollectibleData: collectible,
+		CollectionData:  collection,
+	}, nil
+}
+
+func (o *Client) fetchMetadata(ctx context.Context, uri string) (*Metadata, error) {
+	content, err := o.fetchURI(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	err = json.Unmarshal(content, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (o *Client) fetchURI(ctx context.Context, uri string) ([]byte, error) {
+	uri = strings.TrimSpace(uri)
+
+	if strings.HasPrefix(uri, dataScheme) {
+		return decodeDataURI(uri)
+	}
+
+	if path, ok := ipfsPath(uri); ok && o.downloader != nil {
+		content, err := o.downloader.GetByCID(path, true)
+		if err == nil {
+			return content, nil
+		}
+		logutils.ZapLogger().Debug("ipfs download failed, trying the uri", zap.String("uri", uri), zap.Error(err))
+	}
+
+	uri = toGatewayURL(uri)
+	if !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedURI, uri)
+	}
+	if !o.fetchURIs {
+		return nil, errURIFetchingDisabled
+	}
+
+	return o.doGet(ctx, uri)
+}
+
+func (o *Client) doGet(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		o.connectionStatus.SetIsConnected(false)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	o.connectionStatus.SetIsConnected(true)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, url)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+}
@@ -0,0 +1,154 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const testMetadata = `{
+	"name": "Token #1",
+	"description": "A token",
+	"image": "ipfs://QmImage/1.png",
+	"background_color": "#ffffff",
+	"attributes": [
+		{"trait_type": "Color", "value": "Red"},
+		{"trait_type": "Level", "value": 5, "display_type": "number", "max_value": 10},
+		{"trait_type": "Rare", "value": true}
+	]
+}`
+
+type testDownloader struct {
+	content map[string][]byte
+}
+
+func (d *testDownloader) GetByCID(cid string, download bool) ([]byte, error) {
+	content, ok := d.content[cid]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return content, nil
+}
+
+func TestFetchMetadata(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testMetadata))
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, &testDownloader{content: map[string][]byte{
+		"QmMetadata/1": []byte(testMetadata),
+	}}, true)
+	// the test server is local
+	client.httpClient = server.Client()
+
+	for _, uri := range []string{
+		server.URL + "/token/1",
+		"ipfs://QmMetadata/1",
+		"data:application/json;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==",
+	} {
+		metadata, err := client.fetchMetadata(context.Background(), uri)
+		require.NoError(t, err)
+		require.Equal(t, "Token #1", metadata.Name)
+	}
+
+	_, err := client.fetchMetadata(context.Background(), server.URL+"/token/2")
+	require.Error(t, err)
+
+	_, err = client.fetchMetadata(context.Background(), "ftp://example.com/1")
+	require.ErrorIs(t, err, errUnsupportedURI)
+
+	_, err = client.fetchMetadata(context.Background(), "http://example.com/1")
+	require.ErrorIs(t, err, errUnsupportedURI)
+}
+
+func TestFetchMetadataPrivacy(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testMetadata))
+	}))
+	defer server.Close()
+
+	// local addresses are never reached
+	client := NewClient(nil, nil, true)
+	_, err := client.fetchMetadata(context.Background(), server.URL+"/token/1")
+	require.ErrorIs(t, err, errForbiddenAddress)
+
+	client = NewClient(nil, &testDownloader{content: map[string][]byte{
+		"QmMetadata/1": []byte(testMetadata),
+	}}, false)
+	client.httpClient = server.Client()
+	_, err = client.fetchMetadata(context.Background(), server.URL+"/token/1")
+	require.ErrorIs(t, err, errURIFetchingDisabled)
+
+	// on-chain data and IPFS content are still available
+	_, err = client.fetchMetadata(context.Background(), "ipfs://QmMetadata/1")
+	require.NoError(t, err)
+	_, err = client.fetchMetadata(context.Background(), "data:application/json;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==")
+	require.NoError(t, err)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "192.168.1.1", "172.16.0.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1"} {
+		require.False(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"1.1.1.1", "104.16.0.1", "2606:4700::1111"} {
+		require.True(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestMetadataToCollectibleData(t *testing.T) {
+	client := NewClient(nil, &testDownloader{content: map[string][]byte{
+		"QmMetadata/1": []byte(testMetadata),
+	}}, true)
+
+	metadata, err := client.fetchMetadata(context.Background(), "ipfs://QmMetadata/1")
+	require.NoError(t, err)
+
+	id := thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{
+			ChainID: walletCommon.ChainID(1),
+			Address: common.HexToAddress("0x1"),
+		},
+		TokenID: &bigint.BigInt{Int: big.NewInt(1)},
+	}
+
+	data := metadata.toCollectibleData(id, "ipfs://QmMetadata/1")
+	require.Equal(t, id, data.ID)
+	require.Equal(t, OnchainID, data.Provider)
+	require.Equal(t, "A token", data.Description)
+	require.Equal(t, toGatewayURL("ipfs://QmImage/1.png"), data.ImageURL)
+	require.Equal(t, "ffffff", data.BackgroundColor)
+	require.Equal(t, "ipfs://QmMetadata/1", data.TokenURI)
+	require.Equal(t, []thirdparty.CollectibleTrait{
+		{TraitType: "Color", Value: "Red"},
+		{TraitType: "Level", Value: "5", DisplayType: "number", MaxValue: "10"},
+		{TraitType: "Rare", Value: "true"},
+	}, data.Traits)
+
+	svg := Metadata{ImageData: "<svg></svg>"}
+	require.Equal(t, svgDataURIPrefix+"<svg></svg>", svg.imageURL())
+}
+
+func TestERC1155TokenURI(t *testing.T) {
+	tokenID := &bigint.BigInt{Int: big.NewInt(314592)}
+	require.Equal(t,
+		"https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json",
+		erc1155TokenURI("https://token-cdn-domain/{id}.json", tokenID),
+	)
+}
@@ -0,0 +1,100 @@
+package onchain
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+type AttributeValue string
+
+func (st *AttributeValue) UnmarshalJSON(b []byte) error {
+	var item interface{}
+	if err := json.Unmarshal(b, &item); err != nil {
+		return err
+	}
+
+	switch v := item.(type) {
+	case float64:
+		*st = AttributeValue(strconv.FormatFloat(v, 'f', -1, 64))
+	case string:
+		*st = AttributeValue(v)
+	case bool:
+		*st = AttributeValue(strconv.FormatBool(v))
+	}
+	return nil
+}
+
+type Attribute struct {
+	TraitType   string         `json:"trait_type"`
+	Value       AttributeValue `json:"value"`
+	DisplayType string         `json:"display_type"`
+	MaxValue    AttributeValue `json:"max_value"`
+}
+
+// Metadata is the token metadata JSON, as defined by ERC721/ERC1155 and extended by OpenSea
+type Metadata struct {
+	Name            string      `json:"name"`
+	Description     string      `json:"description"`
+	Image           string      `json:"image"`
+	ImageURL        string      `json:"image_url"`
+	ImageData       string      `json:"image_data"`
+	AnimationURL    string      `json:"animation_url"`
+	BackgroundColor string      `json:"background_color"`
+	Attributes      []Attribute `json:"attributes"`
+}
+
+func (m *Metadata) imageURL() string {
+	switch {
+	case m.Image != "":
+		return toGatewayURL(m.Image)
+	case m.ImageURL != "":
+		return toGatewayURL(m.ImageURL)
+	case m.ImageData != "":
+		// raw SVG content
+		return svgDataURIPrefix + m.ImageData
+	}
+	return ""
+}
+
+func (m *Metadata) toCollectibleTraits() []thirdparty.CollectibleTrait {
+	ret := make([]thirdparty.CollectibleTrait, 0, len(m.Attributes))
+	for _, attr := range m.Attributes {
+		ret = append(ret, thirdparty.CollectibleTrait{
+			TraitType:   attr.TraitType,
+			Value:       string(attr.Value),
+			DisplayType: attr.DisplayType,
+			MaxValue:    string(attr.MaxValue),
+		})
+	}
+	return ret
+}
+
+func (m *Metadata) toCollectibleData(id thirdparty.CollectibleUniqueID, tokenURI string) thirdparty.CollectibleData {
+	return thirdparty.CollectibleData{
+		ID:              id,
+		Provider:        OnchainID,
+		Name:            m.Name,
+		Description:     m.Description,
+		ImageURL:        m.imageURL(),
+		AnimationURL:    toGatewayURL(m.AnimationURL),
+		Traits:          m.toCollectibleTraits(),
+		BackgroundColor: strings.TrimPrefix(m.BackgroundColor, "#"),
+		TokenURI:        tokenURI,
+	}
+}
+
+// erc1155TokenURI substitutes the {id} placeholder of ERC1155 URIs with the lowercase, 64 hex characters token ID
+func erc1155TokenURI(uri string, tokenID *bigint.BigInt) string {
+	if tokenID == nil {
+		return uri
+	}
+	hexID := tokenID.Text(16)
+	if len(hexID) < 64 {
+		hexID = strings.Repeat("0", 64-len(hexID)) + hexID
+	}
+	return strings.ReplaceAll(uri, "{id}", hexID)
+}
@@ -0,0 +1,76 @@
+package onchain
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/status-im/status-go/params"
+)
+
+const (
+	ipfsScheme       = "ipfs://"
+	arweaveScheme    = "ar://"
+	dataScheme       = "data:"
+	arweaveGateway   = "https://arweave.net/"
+	svgDataURIPrefix = "data:image/svg+xml;utf8,"
+)
+
+var errUnsupportedURI = errors.New("unsupported uri")
+
+// ipfsPath returns the "<cid>/<path>" part of ipfs URIs, either ipfs:// or pointing to a gateway
+func ipfsPath(uri string) (string, bool) {
+	if strings.HasPrefix(uri, ipfsScheme) {
+		path := strings.TrimPrefix(uri, ipfsScheme)
+		path = strings.TrimPrefix(path, "ipfs/")
+		return path, path != ""
+	}
+
+	// i.e. https://<gateway>/ipfs/<cid>/<path>
+	parsed, err := url.Parse(uri)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", false
+	}
+	if idx := strings.Index(parsed.Path, "/ipfs/"); idx >= 0 {
+		path := parsed.Path[idx+len("/ipfs/"):]
+		return path, path != ""
+	}
+	return "", false
+}
+
+// toGatewayURL rewrites decentralized storage URIs to HTTP URLs that clients can load
+func toGatewayURL(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, ipfsScheme):
+		if path, ok := ipfsPath(uri); ok {
+			return params.IpfsGatewayURL + path
+		}
+	case strings.HasPrefix(uri, arweaveScheme):
+		return arweaveGateway + strings.TrimPrefix(uri, arweaveScheme)
+	}
+	return uri
+}
+
+// decodeDataURI returns the content of RFC 2397 data URIs
+func decodeDataURI(uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, dataScheme) {
+		return nil, errUnsupportedURI
+	}
+
+	header, data, found := strings.Cut(strings.TrimPrefix(uri, dataScheme), ",")
+	if !found {
+		return nil, errors.New("malformed data uri")
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+
+	unescaped, err := url.PathUnescape(data)
+	if err != nil {
+		// some contracts embed raw JSON containing '%'
+		return []byte(data), nil
+	}
+	return []byte(unescaped), nil
+}
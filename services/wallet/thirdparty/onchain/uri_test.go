@@ -0,0 +1,52 @@
+package onchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/params"
+)
+
+func TestIPFSPath(t *testing.T) {
+	path, ok := ipfsPath("ipfs://QmHash/1.json")
+	require.True(t, ok)
+	require.Equal(t, "QmHash/1.json", path)
+
+	path, ok = ipfsPath("ipfs://ipfs/QmHash")
+	require.True(t, ok)
+	require.Equal(t, "QmHash", path)
+
+	path, ok = ipfsPath("https://gateway.pinata.cloud/ipfs/QmHash/2")
+	require.True(t, ok)
+	require.Equal(t, "QmHash/2", path)
+
+	_, ok = ipfsPath("https://example.com/token/1")
+	require.False(t, ok)
+}
+
+func TestToGatewayURL(t *testing.T) {
+	require.Equal(t, params.IpfsGatewayURL+"QmHash/1.png", toGatewayURL("ipfs://QmHash/1.png"))
+	require.Equal(t, "https://arweave.net/abc", toGatewayURL("ar://abc"))
+	require.Equal(t, "https://example.com/1.png", toGatewayURL("https://example.com/1.png"))
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	content, err := decodeDataURI("data:application/json;base64,eyJuYW1lIjoiVG9rZW4ifQ==")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Token"}`, string(content))
+
+	content, err = decodeDataURI(`data:application/json,%7B%22name%22%3A%22Token%22%7D`)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Token"}`, string(content))
+
+	content, err = decodeDataURI(`data:application/json;utf8,{"name":"100%"}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"100%"}`, string(content))
+
+	_, err = decodeDataURI("data:application/json")
+	require.Error(t, err)
+
+	_, err = decodeDataURI("https://example.com")
+	require.ErrorIs(t, err, errUnsupportedURI)
+}
@@ -0,0 +1,82 @@
+package sourcify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	netUrl "net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const baseURL = "https://sourcify.dev/server"
+
+const notFoundCode = "not_found"
+
+type Client struct {
+	httpClient *thirdparty.HTTPClient
+	baseURL    string
+}
+
+func NewClient() *Client {
+	return &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		baseURL:    baseURL,
+	}
+}
+
+func (c *Client) ID() string {
+	return "sourcify"
+}
+
+type contractResponse struct {
+	Match       *string         `json:"match"`
+	ABI         json.RawMessage `json:"abi"`
+	Compilation struct {
+		Name string `json:"name"`
+	} `json:"compilation"`
+	CustomCode string `json:"customCode"`
+	Message    string `json:"message"`
+}
+
+func handleContractResponse(body []byte) (*thirdparty.VerifiedContract, error) {
+	var resp contractResponse
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.CustomCode == notFoundCode {
+		return nil, nil
+	}
+	if resp.CustomCode != "" {
+		if resp.Message == "" {
+			return nil, errors.New("unknown error")
+		}
+		return nil, errors.New(resp.Message)
+	}
+	// Contracts are returned without a match when only their bytecode is known
+	if resp.Match == nil || len(resp.ABI) == 0 {
+		return nil, nil
+	}
+
+	return &thirdparty.VerifiedContract{
+		Name: resp.Compilation.Name,
+		ABI:  string(resp.ABI),
+	}, nil
+}
+
+func (c *Client) FetchVerifiedContract(ctx context.Context, chainID uint64, address common.Address) (*thirdparty.VerifiedContract, error) {
+	url := fmt.Sprintf("%s/v2/contract/%d/%s", c.baseURL, chainID, address.Hex())
+	params := netUrl.Values{}
+	params.Set("fields", "abi,compilation.name")
+
+	body, err := c.httpClient.DoGetRequest(ctx, url, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return handleContractResponse(body)
+}
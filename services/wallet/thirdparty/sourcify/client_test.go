@@ -0,0 +1,62 @@
+package sourcify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleVerifiedContract(t *testing.T) {
+	data := []byte(`{
+		"match": "exact_match",
+		"chainId": "1",
+		"address": "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+		"abi": [{"name":"transfer","type":"function","inputs":[{"name":"dst","type":"address"},{"name":"wad","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}],
+		"compilation": {"name": "Dai"}
+	}`)
+
+	contract, err := handleContractResponse(data)
+	require.NoError(t, err)
+	require.NotNil(t, contract)
+	require.Equal(t, "Dai", contract.Name)
+	require.Contains(t, contract.ABI, `"name":"transfer"`)
+}
+
+func TestHandleNotVerifiedContract(t *testing.T) {
+	data := []byte(`{"customCode": "not_found", "message": "Contract 0x01 on chain 1 not found or not verified", "errorId": "1"}`)
+
+	contract, err := handleContractResponse(data)
+	require.NoError(t, err)
+	require.Nil(t, contract)
+}
+
+func TestHandleContractError(t *testing.T) {
+	data := []byte(`{"customCode": "invalid_parameter", "message": "Invalid chainId", "errorId": "1"}`)
+
+	_, err := handleContractResponse(data)
+	require.EqualError(t, err, "Invalid chainId")
+}
+
+func TestFetchVerifiedContract(t *testing.T) {
+	address := common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/contract/1/"+address.Hex(), r.URL.Path)
+		require.Equal(t, "abi,compilation.name", r.URL.Query().Get("fields"))
+		_, err := w.Write([]byte(`{"match": "match", "abi": [], "compilation": {"name": "Dai"}}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.baseURL = srv.URL
+
+	contract, err := client.FetchVerifiedContract(context.Background(), 1, address)
+	require.NoError(t, err)
+	require.Equal(t, "Dai", contract.Name)
+	require.Equal(t, "[]", contract.ABI)
+}
@@ -2,6 +2,12 @@ package thirdparty
 
 //go:generate mockgen -package=mock_thirdparty -source=types.go -destination=mock/types.go
 
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 type HistoricalPrice struct {
 	Timestamp int64   `json:"time"`
 	Value     float64 `json:"close"`
@@ -49,3 +55,16 @@ type DataParsed struct {
 type DecoderProvider interface {
 	Run(data string) (*DataParsed, error)
 }
+
+// VerifiedContract is the source verified contract metadata needed to decode its calls
+type VerifiedContract struct {
+	Name string `json:"name"`
+	// ABI is the JSON encoded contract ABI
+	ABI string `json:"abi"`
+}
+
+type VerifiedContractProvider interface {
+	ID() string
+	// FetchVerifiedContract returns nil if the contract is not verified
+	FetchVerifiedContract(ctx context.Context, chainID uint64, address common.Address) (*VerifiedContract, error)
+}
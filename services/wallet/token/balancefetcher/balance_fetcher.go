@@ -74,19 +74,32 @@ func (bf *DefaultBalanceFetcher) fetchBalancesForChain(parent context.Context, c
 
 	ethScanContract, availableAtBlock, err := bf.contractMaker.NewEthScan(client.NetworkID())
 	if err != nil {
-		return nil, errors.Wrap(err, errScanningContract.Error())
+		if !errors.Is(err, ethscan.ErrorNotAvailableOnChainID) {
+			return nil, errors.Wrap(err, errScanningContract.Error())
+		}
+		// The balances are fetched one by one on the chains without the scan contract, e.g. the networks added by the user
+		ethScanContract = nil
 	}
 
 	fetchChainBalance := false
+	erc20Tokens := make([]common.Address, 0, len(tokens))
 
 	for _, token := range tokens {
 		if token == NativeChainAddress {
 			fetchChainBalance = true
+		} else {
+			erc20Tokens = append(erc20Tokens, token)
 		}
 	}
 	if fetchChainBalance {
 		group.Add(func(parent context.Context) error {
-			balances, err := bf.FetchChainBalances(parent, accounts, ethScanContract, atBlock)
+			var balances map[common.Address]map[common.Address]*hexutil.Big
+			var err error
+			if ethScanContract != nil {
+				balances, err = bf.FetchChainBalances(parent, accounts, ethScanContract, atBlock)
+			} else {
+				balances, err = bf.fetchChainBalancesWithClient(parent, client, accounts, atBlock)
+			}
 			if err != nil {
 				return err
 			}
@@ -96,7 +109,7 @@ func (bf *DefaultBalanceFetcher) fetchBalancesForChain(parent context.Context, c
 		})
 	}
 
-	tokenChunks := splitTokensToChunks(tokens, tokenChunkSize)
+	tokenChunks := splitTokensToChunks(erc20Tokens, tokenChunkSize)
 	for accountIdx := range accounts {
 		// Keep the reference to the account. DO NOT USE A LOOP, the account will be overridden in the coroutine
 		account := accounts[accountIdx]
@@ -110,7 +123,7 @@ func (bf *DefaultBalanceFetcher) fetchBalancesForChain(parent context.Context, c
 
 				var accTokenBalance map[common.Address]map[common.Address]*hexutil.Big
 				var err error
-				if atBlock == nil || big.NewInt(int64(availableAtBlock)).Cmp(atBlock) < 0 {
+				if ethScanContract != nil && (atBlock == nil || big.NewInt(int64(availableAtBlock)).Cmp(atBlock) < 0) {
 					accTokenBalance, err = bf.FetchTokenBalancesWithScanContract(ctx, ethScanContract, account, chunk, atBlock)
 				} else {
 					accTokenBalance, err = bf.fetchTokenBalancesWithTokenContracts(ctx, client, account, chunk, atBlock)
@@ -162,6 +175,27 @@ func (bf *DefaultBalanceFetcher) FetchChainBalances(parent context.Context, acco
 	return accTokenBalance, nil
 }
 
+func (bf *DefaultBalanceFetcher) fetchChainBalancesWithClient(parent context.Context, client chain.ClientInterface, accounts []common.Address, atBlock *big.Int) (map[common.Address]map[common.Address]*hexutil.Big, error) {
+	accTokenBalance := make(map[common.Address]map[common.Address]*hexutil.Big)
+
+	ctx, cancel := context.WithTimeout(parent, requestTimeout)
+	defer cancel()
+
+	for _, account := range accounts {
+		balance, err := client.BalanceAt(ctx, account, atBlock)
+		if err != nil {
+			logutils.ZapLogger().Error("can't fetch chain balance", zap.Stringer("account", account), zap.Error(err))
+			return nil, err
+		}
+
+		accTokenBalance[account] = map[common.Address]*hexutil.Big{
+			NativeChainAddress: (*hexutil.Big)(balance),
+		}
+	}
+
+	return accTokenBalance, nil
+}
+
 func (bf *DefaultBalanceFetcher) FetchTokenBalancesWithScanContract(ctx context.Context, ethScanContract ethscan.BalanceScannerIface, account common.Address, chunk []common.Address, atBlock *big.Int) (map[common.Address]map[common.Address]*hexutil.Big, error) {
 	accTokenBalance := make(map[common.Address]map[common.Address]*hexutil.Big)
 	res, err := ethScanContract.TokensBalance(&bind.CallOpts{
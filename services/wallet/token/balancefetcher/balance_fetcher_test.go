@@ -266,6 +266,45 @@ func TestBalanceFetcherFetchBalancesForChainTokensWithTokenContracts(t *testing.
 	require.Equal(t, expectedBalances, balances)
 }
 
+func TestBalanceFetcherFetchBalancesForChainWithoutScanContract(t *testing.T) {
+	ctx := context.Background()
+	accounts := []common.Address{
+		common.HexToAddress("0x1234567890abcdef"),
+		common.HexToAddress("0xabcdef1234567890"),
+	}
+	token := common.HexToAddress("0x0987654321fedcba")
+	const chainID = uint64(777)
+
+	ctrl := gomock.NewController(t)
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	chainClient.EXPECT().NetworkID().Return(chainID).AnyTimes()
+	chainClient.EXPECT().BalanceAt(gomock.Any(), accounts[0], gomock.Nil()).Return(big.NewInt(100), nil)
+	chainClient.EXPECT().BalanceAt(gomock.Any(), accounts[1], gomock.Nil()).Return(big.NewInt(200), nil)
+
+	contractMaker := mock_contracts.NewMockContractMakerIface(ctrl)
+	contractMaker.EXPECT().NewEthScan(chainID).Return(nil, uint(0), ethscan.ErrorNotAvailableOnChainID)
+	contractMaker.EXPECT().NewERC20Caller(chainID, token).Return(&FakeERC20Caller{
+		accountBalances: map[common.Address]*big.Int{
+			accounts[0]: big.NewInt(1000),
+			accounts[1]: big.NewInt(2000),
+		},
+	}, nil).AnyTimes()
+	bf := NewDefaultBalanceFetcher(contractMaker)
+
+	balances, err := bf.fetchBalancesForChain(ctx, chainClient, accounts, []common.Address{NativeChainAddress, token}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[common.Address]map[common.Address]*hexutil.Big{
+		accounts[0]: {
+			NativeChainAddress: (*hexutil.Big)(big.NewInt(100)),
+			token:              (*hexutil.Big)(big.NewInt(1000)),
+		},
+		accounts[1]: {
+			NativeChainAddress: (*hexutil.Big)(big.NewInt(200)),
+			token:              (*hexutil.Big)(big.NewInt(2000)),
+		},
+	}, balances)
+}
+
 func TestBalanceFetcherGetBalancesAtByChain(t *testing.T) {
 	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stdout, log.TerminalFormat(true))))
 
@@ -42,6 +42,8 @@ import (
 
 const (
 	EventCommunityTokenReceived walletevent.EventType = "wallet-community-token-received"
+	// EventInternalBalancesUpdated carries the freshly fetched balances (map[common.Address][]StorageToken) as EventParams
+	EventInternalBalancesUpdated walletevent.EventType = walletevent.InternalEventTypePrefix + "wallet-balances-updated"
 )
 
 type Token struct {
@@ -530,6 +532,31 @@ func (tm *Manager) GetTokens(chainID uint64) ([]*Token, error) {
 	return res, nil
 }
 
+// HasListedTokens returns true when the token lists have tokens of the chain, the chain may not be added yet
+func (tm *Manager) HasListedTokens(chainID uint64) bool {
+	for _, store := range tm.stores {
+		for _, token := range store.GetTokens() {
+			if token.ChainID == chainID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CoveredByTokenLists returns false when the probe of the network found no token of the token lists, the tokens
+// of the chain are then only known from the transfers of the accounts
+func (tm *Manager) CoveredByTokenLists(chainID uint64) bool {
+	if tm.networkManager == nil {
+		return true
+	}
+	network := tm.networkManager.Find(chainID)
+	if network == nil || network.Capabilities == nil {
+		return true
+	}
+	return network.Capabilities.TokenLists
+}
+
 func (tm *Manager) GetTokensByChainIDs(chainIDs []uint64) ([]*Token, error) {
 	tokens, err := tm.GetAllTokens()
 	if err != nil {
@@ -437,3 +437,25 @@ func Test_tokensListsValidity(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenListsOfAddedNetworks(t *testing.T) {
+	appDB, err := helpers.SetupTestMemorySQLDB(appdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	nm := network.NewManager(appDB)
+	require.NoError(t, nm.Upsert(&params.Network{ChainID: 777, Capabilities: &params.NetworkCapabilities{}}))
+	require.NoError(t, nm.Upsert(&params.Network{ChainID: 778, Capabilities: &params.NetworkCapabilities{TokenLists: true}}))
+
+	manager := &Manager{
+		networkManager: nm,
+		stores:         []store{&DefaultStore{[]*Token{{Address: common.Address{1}, Symbol: "TT", ChainID: 778}}}},
+	}
+
+	require.True(t, manager.HasListedTokens(778))
+	require.False(t, manager.HasListedTokens(777))
+
+	require.True(t, manager.CoveredByTokenLists(778))
+	require.False(t, manager.CoveredByTokenLists(777))
+	// The networks without capabilities are assumed to be covered
+	require.True(t, manager.CoveredByTokenLists(1))
+}
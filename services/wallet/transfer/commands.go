@@ -34,6 +34,9 @@ const (
 	EventFetchingHistoryError walletevent.EventType = "fetching-history-error"
 	// EventNonArchivalNodeDetected emitted when a connection to a non archival node is detected
 	EventNonArchivalNodeDetected walletevent.EventType = "non-archival-node-detected"
+	// EventChainReorganized emitted when transfers of the accounts were removed by a reorganization of the chain,
+	// BlockNumber is the last block in common with the new chain
+	EventChainReorganized walletevent.EventType = "chain-reorganized"
 
 	// Internal events emitted when different kinds of transfers are detected
 	EventInternalETHTransferDetected     walletevent.EventType = walletevent.InternalEventTypePrefix + "eth-transfer-detected"
@@ -422,7 +425,8 @@ func (c *transfersCommand) processUnknownErc20CommunityTransactions(ctx context.
 			token := c.tokenManager.FindOrCreateTokenByAddress(ctx, tx.NetworkID, *tx.Transaction.To())
 			if token != nil {
 				isFirst := false
-				if token.Verified || token.CommunityData != nil {
+				// The tokens of the chains without token lists are only discovered from the transfers
+				if token.Verified || token.CommunityData != nil || !c.tokenManager.CoveredByTokenLists(tx.NetworkID) {
 					isFirst, _ = c.tokenManager.MarkAsPreviouslyOwnedToken(token, tx.Address)
 				}
 				if token.CommunityData != nil {
@@ -2,23 +2,28 @@ package transfer
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/contracts"
+	"github.com/status-im/status-go/contracts/balancechecker"
 	nodetypes "github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/rpc/chain"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
+	"github.com/status-im/status-go/rpc/subscriptions"
 	"github.com/status-im/status-go/services/wallet/async"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
@@ -51,20 +56,26 @@ type findNewBlocksCommand struct {
 	lastNonces                   map[common.Address]nonceInfo
 	nonceCheckIntervalIterations int
 	logsCheckIntervalIterations  int
+	trigger                      <-chan struct{} // runs the command before the interval, nil when only polling
+	pendingTxManager             *transactions.PendingTxTracker
 }
 
 func (c *findNewBlocksCommand) Command() async.Command {
-	return async.InfiniteCommand{
+	return async.TriggeredCommand{
 		Interval: 2 * time.Minute,
+		Trigger:  c.trigger,
 		Runable:  c.Run,
 	}.Run
 }
 
 var requestTimeout = 20 * time.Second
 
-func (c *findNewBlocksCommand) detectTransfers(parent context.Context, accounts []common.Address) (*big.Int, []common.Address, error) {
+func (c *findNewBlocksCommand) balancesHashes(parent context.Context) (*big.Int, [][32]byte, error) {
 	bc, err := c.contractMaker.NewBalanceChecker(c.chainClient.NetworkID())
 	if err != nil {
+		if errors.Is(err, balancechecker.ErrorNotAvailableOnChainID) {
+			return c.nativeBalancesHashes(parent)
+		}
 		logutils.ZapLogger().Error("findNewBlocksCommand error creating balance checker", zap.Uint64("chain", c.chainClient.NetworkID()), zap.Error(err))
 		return nil, nil, err
 	}
@@ -89,6 +100,40 @@ func (c *findNewBlocksCommand) detectTransfers(parent context.Context, accounts
 		logutils.ZapLogger().Error("findNewBlocksCommand can't get balances hashes", zap.Error(err))
 		return nil, nil, err
 	}
+	return blockNum, hashes, nil
+}
+
+// nativeBalancesHashes hashes the native balances and the nonces of the accounts on the chains without the balance
+// checker, e.g. the networks added by the user. The token transfers are found by the logs checks.
+func (c *findNewBlocksCommand) nativeBalancesHashes(parent context.Context) (*big.Int, [][32]byte, error) {
+	ctx, cancel := context.WithTimeout(parent, requestTimeout)
+	defer cancel()
+
+	header, err := c.chainClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([][32]byte, 0, len(c.accounts))
+	for _, account := range c.accounts {
+		balance, err := c.chainClient.BalanceAt(ctx, account, header.Number)
+		if err != nil {
+			return nil, nil, err
+		}
+		nonce, err := c.chainClient.NonceAt(ctx, account, header.Number)
+		if err != nil {
+			return nil, nil, err
+		}
+		hashes = append(hashes, crypto.Keccak256Hash(common.BigToHash(balance).Bytes(), new(big.Int).SetUint64(nonce).Bytes()))
+	}
+	return header.Number, hashes, nil
+}
+
+func (c *findNewBlocksCommand) detectTransfers(parent context.Context, accounts []common.Address) (*big.Int, []common.Address, error) {
+	blockNum, hashes, err := c.balancesHashes(parent)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	addressesToCheck := []common.Address{}
 	for idx, account := range accounts {
@@ -238,6 +283,15 @@ func (c *findNewBlocksCommand) Run(parent context.Context) error {
 		return nil
 	}
 
+	err = c.detectReorg(parent)
+	if err != nil {
+		logutils.ZapLogger().Error("findNewBlocksCommand error on reorg detection",
+			zap.Uint64("chain", c.chainClient.NetworkID()),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	headNum, accountsWithDetectedChanges, err := c.detectTransfers(parent, accountsToCheck)
 	if err != nil {
 		logutils.ZapLogger().Error("findNewBlocksCommand error on transfer detection",
@@ -300,6 +354,16 @@ func (c *findNewBlocksCommand) Run(parent context.Context) error {
 	c.fromBlockNumber = headNum
 	c.iteration++
 
+	ctx, cancel := context.WithTimeout(parent, requestTimeout)
+	defer cancel()
+	err = c.saveCheckpoint(ctx, headNum)
+	if err != nil {
+		logutils.ZapLogger().Error("findNewBlocksCommand can't save checked block hash",
+			zap.Uint64("chain", c.chainClient.NetworkID()),
+			zap.Error(err),
+		)
+	}
+
 	return nil
 }
 
@@ -1092,7 +1156,7 @@ func newLoadBlocksAndTransfersCommand(accounts []common.Address, db *Database, a
 	blockDAO *BlockDAO, blockRangesSeqDAO BlockRangeDAOer, chainClient chain.ClientInterface, feed *event.Feed,
 	pendingTxManager *transactions.PendingTxTracker,
 	tokenManager *token.Manager, balanceCacher balance.Cacher, omitHistory bool,
-	blockChainState *blockchainstate.BlockChainState) *loadBlocksAndTransfersCommand {
+	blockChainState *blockchainstate.BlockChainState, subscriptions *subscriptions.Manager) *loadBlocksAndTransfersCommand {
 
 	return &loadBlocksAndTransfersCommand{
 		accounts:         accounts,
@@ -1109,6 +1173,7 @@ func newLoadBlocksAndTransfersCommand(accounts []common.Address, db *Database, a
 		omitHistory:      omitHistory,
 		contractMaker:    tokenManager.ContractMaker,
 		blockChainState:  blockChainState,
+		subscriptions:    subscriptions,
 	}
 }
 
@@ -1128,6 +1193,7 @@ type loadBlocksAndTransfersCommand struct {
 	omitHistory      bool
 	contractMaker    *contracts.ContractMaker
 	blockChainState  *blockchainstate.BlockChainState
+	subscriptions    *subscriptions.Manager // nil when only polling
 
 	// Not to be set by the caller
 	transfersLoaded map[common.Address]bool // For event RecentHistoryReady to be sent only once per account during app lifetime
@@ -1369,6 +1435,7 @@ func (c *loadBlocksAndTransfersCommand) startFetchingNewBlocks(ctx context.Conte
 			c.decLoops()
 		}()
 
+		trigger := make(chan struct{}, 1)
 		newBlocksCmd := &findNewBlocksCommand{
 			findBlocksCommand: &findBlocksCommand{
 				accounts:                  addresses,
@@ -1388,10 +1455,16 @@ func (c *loadBlocksAndTransfersCommand) startFetchingNewBlocks(ctx context.Conte
 			blockChainState:              c.blockChainState,
 			nonceCheckIntervalIterations: nonceCheckIntervalIterations,
 			logsCheckIntervalIterations:  logsCheckIntervalIterations,
+			trigger:                      trigger,
+			pendingTxManager:             c.pendingTxManager,
 		}
 		group := async.NewGroup(ctx)
 		group.Add(newBlocksCmd.Command())
 
+		if c.subscriptions != nil {
+			c.subscribeNewBlocks(ctx, addresses, trigger)
+		}
+
 		// No need to wait for the group since it is infinite
 		<-ctx.Done()
 
@@ -1403,6 +1476,52 @@ func (c *loadBlocksAndTransfersCommand) startFetchingNewBlocks(ctx context.Conte
 	}()
 }
 
+// subscribeNewBlocks follows the blocks pushed by the WebSocket providers of the chain until the context is done.
+// The new heads update the chain state and the token transfers of the accounts trigger the detection of the new
+// blocks right away, ETH transfers are only detected at the interval. The heads replacing blocks and the removed
+// logs trigger the detection of the reorganization.
+func (c *loadBlocksAndTransfersCommand) subscribeNewBlocks(ctx context.Context, addresses []common.Address, trigger chan<- struct{}) {
+	chainID := c.chainClient.NetworkID()
+	heads := make(chan *types.Header, 1)
+	logs := make(chan types.Log, 16)
+
+	downloader := NewERC20TransfersDownloader(c.chainClient, addresses, nil, false)
+	subs := []event.Subscription{
+		c.subscriptions.SubscribeNewHeads(chainID, heads),
+		c.subscriptions.SubscribeFilterLogs(chainID, ethereum.FilterQuery{Topics: downloader.outboundTopics(addresses)}, logs),
+		c.subscriptions.SubscribeFilterLogs(chainID, ethereum.FilterQuery{Topics: downloader.inboundERC20OutboundERC1155Topics(addresses)}, logs),
+		c.subscriptions.SubscribeFilterLogs(chainID, ethereum.FilterQuery{Topics: downloader.inboundTopicsERC1155(addresses)}, logs),
+	}
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+
+	notify := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+
+	var lastHead *types.Header
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case head := <-heads:
+			c.blockChainState.SetLastBlockNumber(chainID, head.Number.Uint64())
+			if isReorg(lastHead, head) {
+				notify()
+			}
+			lastHead = head
+		case <-logs:
+			notify()
+		}
+	}
+}
+
 func (c *loadBlocksAndTransfersCommand) getBlocksToLoad() (map[common.Address][]*big.Int, error) {
 	blocksMap := make(map[common.Address][]*big.Int)
 	for _, account := range c.accounts {
@@ -36,6 +36,7 @@ import (
 	multicommon "github.com/status-im/status-go/multiaccounts/common"
 	"github.com/status-im/status-go/params"
 	statusRpc "github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/chain"
 	ethclient "github.com/status-im/status-go/rpc/chain/ethclient"
 	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
@@ -732,6 +733,13 @@ func (tc *TestClient) SetLimiter(limiter rpclimiter.RequestLimiter) {
 	tc.limiter = limiter
 }
 
+func (tc *TestClient) TrustedHeaders() chain.HeaderSource {
+	if tc.traceAPICalls {
+		tc.t.Log("TrustedHeaders")
+	}
+	return nil
+}
+
 func (tc *TestClient) Close() {
 	if tc.traceAPICalls {
 		tc.t.Log("Close")
@@ -1936,3 +1944,41 @@ func TestTransfersCommand_RetryAndQuitOnMaxError(t *testing.T) {
 		require.Error(t, expectedErr, errorCounter.Error())
 	}
 }
+
+func TestFindNewBlocksCommand_nativeBalancesHashes(t *testing.T) {
+	account := common.Address{1}
+	tc := &TestClient{
+		t:            t,
+		callsCounter: map[string]int{},
+		currentBlock: 10,
+		balanceHistory: map[common.Address]map[uint64]*big.Int{
+			account: {10: big.NewInt(100), 11: big.NewInt(100), 12: big.NewInt(100)},
+		},
+		nonceHistory: map[common.Address]map[uint64]uint64{
+			account: {10: 1, 11: 1, 12: 2},
+		},
+	}
+	cmd := &findNewBlocksCommand{
+		findBlocksCommand: &findBlocksCommand{
+			accounts:    []common.Address{account},
+			chainClient: tc,
+		},
+	}
+
+	blockNum, hashes, err := cmd.nativeBalancesHashes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(10), blockNum.Int64())
+	require.Len(t, hashes, 1)
+
+	// Unchanged
+	tc.currentBlock = 11
+	_, next, err := cmd.nativeBalancesHashes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, hashes, next)
+
+	// Only the nonce changed
+	tc.currentBlock = 12
+	_, next, err = cmd.nativeBalancesHashes(context.Background())
+	require.NoError(t, err)
+	require.NotEqual(t, hashes, next)
+}
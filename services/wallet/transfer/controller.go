@@ -126,7 +126,7 @@ func (c *Controller) CheckRecentHistory(chainIDs []uint64, accounts []common.Add
 
 	const omitHistory = true
 	c.reactor = NewReactor(c.db, c.blockDAO, c.blockRangesSeqDAO, c.accountsDB, c.TransferFeed, c.transactionManager,
-		c.pendingTxManager, c.tokenManager, c.balanceCacher, omitHistory, c.blockChainState)
+		c.pendingTxManager, c.tokenManager, c.balanceCacher, omitHistory, c.blockChainState, c.rpcClient.Subscriptions())
 
 	err = c.reactor.start(chainClients, accounts)
 	if err != nil {
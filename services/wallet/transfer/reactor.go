@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/rpc/chain"
+	"github.com/status-im/status-go/rpc/subscriptions"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
 	"github.com/status-im/status-go/services/wallet/token"
@@ -59,12 +60,14 @@ type Reactor struct {
 	balanceCacher     balance.Cacher
 	omitHistory       bool
 	blockChainState   *blockchainstate.BlockChainState
+	subscriptions     *subscriptions.Manager
 	chainIDs          []uint64
 }
 
 func NewReactor(db *Database, blockDAO *BlockDAO, blockRangesSeqDAO *BlockRangeSequentialDAO, accountsDB *accounts.Database, feed *event.Feed, tm *TransactionManager,
 	pendingTxManager *transactions.PendingTxTracker, tokenManager *token.Manager,
-	balanceCacher balance.Cacher, omitHistory bool, blockChainState *blockchainstate.BlockChainState) *Reactor {
+	balanceCacher balance.Cacher, omitHistory bool, blockChainState *blockchainstate.BlockChainState,
+	subscriptions *subscriptions.Manager) *Reactor {
 	return &Reactor{
 		db:                db,
 		accountsDB:        accountsDB,
@@ -76,6 +79,7 @@ func NewReactor(db *Database, blockDAO *BlockDAO, blockRangesSeqDAO *BlockRangeS
 		balanceCacher:     balanceCacher,
 		omitHistory:       omitHistory,
 		blockChainState:   blockChainState,
+		subscriptions:     subscriptions,
 	}
 }
 
@@ -119,6 +123,7 @@ func (r *Reactor) createFetchStrategy(chainClients map[uint64]chain.ClientInterf
 		r.balanceCacher,
 		r.omitHistory,
 		r.blockChainState,
+		r.subscriptions,
 	)
 }
 
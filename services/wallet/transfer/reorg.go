@@ -0,0 +1,377 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/transactions"
+)
+
+// reorgCheckpointsLimit is the number of hashes of checked blocks kept per chain to find the last block in common
+// with a new chain
+const reorgCheckpointsLimit = 64
+
+// blockCheckpoint is the hash of the last block of a range checked for new transfers
+type blockCheckpoint struct {
+	Number *big.Int
+	Hash   common.Hash
+}
+
+// droppedTransfer is a transfer of a multi-transaction sent by the account whose block is no longer in the chain
+type droppedTransfer struct {
+	TxHash             common.Hash
+	Address            common.Address
+	Transaction        *types.Transaction
+	Timestamp          uint64
+	MultiTransactionID w_common.MultiTransactionIDType
+}
+
+func (db *Database) saveBlockCheckpoint(chainID uint64, number *big.Int, hash common.Hash) (err error) {
+	tx, err := db.client.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO blocks_ranges_hashes (network_id, blk_number, blk_hash) VALUES (?, ?, ?)`,
+		chainID, (*bigint.SQLBigInt)(number), hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM blocks_ranges_hashes WHERE network_id = ? AND blk_number NOT IN
+		(SELECT blk_number FROM blocks_ranges_hashes WHERE network_id = ? ORDER BY blk_number DESC LIMIT ?)`,
+		chainID, chainID, reorgCheckpointsLimit)
+	return err
+}
+
+// getBlockCheckpoints returns the checkpoints of the chain, the newest first
+func (db *Database) getBlockCheckpoints(chainID uint64) ([]blockCheckpoint, error) {
+	rows, err := db.client.Query(`SELECT blk_number, blk_hash FROM blocks_ranges_hashes WHERE network_id = ? ORDER BY blk_number DESC`, chainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []blockCheckpoint
+	for rows.Next() {
+		checkpoint := blockCheckpoint{Number: new(big.Int)}
+		err = rows.Scan((*bigint.SQLBigInt)(checkpoint.Number), &checkpoint.Hash)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints, rows.Err()
+}
+
+// getBlockHashesAfter returns the hashes of the blocks of the chain stored after the block number, by number
+func (db *Database) getBlockHashesAfter(chainID uint64, number *big.Int) (map[uint64][]common.Hash, error) {
+	rows, err := db.client.Query(`SELECT DISTINCT blk_number, blk_hash FROM blocks WHERE network_id = ? AND blk_number > ?`,
+		chainID, (*bigint.SQLBigInt)(number))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[uint64][]common.Hash)
+	for rows.Next() {
+		blockNumber := new(big.Int)
+		var hash common.Hash
+		err = rows.Scan((*bigint.SQLBigInt)(blockNumber), &hash)
+		if err != nil {
+			return nil, err
+		}
+		hashes[blockNumber.Uint64()] = append(hashes[blockNumber.Uint64()], hash)
+	}
+	return hashes, rows.Err()
+}
+
+// rollbackReorganizedBlocks removes the blocks no longer in the chain with their transfers and moves the checked
+// ranges back to the last block in common with the new chain, so that the blocks after it are checked again.
+// It returns the accounts which had transfers in the removed blocks and the transfers of the multi-transactions sent
+// by the accounts, which may be included again in the new chain.
+func (db *Database) rollbackReorganizedBlocks(chainID uint64, lastCommonBlock *big.Int, hashes []common.Hash) (accounts []common.Address, dropped []droppedTransfer, err error) {
+	tx, err := db.client.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	seenAccounts := make(map[common.Address]bool)
+	seenTxs := make(map[common.Hash]bool)
+	for _, hash := range hashes {
+		blockAccounts, err := getBlockAccounts(tx, chainID, hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, account := range blockAccounts {
+			if !seenAccounts[account] {
+				seenAccounts[account] = true
+				accounts = append(accounts, account)
+			}
+		}
+
+		blockDropped, err := getDroppedMultiTransactionTransfers(tx, chainID, hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		// A transaction can have several transfers, e.g. a swap
+		for _, transfer := range blockDropped {
+			if !seenTxs[transfer.TxHash] {
+				seenTxs[transfer.TxHash] = true
+				dropped = append(dropped, transfer)
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM transfers WHERE network_id = ? AND blk_hash = ?`, chainID, hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, err = tx.Exec(`DELETE FROM blocks WHERE network_id = ? AND blk_hash = ?`, chainID, hash)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	number := (*bigint.SQLBigInt)(lastCommonBlock)
+	// The balances are checked again, the first known blocks are kept as the ranges are not checked before them
+	_, err = tx.Exec(`UPDATE blocks_ranges_sequential SET blk_last = MAX(blk_first, ?), balance_check_hash = ''
+		WHERE network_id = ? AND blk_last > ?`, number, chainID, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = tx.Exec(`UPDATE blocks_ranges_sequential SET token_blk_last = MAX(COALESCE(token_blk_first, 0), ?), balance_check_hash = ''
+		WHERE network_id = ? AND token_blk_last > ?`, number, chainID, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM blocks_ranges_hashes WHERE network_id = ? AND blk_number > ?`, chainID, number)
+	return accounts, dropped, err
+}
+
+func getBlockAccounts(tx *sql.Tx, chainID uint64, hash common.Hash) ([]common.Address, error) {
+	rows, err := tx.Query(`SELECT DISTINCT address FROM blocks WHERE network_id = ? AND blk_hash = ?`, chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []common.Address
+	for rows.Next() {
+		var account common.Address
+		if err = rows.Scan(&account); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func getDroppedMultiTransactionTransfers(tx *sql.Tx, chainID uint64, hash common.Hash) ([]droppedTransfer, error) {
+	rows, err := tx.Query(`SELECT tx_hash, address, tx, timestamp, multi_transaction_id FROM transfers
+		WHERE network_id = ? AND blk_hash = ? AND multi_transaction_id > 0 AND tx_from_address = address AND tx_hash IS NOT NULL AND tx IS NOT NULL`,
+		chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dropped []droppedTransfer
+	for rows.Next() {
+		transfer := droppedTransfer{Transaction: &types.Transaction{}}
+		err = rows.Scan(&transfer.TxHash, &transfer.Address, &JSONBlob{transfer.Transaction}, &transfer.Timestamp, &transfer.MultiTransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Transaction.To() == nil {
+			// Contract deployments are not tracked
+			continue
+		}
+		dropped = append(dropped, transfer)
+	}
+	return dropped, rows.Err()
+}
+
+// detectReorg compares the hashes of the last checked blocks with the chain. When the chain was reorganized, the
+// transfers of the blocks no longer in the chain are removed and the blocks after the last one in common are
+// checked again. When the chain doesn't return a block, as a lagging provider would, nothing is compared and the
+// detection is retried on the next iteration.
+func (c *findNewBlocksCommand) detectReorg(parent context.Context) error {
+	chainID := c.chainClient.NetworkID()
+	checkpoints, err := c.db.getBlockCheckpoints(chainID)
+	if err != nil || len(checkpoints) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(parent, requestTimeout)
+	defer cancel()
+
+	var lastCommonBlock *big.Int
+	for i, checkpoint := range checkpoints {
+		hash, known, err := c.canonicalHash(ctx, checkpoint.Number)
+		if err != nil || !known {
+			return err
+		}
+		if hash == checkpoint.Hash {
+			if i == 0 {
+				return nil
+			}
+			lastCommonBlock = checkpoint.Number
+			break
+		}
+	}
+	if lastCommonBlock == nil {
+		oldest := checkpoints[len(checkpoints)-1].Number
+		logutils.ZapLogger().Warn("reorganization deeper than the checked blocks kept",
+			zap.Uint64("chain", chainID),
+			zap.Stringer("oldest", oldest),
+		)
+		lastCommonBlock = new(big.Int).Sub(oldest, big.NewInt(1))
+	}
+
+	blockHashes, err := c.db.getBlockHashesAfter(chainID, lastCommonBlock)
+	if err != nil {
+		return err
+	}
+	var reorganized []common.Hash
+	for number, hashes := range blockHashes {
+		hash, known, err := c.canonicalHash(ctx, new(big.Int).SetUint64(number))
+		if err != nil || !known {
+			return err
+		}
+		for _, h := range hashes {
+			if h != hash {
+				reorganized = append(reorganized, h)
+			}
+		}
+	}
+
+	logutils.ZapLogger().Info("chain reorganization detected",
+		zap.Uint64("chain", chainID),
+		zap.Stringer("lastCommonBlock", lastCommonBlock),
+		zap.Int("removedBlocks", len(reorganized)),
+	)
+
+	accounts, dropped, err := c.db.rollbackReorganizedBlocks(chainID, lastCommonBlock, reorganized)
+	if err != nil {
+		return err
+	}
+
+	if c.fromBlockNumber != nil && c.fromBlockNumber.Cmp(lastCommonBlock) > 0 {
+		c.fromBlockNumber = new(big.Int).Set(lastCommonBlock)
+	}
+	if c.logsCheckLastKnownBlock != nil && c.logsCheckLastKnownBlock.Cmp(lastCommonBlock) > 0 {
+		c.logsCheckLastKnownBlock = new(big.Int).Set(lastCommonBlock)
+	}
+	// The balances and nonces are cached by block number
+	c.balanceCacher.Clear()
+
+	c.trackDroppedTransfers(chainID, dropped)
+
+	if len(accounts) > 0 && c.feed != nil {
+		c.feed.Send(walletevent.Event{
+			Type:        EventChainReorganized,
+			Accounts:    accounts,
+			ChainID:     chainID,
+			BlockNumber: lastCommonBlock,
+		})
+	}
+	return nil
+}
+
+// canonicalHash returns the hash of the block of the chain, known is false when the chain doesn't have it
+func (c *findNewBlocksCommand) canonicalHash(ctx context.Context, number *big.Int) (hash common.Hash, known bool, err error) {
+	header, err := c.chainClient.HeaderByNumber(ctx, number)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && header == nil) {
+		logutils.ZapLogger().Debug("block not found, reorganization check skipped",
+			zap.Uint64("chain", c.chainClient.NetworkID()),
+			zap.Stringer("block", number),
+		)
+		return common.Hash{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	return header.Hash(), true, nil
+}
+
+// saveCheckpoint keeps the hash of the last checked block
+func (c *findNewBlocksCommand) saveCheckpoint(ctx context.Context, number *big.Int) error {
+	hash, known, err := c.canonicalHash(ctx, number)
+	if err != nil || !known {
+		return err
+	}
+	return c.db.saveBlockCheckpoint(c.chainClient.NetworkID(), number, hash)
+}
+
+// trackDroppedTransfers tracks the transactions of the dropped transfers as pending again, their multi-transactions
+// are pending until the transactions are included in the new chain
+func (c *findNewBlocksCommand) trackDroppedTransfers(chainID uint64, dropped []droppedTransfer) {
+	if c.pendingTxManager == nil {
+		return
+	}
+
+	for _, transfer := range dropped {
+		autoDelete := false
+		err := c.pendingTxManager.StoreAndTrackPendingTx(&transactions.PendingTransaction{
+			Hash:               transfer.TxHash,
+			Timestamp:          transfer.Timestamp,
+			Value:              bigint.BigInt{Int: transfer.Transaction.Value()},
+			From:               transfer.Address,
+			To:                 *transfer.Transaction.To(),
+			Data:               string(transfer.Transaction.Data()),
+			GasPrice:           bigint.BigInt{Int: transfer.Transaction.GasPrice()},
+			GasLimit:           bigint.BigInt{Int: new(big.Int).SetUint64(transfer.Transaction.Gas())},
+			Nonce:              transfer.Transaction.Nonce(),
+			Type:               transactions.WalletTransfer,
+			ChainID:            w_common.ChainID(chainID),
+			MultiTransactionID: transfer.MultiTransactionID,
+			// Deleted once the transfer is downloaded again
+			AutoDelete: &autoDelete,
+		})
+		if err != nil {
+			logutils.ZapLogger().Error("can't track dropped transaction",
+				zap.Uint64("chain", chainID),
+				zap.Stringer("hash", transfer.TxHash),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// isReorg returns true when the new head doesn't extend the previous one. Heads missed in between can't be compared.
+func isReorg(previous, head *types.Header) bool {
+	if previous == nil {
+		return false
+	}
+	if head.Number.Cmp(previous.Number) <= 0 {
+		return true
+	}
+	return head.Number.Uint64() == previous.Number.Uint64()+1 && head.ParentHash != previous.Hash()
+}
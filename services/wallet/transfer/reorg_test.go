@@ -0,0 +1,213 @@
+package transfer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/services/wallet/balance"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+// TestClientWithReorg returns other blocks after the fork block, and no block from the missing block on
+type TestClientWithReorg struct {
+	*TestClient
+	forkBlock    uint64
+	missingBlock uint64
+}
+
+func (tc *TestClientWithReorg) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if tc.missingBlock != 0 && number.Uint64() >= tc.missingBlock {
+		return nil, ethereum.NotFound
+	}
+	header, err := tc.TestClient.HeaderByNumber(ctx, number)
+	if err != nil || tc.forkBlock == 0 || header.Number.Uint64() <= tc.forkBlock {
+		return header, err
+	}
+	header.Extra = []byte("fork")
+	return header, nil
+}
+
+func saveReorgTestBlock(t *testing.T, db *Database, chainID uint64, address common.Address, number int64, hash common.Hash, mtID w_common.MultiTransactionIDType) {
+	require.NoError(t, db.SaveBlocks(chainID, []*DBHeader{{Number: big.NewInt(number), Hash: hash, Address: address}}))
+
+	tx := types.NewTransaction(uint64(number), common.Address{0xaa}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	receipt := types.NewReceipt(nil, false, 100)
+	receipt.TxHash = tx.Hash()
+	receipt.BlockHash = hash
+	receipt.Logs = []*types.Log{}
+	transfers := []Transfer{{
+		ID:                 tx.Hash(),
+		Type:               w_common.EthTransfer,
+		BlockHash:          hash,
+		BlockNumber:        big.NewInt(number),
+		Address:            address,
+		From:               address,
+		Timestamp:          123,
+		Transaction:        tx,
+		Receipt:            receipt,
+		MultiTransactionID: mtID,
+	}}
+	sqlTx, err := db.client.Begin()
+	require.NoError(t, err)
+	require.NoError(t, saveTransfersMarkBlocksLoaded(sqlTx, chainID, address, transfers, []*big.Int{big.NewInt(number)}))
+	require.NoError(t, sqlTx.Commit())
+}
+
+func TestIsReorg(t *testing.T) {
+	previous := getTestHeader(big.NewInt(10))
+	next := getTestHeader(big.NewInt(11))
+	next.ParentHash = previous.Hash()
+
+	require.False(t, isReorg(nil, next))
+	require.False(t, isReorg(previous, next))
+
+	// The head replaces the previous one
+	require.True(t, isReorg(previous, getTestHeader(big.NewInt(10))))
+
+	// The head has another parent
+	other := getTestHeader(big.NewInt(11))
+	other.ParentHash = common.Hash{1}
+	require.True(t, isReorg(previous, other))
+
+	// Heads were missed
+	require.False(t, isReorg(previous, getTestHeader(big.NewInt(13))))
+}
+
+func TestDatabase_rollbackReorganizedBlocks(t *testing.T) {
+	db, _, stop := setupTestDB(t)
+	defer stop()
+
+	const chainID = 777
+	address := common.Address{1}
+	saveReorgTestBlock(t, db, chainID, address, 10, common.Hash{10}, w_common.NoMultiTransactionID)
+	saveReorgTestBlock(t, db, chainID, address, 11, common.Hash{11}, 5)
+
+	blockRangeDAO := &BlockRangeSequentialDAO{db.client}
+	blockRange := newEthTokensBlockRanges()
+	blockRange.eth = &BlockRange{Start: big.NewInt(1), FirstKnown: big.NewInt(1), LastKnown: big.NewInt(12)}
+	blockRange.tokens = &BlockRange{Start: big.NewInt(1), FirstKnown: big.NewInt(1), LastKnown: big.NewInt(12)}
+	blockRange.balanceCheckHash = "hash"
+	require.NoError(t, blockRangeDAO.upsertRange(chainID, address, blockRange))
+
+	for i := int64(8); i <= 12; i++ {
+		require.NoError(t, db.saveBlockCheckpoint(chainID, big.NewInt(i), common.Hash{byte(i)}))
+	}
+
+	accounts, dropped, err := db.rollbackReorganizedBlocks(chainID, big.NewInt(10), []common.Hash{{11}})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{address}, accounts)
+	require.Len(t, dropped, 1)
+	require.Equal(t, w_common.MultiTransactionIDType(5), dropped[0].MultiTransactionID)
+	require.Equal(t, address, dropped[0].Address)
+	require.Equal(t, common.Address{0xaa}, *dropped[0].Transaction.To())
+
+	transfers, err := db.GetTransfersInRange(chainID, address, big.NewInt(0), big.NewInt(20))
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, common.Hash{10}, transfers[0].BlockHash)
+
+	hashes, err := db.getBlockHashesAfter(chainID, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, map[uint64][]common.Hash{10: {{10}}}, hashes)
+
+	blockRange, _, err = blockRangeDAO.getBlockRange(chainID, address)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), blockRange.eth.LastKnown.Int64())
+	require.Equal(t, int64(10), blockRange.tokens.LastKnown.Int64())
+	require.Equal(t, int64(1), blockRange.eth.FirstKnown.Int64())
+	require.Empty(t, blockRange.balanceCheckHash)
+
+	checkpoints, err := db.getBlockCheckpoints(chainID)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 3)
+	require.Equal(t, int64(10), checkpoints[0].Number.Int64())
+}
+
+func TestFindNewBlocksCommand_detectReorg(t *testing.T) {
+	db, _, stop := setupTestDB(t)
+	defer stop()
+
+	tc := &TestClientWithReorg{
+		TestClient: &TestClient{
+			t:            t,
+			callsCounter: map[string]int{},
+			currentBlock: 12,
+		},
+	}
+	chainID := tc.NetworkID()
+	address := common.Address{1}
+	ctx := context.Background()
+
+	feed := &event.Feed{}
+	events := make(chan walletevent.Event, 10)
+	sub := feed.Subscribe(events)
+	defer sub.Unsubscribe()
+
+	cmd := &findNewBlocksCommand{
+		findBlocksCommand: &findBlocksCommand{
+			accounts:        []common.Address{address},
+			db:              db,
+			blockRangeDAO:   &BlockRangeSequentialDAO{db.client},
+			chainClient:     tc,
+			balanceCacher:   balance.NewCacherWithTTL(5 * time.Minute),
+			feed:            feed,
+			fromBlockNumber: big.NewInt(12),
+		},
+	}
+
+	kept, err := tc.HeaderByNumber(ctx, big.NewInt(9))
+	require.NoError(t, err)
+	removed, err := tc.HeaderByNumber(ctx, big.NewInt(11))
+	require.NoError(t, err)
+	saveReorgTestBlock(t, db, chainID, address, 9, kept.Hash(), w_common.NoMultiTransactionID)
+	saveReorgTestBlock(t, db, chainID, address, 11, removed.Hash(), w_common.NoMultiTransactionID)
+	for i := int64(8); i <= 12; i += 2 {
+		require.NoError(t, cmd.saveCheckpoint(ctx, big.NewInt(i)))
+	}
+
+	// No reorganization
+	require.NoError(t, cmd.detectReorg(ctx))
+	require.Equal(t, int64(12), cmd.fromBlockNumber.Int64())
+
+	// The provider lags behind the last checked block, nothing is rolled back
+	tc.missingBlock = 12
+	require.NoError(t, cmd.detectReorg(ctx))
+	require.Equal(t, int64(12), cmd.fromBlockNumber.Int64())
+	hashes, err := db.getBlockHashesAfter(chainID, big.NewInt(0))
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+	tc.missingBlock = 0
+
+	// The chain changed after the block 10
+	tc.forkBlock = 10
+	require.NoError(t, cmd.detectReorg(ctx))
+	require.Equal(t, int64(10), cmd.fromBlockNumber.Int64())
+
+	hashes, err = db.getBlockHashesAfter(chainID, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, map[uint64][]common.Hash{9: {kept.Hash()}}, hashes)
+
+	select {
+	case ev := <-events:
+		require.Equal(t, EventChainReorganized, ev.Type)
+		require.Equal(t, []common.Address{address}, ev.Accounts)
+		require.Equal(t, chainID, ev.ChainID)
+		require.Equal(t, int64(10), ev.BlockNumber.Int64())
+	case <-time.After(time.Second):
+		require.Fail(t, "no reorganization event")
+	}
+
+	// The reorganization is handled once
+	require.NoError(t, cmd.detectReorg(ctx))
+	require.Equal(t, int64(10), cmd.fromBlockNumber.Int64())
+}
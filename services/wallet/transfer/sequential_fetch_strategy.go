@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/rpc/chain"
+	"github.com/status-im/status-go/rpc/subscriptions"
 	"github.com/status-im/status-go/services/wallet/async"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
@@ -28,6 +29,7 @@ func NewSequentialFetchStrategy(db *Database, blockDAO *BlockDAO, blockRangesSeq
 	balanceCacher balance.Cacher,
 	omitHistory bool,
 	blockChainState *blockchainstate.BlockChainState,
+	subscriptions *subscriptions.Manager,
 ) *SequentialFetchStrategy {
 
 	return &SequentialFetchStrategy{
@@ -43,6 +45,7 @@ func NewSequentialFetchStrategy(db *Database, blockDAO *BlockDAO, blockRangesSeq
 		balanceCacher:     balanceCacher,
 		omitHistory:       omitHistory,
 		blockChainState:   blockChainState,
+		subscriptions:     subscriptions,
 	}
 }
 
@@ -61,13 +64,14 @@ type SequentialFetchStrategy struct {
 	balanceCacher     balance.Cacher
 	omitHistory       bool
 	blockChainState   *blockchainstate.BlockChainState
+	subscriptions     *subscriptions.Manager
 }
 
 func (s *SequentialFetchStrategy) newCommand(chainClient chain.ClientInterface,
 	accounts []common.Address) async.Commander {
 
 	return newLoadBlocksAndTransfersCommand(accounts, s.db, s.accountsDB, s.blockDAO, s.blockRangesSeqDAO, chainClient, s.feed,
-		s.pendingTxManager, s.tokenManager, s.balanceCacher, s.omitHistory, s.blockChainState)
+		s.pendingTxManager, s.tokenManager, s.balanceCacher, s.omitHistory, s.blockChainState, s.subscriptions)
 }
 
 func (s *SequentialFetchStrategy) start() error {
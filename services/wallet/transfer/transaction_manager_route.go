@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"math/big"
 
+	"go.uber.org/zap"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/logutils"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
@@ -251,7 +254,7 @@ func (tm *TransactionManager) BuildTransactionsFromRoute(route routes.Route, pat
 			response.Hashes = append(response.Hashes, txDetails.ApprovalTxData.HashToSign)
 
 			// if approval is needed for swap, we cannot build the swap tx before the approval tx is mined
-			if path.ProcessorName == pathProcessorCommon.ProcessorSwapParaswapName {
+			if walletCommon.IsProcessorSwap(path.ProcessorName) {
 				continue
 			}
 		}
@@ -372,7 +375,7 @@ func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiT
 			transactions = append(transactions, response)
 
 			// if approval is needed for swap, then we need to wait for the approval tx to be mined before sending the swap tx
-			if desc.RouterPath.ProcessorName == pathProcessorCommon.ProcessorSwapParaswapName {
+			if walletCommon.IsProcessorSwap(desc.RouterPath.ProcessorName) {
 				continue
 			}
 		}
@@ -385,12 +388,35 @@ func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiT
 			}
 
 			transactions = append(transactions, response)
+
+			if walletCommon.IsProcessorBridge(desc.RouterPath.ProcessorName) {
+				tm.trackBridgeTransfer(desc.RouterPath, desc.TxData.SentHash)
+			}
 		}
 	}
 
 	return
 }
 
+// trackBridgeTransfer follows the bridged funds until they arrive on the destination chain, failing to do so doesn't affect the sent tx
+func (tm *TransactionManager) trackBridgeTransfer(path *routes.Path, sentHash types.Hash) {
+	if tm.pendingTracker == nil {
+		return
+	}
+
+	err := tm.pendingTracker.TrackBridgeTransfer(
+		transactions.TxIdentity{
+			ChainID: walletCommon.ChainID(path.FromChain.ChainID),
+			Hash:    common.Hash(sentHash),
+		},
+		walletCommon.ChainID(path.ToChain.ChainID),
+		path.ProcessorName,
+	)
+	if err != nil && err != transactions.ErrNoBridgeStatusChecker {
+		logutils.ZapLogger().Error("failed to track bridge transfer", zap.String("processor", path.ProcessorName), zap.Error(err))
+	}
+}
+
 func (tm *TransactionManager) GetRouterTransactions() []*wallettypes.RouterTransactionDetails {
 	return tm.routerTransactions
 }
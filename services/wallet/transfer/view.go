@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/addressbook"
 	w_common "github.com/status-im/status-go/services/wallet/common"
 )
 
@@ -36,6 +38,9 @@ type View struct {
 	NetworkID            uint64         `json:"networkId"`
 	MultiTransactionID   int64          `json:"multiTransactionID"`
 	BaseGasFees          string         `json:"base_gas_fee"`
+	// FromLabel and ToLabel are filled in by the wallet API from the address book
+	FromLabel *addressbook.Label `json:"fromLabel,omitempty"`
+	ToLabel   *addressbook.Label `json:"toLabel,omitempty"`
 }
 
 func castToTransferViews(transfers []Transfer) []View {
@@ -0,0 +1,70 @@
+package txdecoder
+
+import (
+	"database/sql"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDatabase(sqlDb *sql.DB) *Database {
+	return &Database{
+		db: sqlDb,
+	}
+}
+
+// SaveContractABI inserts or replaces the ABI imported for a contract
+func (d *Database) SaveContractABI(contractABI *ContractABI) error {
+	_, err := d.db.Exec(`INSERT OR REPLACE INTO contract_abis (chain_id, address, name, abi, created_at) VALUES (?, ?, ?, ?, ?)`,
+		contractABI.ChainID, contractABI.Address, contractABI.Name, contractABI.ABI, contractABI.CreatedAt)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanContractABI(row rowScanner) (*ContractABI, error) {
+	contractABI := &ContractABI{}
+	err := row.Scan(&contractABI.ChainID, &contractABI.Address, &contractABI.Name, &contractABI.ABI, &contractABI.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return contractABI, nil
+}
+
+// GetContractABI returns nil if no ABI was imported for the contract
+func (d *Database) GetContractABI(chainID uint64, address common.Address) (*ContractABI, error) {
+	contractABI, err := scanContractABI(d.db.QueryRow(`SELECT chain_id, address, name, abi, created_at
+		FROM contract_abis WHERE chain_id = ? AND address = ?`, chainID, address))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return contractABI, err
+}
+
+func (d *Database) GetContractABIs() ([]*ContractABI, error) {
+	rows, err := d.db.Query(`SELECT chain_id, address, name, abi, created_at FROM contract_abis ORDER BY chain_id, address`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contractABIs := make([]*ContractABI, 0)
+	for rows.Next() {
+		contractABI, err := scanContractABI(rows)
+		if err != nil {
+			return nil, err
+		}
+		contractABIs = append(contractABIs, contractABI)
+	}
+	return contractABIs, rows.Err()
+}
+
+func (d *Database) DeleteContractABI(chainID uint64, address common.Address) error {
+	_, err := d.db.Exec(`DELETE FROM contract_abis WHERE chain_id = ? AND address = ?`, chainID, address)
+	return err
+}
@@ -0,0 +1,58 @@
+package txdecoder
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupDatabaseTest(t *testing.T) (*Database, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewDatabase(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestContractABIs(t *testing.T) {
+	db, cleanup := setupDatabaseTest(t)
+	defer cleanup()
+
+	contract := common.HexToAddress("0xc0ffee")
+	stored, err := db.GetContractABI(1, contract)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	contractABI := &ContractABI{
+		ChainID:   1,
+		Address:   contract,
+		Name:      "Coffee",
+		ABI:       `[]`,
+		CreatedAt: 100,
+	}
+	require.NoError(t, db.SaveContractABI(contractABI))
+
+	stored, err = db.GetContractABI(1, contract)
+	require.NoError(t, err)
+	require.Equal(t, contractABI, stored)
+
+	stored, err = db.GetContractABI(10, contract)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	contractABI.Name = "Coffee v2"
+	require.NoError(t, db.SaveContractABI(contractABI))
+	contractABIs, err := db.GetContractABIs()
+	require.NoError(t, err)
+	require.Equal(t, []*ContractABI{contractABI}, contractABIs)
+
+	require.NoError(t, db.DeleteContractABI(1, contract))
+	contractABIs, err = db.GetContractABIs()
+	require.NoError(t, err)
+	require.Empty(t, contractABIs)
+}
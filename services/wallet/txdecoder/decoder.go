@@ -0,0 +1,253 @@
+package txdecoder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+// TokenResolver is implemented by token.Manager
+type TokenResolver interface {
+	FindTokenByAddress(chainID uint64, address common.Address) *token.Token
+}
+
+const (
+	verifiedCacheTTL      = 24 * time.Hour
+	verifiedCacheCapacity = 1000
+	// verifiedFetchTimeout bounds the verified contract provider lookup, the decoding runs
+	// while the user waits for the signing prompt
+	verifiedFetchTimeout = 3 * time.Second
+)
+
+type contractKey struct {
+	chainID uint64
+	address common.Address
+}
+
+// parsedContractABI is a contract ABI ready to look up methods
+type parsedContractABI struct {
+	name string
+	abi  *abi.ABI
+}
+
+// Decoder decodes transactions calldata looking up the called method in, by priority: the ABIs imported by the user,
+// the verified contract provider, the bundled well-known ABIs and finally the signature directories
+type Decoder struct {
+	db         *Database
+	verified   thirdparty.VerifiedContractProvider
+	tokens     TokenResolver
+	signatures []thirdparty.DecoderProvider
+
+	verifiedTimeout time.Duration
+	verifiedCache   *ttlcache.Cache[contractKey, *parsedContractABI]
+}
+
+func NewDecoder(db *Database, verified thirdparty.VerifiedContractProvider, tokens TokenResolver, signatures ...thirdparty.DecoderProvider) *Decoder {
+	return &Decoder{
+		db:              db,
+		verified:        verified,
+		tokens:          tokens,
+		signatures:      signatures,
+		verifiedTimeout: verifiedFetchTimeout,
+		verifiedCache: ttlcache.New[contractKey, *parsedContractABI](
+			ttlcache.WithTTL[contractKey, *parsedContractABI](verifiedCacheTTL),
+			ttlcache.WithCapacity[contractKey, *parsedContractABI](verifiedCacheCapacity),
+			ttlcache.WithDisableTouchOnHit[contractKey, *parsedContractABI](),
+		),
+	}
+}
+
+// ImportContractABI validates and stores the ABI of a contract, replacing the previously imported one
+func (d *Decoder) ImportContractABI(chainID uint64, address common.Address, name string, abiJSON string) error {
+	if _, err := abi.JSON(strings.NewReader(abiJSON)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidABI, err)
+	}
+
+	return d.db.SaveContractABI(&ContractABI{
+		ChainID:   chainID,
+		Address:   address,
+		Name:      name,
+		ABI:       abiJSON,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+func (d *Decoder) RemoveContractABI(chainID uint64, address common.Address) error {
+	return d.db.DeleteContractABI(chainID, address)
+}
+
+func (d *Decoder) GetContractABIs() ([]*ContractABI, error) {
+	return d.db.GetContractABIs()
+}
+
+func (d *Decoder) importedContractABI(chainID uint64, address common.Address) (*parsedContractABI, error) {
+	stored, err := d.db.GetContractABI(chainID, address)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(stored.ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedContractABI{name: stored.Name, abi: &parsed}, nil
+}
+
+// verifiedContractABI caches the contracts verification, failed or timed out requests are retried on the next call
+func (d *Decoder) verifiedContractABI(ctx context.Context, chainID uint64, address common.Address) (*parsedContractABI, error) {
+	if d.verified == nil {
+		return nil, nil
+	}
+
+	key := contractKey{chainID: chainID, address: address}
+	if item := d.verifiedCache.Get(key); item != nil {
+		return item.Value(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.verifiedTimeout)
+	defer cancel()
+
+	contract, err := d.verified.FetchVerifiedContract(ctx, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *parsedContractABI
+	if contract != nil {
+		parsed, err := abi.JSON(strings.NewReader(contract.ABI))
+		if err != nil {
+			return nil, err
+		}
+		res = &parsedContractABI{name: contract.Name, abi: &parsed}
+	}
+
+	d.verifiedCache.Set(key, res, ttlcache.DefaultTTL)
+
+	return res, nil
+}
+
+// signatureMethod builds the method from a text signature returned by the signature directories
+func signatureMethod(signature string) (*abi.Method, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return nil, fmt.Errorf("invalid signature %s", signature)
+	}
+	name := signature[:open]
+	rawTypes := signature[open+1 : len(signature)-1]
+
+	inputs := abi.Arguments{}
+	if len(rawTypes) > 0 {
+		for i, rawType := range strings.Split(rawTypes, ",") {
+			typ, err := abi.NewType(rawType, "", nil)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, abi.Argument{Name: fmt.Sprint(i), Type: typ})
+		}
+	}
+
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, inputs, nil)
+	return &method, nil
+}
+
+// Decode decodes the calldata of a transaction sent to a contract, the value is used to summarize payable calls.
+// It returns nil for contract deployments
+func (d *Decoder) Decode(ctx context.Context, chainID uint64, to *common.Address, data []byte, value *big.Int) (*DecodedCall, error) {
+	if to == nil {
+		return nil, nil
+	}
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	call := &DecodedCall{
+		ChainID:  chainID,
+		Contract: *to,
+		Params:   make([]Param, 0),
+		Source:   SourceNone,
+	}
+
+	if len(data) == 0 {
+		if value.Sign() > 0 {
+			call.Summary = d.nativeTransferSummary(chainID, *to, value)
+		}
+		return call, nil
+	}
+	if len(data) < 4 {
+		return nil, ErrInvalidCallData
+	}
+	call.Selector = hexutil.Encode(data[:4])
+
+	var method *abi.Method
+	for _, source := range []struct {
+		source Source
+		fetch  func() (*parsedContractABI, error)
+	}{
+		{SourceImported, func() (*parsedContractABI, error) { return d.importedContractABI(chainID, *to) }},
+		{SourceVerified, func() (*parsedContractABI, error) { return d.verifiedContractABI(ctx, chainID, *to) }},
+	} {
+		contractABI, err := source.fetch()
+		if err != nil || contractABI == nil {
+			continue
+		}
+		call.ContractName = contractABI.name
+		if method, err = contractABI.abi.MethodById(data[:4]); err == nil {
+			call.Source = source.source
+			break
+		}
+	}
+
+	if call.Source == SourceNone {
+		var selector [4]byte
+		copy(selector[:], data[:4])
+		if bundled, ok := bundledMethods[selector]; ok {
+			method = &bundled
+			call.Source = SourceBundled
+		}
+	}
+
+	if call.Source == SourceNone {
+		for _, provider := range d.signatures {
+			parsed, err := provider.Run(hexutil.Encode(data))
+			if err != nil || parsed == nil {
+				continue
+			}
+			if method, err = signatureMethod(parsed.Signature); err == nil {
+				call.Source = SourceSignatureDirectory
+				break
+			}
+		}
+	}
+
+	if call.Source == SourceNone {
+		return nil, ErrUnknownMethod
+	}
+
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	call.Method = method.RawName
+	call.Signature = method.Sig
+	for i, input := range method.Inputs {
+		call.Params = append(call.Params, Param{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatValue(args[i]),
+		})
+	}
+	call.Summary = d.summarize(chainID, *to, method.Sig, args, value)
+
+	return call, nil
+}
@@ -0,0 +1,277 @@
+package txdecoder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	daiAddress  = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	wethAddress = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	routerAddr  = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	recipient   = common.HexToAddress("0xbeef")
+)
+
+type fakeTokens struct{}
+
+func (f *fakeTokens) FindTokenByAddress(chainID uint64, address common.Address) *token.Token {
+	switch address {
+	case common.Address{}:
+		return &token.Token{Symbol: "ETH", Decimals: 18, ChainID: chainID}
+	case daiAddress:
+		return &token.Token{Address: daiAddress, Symbol: "DAI", Decimals: 18, ChainID: chainID}
+	case wethAddress:
+		return &token.Token{Address: wethAddress, Symbol: "WETH", Decimals: 18, ChainID: chainID}
+	}
+	return nil
+}
+
+type fakeVerifiedProvider struct {
+	contracts map[common.Address]*thirdparty.VerifiedContract
+	calls     int
+}
+
+func (f *fakeVerifiedProvider) ID() string {
+	return "fake"
+}
+
+func (f *fakeVerifiedProvider) FetchVerifiedContract(ctx context.Context, chainID uint64, address common.Address) (*thirdparty.VerifiedContract, error) {
+	f.calls++
+	return f.contracts[address], nil
+}
+
+// slowVerifiedProvider answers once the request is cancelled, like a provider that doesn't respond
+type slowVerifiedProvider struct {
+	calls int
+}
+
+func (f *slowVerifiedProvider) ID() string {
+	return "slow"
+}
+
+func (f *slowVerifiedProvider) FetchVerifiedContract(ctx context.Context, chainID uint64, address common.Address) (*thirdparty.VerifiedContract, error) {
+	f.calls++
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type fakeSignatures struct {
+	signature string
+}
+
+func (f *fakeSignatures) Run(data string) (*thirdparty.DataParsed, error) {
+	if f.signature == "" {
+		return nil, errors.New("couldn't find a corresponding signature")
+	}
+	return &thirdparty.DataParsed{Signature: f.signature}, nil
+}
+
+func setupDecoderTest(t *testing.T, signatures ...thirdparty.DecoderProvider) (*Decoder, *fakeVerifiedProvider) {
+	db, cleanup := setupDatabaseTest(t)
+	t.Cleanup(cleanup)
+
+	verified := &fakeVerifiedProvider{contracts: make(map[common.Address]*thirdparty.VerifiedContract)}
+	return NewDecoder(db, verified, &fakeTokens{}, signatures...), verified
+}
+
+func pack(t *testing.T, abiJSON string, method string, args ...interface{}) []byte {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+	data, err := parsed.Pack(method, args...)
+	require.NoError(t, err)
+	return data
+}
+
+func eth(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+func TestDecodeNativeTransfer(t *testing.T) {
+	decoder, _ := setupDecoderTest(t)
+
+	call, err := decoder.Decode(context.Background(), 1, &recipient, nil, big.NewInt(1.5e18))
+	require.NoError(t, err)
+	require.Equal(t, SourceNone, call.Source)
+	require.Empty(t, call.Params)
+	require.Equal(t, SummaryTransfer, call.Summary.Kind)
+	require.Equal(t, "Send 1.5 ETH to "+recipient.Hex(), call.Summary.Text)
+
+	call, err = decoder.Decode(context.Background(), 1, nil, []byte{0x60, 0x80}, nil)
+	require.NoError(t, err)
+	require.Nil(t, call)
+
+	_, err = decoder.Decode(context.Background(), 1, &recipient, []byte{0x01}, nil)
+	require.Equal(t, ErrInvalidCallData, err)
+}
+
+func TestDecodeTokenTransferAndApprovals(t *testing.T) {
+	decoder, _ := setupDecoderTest(t)
+
+	call, err := decoder.Decode(context.Background(), 1, &daiAddress, pack(t, erc20ABI, "transfer", recipient, eth(10)), nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceBundled, call.Source)
+	require.Equal(t, "transfer", call.Method)
+	require.Equal(t, "0xa9059cbb", call.Selector)
+	require.Equal(t, []Param{
+		{Name: "to", Type: "address", Value: recipient.Hex()},
+		{Name: "amount", Type: "uint256", Value: eth(10).String()},
+	}, call.Params)
+	require.Equal(t, "Send 10 DAI to "+recipient.Hex(), call.Summary.Text)
+
+	call, err = decoder.Decode(context.Background(), 1, &daiAddress, pack(t, erc20ABI, "approve", routerAddr, math.MaxBig256), nil)
+	require.NoError(t, err)
+	require.Equal(t, SummaryApproval, call.Summary.Kind)
+	require.True(t, call.Summary.Unlimited)
+	require.Equal(t, routerAddr, call.Summary.Counterparty)
+	require.Equal(t, "Allow "+routerAddr.Hex()+" to spend an unlimited amount of DAI", call.Summary.Text)
+
+	call, err = decoder.Decode(context.Background(), 1, &daiAddress, pack(t, erc20ABI, "approve", routerAddr, big.NewInt(2.5e17)), nil)
+	require.NoError(t, err)
+	require.False(t, call.Summary.Unlimited)
+	require.Equal(t, "Allow "+routerAddr.Hex()+" to spend 0.25 DAI", call.Summary.Text)
+
+	collection := common.HexToAddress("0xc011ec7")
+	call, err = decoder.Decode(context.Background(), 1, &collection, pack(t, erc721ABI, "setApprovalForAll", routerAddr, true), nil)
+	require.NoError(t, err)
+	require.Equal(t, SummaryApprovalForAll, call.Summary.Kind)
+	require.True(t, call.Summary.Unlimited)
+
+	// transferFrom of an unknown token is a collectible transfer
+	call, err = decoder.Decode(context.Background(), 1, &collection, pack(t, erc20ABI, "transferFrom", routerAddr, recipient, big.NewInt(42)), nil)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(42)), call.Summary.TokenID)
+	require.Equal(t, "Send collectible #42 of "+collection.Hex()+" to "+recipient.Hex(), call.Summary.Text)
+}
+
+func TestDecodeSwaps(t *testing.T) {
+	decoder, _ := setupDecoderTest(t)
+
+	data := pack(t, uniswapV2RouterABI, "swapExactETHForTokens", eth(2000), []common.Address{wethAddress, daiAddress}, recipient, big.NewInt(1))
+	call, err := decoder.Decode(context.Background(), 1, &routerAddr, data, eth(1))
+	require.NoError(t, err)
+	require.Equal(t, SummarySwap, call.Summary.Kind)
+	require.Equal(t, common.Address{}, call.Summary.Token)
+	require.Equal(t, daiAddress, *call.Summary.TokenOut)
+	require.Equal(t, "Swap 1 ETH for at least 2000 DAI", call.Summary.Text)
+
+	data = pack(t, uniswapV2RouterABI, "swapTokensForExactTokens", eth(1), eth(2100), []common.Address{daiAddress, wethAddress}, recipient, big.NewInt(1))
+	call, err = decoder.Decode(context.Background(), 1, &routerAddr, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Swap at most 2100 DAI for 1 WETH", call.Summary.Text)
+
+	params := struct {
+		TokenIn           common.Address
+		TokenOut          common.Address
+		Fee               *big.Int
+		Recipient         common.Address
+		AmountIn          *big.Int
+		AmountOutMinimum  *big.Int
+		SqrtPriceLimitX96 *big.Int
+	}{wethAddress, daiAddress, big.NewInt(3000), recipient, eth(1), eth(1990), big.NewInt(0)}
+	data = pack(t, uniswapV3Router02ABI, "exactInputSingle", params)
+	call, err = decoder.Decode(context.Background(), 1, &routerAddr, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, "exactInputSingle", call.Method)
+	require.Equal(t, "(address,address,uint24,address,uint256,uint256,uint160)", call.Params[0].Type)
+	require.Equal(t, recipient, call.Summary.Counterparty)
+	require.Equal(t, "Swap 1 WETH for at least 1990 DAI", call.Summary.Text)
+}
+
+func TestDecodeWithContractABIs(t *testing.T) {
+	const mintABI = `[{"name":"mint","type":"function","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]}]`
+	const claimABI = `[{"name":"claim","type":"function","inputs":[{"name":"id","type":"uint256"}],"outputs":[]}]`
+
+	decoder, verified := setupDecoderTest(t)
+	contract := common.HexToAddress("0xc0ffee")
+	mintData := pack(t, mintABI, "mint", recipient, big.NewInt(1))
+	claimData := pack(t, claimABI, "claim", big.NewInt(7))
+
+	verified.contracts[contract] = &thirdparty.VerifiedContract{Name: "Coffee", ABI: claimABI}
+
+	_, err := decoder.Decode(context.Background(), 1, &contract, mintData, nil)
+	require.Equal(t, ErrUnknownMethod, err)
+
+	call, err := decoder.Decode(context.Background(), 1, &contract, claimData, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceVerified, call.Source)
+	require.Equal(t, "Coffee", call.ContractName)
+	require.Equal(t, []Param{{Name: "id", Type: "uint256", Value: "7"}}, call.Params)
+	require.Nil(t, call.Summary)
+	// The verified contract is fetched once
+	require.Equal(t, 1, verified.calls)
+
+	err = decoder.ImportContractABI(1, contract, "Coffee shop", `[{"name":`)
+	require.ErrorIs(t, err, ErrInvalidABI)
+
+	require.NoError(t, decoder.ImportContractABI(1, contract, "Coffee shop", mintABI))
+	call, err = decoder.Decode(context.Background(), 1, &contract, mintData, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceImported, call.Source)
+	require.Equal(t, "Coffee shop", call.ContractName)
+
+	// Methods missing from the imported ABI are looked up in the next sources
+	call, err = decoder.Decode(context.Background(), 1, &contract, claimData, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceVerified, call.Source)
+	require.Equal(t, 1, verified.calls)
+
+	require.NoError(t, decoder.RemoveContractABI(1, contract))
+	contractABIs, err := decoder.GetContractABIs()
+	require.NoError(t, err)
+	require.Empty(t, contractABIs)
+}
+
+func TestDecodeWithSignatureDirectory(t *testing.T) {
+	signatures := &fakeSignatures{}
+	decoder, _ := setupDecoderTest(t, &fakeSignatures{}, signatures)
+	contract := common.HexToAddress("0xc0ffee")
+	data := pack(t, `[{"name":"brew","type":"function","inputs":[{"name":"cups","type":"uint8"},{"name":"hot","type":"bool"}],"outputs":[]}]`, "brew", uint8(2), true)
+
+	_, err := decoder.Decode(context.Background(), 1, &contract, data, nil)
+	require.Equal(t, ErrUnknownMethod, err)
+
+	signatures.signature = "brew(uint8,bool)"
+	call, err := decoder.Decode(context.Background(), 1, &contract, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceSignatureDirectory, call.Source)
+	require.Equal(t, "brew", call.Method)
+	require.Equal(t, []Param{
+		{Name: "0", Type: "uint8", Value: "2"},
+		{Name: "1", Type: "bool", Value: "true"},
+	}, call.Params)
+}
+
+func TestDecodeWithVerifiedProviderTimeout(t *testing.T) {
+	db, cleanup := setupDatabaseTest(t)
+	t.Cleanup(cleanup)
+
+	verified := &slowVerifiedProvider{}
+	decoder := NewDecoder(db, verified, &fakeTokens{})
+	decoder.verifiedTimeout = 10 * time.Millisecond
+
+	data := pack(t, erc20ABI, "transfer", recipient, eth(1))
+
+	// The bundled ABIs are used when the verified contract provider doesn't answer in time
+	call, err := decoder.Decode(context.Background(), 1, &daiAddress, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceBundled, call.Source)
+
+	// Timed out lookups are not cached
+	_, err = decoder.Decode(context.Background(), 1, &daiAddress, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, verified.calls)
+}
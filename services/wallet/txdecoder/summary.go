@@ -0,0 +1,302 @@
+package txdecoder
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	defaultNativeSymbol   = "ETH"
+	defaultNativeDecimals = 18
+	// maxDisplayedDecimals is the precision of the amounts in the summary texts
+	maxDisplayedDecimals = 6
+)
+
+// unlimitedAllowanceThreshold is the approved amount considered as the whole token supply, dApps usually approve 2^256-1
+var unlimitedAllowanceThreshold = new(big.Int).Lsh(big.NewInt(1), 128)
+
+type summarizer func(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary
+
+// summarizers are indexed by method signature, so that calls decoded from any source are summarized
+var summarizers = map[string]summarizer{
+	"transfer(address,uint256)":                                                           summarizeTransfer,
+	"transferFrom(address,address,uint256)":                                               summarizeTransferFrom,
+	"safeTransferFrom(address,address,uint256)":                                           summarizeCollectibleTransfer,
+	"safeTransferFrom(address,address,uint256,bytes)":                                     summarizeCollectibleTransfer,
+	"safeTransferFrom(address,address,uint256,uint256,bytes)":                             summarizeMultiTokenTransfer,
+	"approve(address,uint256)":                                                            summarizeApproval,
+	"increaseAllowance(address,uint256)":                                                  summarizeApproval,
+	"setApprovalForAll(address,bool)":                                                     summarizeApprovalForAll,
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)":                 summarizeV2Swap(false, false, false),
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)":                 summarizeV2Swap(true, false, false),
+	"swapExactETHForTokens(uint256,address[],address,uint256)":                            summarizeV2Swap(false, true, false),
+	"swapETHForExactTokens(uint256,address[],address,uint256)":                            summarizeV2Swap(true, true, false),
+	"swapExactTokensForETH(uint256,uint256,address[],address,uint256)":                    summarizeV2Swap(false, false, true),
+	"swapTokensForExactETH(uint256,uint256,address[],address,uint256)":                    summarizeV2Swap(true, false, true),
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))":  summarizeV3Swap(false, true),
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))": summarizeV3Swap(true, true),
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint160))":          summarizeV3Swap(false, false),
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160))":         summarizeV3Swap(true, false),
+}
+
+func (d *Decoder) summarize(chainID uint64, contract common.Address, signature string, args []interface{}, value *big.Int) (summary *Summary) {
+	summarizer, ok := summarizers[signature]
+	if !ok {
+		return nil
+	}
+	// Calls decoded from the signature directories may have unexpected types
+	defer func() {
+		if r := recover(); r != nil {
+			summary = nil
+		}
+	}()
+	return summarizer(d, chainID, contract, args, value)
+}
+
+// tokenAmount formats the amount with the token symbol, unknown tokens are shown as raw amounts of the token contract
+func (d *Decoder) tokenAmount(chainID uint64, address common.Address, amount *big.Int) string {
+	if d.tokens != nil {
+		if token := d.tokens.FindTokenByAddress(chainID, address); token != nil {
+			return fmt.Sprintf("%s %s", formatAmount(amount, token.Decimals), token.Symbol)
+		}
+	}
+	if address == (common.Address{}) {
+		return fmt.Sprintf("%s %s", formatAmount(amount, defaultNativeDecimals), defaultNativeSymbol)
+	}
+	return fmt.Sprintf("%s of token %s", amount.String(), address.Hex())
+}
+
+func (d *Decoder) isKnownToken(chainID uint64, address common.Address) bool {
+	return d.tokens != nil && d.tokens.FindTokenByAddress(chainID, address) != nil
+}
+
+func (d *Decoder) nativeTransferSummary(chainID uint64, to common.Address, value *big.Int) *Summary {
+	return &Summary{
+		Kind:         SummaryTransfer,
+		Text:         fmt.Sprintf("Send %s to %s", d.tokenAmount(chainID, common.Address{}, value), to.Hex()),
+		Amount:       (*hexutil.Big)(value),
+		Counterparty: to,
+	}
+}
+
+func summarizeTransfer(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	to := args[0].(common.Address)
+	amount := args[1].(*big.Int)
+	return &Summary{
+		Kind:         SummaryTransfer,
+		Text:         fmt.Sprintf("Send %s to %s", d.tokenAmount(chainID, contract, amount), to.Hex()),
+		Token:        contract,
+		Amount:       (*hexutil.Big)(amount),
+		Counterparty: to,
+	}
+}
+
+// summarizeTransferFrom handles both ERC20 and ERC721 transferFrom, they share the same selector
+func summarizeTransferFrom(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	if !d.isKnownToken(chainID, contract) {
+		return summarizeCollectibleTransfer(d, chainID, contract, args, value)
+	}
+	from := args[0].(common.Address)
+	to := args[1].(common.Address)
+	amount := args[2].(*big.Int)
+	return &Summary{
+		Kind:         SummaryTransfer,
+		Text:         fmt.Sprintf("Send %s from %s to %s", d.tokenAmount(chainID, contract, amount), from.Hex(), to.Hex()),
+		Token:        contract,
+		Amount:       (*hexutil.Big)(amount),
+		Counterparty: to,
+	}
+}
+
+func summarizeCollectibleTransfer(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	to := args[1].(common.Address)
+	tokenID := args[2].(*big.Int)
+	return &Summary{
+		Kind:         SummaryTransfer,
+		Text:         fmt.Sprintf("Send collectible #%s of %s to %s", tokenID.String(), contract.Hex(), to.Hex()),
+		Token:        contract,
+		Amount:       (*hexutil.Big)(big.NewInt(1)),
+		TokenID:      (*hexutil.Big)(tokenID),
+		Counterparty: to,
+	}
+}
+
+func summarizeMultiTokenTransfer(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	to := args[1].(common.Address)
+	tokenID := args[2].(*big.Int)
+	amount := args[3].(*big.Int)
+	return &Summary{
+		Kind:         SummaryTransfer,
+		Text:         fmt.Sprintf("Send %s of collectible #%s of %s to %s", amount.String(), tokenID.String(), contract.Hex(), to.Hex()),
+		Token:        contract,
+		Amount:       (*hexutil.Big)(amount),
+		TokenID:      (*hexutil.Big)(tokenID),
+		Counterparty: to,
+	}
+}
+
+// summarizeApproval handles ERC20 approvals, approving a single ERC721 collectible shares the same selector
+func summarizeApproval(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	spender := args[0].(common.Address)
+	amount := args[1].(*big.Int)
+	summary := &Summary{
+		Kind:         SummaryApproval,
+		Token:        contract,
+		Amount:       (*hexutil.Big)(amount),
+		Counterparty: spender,
+	}
+	if amount.Cmp(unlimitedAllowanceThreshold) >= 0 {
+		summary.Unlimited = true
+		symbol := contract.Hex()
+		if d.tokens != nil {
+			if token := d.tokens.FindTokenByAddress(chainID, contract); token != nil {
+				symbol = token.Symbol
+			}
+		}
+		summary.Text = fmt.Sprintf("Allow %s to spend an unlimited amount of %s", spender.Hex(), symbol)
+	} else {
+		summary.Text = fmt.Sprintf("Allow %s to spend %s", spender.Hex(), d.tokenAmount(chainID, contract, amount))
+	}
+	return summary
+}
+
+func summarizeApprovalForAll(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+	operator := args[0].(common.Address)
+	approved := args[1].(bool)
+	summary := &Summary{
+		Kind:         SummaryApprovalForAll,
+		Token:        contract,
+		Unlimited:    approved,
+		Counterparty: operator,
+	}
+	if approved {
+		summary.Text = fmt.Sprintf("Allow %s to transfer all your collectibles of %s", operator.Hex(), contract.Hex())
+	} else {
+		summary.Text = fmt.Sprintf("Revoke the permission of %s to transfer your collectibles of %s", operator.Hex(), contract.Hex())
+	}
+	return summary
+}
+
+func swapSummary(d *Decoder, chainID uint64, tokenIn common.Address, amountIn *big.Int, tokenOut common.Address, amountOut *big.Int, exactOut bool, recipient common.Address) *Summary {
+	text := fmt.Sprintf("Swap %s for at least %s", d.tokenAmount(chainID, tokenIn, amountIn), d.tokenAmount(chainID, tokenOut, amountOut))
+	if exactOut {
+		text = fmt.Sprintf("Swap at most %s for %s", d.tokenAmount(chainID, tokenIn, amountIn), d.tokenAmount(chainID, tokenOut, amountOut))
+	}
+	return &Summary{
+		Kind:         SummarySwap,
+		Text:         text,
+		Token:        tokenIn,
+		Amount:       (*hexutil.Big)(amountIn),
+		Counterparty: recipient,
+		TokenOut:     &tokenOut,
+		AmountOut:    (*hexutil.Big)(amountOut),
+	}
+}
+
+// summarizeV2Swap handles the UniswapV2 router swaps, the wrapped native token in the path is shown as the native token
+func summarizeV2Swap(exactOut bool, nativeIn bool, nativeOut bool) summarizer {
+	return func(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+		var amountIn, amountOut *big.Int
+		if nativeIn {
+			amountIn = value
+			amountOut = args[0].(*big.Int)
+			args = args[1:]
+		} else if exactOut {
+			amountOut = args[0].(*big.Int)
+			amountIn = args[1].(*big.Int)
+			args = args[2:]
+		} else {
+			amountIn = args[0].(*big.Int)
+			amountOut = args[1].(*big.Int)
+			args = args[2:]
+		}
+		path := args[0].([]common.Address)
+		recipient := args[1].(common.Address)
+		if len(path) < 2 {
+			return nil
+		}
+
+		tokenIn := path[0]
+		if nativeIn {
+			tokenIn = common.Address{}
+		}
+		tokenOut := path[len(path)-1]
+		if nativeOut {
+			tokenOut = common.Address{}
+		}
+		return swapSummary(d, chainID, tokenIn, amountIn, tokenOut, amountOut, exactOut, recipient)
+	}
+}
+
+// summarizeV3Swap handles the UniswapV3 single pool swaps, SwapRouter params have a deadline while SwapRouter02 ones don't
+func summarizeV3Swap(exactOut bool, withDeadline bool) summarizer {
+	return func(d *Decoder, chainID uint64, contract common.Address, args []interface{}, value *big.Int) *Summary {
+		params := reflect.ValueOf(args[0])
+		amountsIndex := 4
+		if withDeadline {
+			amountsIndex = 5
+		}
+		tokenIn := params.Field(0).Interface().(common.Address)
+		tokenOut := params.Field(1).Interface().(common.Address)
+		recipient := params.Field(3).Interface().(common.Address)
+		amountIn := params.Field(amountsIndex).Interface().(*big.Int)
+		amountOut := params.Field(amountsIndex + 1).Interface().(*big.Int)
+		if exactOut {
+			amountIn, amountOut = amountOut, amountIn
+		}
+		return swapSummary(d, chainID, tokenIn, amountIn, tokenOut, amountOut, exactOut, recipient)
+	}
+}
+
+// formatAmount prints the amount with up to maxDisplayedDecimals digits and no trailing zeros
+func formatAmount(amount *big.Int, decimals uint) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	res := new(big.Rat).SetFrac(amount, unit).FloatString(maxDisplayedDecimals)
+	if strings.Contains(res, ".") {
+		res = strings.TrimRight(strings.TrimRight(res, "0"), ".")
+	}
+	return res
+}
+
+// formatValue prints a decoded argument, bytes are hex encoded and composite values are printed element by element
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case string:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = formatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case reflect.Struct:
+		elems := make([]string, rv.NumField())
+		for i := range elems {
+			elems[i] = formatValue(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(elems, ", ") + ")"
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,80 @@
+package txdecoder
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	ErrInvalidCallData = errors.New("calldata is shorter than a method selector")
+	ErrInvalidABI      = errors.New("invalid contract ABI")
+	ErrUnknownMethod   = errors.New("couldn't find the called method in any ABI source")
+)
+
+// Source tells where the ABI used to decode a call comes from, sorted from the most to the least trusted
+type Source string
+
+const (
+	SourceNone               Source = "none"
+	SourceImported           Source = "imported"
+	SourceVerified           Source = "verified"
+	SourceBundled            Source = "bundled"
+	SourceSignatureDirectory Source = "signatureDirectory"
+)
+
+// ContractABI is an ABI imported by the user for a contract
+type ContractABI struct {
+	ChainID   uint64         `json:"chainId"`
+	Address   common.Address `json:"address"`
+	Name      string         `json:"name"`
+	ABI       string         `json:"abi"`
+	CreatedAt int64          `json:"createdAt"`
+}
+
+type Param struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type SummaryKind string
+
+const (
+	SummaryTransfer       SummaryKind = "transfer"
+	SummaryApproval       SummaryKind = "approval"
+	SummaryApprovalForAll SummaryKind = "approvalForAll"
+	SummarySwap           SummaryKind = "swap"
+)
+
+// Summary is the human-readable meaning of the call for the well-known kinds of calls
+type Summary struct {
+	Kind SummaryKind `json:"kind"`
+	Text string      `json:"text"`
+	// Token is the sent, approved or swapped token, the zero address for the native token
+	Token  common.Address `json:"token"`
+	Amount *hexutil.Big   `json:"amount,omitempty"`
+	// TokenID is set for collectibles
+	TokenID *hexutil.Big `json:"tokenId,omitempty"`
+	// Unlimited is set for approvals of (almost) the whole token supply and approvals for all the collectibles
+	Unlimited bool `json:"unlimited"`
+	// Counterparty is the recipient of a transfer or swap, the spender of an approval
+	Counterparty common.Address `json:"counterparty"`
+	// TokenOut and AmountOut are the received token of a swap and its minimum (or exact) amount
+	TokenOut  *common.Address `json:"tokenOut,omitempty"`
+	AmountOut *hexutil.Big    `json:"amountOut,omitempty"`
+}
+
+// DecodedCall is the decoded calldata of a transaction
+type DecodedCall struct {
+	ChainID      uint64         `json:"chainId"`
+	Contract     common.Address `json:"contract"`
+	ContractName string         `json:"contractName,omitempty"`
+	Method       string         `json:"method"`
+	Signature    string         `json:"signature"`
+	Selector     string         `json:"selector"`
+	Params       []Param        `json:"params"`
+	Source       Source         `json:"source"`
+	Summary      *Summary       `json:"summary,omitempty"`
+}
@@ -0,0 +1,81 @@
+package walletconnect
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const EncryptionVersionX25519 = "x25519-xsalsa20-poly1305"
+
+var (
+	ErrUnsupportedEncryptionVersion = errors.New("unsupported encryption version")
+	ErrInvalidEncryptedData         = errors.New("invalid encrypted data")
+	ErrDecryptionFailed             = errors.New("failed to decrypt the data")
+)
+
+// EncryptedData is the payload of the dApps "eth_decrypt" requests, fields are base64 encoded
+type EncryptedData struct {
+	Version        string `json:"version"`
+	Nonce          string `json:"nonce"`
+	EphemPublicKey string `json:"ephemPublicKey"`
+	Ciphertext     string `json:"ciphertext"`
+}
+
+// EncryptionPublicKeyForDApps returns the base64 encoded X25519 public key of the account key,
+// as nacl's box.keyPair.fromSecretKey does, for the dApps "eth_getEncryptionPublicKey" requests
+func EncryptionPublicKeyForDApps(privateKey *ecdsa.PrivateKey) (string, error) {
+	publicKey, err := curve25519.X25519(crypto.FromECDSA(privateKey), curve25519.Basepoint)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// DecryptForDApps decrypts the JSON encoded EncryptedData of the dApps "eth_decrypt" requests
+// with the account key
+func DecryptForDApps(encryptedJson string, privateKey *ecdsa.PrivateKey) (string, error) {
+	var data EncryptedData
+	err := json.Unmarshal([]byte(encryptedJson), &data)
+	if err != nil {
+		return "", err
+	}
+
+	if data.Version != EncryptionVersionX25519 {
+		return "", ErrUnsupportedEncryptionVersion
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(data.Nonce)
+	if err != nil || len(nonce) != 24 {
+		return "", ErrInvalidEncryptedData
+	}
+
+	ephemPublicKey, err := base64.StdEncoding.DecodeString(data.EphemPublicKey)
+	if err != nil || len(ephemPublicKey) != 32 {
+		return "", ErrInvalidEncryptedData
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(data.Ciphertext)
+	if err != nil {
+		return "", ErrInvalidEncryptedData
+	}
+
+	var nonceArray [24]byte
+	var publicKeyArray, privateKeyArray [32]byte
+	copy(nonceArray[:], nonce)
+	copy(publicKeyArray[:], ephemPublicKey)
+	copy(privateKeyArray[:], crypto.FromECDSA(privateKey))
+
+	plaintext, ok := box.Open(nil, ciphertext, &nonceArray, &publicKeyArray, &privateKeyArray)
+	if !ok {
+		return "", ErrDecryptionFailed
+	}
+
+	return string(plaintext), nil
+}
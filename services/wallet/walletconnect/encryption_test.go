@@ -0,0 +1,71 @@
+package walletconnect
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func encryptForPublicKey(t *testing.T, publicKey string, message string) EncryptedData {
+	recipientPublicKey, err := base64.StdEncoding.DecodeString(publicKey)
+	require.NoError(t, err)
+
+	ephemPublicKey, ephemPrivateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	require.NoError(t, err)
+
+	var recipientKey [32]byte
+	copy(recipientKey[:], recipientPublicKey)
+
+	return EncryptedData{
+		Version:        EncryptionVersionX25519,
+		Nonce:          base64.StdEncoding.EncodeToString(nonce[:]),
+		EphemPublicKey: base64.StdEncoding.EncodeToString(ephemPublicKey[:]),
+		Ciphertext:     base64.StdEncoding.EncodeToString(box.Seal(nil, []byte(message), &nonce, &recipientKey, ephemPrivateKey)),
+	}
+}
+
+func TestDecryptMessageEncryptedForEncryptionPublicKey(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	publicKey, err := EncryptionPublicKeyForDApps(privateKey)
+	require.NoError(t, err)
+
+	message := "Hello, Bob!"
+	encryptedData := encryptForPublicKey(t, publicKey, message)
+	encrypted, err := json.Marshal(encryptedData)
+	require.NoError(t, err)
+
+	decrypted, err := DecryptForDApps(string(encrypted), privateKey)
+	require.NoError(t, err)
+	require.Equal(t, message, decrypted)
+
+	// Tampered ciphertexts are rejected
+	encryptedData.Nonce = base64.StdEncoding.EncodeToString(make([]byte, 24))
+	tampered, err := json.Marshal(encryptedData)
+	require.NoError(t, err)
+
+	_, err = DecryptForDApps(string(tampered), privateKey)
+	require.Equal(t, ErrDecryptionFailed, err)
+}
+
+func TestFailToDecryptUnsupportedVersion(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	encrypted, err := json.Marshal(EncryptedData{Version: "x25519-chacha20-poly1305"})
+	require.NoError(t, err)
+
+	_, err = DecryptForDApps(string(encrypted), privateKey)
+	require.Equal(t, ErrUnsupportedEncryptionVersion, err)
+}
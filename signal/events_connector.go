@@ -8,6 +8,10 @@ const (
 	EventConnectorSendRequestAccounts   = "connector.sendRequestAccounts"
 	EventConnectorSendTransaction       = "connector.sendTransaction"
 	EventConnectorSign                  = "connector.sign"
+	EventConnectorSendCalls             = "connector.sendCalls"
+	EventConnectorAddEthereumChain      = "connector.addEthereumChain"
+	EventConnectorWatchAsset            = "connector.watchAsset"
+	EventConnectorKeyAccess             = "connector.keyAccess"
 	EventConnectorDAppPermissionGranted = "connector.dAppPermissionGranted"
 	EventConnectorDAppPermissionRevoked = "connector.dAppPermissionRevoked"
 	EventConnectorDAppChainIdSwitched   = "connector.dAppChainIdSwitched"
@@ -26,11 +30,49 @@ type ConnectorSendRequestAccountsSignal struct {
 }
 
 // ConnectorSendTransactionSignal is triggered when a transaction is requested to be sent.
+// DecodedCall is the JSON encoded decoded calldata, empty if it couldn't be decoded
 type ConnectorSendTransactionSignal struct {
+	ConnectorDApp
+	RequestID   string `json:"requestId"`
+	ChainID     uint64 `json:"chainId"`
+	TxArgs      string `json:"txArgs"`
+	DecodedCall string `json:"decodedCall,omitempty"`
+}
+
+// ConnectorSendCallsSignal is triggered when a batch of calls is requested to be sent.
+// Each call is a transaction with the next nonce, the calls following a failing one must not be sent.
+// DecodedCalls is the JSON encoded array of the decoded calldata, with null for the calls that couldn't be decoded
+type ConnectorSendCallsSignal struct {
+	ConnectorDApp
+	RequestID    string `json:"requestId"`
+	ChainID      uint64 `json:"chainId"`
+	TxArgs       string `json:"txArgs"`
+	DecodedCalls string `json:"decodedCalls"`
+}
+
+// ConnectorAddEthereumChainSignal is triggered when a dApp requests to add a network
+type ConnectorAddEthereumChainSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	Network   string `json:"network"`
+}
+
+// ConnectorWatchAssetSignal is triggered when a dApp requests to add a token to the wallet
+type ConnectorWatchAssetSignal struct {
 	ConnectorDApp
 	RequestID string `json:"requestId"`
-	ChainID   uint64 `json:"chainId"`
-	TxArgs    string `json:"txArgs"`
+	Token     string `json:"token"`
+}
+
+// ConnectorKeyAccessSignal is triggered when a dApp requests an operation with the private key of the shared account.
+// The client computes the result with the wallet's GetEncryptionPublicKeyForDApps or DecryptForDApps.
+// Data is the JSON encoded encrypted message for eth_decrypt
+type ConnectorKeyAccessSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	Address   string `json:"address"`
+	Method    string `json:"method"`
+	Data      string `json:"data"`
 }
 
 type ConnectorSendDappPermissionGrantedSignal struct {
@@ -39,12 +81,15 @@ type ConnectorSendDappPermissionGrantedSignal struct {
 	SharedAccount types.Address `json:"sharedAccount"`
 }
 
+// ConnectorSignSignal is triggered when a message is requested to be signed.
+// TypedDataAnalysis is the JSON encoded breakdown of eth_signTypedData_v3/v4 messages, empty otherwise
 type ConnectorSignSignal struct {
 	ConnectorDApp
-	RequestID string `json:"requestId"`
-	Challenge string `json:"challenge"`
-	Address   string `json:"address"`
-	Method    string `json:"method"`
+	RequestID         string `json:"requestId"`
+	Challenge         string `json:"challenge"`
+	Address           string `json:"address"`
+	Method            string `json:"method"`
+	TypedDataAnalysis string `json:"typedDataAnalysis,omitempty"`
 }
 
 type ConnectorDAppChainIdSwitchedSignal struct {
@@ -59,22 +104,60 @@ func SendConnectorSendRequestAccounts(dApp ConnectorDApp, requestID string) {
 	})
 }
 
-func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, requestID string) {
+func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, decodedCall string, requestID string) {
 	send(EventConnectorSendTransaction, ConnectorSendTransactionSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
 		ChainID:       chainID,
 		TxArgs:        txArgs,
+		DecodedCall:   decodedCall,
 	})
 }
 
-func SendConnectorSign(dApp ConnectorDApp, requestID, challenge, address string, method string) {
+func SendConnectorSendCalls(dApp ConnectorDApp, chainID uint64, txArgs string, decodedCalls string, requestID string) {
+	send(EventConnectorSendCalls, ConnectorSendCallsSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		TxArgs:        txArgs,
+		DecodedCalls:  decodedCalls,
+	})
+}
+
+func SendConnectorSign(dApp ConnectorDApp, requestID, challenge, address string, method string, typedDataAnalysis string) {
 	send(EventConnectorSign, ConnectorSignSignal{
+		ConnectorDApp:     dApp,
+		RequestID:         requestID,
+		Challenge:         challenge,
+		Address:           address,
+		Method:            method,
+		TypedDataAnalysis: typedDataAnalysis,
+	})
+}
+
+func SendConnectorAddEthereumChain(dApp ConnectorDApp, requestID string, network string) {
+	send(EventConnectorAddEthereumChain, ConnectorAddEthereumChainSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		Network:       network,
+	})
+}
+
+func SendConnectorWatchAsset(dApp ConnectorDApp, requestID string, token string) {
+	send(EventConnectorWatchAsset, ConnectorWatchAssetSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		Token:         token,
+	})
+}
+
+func SendConnectorKeyAccess(dApp ConnectorDApp, requestID, address, method, data string) {
+	send(EventConnectorKeyAccess, ConnectorKeyAccessSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
-		Challenge:     challenge,
 		Address:       address,
 		Method:        method,
+		Data:          data,
 	})
 }
 
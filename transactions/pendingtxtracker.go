@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -19,8 +20,10 @@ import (
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
 	ethTypes "github.com/status-im/status-go/eth-node/types"
 
+	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/subscriptions"
 	"github.com/status-im/status-go/services/rpcfilters"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
@@ -37,6 +40,8 @@ const (
 	EventPendingTransactionStatusChanged walletevent.EventType = "pending-transaction-status-changed"
 
 	PendingCheckInterval = 10 * time.Second
+	// PushedCheckMinInterval limits the checks triggered by the new blocks pushed by the WebSocket providers
+	PushedCheckMinInterval = 2 * time.Second
 
 	GetTransactionReceiptRPCName = "eth_getTransactionReceipt"
 )
@@ -93,8 +98,16 @@ type PendingTxTracker struct {
 	rpcFilter *rpcfilters.Service
 	eventFeed *event.Feed
 
+	bridgeCheckers       map[string]BridgeStatusChecker
+	bridgeCheckersMutex  sync.RWMutex
+	bridgeTransferMaxAge time.Duration
+
 	taskRunner *ConditionalRepeater
 	logger     *zap.Logger
+
+	subscriptions *subscriptions.Manager // nil when only polling
+	headSubs      map[common.ChainID]event.Subscription
+	headSubsMu    sync.Mutex
 }
 
 func NewPendingTxTracker(db *sql.DB, rpcClient rpc.ClientInterface, rpcFilter *rpcfilters.Service, eventFeed *event.Feed, checkInterval time.Duration) *PendingTxTracker {
@@ -105,7 +118,10 @@ func NewPendingTxTracker(db *sql.DB, rpcClient rpc.ClientInterface, rpcFilter *r
 		rpcClient:             rpcClient,
 		eventFeed:             eventFeed,
 		rpcFilter:             rpcFilter,
+		bridgeCheckers:        make(map[string]BridgeStatusChecker),
+		bridgeTransferMaxAge:  defaultBridgeTransferMaxAge,
 		logger:                logutils.ZapLogger().Named("PendingTxTracker"),
+		headSubs:              make(map[common.ChainID]event.Subscription),
 	}
 	tm.taskRunner = NewConditionalRepeater(checkInterval, func(ctx context.Context) bool {
 		return tm.fetchAndUpdateDB(ctx)
@@ -113,6 +129,54 @@ func NewPendingTxTracker(db *sql.DB, rpcClient rpc.ClientInterface, rpcFilter *r
 	return tm
 }
 
+// SetSubscriptions checks the pending transactions as soon as a new block of their chain is pushed, on top of
+// the checks at regular intervals
+func (tm *PendingTxTracker) SetSubscriptions(manager *subscriptions.Manager) {
+	tm.subscriptions = manager
+}
+
+// watchNewHeads follows the new blocks of the chains, the other chains are not followed anymore
+func (tm *PendingTxTracker) watchNewHeads(chainIDs map[common.ChainID][]eth.Hash) {
+	if tm.subscriptions == nil {
+		return
+	}
+
+	tm.headSubsMu.Lock()
+	defer tm.headSubsMu.Unlock()
+
+	for chainID, sub := range tm.headSubs {
+		if _, ok := chainIDs[chainID]; !ok {
+			sub.Unsubscribe()
+			delete(tm.headSubs, chainID)
+		}
+	}
+
+	for chainID := range chainIDs {
+		if _, ok := tm.headSubs[chainID]; ok {
+			continue
+		}
+		heads := make(chan *types.Header, 1)
+		sub := tm.subscriptions.SubscribeNewHeads(uint64(chainID), heads)
+		tm.headSubs[chainID] = sub
+
+		go func() {
+			defer gocommon.LogOnPanic()
+			var lastCheck time.Time
+			for {
+				select {
+				case <-sub.Err():
+					return
+				case <-heads:
+					if time.Since(lastCheck) >= PushedCheckMinInterval {
+						lastCheck = time.Now()
+						tm.taskRunner.RunUntilDone()
+					}
+				}
+			}
+		}()
+	}
+}
+
 type txStatusRes struct {
 	Status TxStatus
 	hash   eth.Hash
@@ -133,6 +197,7 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 		chainID := tx.ChainID
 		txsMap[chainID] = append(txsMap[chainID], tx.Hash)
 	}
+	tm.watchNewHeads(txsMap)
 
 	doneCount := 0
 	// Batch request for each chain
@@ -160,8 +225,12 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 		tm.emitNotifications(chainID, updateRes)
 	}
 
-	if len(txs) == doneCount {
+	// bridge transfers are followed until the funds arrive, after their source tx is done
+	pendingBridgeTransfers := tm.checkBridgeTransfers(ctx)
+
+	if len(txs) == doneCount && pendingBridgeTransfers == 0 {
 		res = WorkDone
+		tm.watchNewHeads(nil)
 	}
 
 	tm.logger.Debug("Done PTs iteration", zap.Int("count", doneCount), zap.Bool("completed", res))
@@ -450,6 +519,7 @@ func (tm *PendingTxTracker) Protocols() []p2p.Protocol {
 
 func (tm *PendingTxTracker) Stop() error {
 	tm.taskRunner.Stop()
+	tm.watchNewHeads(nil)
 	return nil
 }
 
@@ -468,6 +538,7 @@ const (
 	DeployOwnerToken          PendingTrxType = "DeployOwnerToken"
 	SetSignerPublicKey        PendingTrxType = "SetSignerPublicKey"
 	WalletConnectTransfer     PendingTrxType = "WalletConnectTransfer"
+	ConnectorSendCalls        PendingTrxType = "ConnectorSendCalls"
 )
 
 type PendingTransaction struct {
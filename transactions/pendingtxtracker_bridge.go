@@ -0,0 +1,193 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+// EventPendingBridgeTransferStatusChanged is emitted when the funds of a bridge transfer arrive on the destination chain
+// or when the transfer fails. Carries BridgeTransfer in message
+const EventPendingBridgeTransferStatusChanged walletevent.EventType = "pending-bridge-transfer-status-changed"
+
+// Unknown is the status of the bridge transfers whose funds did not arrive within the max age after the source tx
+// confirmation, they are not tracked anymore
+const Unknown TxStatus = "Unknown"
+
+// defaultBridgeTransferMaxAge is how long the bridge transfers are tracked after the source tx confirmation, the
+// supported bridges deliver within minutes
+const defaultBridgeTransferMaxAge = 6 * time.Hour
+
+var ErrNoBridgeStatusChecker = errors.New("no status checker for bridge")
+
+// BridgeStatus is the state of the destination chain side of a bridge transfer
+type BridgeStatus struct {
+	Status TxStatus
+	// DestinationHash is the tx delivering the funds, set once Status is Success
+	DestinationHash eth.Hash
+}
+
+// BridgeStatusChecker resolves where the funds of a bridge transfer are, implemented by the bridge path processors
+type BridgeStatusChecker interface {
+	// Name returns the name of the bridge, as used by the router
+	Name() string
+	// CheckBridgeStatus is called once the source tx is confirmed, until it returns a status other than Pending
+	CheckBridgeStatus(ctx context.Context, source TxIdentity, destinationChainID common.ChainID) (BridgeStatus, error)
+}
+
+// RegisterBridgeStatusChecker makes the transfers of the checker's bridge trackable with TrackBridgeTransfer
+func (tm *PendingTxTracker) RegisterBridgeStatusChecker(checker BridgeStatusChecker) {
+	tm.bridgeCheckersMutex.Lock()
+	defer tm.bridgeCheckersMutex.Unlock()
+
+	tm.bridgeCheckers[checker.Name()] = checker
+}
+
+func (tm *PendingTxTracker) bridgeStatusChecker(bridgeName string) (BridgeStatusChecker, bool) {
+	tm.bridgeCheckersMutex.RLock()
+	defer tm.bridgeCheckersMutex.RUnlock()
+
+	checker, ok := tm.bridgeCheckers[bridgeName]
+	return checker, ok
+}
+
+// TrackBridgeTransfer follows the transfer started by the source tx until the funds arrive on the destination chain.
+// The source tx is expected to be tracked as a pending transaction too
+func (tm *PendingTxTracker) TrackBridgeTransfer(source TxIdentity, destinationChainID common.ChainID, bridgeName string) error {
+	if _, ok := tm.bridgeStatusChecker(bridgeName); !ok {
+		return ErrNoBridgeStatusChecker
+	}
+
+	err := tm.trackedTxDB.PutBridgeTransfer(BridgeTransfer{
+		Source: source,
+		Destination: TxIdentity{
+			ChainID: destinationChainID,
+		},
+		BridgeName: bridgeName,
+		Status:     Pending,
+		Timestamp:  uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		return err
+	}
+
+	tm.taskRunner.RunUntilDone()
+
+	return nil
+}
+
+// GetBridgeTransfer returns the state of the bridge transfer started by the source tx
+func (tm *PendingTxTracker) GetBridgeTransfer(source TxIdentity) (*BridgeTransfer, error) {
+	transfer, err := tm.trackedTxDB.GetBridgeTransfer(source)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// checkBridgeTransfers updates the pending bridge transfers and returns how many are still pending
+func (tm *PendingTxTracker) checkBridgeTransfers(ctx context.Context) int {
+	transfers, err := tm.trackedTxDB.GetBridgeTransfersByStatus(Pending)
+	if err != nil {
+		tm.logger.Error("Failed to get pending bridge transfers", zap.Error(err))
+		return 0
+	}
+
+	stillPending := 0
+	for _, transfer := range transfers {
+		status, err := tm.fetchBridgeStatus(ctx, &transfer)
+		if err != nil {
+			tm.logger.Warn("Failed to check bridge transfer status", zap.Stringer("hash", transfer.Source.Hash), zap.String("bridge", transfer.BridgeName), zap.Error(err))
+			status = BridgeStatus{Status: Pending}
+		}
+		if status.Status == Pending {
+			if !tm.bridgeTransferExpired(transfer) {
+				stillPending++
+				continue
+			}
+			tm.logger.Warn("Bridge transfer not delivered in time, stop tracking it", zap.Stringer("hash", transfer.Source.Hash), zap.String("bridge", transfer.BridgeName))
+			status = BridgeStatus{Status: Unknown}
+		}
+
+		err = tm.trackedTxDB.UpdateBridgeTransferStatus(transfer.Source, status.Status, status.DestinationHash)
+		if err != nil {
+			tm.logger.Error("Failed to update bridge transfer status", zap.Stringer("hash", transfer.Source.Hash), zap.Error(err))
+			stillPending++
+			continue
+		}
+
+		transfer.Status = status.Status
+		transfer.Destination.Hash = status.DestinationHash
+		tm.emitBridgeNotification(transfer)
+	}
+
+	return stillPending
+}
+
+// bridgeTransferExpired checks if the transfer is still pending too long after the source tx confirmation
+func (tm *PendingTxTracker) bridgeTransferExpired(transfer BridgeTransfer) bool {
+	if transfer.SourceConfirmedAt == 0 {
+		return false
+	}
+	confirmedAt := time.Unix(int64(transfer.SourceConfirmedAt), 0)
+	return time.Since(confirmedAt) > tm.bridgeTransferMaxAge
+}
+
+// fetchBridgeStatus returns the status of the transfer, recording when its source tx was found confirmed
+func (tm *PendingTxTracker) fetchBridgeStatus(ctx context.Context, transfer *BridgeTransfer) (BridgeStatus, error) {
+	sourceTx, err := tm.trackedTxDB.GetTx(transfer.Source)
+	if err != nil && err != sql.ErrNoRows {
+		return BridgeStatus{}, err
+	}
+	if err == nil {
+		switch sourceTx.Status {
+		case Pending:
+			// nothing can arrive before the source tx is confirmed
+			return BridgeStatus{Status: Pending}, nil
+		case Failed:
+			return BridgeStatus{Status: Failed}, nil
+		}
+	}
+
+	if transfer.SourceConfirmedAt == 0 {
+		confirmedAt := uint64(time.Now().Unix())
+		err = tm.trackedTxDB.UpdateBridgeTransferSourceConfirmedAt(transfer.Source, confirmedAt)
+		if err != nil {
+			return BridgeStatus{}, err
+		}
+		transfer.SourceConfirmedAt = confirmedAt
+	}
+
+	checker, ok := tm.bridgeStatusChecker(transfer.BridgeName)
+	if !ok {
+		return BridgeStatus{}, ErrNoBridgeStatusChecker
+	}
+
+	return checker.CheckBridgeStatus(ctx, transfer.Source, transfer.Destination.ChainID)
+}
+
+func (tm *PendingTxTracker) emitBridgeNotification(transfer BridgeTransfer) {
+	if tm.eventFeed == nil {
+		return
+	}
+
+	jsonPayload, err := json.Marshal(transfer)
+	if err != nil {
+		tm.logger.Error("Failed to marshal bridge transfer status", zap.Stringer("hash", transfer.Source.Hash), zap.Error(err))
+		return
+	}
+	tm.eventFeed.Send(walletevent.Event{
+		Type:    EventPendingBridgeTransferStatusChanged,
+		ChainID: uint64(transfer.Destination.ChainID),
+		Message: string(jsonPayload),
+	})
+}
@@ -0,0 +1,179 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+type testBridgeStatusChecker struct {
+	mu       sync.Mutex
+	statuses []BridgeStatus
+	calls    int
+}
+
+func (c *testBridgeStatusChecker) Name() string {
+	return "TestBridge"
+}
+
+// CheckBridgeStatus returns the configured statuses in order, repeating the last one
+func (c *testBridgeStatusChecker) CheckBridgeStatus(ctx context.Context, source TxIdentity, destinationChainID common.ChainID) (BridgeStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := c.statuses[min(c.calls, len(c.statuses)-1)]
+	c.calls++
+	return status, nil
+}
+
+func (c *testBridgeStatusChecker) callsCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func waitForBridgeTransferEvent(t *testing.T, eventChan chan walletevent.Event) BridgeTransfer {
+	for {
+		select {
+		case we := <-eventChan:
+			if we.Type != EventPendingBridgeTransferStatusChanged {
+				continue
+			}
+			var transfer BridgeTransfer
+			err := json.Unmarshal([]byte(we.Message), &transfer)
+			require.NoError(t, err)
+			return transfer
+		case <-time.After(1 * time.Second):
+			t.Fatal("timeout waiting for bridge transfer event")
+		}
+	}
+}
+
+func TestPendingTxTracker_BridgeTransferDelivered(t *testing.T) {
+	checkInterval := 10 * time.Millisecond
+	m, stop, chainClient, eventFeed := setupTestTransactionDB(t, &checkInterval)
+	defer stop()
+
+	destinationHash := eth.HexToHash("0x1234")
+	checker := &testBridgeStatusChecker{
+		statuses: []BridgeStatus{
+			{Status: Pending},
+			{Status: Success, DestinationHash: destinationHash},
+		},
+	}
+	m.RegisterBridgeStatusChecker(checker)
+
+	txs := MockTestTransactions(t, chainClient, []TestTxSummary{{}})
+
+	eventChan := make(chan walletevent.Event, 10)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	source := TxIdentity{ChainID: txs[0].ChainID, Hash: txs[0].Hash}
+	err := m.StoreAndTrackPendingTx(&txs[0])
+	require.NoError(t, err)
+	err = m.TrackBridgeTransfer(source, common.ChainID(common.OptimismMainnet), checker.Name())
+	require.NoError(t, err)
+
+	transfer := waitForBridgeTransferEvent(t, eventChan)
+	require.Equal(t, source, transfer.Source)
+	require.Equal(t, common.ChainID(common.OptimismMainnet), transfer.Destination.ChainID)
+	require.Equal(t, destinationHash, transfer.Destination.Hash)
+	require.Equal(t, Success, transfer.Status)
+	require.Equal(t, 2, checker.callsCount())
+
+	stored, err := m.GetBridgeTransfer(source)
+	require.NoError(t, err)
+	require.Equal(t, transfer, *stored)
+
+	err = m.Stop()
+	require.NoError(t, err)
+	waitForTaskToStop(m)
+}
+
+func TestPendingTxTracker_BridgeTransferSourceFailed(t *testing.T) {
+	m, stop, chainClient, eventFeed := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	checker := &testBridgeStatusChecker{
+		statuses: []BridgeStatus{{Status: Success}},
+	}
+	m.RegisterBridgeStatusChecker(checker)
+
+	txs := MockTestTransactions(t, chainClient, []TestTxSummary{{failStatus: true}})
+
+	eventChan := make(chan walletevent.Event, 10)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	source := TxIdentity{ChainID: txs[0].ChainID, Hash: txs[0].Hash}
+	err := m.StoreAndTrackPendingTx(&txs[0])
+	require.NoError(t, err)
+	err = m.TrackBridgeTransfer(source, common.ChainID(common.ArbitrumMainnet), checker.Name())
+	require.NoError(t, err)
+
+	transfer := waitForBridgeTransferEvent(t, eventChan)
+	require.Equal(t, Failed, transfer.Status)
+	require.Equal(t, eth.Hash{}, transfer.Destination.Hash)
+	require.Equal(t, 0, checker.callsCount())
+
+	err = m.Stop()
+	require.NoError(t, err)
+	waitForTaskToStop(m)
+}
+
+func TestPendingTxTracker_BridgeTransferExpired(t *testing.T) {
+	checkInterval := 10 * time.Millisecond
+	m, stop, chainClient, eventFeed := setupTestTransactionDB(t, &checkInterval)
+	defer stop()
+
+	// The funds never arrive
+	checker := &testBridgeStatusChecker{
+		statuses: []BridgeStatus{{Status: Pending}},
+	}
+	m.RegisterBridgeStatusChecker(checker)
+	m.bridgeTransferMaxAge = 0
+
+	txs := MockTestTransactions(t, chainClient, []TestTxSummary{{}})
+
+	eventChan := make(chan walletevent.Event, 10)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	source := TxIdentity{ChainID: txs[0].ChainID, Hash: txs[0].Hash}
+	err := m.StoreAndTrackPendingTx(&txs[0])
+	require.NoError(t, err)
+	err = m.TrackBridgeTransfer(source, common.ChainID(common.OptimismMainnet), checker.Name())
+	require.NoError(t, err)
+
+	transfer := waitForBridgeTransferEvent(t, eventChan)
+	require.Equal(t, Unknown, transfer.Status)
+	require.NotZero(t, transfer.SourceConfirmedAt)
+	require.Equal(t, eth.Hash{}, transfer.Destination.Hash)
+
+	stored, err := m.GetBridgeTransfer(source)
+	require.NoError(t, err)
+	require.Equal(t, Unknown, stored.Status)
+
+	err = m.Stop()
+	require.NoError(t, err)
+	waitForTaskToStop(m)
+}
+
+func TestPendingTxTracker_BridgeTransferUnknownBridge(t *testing.T) {
+	m, stop, _, _ := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	err := m.TrackBridgeTransfer(TxIdentity{ChainID: common.ChainID(common.EthereumMainnet), Hash: eth.HexToHash("0x1")},
+		common.ChainID(common.OptimismMainnet), "Unknown")
+	require.ErrorIs(t, err, ErrNoBridgeStatusChecker)
+}
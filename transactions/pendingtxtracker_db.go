@@ -5,6 +5,8 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 
+	eth "github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/sqlite"
 )
 
@@ -113,3 +115,113 @@ func updateTxStatus(creator sqlite.StatementCreator, txID TxIdentity, status TxS
 
 	return err
 }
+
+// BridgeTransfer follows the funds sent by a bridge tx until they arrive on the destination chain
+type BridgeTransfer struct {
+	Source TxIdentity `json:"source"`
+	// Destination hash is empty until the funds are delivered
+	Destination TxIdentity `json:"destination"`
+	BridgeName  string     `json:"bridgeName"`
+	Status      TxStatus   `json:"status"`
+	Timestamp   uint64     `json:"timestamp"`
+	// SourceConfirmedAt is when the tracker found the source tx confirmed, 0 until then
+	SourceConfirmedAt uint64 `json:"sourceConfirmedAt"`
+}
+
+var bridgeTransferColumns = []string{"source_chain_id", "source_tx_hash", "destination_chain_id", "destination_tx_hash", "bridge_name", "status", "timestamp", "source_confirmed_at"}
+
+func (db *DB) PutBridgeTransfer(transfer BridgeTransfer) error {
+	q := sq.Replace("tracked_bridge_transfers").
+		Columns(bridgeTransferColumns...).
+		Values(transfer.Source.ChainID, transfer.Source.Hash, transfer.Destination.ChainID, transfer.Destination.Hash,
+			transfer.BridgeName, transfer.Status, transfer.Timestamp, transfer.SourceConfirmedAt)
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(query, args...)
+	return err
+}
+
+func (db *DB) GetBridgeTransfer(source TxIdentity) (transfer BridgeTransfer, err error) {
+	q := sq.Select(bridgeTransferColumns...).
+		From("tracked_bridge_transfers").
+		Where(sq.Eq{"source_chain_id": source.ChainID, "source_tx_hash": source.Hash})
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return
+	}
+
+	row := db.db.QueryRow(query, args...)
+	err = scanBridgeTransfer(row, &transfer)
+
+	return
+}
+
+// GetBridgeTransfersByStatus returns the bridge transfers in the given status, oldest first
+func (db *DB) GetBridgeTransfersByStatus(status TxStatus) ([]BridgeTransfer, error) {
+	q := sq.Select(bridgeTransferColumns...).
+		From("tracked_bridge_transfers").
+		Where(sq.Eq{"status": status}).
+		OrderBy("timestamp ASC")
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []BridgeTransfer
+	for rows.Next() {
+		var transfer BridgeTransfer
+		err = scanBridgeTransfer(rows, &transfer)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+func (db *DB) UpdateBridgeTransferStatus(source TxIdentity, status TxStatus, destinationHash eth.Hash) error {
+	q := sq.Update("tracked_bridge_transfers").
+		Set("status", status).
+		Set("destination_tx_hash", destinationHash).
+		Where(sq.Eq{"source_chain_id": source.ChainID, "source_tx_hash": source.Hash})
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(query, args...)
+	return err
+}
+
+func (db *DB) UpdateBridgeTransferSourceConfirmedAt(source TxIdentity, confirmedAt uint64) error {
+	q := sq.Update("tracked_bridge_transfers").
+		Set("source_confirmed_at", confirmedAt).
+		Where(sq.Eq{"source_chain_id": source.ChainID, "source_tx_hash": source.Hash})
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(query, args...)
+	return err
+}
+
+func scanBridgeTransfer(row sq.RowScanner, transfer *BridgeTransfer) error {
+	return row.Scan(&transfer.Source.ChainID, &transfer.Source.Hash, &transfer.Destination.ChainID, &transfer.Destination.Hash,
+		&transfer.BridgeName, &transfer.Status, &transfer.Timestamp, &transfer.SourceConfirmedAt)
+}
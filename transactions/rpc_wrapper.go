@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/status-go/eth-node/types"
 
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/chain"
 )
 
 // rpcWrapper wraps provides convenient interface for ethereum RPC APIs we need for sending transactions
@@ -26,10 +27,13 @@ func newRPCWrapper(client rpc.ClientInterface, chainID uint64) *rpcWrapper {
 
 // PendingNonceAt returns the account nonce of the given account in the pending state.
 // This is the nonce that should be used for the next transaction.
+// It is read with the signing nonce quorum when one is configured for the chain.
 func (w *rpcWrapper) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	var result hexutil.Uint64
-	err := w.RPCClient.CallContext(ctx, &result, w.chainID, "eth_getTransactionCount", account, "pending")
-	return uint64(result), err
+	ethClient, err := w.RPCClient.EthClient(w.chainID)
+	if err != nil {
+		return 0, err
+	}
+	return ethClient.PendingNonceAt(chain.WithQuorumOperation(ctx, chain.QuorumOperationSigningNonce), account)
 }
 
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
@@ -0,0 +1,11 @@
+-- cache of the computed portfolio P&L reports, invalidated when new transfers are stored
+CREATE TABLE IF NOT EXISTS portfolio_pnl_cache (
+    accounts_key VARCHAR NOT NULL,
+    method INT NOT NULL,
+    currency VARCHAR NOT NULL,
+    events_count INT NOT NULL,
+    last_event_timestamp INT NOT NULL,
+    computed_at INT NOT NULL,
+    report BLOB NOT NULL,
+    PRIMARY KEY (accounts_key, method, currency)
+) WITHOUT ROWID;
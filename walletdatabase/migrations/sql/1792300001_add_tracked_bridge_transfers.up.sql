@@ -0,0 +1,13 @@
+-- store state of the destination chain side of bridge transfers
+CREATE TABLE IF NOT EXISTS tracked_bridge_transfers(
+    source_chain_id UNSIGNED BIGINT NOT NULL,
+    source_tx_hash BLOB NOT NULL,
+    destination_chain_id UNSIGNED BIGINT NOT NULL,
+    destination_tx_hash BLOB NOT NULL,
+    bridge_name TEXT NOT NULL,
+    status STRING NOT NULL,
+    timestamp INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tracked_bridge_transfers_per_source ON tracked_bridge_transfers (source_chain_id, source_tx_hash);
+CREATE INDEX IF NOT EXISTS idx_tracked_bridge_transfers_status ON tracked_bridge_transfers (status);
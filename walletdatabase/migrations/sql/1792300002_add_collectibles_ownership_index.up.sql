@@ -0,0 +1,17 @@
+-- collectibles balances derived from the account's ERC721/ERC1155 transfer logs
+CREATE TABLE IF NOT EXISTS collectibles_ownership_index (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner_address VARCHAR NOT NULL,
+    contract_address VARCHAR NOT NULL,
+    token_id BLOB NOT NULL,
+    balance BLOB NOT NULL,
+    PRIMARY KEY (chain_id, owner_address, contract_address, token_id)
+);
+
+-- last block whose transfer logs are reflected in collectibles_ownership_index
+CREATE TABLE IF NOT EXISTS collectibles_ownership_index_progress (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner_address VARCHAR NOT NULL,
+    last_indexed_block UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (chain_id, owner_address)
+);
@@ -0,0 +1,15 @@
+-- user defined price and balance alerts
+CREATE TABLE IF NOT EXISTS wallet_alert_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type VARCHAR NOT NULL,
+    symbol VARCHAR NOT NULL,
+    currency VARCHAR NOT NULL DEFAULT '',
+    threshold REAL NOT NULL,
+    account VARCHAR NOT NULL DEFAULT '',
+    chain_id UNSIGNED BIGINT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- last observed price, or balance the next change is measured from; NULL until first evaluated
+    last_value REAL,
+    created_at INTEGER NOT NULL,
+    last_triggered_at INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,36 @@
+-- Safe (Gnosis) multisig contracts followed by the wallet
+CREATE TABLE IF NOT EXISTS safe_accounts (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address VARCHAR NOT NULL,
+    name VARCHAR NOT NULL DEFAULT '',
+    version VARCHAR NOT NULL,
+    -- JSON array of the owner addresses
+    owners TEXT NOT NULL,
+    threshold INTEGER NOT NULL,
+    nonce UNSIGNED BIGINT NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, address)
+);
+
+-- Safe transactions proposed from the wallet, visible in activity through multi_transaction_id
+CREATE TABLE IF NOT EXISTS safe_proposals (
+    safe_tx_hash BLOB NOT NULL PRIMARY KEY,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    safe_address VARCHAR NOT NULL,
+    nonce UNSIGNED BIGINT NOT NULL,
+    -- JSON encoded SafeTx
+    safe_tx BLOB NOT NULL,
+    status VARCHAR NOT NULL,
+    multi_transaction_id INTEGER NOT NULL DEFAULT 0,
+    execution_tx_hash BLOB,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_safe_proposals_safe ON safe_proposals (chain_id, safe_address, status);
+
+CREATE TABLE IF NOT EXISTS safe_proposal_signatures (
+    safe_tx_hash BLOB NOT NULL,
+    owner VARCHAR NOT NULL,
+    signature BLOB NOT NULL,
+    PRIMARY KEY (safe_tx_hash, owner)
+);
@@ -0,0 +1,12 @@
+-- connector_call_batches keeps the transactions sent for the EIP-5792 wallet_sendCalls batches of the connected dApps
+CREATE TABLE IF NOT EXISTS connector_call_batches (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    from_address TEXT NOT NULL,
+    atomic BOOLEAN NOT NULL,
+    tx_hashes TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX IF NOT EXISTS idx_connector_call_batches_url ON connector_call_batches (url);
@@ -0,0 +1,18 @@
+-- connector_dapp_policies keeps the optional restrictions set by the user on the transactions of a connected dApp
+CREATE TABLE IF NOT EXISTS connector_dapp_policies (
+    url TEXT PRIMARY KEY,
+    allowed_contracts TEXT NOT NULL,
+    allowed_methods TEXT NOT NULL,
+    max_value_per_tx TEXT,
+    max_value_per_day TEXT,
+    expires_at INTEGER NOT NULL DEFAULT 0
+) WITHOUT ROWID;
+
+-- connector_dapp_spendings keeps the native value sent by the connected dApps to enforce their daily limit
+CREATE TABLE IF NOT EXISTS connector_dapp_spendings (
+    url TEXT NOT NULL,
+    value TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_connector_dapp_spendings_url_created_at ON connector_dapp_spendings (url, created_at);
@@ -0,0 +1,10 @@
+-- Contract ABIs imported by the user to decode the calldata of transactions sent to the contract
+CREATE TABLE IF NOT EXISTS contract_abis (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address VARCHAR NOT NULL,
+    name VARCHAR NOT NULL DEFAULT '',
+    -- JSON encoded ABI
+    abi TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, address)
+);
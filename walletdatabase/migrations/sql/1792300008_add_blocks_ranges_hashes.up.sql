@@ -0,0 +1,7 @@
+-- Hashes of the last blocks of the ranges checked for new transfers, used to detect the reorganizations of the chain
+CREATE TABLE IF NOT EXISTS blocks_ranges_hashes (
+    network_id UNSIGNED BIGINT NOT NULL,
+    blk_number BIGINT NOT NULL,
+    blk_hash BLOB NOT NULL,
+    PRIMARY KEY (network_id, blk_number)
+) WITHOUT ROWID;
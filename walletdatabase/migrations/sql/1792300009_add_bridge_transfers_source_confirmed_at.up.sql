@@ -0,0 +1,2 @@
+-- time the source tx of a bridge transfer was found confirmed, used to stop tracking the transfers that never complete
+ALTER TABLE tracked_bridge_transfers ADD COLUMN source_confirmed_at INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- batch ids are chosen by the dApps, scope them to the dApp url so a dApp can't probe the batches of another one
+CREATE TABLE IF NOT EXISTS connector_call_batches_new (
+    id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    from_address TEXT NOT NULL,
+    atomic BOOLEAN NOT NULL,
+    tx_hashes TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (url, id)
+) WITHOUT ROWID;
+
+INSERT INTO connector_call_batches_new (id, url, chain_id, from_address, atomic, tx_hashes, created_at)
+SELECT id, url, chain_id, from_address, atomic, tx_hashes, created_at FROM connector_call_batches;
+
+DROP TABLE connector_call_batches;
+
+ALTER TABLE connector_call_batches_new RENAME TO connector_call_batches;
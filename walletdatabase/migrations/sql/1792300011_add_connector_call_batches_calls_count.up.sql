@@ -0,0 +1,6 @@
+-- calls_count is the number of calls of the batch, the client stops sending them at the first failing one
+-- so a batch can have less transactions than calls. The existing batches had a transaction per call
+-- or a single one for the atomic batches
+ALTER TABLE connector_call_batches ADD COLUMN calls_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE connector_call_batches SET calls_count = json_array_length(tx_hashes);